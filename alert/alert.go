@@ -25,6 +25,9 @@ type Alert struct {
 	ServiceID string    `json:"service_id"`
 	CreatedAt time.Time `json:"created_at"`
 	Dedup     *DedupID  `json:"dedup"`
+
+	// Flapping indicates the alert is being held open because its dedup key is flapping.
+	Flapping bool `json:"flapping"`
 }
 
 // DedupKey will return the de-duplication key for the alert.
@@ -45,7 +48,7 @@ func (a *Alert) DedupKey() *DedupID {
 }
 
 func (a *Alert) scanFrom(scanFn func(...interface{}) error) error {
-	return scanFn(&a.ID, &a.Summary, &a.Details, &a.ServiceID, &a.Source, &a.Status, &a.CreatedAt, &a.Dedup)
+	return scanFn(&a.ID, &a.Summary, &a.Details, &a.ServiceID, &a.Source, &a.Status, &a.CreatedAt, &a.Dedup, &a.Flapping)
 }
 
 func (a Alert) Normalize() (*Alert, error) {
@@ -60,7 +63,7 @@ func (a Alert) Normalize() (*Alert, error) {
 	err := validate.Many(
 		validate.Text("Summary", a.Summary, 1, MaxSummaryLength),
 		validate.Text("Details", a.Details, 0, MaxDetailsLength),
-		validate.OneOf("Source", a.Source, SourceManual, SourceGrafana, SourceSite24x7, SourcePrometheusAlertmanager, SourceEmail, SourceGeneric),
+		validate.OneOf("Source", a.Source, SourceManual, SourceGrafana, SourceSite24x7, SourcePrometheusAlertmanager, SourceEmail, SourceGeneric, SourceDatadog, SourceNewRelic, SourceAWSSNS),
 		validate.OneOf("Status", a.Status, StatusTriggered, StatusActive, StatusClosed),
 		validate.UUID("ServiceID", a.ServiceID),
 	)
@@ -18,6 +18,7 @@ const (
 	DedupTypeUser      = DedupType("user")
 	DedupTypeAuto      = DedupType("auto")
 	DedupTypeHeartbeat = DedupType("heartbeat")
+	DedupTypeCheck     = DedupType("check")
 )
 
 // DedupID represents a de-duplication ID for alerts.
@@ -162,7 +162,8 @@ func (db *DB) LegacySearch(ctx context.Context, opts *LegacySearchOptions) ([]Al
 			a.source,
 			a.status,
 			a.created_at,
-			a.dedup_key
+			a.dedup_key,
+			(a.status != 'closed' and exists (select 1 from alert_flapping f where f.alert_id = a.id))
 		FROM alerts a
 		JOIN services svc ON svc.id = a.service_id
 		%s
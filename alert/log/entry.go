@@ -40,6 +40,15 @@ func (e Entry) Meta() interface{} {
 		dest = &NotificationMetaData{}
 	case TypeCreated:
 		dest = &CreatedMetaData{}
+	case TypeClosed:
+		if len(e.meta) == 0 {
+			return nil
+		}
+		dest = &ClosedMetaData{}
+	case TypeFlapping:
+		dest = &FlappingMetaData{}
+	case TypeAckTimeout:
+		dest = &AckTimeoutMetaData{}
 	default:
 		return nil
 	}
@@ -105,6 +114,13 @@ func (e Entry) Subject() *Subject {
 }
 
 func escalationMsg(m *EscalationMetaData) string {
+	if m.Fallback {
+		msg := " to fallback target"
+		if m.OldDelayMinutes > 0 {
+			msg += fmt.Sprintf(" automatically after %d minutes", m.OldDelayMinutes)
+		}
+		return msg + " (all repeats exhausted)"
+	}
 	msg := fmt.Sprintf(" to step #%d", m.NewStepIndex+1)
 	if m.Repeat {
 		msg += " (policy repeat)"
@@ -120,6 +136,19 @@ func escalationMsg(m *EscalationMetaData) string {
 	return msg
 }
 
+func flappingMsg(m *FlappingMetaData) string {
+	switch {
+	case m.Stable:
+		return "Flapping ended, alert is stable"
+	case m.Transitions > 0:
+		return fmt.Sprintf("Flapping detected after %d transitions, close suppressed", m.Transitions)
+	case m.SourceClosed:
+		return "Flapping, close suppressed"
+	}
+
+	return "Flapping, re-trigger suppressed"
+}
+
 func (e Entry) String() string {
 	var msg string
 	var infinitive bool
@@ -130,6 +159,10 @@ func (e Entry) String() string {
 		msg = "Acknowledged"
 	case TypeClosed:
 		msg = "Closed"
+		meta, ok := e.Meta().(*ClosedMetaData)
+		if ok && meta.AutoResolveMinutes > 0 {
+			msg += fmt.Sprintf(" automatically after %d minutes of inactivity", meta.AutoResolveMinutes)
+		}
 	case TypeEscalated:
 		msg = "Escalated"
 		meta, ok := e.Meta().(*EscalationMetaData)
@@ -148,6 +181,19 @@ func (e Entry) String() string {
 		msg = "Suppressed duplicate: created"
 	case TypeEscalationRequest:
 		msg = "Escalation requested"
+	case TypeFlapping:
+		msg = "Flapping"
+		meta, ok := e.Meta().(*FlappingMetaData)
+		if ok {
+			msg = flappingMsg(meta)
+		}
+	case TypeAckTimeout:
+		msg = "Acknowledgement expired"
+		meta, ok := e.Meta().(*AckTimeoutMetaData)
+		if ok && meta.TimeoutMinutes > 0 {
+			msg += fmt.Sprintf(" after %d minutes", meta.TimeoutMinutes)
+		}
+		msg += ", escalation resumed"
 	default:
 		return "Error"
 	}
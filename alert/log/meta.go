@@ -7,6 +7,9 @@ type EscalationMetaData struct {
 	Deleted         bool
 	OldDelayMinutes int
 	NoOneOnCall     bool
+
+	// Fallback is set when the policy's fallback target was notified after all repeats were exhausted.
+	Fallback bool
 }
 
 type NotificationMetaData struct {
@@ -16,3 +19,19 @@ type NotificationMetaData struct {
 type CreatedMetaData struct {
 	EPNoSteps bool
 }
+
+type ClosedMetaData struct {
+	// AutoResolveMinutes is set when the alert was closed automatically due to inactivity.
+	AutoResolveMinutes int
+}
+
+type AckTimeoutMetaData struct {
+	TimeoutMinutes int
+}
+
+type FlappingMetaData struct {
+	// Transitions is set to the number of open/close transitions when flapping is first detected.
+	Transitions  int
+	SourceClosed bool
+	Stable       bool
+}
@@ -72,7 +72,9 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 			select "type", name from notification_channels where id = $1
 		`),
 		lookupHBInterval: p.P(`
-			select extract(epoch from heartbeat_interval)/60 from heartbeat_monitors where id = $1
+			select extract(epoch from heartbeat_interval)/60, cron_schedule notnull, last_report_failed
+			from heartbeat_monitors
+			where id = $1
 		`),
 		lookupIKeyType: p.P(`select "type" from integration_keys where id = $1`),
 		insertEP: p.P(`
@@ -359,7 +361,8 @@ func (db *DB) logAny(ctx context.Context, tx *sql.Tx, insertStmt *sql.Stmt, id i
 		case permission.SourceTypeHeartbeat:
 			r.subject._type = SubjectTypeHeartbeatMonitor
 			var minutes int
-			err = txWrap(ctx, tx, db.lookupHBInterval).QueryRowContext(ctx, src.ID).Scan(&minutes)
+			var isCron, failed bool
+			err = txWrap(ctx, tx, db.lookupHBInterval).QueryRowContext(ctx, src.ID).Scan(&minutes, &isCron, &failed)
 			if err != nil {
 				return errors.Wrap(err, "lookup heartbeat monitor interval by ID")
 			}
@@ -368,7 +371,14 @@ func (db *DB) logAny(ctx context.Context, tx *sql.Tx, insertStmt *sql.Stmt, id i
 				if minutes == 1 {
 					s = ""
 				}
-				r.subject.classifier = fmt.Sprintf("expired after %d minute"+s, minutes)
+				switch {
+				case failed:
+					r.subject.classifier = "reported failure"
+				case isCron:
+					r.subject.classifier = "missed scheduled check-in"
+				default:
+					r.subject.classifier = fmt.Sprintf("expired after %d minute"+s, minutes)
+				}
 			} else if r.Type() == TypeClosed {
 				r.subject.classifier = "healthy"
 			}
@@ -384,12 +394,20 @@ func (db *DB) logAny(ctx context.Context, tx *sql.Tx, insertStmt *sql.Stmt, id i
 			switch ikeyType {
 			case integrationkey.TypeGeneric:
 				r.subject.classifier = "Generic API"
+			case integrationkey.TypeGenericJSON:
+				r.subject.classifier = "Generic JSON"
 			case integrationkey.TypeGrafana:
 				r.subject.classifier = "Grafana"
 			case integrationkey.TypeSite24x7:
 				r.subject.classifier = "Site24x7"
 			case integrationkey.TypeEmail:
 				r.subject.classifier = "Email"
+			case integrationkey.TypeDatadog:
+				r.subject.classifier = "Datadog"
+			case integrationkey.TypeNewRelic:
+				r.subject.classifier = "New Relic"
+			case integrationkey.TypeAWSSNS:
+				r.subject.classifier = "AWS SNS"
 			}
 			r.subject.integrationKeyID.Valid = true
 			r.subject.integrationKeyID.String = src.ID
@@ -16,6 +16,8 @@ const (
 	TypePolicyUpdated      Type = "policy_updated"
 	TypeDuplicateSupressed Type = "duplicate_suppressed"
 	TypeEscalationRequest  Type = "escalation_request"
+	TypeFlapping           Type = "flapping"
+	TypeAckTimeout         Type = "ack_timeout"
 
 	// not exported, status_changed will be turned into an acknowledged where appropriate
 	_TypeStatusChanged Type = "status_changed"
@@ -83,7 +83,8 @@ var searchTemplate = template.Must(template.New("search").Parse(`
 		a.source,
 		a.status,
 		created_at,
-		a.dedup_key
+		a.dedup_key,
+		(a.status != 'closed' and exists (select 1 from alert_flapping f where f.alert_id = a.id))
 	FROM alerts a
 	{{ if .Search }}
 		JOIN services svc ON svc.id = a.service_id
@@ -16,6 +16,9 @@ const (
 	SourcePrometheusAlertmanager Source = "prometheusAlertmanager" // prometheus alertmanager alert
 	SourceManual                 Source = "manual"                 // manually triggered
 	SourceGeneric                Source = "generic"                // generic API
+	SourceDatadog                Source = "datadog"                // datadog webhook
+	SourceNewRelic               Source = "newRelic"               // new relic webhook
+	SourceAWSSNS                 Source = "awsSNS"                 // aws sns notification
 )
 
 func (s Source) Value() (driver.Value, error) {
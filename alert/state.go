@@ -10,4 +10,8 @@ type State struct {
 	StepNumber     int
 	RepeatCount    int
 	LastEscalation time.Time
+
+	// FallbackNotified indicates the escalation policy's fallback target has been notified
+	// after all repeats were exhausted.
+	FallbackNotified bool
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	alertlog "github.com/target/goalert/alert/log"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/log"
@@ -24,7 +25,9 @@ type Store interface {
 	Create(context.Context, *Alert) (*Alert, error)
 
 	// CreateOrUpdate will create an alert or log a "duplicate suppressed message" if
-	// Status is Triggered. If Status is Closed, it will close and return the result.
+	// Status is Triggered. If Status is Closed, it will close and return the result, unless
+	// flap detection is enabled and the dedup key is flapping, in which case the alert is
+	// kept open.
 	//
 	// In the case that Status is closed but a matching alert is not present, nil is returned.
 	// Otherwise the current alert is returned.
@@ -83,6 +86,12 @@ type DB struct {
 
 	noStepsBySvc *sql.Stmt
 
+	findOpenDedup    *sql.Stmt
+	addTransition    *sql.Stmt
+	countTransitions *sql.Stmt
+	setFlapping      *sql.Stmt
+	reopenFlapping   *sql.Stmt
+
 	epID *sql.Stmt
 
 	escalate *sql.Stmt
@@ -164,13 +173,14 @@ func NewDB(ctx context.Context, db *sql.DB, logDB alertlog.Store) (*DB, error) {
 				a.source,
 				a.status,
 				created_at,
-				a.dedup_key
+				a.dedup_key,
+				(a.status != 'closed' and exists (select 1 from alert_flapping f where f.alert_id = a.id))
 			FROM alerts a
 			WHERE a.id = ANY ($1)
 		`),
 		createUpdNew: p(`
 			WITH existing as (
-				SELECT id, summary, details, status, source, created_at, false
+				SELECT id, summary, details, status, source, created_at, false, exists (select 1 from alert_flapping f where f.alert_id = alerts.id)
 				FROM alerts
 				WHERE service_id = $3 AND dedup_key = $5
 			), to_insert as (
@@ -184,7 +194,7 @@ func NewDB(ctx context.Context, db *sql.DB, logDB alertlog.Store) (*DB, error) {
 				)
 				SELECT $1, $2, $3, $4, $5
 				FROM to_insert
-				RETURNING id, summary, details, status, source, created_at, true
+				RETURNING id, summary, details, status, source, created_at, true, false
 			)
 			SELECT * FROM existing
 			UNION
@@ -211,6 +221,33 @@ func NewDB(ctx context.Context, db *sql.DB, logDB alertlog.Store) (*DB, error) {
 			RETURNING id, summary, details, created_at
 		`),
 
+		findOpenDedup: p(`
+			SELECT a.id, a.summary, a.details, a.status, a.created_at, f.alert_id NOTNULL
+			FROM alerts a
+			LEFT JOIN alert_flapping f ON f.alert_id = a.id
+			WHERE a.service_id = $1 AND a.dedup_key = $2
+		`),
+		addTransition: p(`INSERT INTO alert_dedup_transitions (service_id, dedup_key, closed) VALUES ($1, $2, $3)`),
+		countTransitions: p(`
+			SELECT count(*)
+			FROM alert_dedup_transitions
+			WHERE
+				service_id = $1 AND
+				dedup_key = $2 AND
+				created_at > now() - $3::int * '1 minute'::interval
+		`),
+		setFlapping: p(`
+			INSERT INTO alert_flapping (alert_id, source_closed)
+			VALUES ($1, $2)
+			ON CONFLICT (alert_id) DO UPDATE
+			SET source_closed = $2, last_transition_at = now()
+		`),
+		reopenFlapping: p(`
+			UPDATE alert_flapping
+			SET source_closed = false, last_transition_at = now()
+			WHERE alert_id = $1 AND source_closed
+		`),
+
 		getCreationTime: p("SELECT created_at FROM alerts WHERE id = $1"),
 		getServiceID:    p("SELECT service_id FROM alerts WHERE id = $1"),
 		updateByStatusAndService: p(`
@@ -243,7 +280,7 @@ func NewDB(ctx context.Context, db *sql.DB, logDB alertlog.Store) (*DB, error) {
 		`),
 
 		epState: p(`
-			SELECT alert_id, last_escalation, loop_count, escalation_policy_step_number 
+			SELECT alert_id, last_escalation, loop_count, escalation_policy_step_number, fallback_notified
 			FROM escalation_policy_state
 			WHERE alert_id = ANY ($1)
 		`),
@@ -592,6 +629,9 @@ func (db *DB) CreateOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Aler
 		return nil, false, err
 	}
 
+	cfg := config.FromContext(ctx)
+	flapDetect := cfg.Alerts.FlapThreshold > 0
+
 	var inserted bool
 	var logType alertlog.Type
 	var meta interface{}
@@ -600,7 +640,16 @@ func (db *DB) CreateOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Aler
 		var m alertlog.CreatedMetaData
 		err = tx.Stmt(db.createUpdNew).
 			QueryRowContext(ctx, n.Summary, n.Details, n.ServiceID, n.Source, n.DedupKey()).
-			Scan(&n.ID, &n.Summary, &n.Details, &n.Status, &n.Source, &n.CreatedAt, &inserted)
+			Scan(&n.ID, &n.Summary, &n.Details, &n.Status, &n.Source, &n.CreatedAt, &inserted, &n.Flapping)
+		if err == nil && flapDetect {
+			var reopened bool
+			reopened, err = db.recordTrigger(ctx, tx, n, inserted)
+			if reopened {
+				logType = alertlog.TypeFlapping
+				meta = &alertlog.FlappingMetaData{}
+				break
+			}
+		}
 		if !inserted {
 			logType = alertlog.TypeDuplicateSupressed
 		} else {
@@ -620,6 +669,16 @@ func (db *DB) CreateOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Aler
 			logType = alertlog.TypeAcknowledged
 		}
 	case StatusClosed:
+		if flapDetect {
+			var held bool
+			held, err = db.holdFlapping(ctx, tx, n, cfg)
+			if err != nil {
+				return nil, false, err
+			}
+			if held {
+				return n, false, nil
+			}
+		}
 		err = tx.Stmt(db.createUpdClose).
 			QueryRowContext(ctx, n.ServiceID, n.DedupKey()).
 			Scan(&n.ID, &n.Summary, &n.Details, &n.CreatedAt)
@@ -639,6 +698,72 @@ func (db *DB) CreateOrUpdateTx(ctx context.Context, tx *sql.Tx, a *Alert) (*Aler
 	return n, inserted, nil
 }
 
+// recordTrigger records an open transition for a newly created alert, or for an existing
+// flapping alert whose source had closed. It returns true in the latter case.
+func (db *DB) recordTrigger(ctx context.Context, tx *sql.Tx, n *Alert, inserted bool) (bool, error) {
+	if !inserted {
+		if !n.Flapping {
+			return false, nil
+		}
+		res, err := tx.StmtContext(ctx, db.reopenFlapping).ExecContext(ctx, n.ID)
+		if err != nil {
+			return false, err
+		}
+		rows, err := res.RowsAffected()
+		if err != nil || rows == 0 {
+			return false, err
+		}
+	}
+
+	_, err := tx.StmtContext(ctx, db.addTransition).ExecContext(ctx, n.ServiceID, n.DedupKey(), false)
+	if err != nil {
+		return false, err
+	}
+
+	return !inserted, nil
+}
+
+// holdFlapping records a close transition for an open alert. It returns true if the
+// alert should instead be kept open because its dedup key is flapping.
+func (db *DB) holdFlapping(ctx context.Context, tx *sql.Tx, n *Alert, cfg config.Config) (bool, error) {
+	err := tx.StmtContext(ctx, db.findOpenDedup).
+		QueryRowContext(ctx, n.ServiceID, n.DedupKey()).
+		Scan(&n.ID, &n.Summary, &n.Details, &n.Status, &n.CreatedAt, &n.Flapping)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.StmtContext(ctx, db.addTransition).ExecContext(ctx, n.ServiceID, n.DedupKey(), true)
+	if err != nil {
+		return false, err
+	}
+
+	meta := &alertlog.FlappingMetaData{SourceClosed: true}
+	if !n.Flapping {
+		err = tx.StmtContext(ctx, db.countTransitions).
+			QueryRowContext(ctx, n.ServiceID, n.DedupKey(), int(cfg.FlapWindow()/time.Minute)).
+			Scan(&meta.Transitions)
+		if err != nil {
+			return false, err
+		}
+		if meta.Transitions < cfg.Alerts.FlapThreshold {
+			return false, nil
+		}
+	}
+
+	_, err = tx.StmtContext(ctx, db.setFlapping).ExecContext(ctx, n.ID, true)
+	if err != nil {
+		return false, err
+	}
+	n.Flapping = true
+	db.logDB.MustLogTx(ctx, tx, n.ID, alertlog.TypeFlapping, meta)
+
+	return true, nil
+}
+
 func (db *DB) CreateOrUpdate(ctx context.Context, a *Alert) (*Alert, error) {
 	err := permission.LimitCheckAny(ctx,
 		permission.System,
@@ -851,7 +976,7 @@ func (db *DB) State(ctx context.Context, alertIDs []int) ([]State, error) {
 	list := make([]State, 0, len(alertIDs))
 	for rows.Next() {
 		var s State
-		err = rows.Scan(&s.AlertID, &t, &s.RepeatCount, &s.StepNumber)
+		err = rows.Scan(&s.AlertID, &t, &s.RepeatCount, &s.StepNumber, &s.FallbackNotified)
 		if t.Valid {
 			s.LastEscalation = t.Time
 		}
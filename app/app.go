@@ -11,6 +11,7 @@ import (
 	"github.com/target/goalert/alert"
 	alertlog "github.com/target/goalert/alert/log"
 	"github.com/target/goalert/app/lifecycle"
+	"github.com/target/goalert/audit"
 	"github.com/target/goalert/auth"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/auth/nonce"
@@ -22,6 +23,7 @@ import (
 	"github.com/target/goalert/graphql"
 	"github.com/target/goalert/graphql2/graphqlapp"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/holiday"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/label"
@@ -32,11 +34,18 @@ import (
 	"github.com/target/goalert/notification/twilio"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/outgoingwebhook"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/schedule/shiftexport"
+	"github.com/target/goalert/schedule/shiftimport"
+	"github.com/target/goalert/schedule/shiftreport"
 	"github.com/target/goalert/service"
+	"github.com/target/goalert/shiftrequest"
+	"github.com/target/goalert/syntheticcheck"
+	"github.com/target/goalert/timeoff"
 	"github.com/target/goalert/timezone"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
@@ -115,6 +124,16 @@ type App struct {
 	NCStore       notificationchannel.Store
 	TimeZoneStore *timezone.Store
 	NoticeStore   *notice.Store
+	AuditStore    *audit.Store
+
+	OutgoingWebhookStore *outgoingwebhook.Store
+	SyntheticCheckStore  *syntheticcheck.Store
+	ShiftRequestStore    *shiftrequest.Store
+	TimeOffStore         *timeoff.Store
+	ShiftImportStore     *shiftimport.Store
+	ShiftExportStore     *shiftexport.Store
+	ShiftReportStore     *shiftreport.Store
+	HolidayStore         *holiday.Store
 }
 
 // NewApp constructs a new App and binds the listening socket.
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
@@ -22,8 +23,11 @@ import (
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/migrate"
+	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/remotemonitor"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/shiftimport"
 	"github.com/target/goalert/sqltrace"
 	"github.com/target/goalert/switchover"
 	"github.com/target/goalert/switchover/dbsync"
@@ -625,6 +629,156 @@ Migration: %s (#%d)
 			return nil
 		},
 	}
+
+	importScheduleCmd = &cobra.Command{
+		Use:   "import-schedule",
+		Short: "Import fixed shifts into a schedule from an iCal or CSV file.",
+		Long: `Import fixed shifts into a schedule from an iCal or CSV file.
+
+CSV files must have rows of (user email, start, end), with an optional header row.
+For iCal files, each event is a shift for the user identified by the first attendee
+(falling back to the organizer, or an email address in the summary).
+
+Nothing is changed if any conflicts are found, or if --dry-run is set.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if viper.GetBool("verbose") {
+				log.EnableVerbose()
+			}
+
+			err := viper.ReadInConfig()
+			// ignore file not found error
+			if err != nil && !isCfgNotFound(err) {
+				return errors.Wrap(err, "read config")
+			}
+
+			c, err := getConfig()
+			if err != nil {
+				return err
+			}
+
+			fileName := cmd.Flag("file").Value.String()
+			if fileName == "" {
+				return validation.NewFieldError("file", "is required")
+			}
+			format := shiftimport.Format(cmd.Flag("format").Value.String())
+			if format == "" {
+				format = shiftimport.FormatCSV
+				if strings.HasSuffix(strings.ToLower(fileName), ".ics") {
+					format = shiftimport.FormatICal
+				}
+			}
+
+			var r io.Reader = os.Stdin
+			if fileName != "-" {
+				f, err := os.Open(fileName)
+				if err != nil {
+					return errors.Wrap(err, "open file")
+				}
+				defer f.Close()
+				r = f
+			}
+			data, err := io.ReadAll(io.LimitReader(r, shiftimport.MaxDataSize+1))
+			if err != nil {
+				return errors.Wrap(err, "read file")
+			}
+			if len(data) > shiftimport.MaxDataSize {
+				return errors.New("file is too large")
+			}
+
+			db, err := sql.Open("pgx", c.DBURL)
+			if err != nil {
+				return errors.Wrap(err, "connect to postgres")
+			}
+			defer db.Close()
+
+			ctx := permission.SystemContext(context.Background(), "ImportSchedule")
+
+			userStore, err := user.NewDB(ctx, db)
+			if err != nil {
+				return errors.Wrap(err, "init user store")
+			}
+			schedStore, err := schedule.NewStore(ctx, db, userStore)
+			if err != nil {
+				return errors.Wrap(err, "init schedule store")
+			}
+			overrideStore, err := override.NewDB(ctx, db)
+			if err != nil {
+				return errors.Wrap(err, "init override store")
+			}
+			importStore, err := shiftimport.NewStore(ctx, db, schedStore, overrideStore)
+			if err != nil {
+				return errors.Wrap(err, "init shift import store")
+			}
+
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				return errors.Wrap(err, "begin tx")
+			}
+			defer tx.Rollback()
+
+			scheduleID := cmd.Flag("schedule-id").Value.String()
+			sched, err := schedStore.FindOneForUpdate(ctx, tx, scheduleID)
+			if err != nil {
+				return errors.Wrap(err, "find schedule")
+			}
+			loc := sched.TimeZone
+			if tz := cmd.Flag("time-zone").Value.String(); tz != "" {
+				loc, err = util.LoadLocation(tz)
+				if err != nil {
+					return errors.Wrap(err, "load time zone")
+				}
+			}
+
+			rows, err := shiftimport.Parse(format, bytes.NewReader(data), loc)
+			if err != nil {
+				return errors.Wrap(err, "parse file")
+			}
+
+			dryRun := cmd.Flag("dry-run").Value.String() == "true"
+			res, err := importStore.ImportTx(ctx, tx, shiftimport.Options{
+				ScheduleID: scheduleID,
+				Mode:       shiftimport.Mode(cmd.Flag("mode").Value.String()),
+				Rows:       rows,
+				DryRun:     dryRun,
+			})
+			if err != nil {
+				return errors.Wrap(err, "import shifts")
+			}
+
+			w := cmd.OutOrStdout()
+			const timeFmt = "2006-01-02 15:04 MST"
+			for _, s := range res.Shifts {
+				fmt.Fprintf(w, "line %d: %s from %s to %s\n", s.Line, s.Email, s.Start.In(loc).Format(timeFmt), s.End.In(loc).Format(timeFmt))
+			}
+			for _, c := range res.Conflicts {
+				if c.Line == 0 {
+					fmt.Fprintf(w, "CONFLICT: %s\n", c.Message)
+					continue
+				}
+				fmt.Fprintf(w, "CONFLICT line %d: %s (%s)\n", c.Line, c.Message, c.Email)
+			}
+			if res.Skipped > 0 {
+				fmt.Fprintf(w, "Skipped %d shift(s) that have already ended.\n", res.Skipped)
+			}
+
+			if len(res.Conflicts) > 0 {
+				return errors.Errorf("found %d conflict(s), nothing was imported", len(res.Conflicts))
+			}
+			if dryRun {
+				fmt.Fprintf(w, "Dry run, %d shift(s) would be imported.\n", len(res.Shifts))
+				return nil
+			}
+
+			err = tx.Commit()
+			if err != nil {
+				return errors.Wrap(err, "commit tx")
+			}
+
+			log.Logf(ctx, "Imported %d shift(s) into schedule '%s'.", len(res.Shifts), sched.Name)
+
+			return nil
+		},
+	}
 )
 
 // getConfig will load the current configuration from viper
@@ -656,6 +810,7 @@ func getConfig() (Config, error) {
 
 		SlackBaseURL:  viper.GetString("slack-base-url"),
 		TwilioBaseURL: viper.GetString("twilio-base-url"),
+		SNSBaseURL:    viper.GetString("sns-base-url"),
 
 		DBURL:     viper.GetString("db-url"),
 		DBURLNext: viper.GetString("db-url-next"),
@@ -734,6 +889,7 @@ func init() {
 
 	RootCmd.Flags().String("twilio-base-url", def.TwilioBaseURL, "Override the Twilio API URL.")
 	RootCmd.Flags().String("slack-base-url", def.SlackBaseURL, "Override the Slack base URL.")
+	RootCmd.Flags().String("sns-base-url", def.SNSBaseURL, "Override the AWS SNS URL that signing certificates and subscription confirmations must be served from.")
 
 	RootCmd.Flags().String("region-name", def.RegionName, "Name of region for message processing (case sensitive). Only one instance per-region-name will process outgoing messages.")
 
@@ -782,9 +938,16 @@ func init() {
 
 	testCmd.Flags().Bool("offline", false, "Only perform offline checks.")
 
+	importScheduleCmd.Flags().String("schedule-id", "", "ID of the schedule to import shifts into (required).")
+	importScheduleCmd.Flags().StringP("file", "f", "", "File to import, or '-' to read from stdin (required).")
+	importScheduleCmd.Flags().String("format", "", "Format of the file, 'csv' or 'ical'. Default is 'ical' for .ics files, otherwise 'csv'.")
+	importScheduleCmd.Flags().String("mode", string(shiftimport.ModeTemporarySchedule), "How to apply shifts, 'temporary_schedule' to replace the schedule for the imported time range, or 'overrides' to add each shift as an override.")
+	importScheduleCmd.Flags().String("time-zone", "", "Time zone for times without an explicit offset. Default is the schedule's time zone.")
+	importScheduleCmd.Flags().Bool("dry-run", false, "Only preview the import, without making any changes.")
+
 	monitorCmd.Flags().StringP("config-file", "f", "", "Configuration file for monitoring (required).")
 	initCertCommands()
-	RootCmd.AddCommand(versionCmd, testCmd, migrateCmd, exportCmd, monitorCmd, switchCmd, addUserCmd, getConfigCmd, setConfigCmd, genCerts)
+	RootCmd.AddCommand(versionCmd, testCmd, migrateCmd, exportCmd, monitorCmd, switchCmd, addUserCmd, importScheduleCmd, getConfigCmd, setConfigCmd, genCerts)
 
 	err := viper.BindPFlags(RootCmd.Flags())
 	if err != nil {
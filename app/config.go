@@ -35,6 +35,7 @@ type Config struct {
 
 	TwilioBaseURL string
 	SlackBaseURL  string
+	SNSBaseURL    string
 
 	DBURL     string
 	DBURLNext string
@@ -3,10 +3,12 @@ package app
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"os"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"github.com/target/goalert/audit"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/log"
@@ -45,11 +47,21 @@ func getSetConfig(setCfg bool, data []byte) error {
 		return errors.Wrap(err, "init config store")
 	}
 	if setCfg {
+		_, _, oldData, err := s.ConfigData(ctx, tx)
+		if err != nil {
+			return errors.Wrap(err, "read config")
+		}
+
 		id, err := s.SetConfigData(ctx, tx, data)
 		if err != nil {
 			return errors.Wrap(err, "save config")
 		}
 
+		err = logSetConfig(ctx, db, tx, oldData, data)
+		if err != nil {
+			return errors.Wrap(err, "record audit log")
+		}
+
 		err = tx.Commit()
 		if err != nil {
 			return errors.Wrap(err, "commit changes")
@@ -71,3 +83,29 @@ func getSetConfig(setCfg bool, data []byte) error {
 	_, err = os.Stdout.Write(data)
 	return err
 }
+
+// logSetConfig will record an audit log entry for replacing the config data.
+func logSetConfig(ctx context.Context, db *sql.DB, tx *sql.Tx, oldData, newData []byte) error {
+	var before, after config.Config
+	err := json.Unmarshal(oldData, &before)
+	if err != nil {
+		return errors.Wrap(err, "parse old config")
+	}
+	err = json.Unmarshal(newData, &after)
+	if err != nil {
+		return errors.Wrap(err, "parse new config")
+	}
+
+	e := audit.Entry{Action: "set-config"}
+	e.Before, e.After, err = audit.ConfigSnapshots(before, after)
+	if err != nil {
+		return err
+	}
+
+	a, err := audit.NewStore(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "init audit store")
+	}
+
+	return a.LogTx(ctx, tx, &e)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/target/goalert/auth"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/auth/github"
+	"github.com/target/goalert/auth/ldap"
 	"github.com/target/goalert/auth/oidc"
 )
 
@@ -19,6 +20,7 @@ func (app *App) initAuth(ctx context.Context) error {
 		IntKeyStore:    app.IntegrationKeyStore,
 		CalSubStore:    app.CalSubStore,
 		APIKeyring:     app.APIKeyring,
+		RotationStore:  app.RotationStore,
 	})
 	if err != nil {
 		return errors.Wrap(err, "init auth handler")
@@ -45,6 +47,12 @@ func (app *App) initAuth(ctx context.Context) error {
 	}
 	app.AuthHandler.AddIdentityProvider("github", githubProvider)
 
+	ldapProvider, err := ldap.NewProvider(ctx, ldap.Config{})
+	if err != nil {
+		return errors.Wrap(err, "init LDAP auth provider")
+	}
+	app.AuthHandler.AddIdentityProvider("ldap", ldapProvider)
+
 	basicProvider, err := basic.NewProvider(ctx, app.AuthBasicStore)
 	if err != nil {
 		return errors.Wrap(err, "init basic auth provider")
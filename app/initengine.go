@@ -38,6 +38,10 @@ func (app *App) initEngine(ctx context.Context) error {
 		UserStore:           app.UserStore,
 		NotificationStore:   app.NotificationStore,
 		NCStore:             app.NCStore,
+		ShiftRequestStore:   app.ShiftRequestStore,
+		OnCallStore:         app.OnCallStore,
+		ScheduleStore:       app.ScheduleStore,
+		TimeOffStore:        app.TimeOffStore,
 
 		ConfigSource: app.ConfigStore,
 
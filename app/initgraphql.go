@@ -6,6 +6,7 @@ import (
 	"github.com/target/goalert/graphql"
 	"github.com/target/goalert/graphql2/graphqlapp"
 	"github.com/target/goalert/schedule/shiftcalc"
+	"github.com/target/goalert/util/pubsub"
 )
 
 func (app *App) initGraphQL(ctx context.Context) error {
@@ -44,6 +45,16 @@ func (app *App) initGraphQL(ctx context.Context) error {
 		SlackStore:        app.slackChan,
 		HeartbeatStore:    app.HeartbeatStore,
 		NoticeStore:       *app.NoticeStore,
+		AuditStore:        app.AuditStore,
+		WebhookStore:      app.OutgoingWebhookStore,
+		SyntheticStore:    app.SyntheticCheckStore,
+		ShiftReqStore:     app.ShiftRequestStore,
+		TimeOffStore:      app.TimeOffStore,
+		ShiftImport:       app.ShiftImportStore,
+		ShiftExport:       app.ShiftExportStore,
+		ShiftReport:       app.ShiftReportStore,
+		HolidayStore:      app.HolidayStore,
+		Events:            pubsub.NewBroker(),
 		Twilio:            app.twilioConfig,
 		AuthHandler:       app.AuthHandler,
 	}
@@ -9,10 +9,13 @@ import (
 
 	"contrib.go.opencensus.io/exporter/stackdriver/propagation"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/target/goalert/awssns"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/datadog"
 	"github.com/target/goalert/genericapi"
 	"github.com/target/goalert/grafana"
 	"github.com/target/goalert/mailgun"
+	"github.com/target/goalert/newrelic"
 	"github.com/target/goalert/notification/twilio"
 	prometheus "github.com/target/goalert/prometheusalertmanager"
 	"github.com/target/goalert/site24x7"
@@ -112,7 +115,7 @@ func (app *App) initHTTP(ctx context.Context) error {
 		logRequest(app.cfg.LogRequests),
 
 		// max request time
-		timeout(2 * time.Minute),
+		timeout(2*time.Minute, app.isGraphQLSubscription),
 
 		func(next http.Handler) http.Handler {
 			return http.StripPrefix(app.cfg.HTTPPrefix, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -143,6 +146,8 @@ func (app *App) initHTTP(ctx context.Context) error {
 			perIntKey:  1,
 			perService: 2,
 			perUser:    3,
+
+			exempt: app.isGraphQLSubscription,
 		}.Middleware,
 
 		wrapGzip,
@@ -188,11 +193,19 @@ func (app *App) initHTTP(ctx context.Context) error {
 	mux.HandleFunc("/api/v2/grafana/incoming", grafana.GrafanaToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
 	mux.HandleFunc("/api/v2/site24x7/incoming", site24x7.Site24x7ToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
 	mux.HandleFunc("/api/v2/prometheusalertmanager/incoming", prometheus.PrometheusAlertmanagerEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/datadog/incoming", datadog.DatadogToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/newrelic/incoming", newrelic.NewRelicToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.Handle("/api/v2/sns/incoming", awssns.NewHandler(awssns.Config{
+		AlertStore: app.AlertStore,
+		BaseURL:    app.cfg.SNSBaseURL,
+	}))
 
 	mux.HandleFunc("/api/v2/generic/incoming", generic.ServeCreateAlert)
+	mux.HandleFunc("/api/v2/generic/json", generic.ServeCreateAlertJSON)
 	mux.HandleFunc("/api/v2/heartbeat/", generic.ServeHeartbeatCheck)
 	mux.HandleFunc("/api/v2/user-avatar/", generic.ServeUserAvatar)
 	mux.HandleFunc("/api/v2/calendar", app.CalSubStore.ServeICalData)
+	mux.HandleFunc("/api/v2/schedules/shifts/export", app.ShiftExportStore.ServeExport)
 
 	mux.HandleFunc("/api/v2/twilio/message", app.twilioSMS.ServeMessage)
 	mux.HandleFunc("/api/v2/twilio/message/status", app.twilioSMS.ServeStatusCallback)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/target/goalert/alert"
 	alertlog "github.com/target/goalert/alert/log"
+	"github.com/target/goalert/audit"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/auth/nonce"
 	"github.com/target/goalert/calendarsubscription"
@@ -13,6 +14,7 @@ import (
 	"github.com/target/goalert/engine/resolver"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/holiday"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/label"
@@ -22,12 +24,19 @@ import (
 	"github.com/target/goalert/notification/slack"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/outgoingwebhook"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/schedule/shiftexport"
+	"github.com/target/goalert/schedule/shiftimport"
+	"github.com/target/goalert/schedule/shiftreport"
 	"github.com/target/goalert/service"
+	"github.com/target/goalert/shiftrequest"
+	"github.com/target/goalert/syntheticcheck"
+	"github.com/target/goalert/timeoff"
 	"github.com/target/goalert/timezone"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
@@ -270,5 +279,68 @@ func (app *App) initStores(ctx context.Context) error {
 		return errors.Wrap(err, "init notice store")
 	}
 
+	if app.AuditStore == nil {
+		app.AuditStore, err = audit.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init audit store")
+	}
+
+	if app.OutgoingWebhookStore == nil {
+		app.OutgoingWebhookStore, err = outgoingwebhook.NewStore(ctx, app.db, app.cfg.EncryptionKeys)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init outgoing webhook store")
+	}
+
+	if app.SyntheticCheckStore == nil {
+		app.SyntheticCheckStore, err = syntheticcheck.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init synthetic check store")
+	}
+
+	if app.ShiftRequestStore == nil {
+		app.ShiftRequestStore, err = shiftrequest.NewStore(ctx, app.db, app.OverrideStore)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init shift request store")
+	}
+
+	if app.TimeOffStore == nil {
+		app.TimeOffStore, err = timeoff.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init time off store")
+	}
+
+	if app.ShiftImportStore == nil {
+		app.ShiftImportStore, err = shiftimport.NewStore(ctx, app.db, app.ScheduleStore, app.OverrideStore)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init shift import store")
+	}
+
+	if app.ShiftExportStore == nil {
+		app.ShiftExportStore, err = shiftexport.NewStore(ctx, app.db, app.OnCallStore)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init shift export store")
+	}
+
+	if app.ShiftReportStore == nil {
+		app.ShiftReportStore, err = shiftreport.NewStore(ctx, app.db, app.OnCallStore)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init shift report store")
+	}
+
+	if app.HolidayStore == nil {
+		app.HolidayStore, err = holiday.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init holiday store")
+	}
+
 	return nil
 }
@@ -5,13 +5,15 @@ import (
 
 	"github.com/jackc/pgconn"
 	"github.com/pkg/errors"
+	"github.com/target/goalert/graphql2/graphqlapp"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
 )
 
 func (app *App) listenEvents(ctx context.Context) (<-chan struct{}, error) {
-	l, err := sqlutil.NewListener(ctx, (*sqlutil.DBConnector)(app.db), "/goalert/config-refresh")
+	channels := append([]string{"/goalert/config-refresh"}, graphqlapp.NotifyChannels...)
+	l, err := sqlutil.NewListener(ctx, (*sqlutil.DBConnector)(app.db), channels...)
 	if err != nil {
 		return nil, err
 	}
@@ -52,6 +54,8 @@ func (app *App) listenEvents(ctx context.Context) (<-chan struct{}, error) {
 				permission.SudoContext(ctx, func(ctx context.Context) {
 					log.Log(ctx, app.ConfigStore.Reload(ctx))
 				})
+			default:
+				app.graphql2.Notify(n.Channel, n.Payload)
 			}
 		}
 	}()
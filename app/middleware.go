@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/graphql"
+	"github.com/target/goalert/graphql2/graphqlapp"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/log"
 )
@@ -162,9 +163,20 @@ func authCheckLimit(max int) func(http.Handler) http.Handler {
 	}
 }
 
-func timeout(timeout time.Duration) func(http.Handler) http.Handler {
+// isGraphQLSubscription will return true if req is a WebSocket handshake for the GraphQL endpoint.
+func (app *App) isGraphQLSubscription(req *http.Request) bool {
+	return strings.TrimPrefix(req.URL.Path, app.cfg.HTTPPrefix) == "/api/graphql" &&
+		graphqlapp.IsWebSocketUpgrade(req)
+}
+
+func timeout(timeout time.Duration, exempt func(*http.Request) bool) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if exempt(req) {
+				// GraphQL subscriptions stay open until the client disconnects
+				next.ServeHTTP(w, req)
+				return
+			}
 			ctx, cancel := context.WithTimeout(req.Context(), timeout)
 			defer cancel()
 			next.ServeHTTP(w, req.WithContext(ctx))
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_isGraphQLSubscription(t *testing.T) {
+	app := &App{cfg: Config{HTTPPrefix: "/goalert"}}
+
+	check := func(desc string, expected bool, method, path, conn, upgrade string) {
+		t.Helper()
+		req := httptest.NewRequest(method, path, nil)
+		if conn != "" {
+			req.Header.Set("Connection", conn)
+		}
+		if upgrade != "" {
+			req.Header.Set("Upgrade", upgrade)
+		}
+		assert.Equal(t, expected, app.isGraphQLSubscription(req), desc)
+	}
+
+	check("handshake", true, "GET", "/goalert/api/graphql", "Upgrade", "websocket")
+	check("handshake (prefix stripped)", true, "GET", "/api/graphql", "keep-alive, Upgrade", "WebSocket")
+	check("other path", false, "GET", "/goalert/api/v2/identity/providers", "Upgrade", "websocket")
+	check("missing connection", false, "GET", "/goalert/api/graphql", "", "websocket")
+	check("post", false, "POST", "/goalert/api/graphql", "Upgrade", "websocket")
+	check("plain request", false, "POST", "/goalert/api/graphql", "", "")
+}
@@ -13,6 +13,9 @@ type conReqLimit struct {
 	perUser    int
 	perService int
 	perIntKey  int
+
+	// exempt requests are not counted against the per-user limit
+	exempt func(*http.Request) bool
 }
 
 func getIntKey(ctx context.Context) string {
@@ -56,7 +59,8 @@ func (cfg conReqLimit) Middleware(next http.Handler) http.Handler {
 			defer svcLim.Unlock(id)
 		}
 
-		if id := permission.UserID(ctx); id != "" {
+		// long-lived WebSocket connections (GraphQL subscriptions) would otherwise hold a slot
+		if id := permission.UserID(ctx); id != "" && !cfg.exempt(req) {
 			if failure(userLim.Lock(ctx, id)) {
 				return
 			}
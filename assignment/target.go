@@ -61,6 +61,10 @@ type (
 	CalendarSubscriptionTarget string
 	// UserSessionTarget implements the Target interface by wrapping a UserSession ID.
 	UserSessionTarget string
+	// OutgoingWebhookTarget implements the Target interface by wrapping an OutgoingWebhook ID.
+	OutgoingWebhookTarget string
+	// SyntheticCheckTarget implements the Target interface by wrapping a SyntheticCheck ID.
+	SyntheticCheckTarget string
 )
 
 // TargetType implements the Target interface.
@@ -146,3 +150,15 @@ func (UserSessionTarget) TargetType() TargetType { return TargetTypeUserSession
 
 // TargetID implements the Target interface.
 func (s UserSessionTarget) TargetID() string { return string(s) }
+
+// TargetType implements the Target interface.
+func (OutgoingWebhookTarget) TargetType() TargetType { return TargetTypeOutgoingWebhook }
+
+// TargetID implements the Target interface.
+func (w OutgoingWebhookTarget) TargetID() string { return string(w) }
+
+// TargetType implements the Target interface.
+func (SyntheticCheckTarget) TargetType() TargetType { return TargetTypeSyntheticCheck }
+
+// TargetID implements the Target interface.
+func (c SyntheticCheckTarget) TargetID() string { return string(c) }
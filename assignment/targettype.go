@@ -31,6 +31,8 @@ const (
 	TargetTypeContactMethod
 	TargetTypeHeartbeatMonitor
 	TargetTypeUserSession
+	TargetTypeOutgoingWebhook
+	TargetTypeSyntheticCheck
 )
 
 var _ graphql.Marshaler = TargetType(0)
@@ -71,6 +73,10 @@ func (tt *TargetType) UnmarshalText(data []byte) error {
 		*tt = TargetTypeHeartbeatMonitor
 	case "userSession":
 		*tt = TargetTypeUserSession
+	case "outgoingWebhook":
+		*tt = TargetTypeOutgoingWebhook
+	case "syntheticCheck":
+		*tt = TargetTypeSyntheticCheck
 	default:
 		return validation.NewFieldError("TargetType", "unknown target type "+str)
 	}
@@ -119,6 +125,10 @@ func (tt TargetType) MarshalText() ([]byte, error) {
 		return []byte("heartbeatMonitor"), nil
 	case TargetTypeUserSession:
 		return []byte("userSession"), nil
+	case TargetTypeOutgoingWebhook:
+		return []byte("outgoingWebhook"), nil
+	case TargetTypeSyntheticCheck:
+		return []byte("syntheticCheck"), nil
 	}
 
 	return nil, validation.NewFieldError("TargetType", "unknown target type "+tt.String())
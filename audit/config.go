@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/target/goalert/config"
+)
+
+const (
+	redacted        = "<redacted>"
+	redactedChanged = "<redacted:changed>"
+)
+
+// flattenConfig returns a map of config IDs (e.g. General.PublicURL) to values.
+// Fields tagged as passwords are returned separately.
+func flattenConfig(cfg config.Config) (values map[string]interface{}, passwords map[string]string) {
+	values = make(map[string]interface{})
+	passwords = make(map[string]string)
+
+	v := reflect.ValueOf(cfg)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sect := t.Field(i)
+		if sect.PkgPath != "" || sect.Type.Kind() != reflect.Struct {
+			continue
+		}
+		sv := v.Field(i)
+		for j := 0; j < sect.Type.NumField(); j++ {
+			f := sect.Type.Field(j)
+			id := sect.Name + "." + f.Name
+			if f.Tag.Get("password") == "true" {
+				passwords[id] = sv.Field(j).String()
+				continue
+			}
+			values[id] = sv.Field(j).Interface()
+		}
+	}
+
+	return values, passwords
+}
+
+// ConfigSnapshots will return Before and After snapshots for a config change.
+//
+// Password values are never included; they are replaced with a placeholder
+// that indicates if the value was changed.
+func ConfigSnapshots(before, after config.Config) (json.RawMessage, json.RawMessage, error) {
+	bVals, bPass := flattenConfig(before)
+	aVals, aPass := flattenConfig(after)
+
+	for id, b := range bPass {
+		a := aPass[id]
+		if b != "" {
+			bVals[id] = redacted
+		} else {
+			bVals[id] = ""
+		}
+		switch {
+		case a == "":
+			aVals[id] = ""
+		case a != b:
+			aVals[id] = redactedChanged
+		default:
+			aVals[id] = redacted
+		}
+	}
+
+	bData, err := json.Marshal(bVals)
+	if err != nil {
+		return nil, nil, err
+	}
+	aData, err := json.Marshal(aVals)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bData, aData, nil
+}
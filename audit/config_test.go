@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+)
+
+func TestConfigSnapshots(t *testing.T) {
+	var before, after config.Config
+	before.General.PublicURL = "http://old.example.com"
+	before.Slack.ClientSecret = "secret1"
+	before.Twilio.AuthToken = "token"
+	after.General.PublicURL = "http://new.example.com"
+	after.Slack.ClientSecret = "secret2"
+	after.Twilio.AuthToken = "token"
+	after.SMTP.Password = "pass"
+
+	b, a, err := ConfigSnapshots(before, after)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(b)+string(a), "secret")
+	assert.NotContains(t, string(b)+string(a), "token")
+	assert.NotContains(t, string(b)+string(a), `"pass"`)
+
+	var bVals, aVals map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &bVals))
+	require.NoError(t, json.Unmarshal(a, &aVals))
+
+	assert.Equal(t, "http://old.example.com", bVals["General.PublicURL"])
+	assert.Equal(t, "http://new.example.com", aVals["General.PublicURL"])
+	assert.Equal(t, redacted, bVals["Slack.ClientSecret"])
+	assert.Equal(t, redactedChanged, aVals["Slack.ClientSecret"])
+	assert.Equal(t, redacted, aVals["Twilio.AuthToken"], "unchanged password")
+	assert.Equal(t, "", bVals["SMTP.Password"])
+	assert.Equal(t, redactedChanged, aVals["SMTP.Password"])
+
+	changes := Entry{Before: b, After: a}.Changes()
+	var fields []string
+	for _, c := range changes {
+		fields = append(fields, c.Field)
+	}
+	assert.Equal(t, []string{"General.PublicURL", "SMTP.Password", "Slack.ClientSecret"}, fields)
+}
@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/target/goalert/assignment"
+)
+
+// An Entry is a record of a single change made within GoAlert.
+type Entry struct {
+	ID        int
+	Timestamp time.Time
+
+	// ActorUserID is the ID of the user that made the change, if any.
+	ActorUserID string
+
+	// ActorSource describes how the actor was authenticated (e.g. AuthProvider{<session ID>}
+	// or System{SetConfig}).
+	ActorSource string
+
+	// Action is the name of the operation performed (e.g. updateService or set-config).
+	Action string
+
+	// Target is the entity that was changed, if any.
+	Target assignment.Target
+
+	// Before and After hold JSON snapshots of the target prior to, and after the change.
+	Before, After json.RawMessage
+}
+
+// A Change describes a single top-level field that differs between
+// the Before and After snapshots of an Entry.
+type Change struct {
+	Field  string
+	Before *string
+	After  *string
+}
+
+func rawFields(data json.RawMessage) map[string]json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		// not an object, treat as a single value
+		return map[string]json.RawMessage{"": data}
+	}
+	return m
+}
+
+func rawString(data json.RawMessage) *string {
+	if data == nil {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
+
+// Changes returns the list of top-level fields that differ between the Before and After
+// snapshots, sorted by field name.
+func (e Entry) Changes() []Change {
+	before := rawFields(e.Before)
+	after := rawFields(e.After)
+
+	var changes []Change
+	for field, b := range before {
+		a, ok := after[field]
+		if ok && jsonEqual(a, b) {
+			continue
+		}
+		changes = append(changes, Change{Field: field, Before: rawString(b), After: rawString(a)})
+	}
+	for field, a := range after {
+		if _, ok := before[field]; ok {
+			continue
+		}
+		changes = append(changes, Change{Field: field, After: rawString(a)})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+
+	var av, bv interface{}
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return false
+	}
+	aData, _ := json.Marshal(av)
+	bData, _ := json.Marshal(bv)
+	return bytes.Equal(aData, bData)
+}
@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntry_Changes(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	e := Entry{
+		Before: json.RawMessage(`{"name": "foo", "description": "bar", "labels": {"a": "1"}, "removed": true}`),
+		After:  json.RawMessage(`{"name":"foo","description":"baz","labels":{"a":"1"},"added":1}`),
+	}
+	assert.Equal(t, []Change{
+		{Field: "added", After: str("1")},
+		{Field: "description", Before: str(`"bar"`), After: str(`"baz"`)},
+		{Field: "removed", Before: str("true")},
+	}, e.Changes())
+
+	created := Entry{After: json.RawMessage(`{"name":"foo"}`)}
+	assert.Equal(t, []Change{{Field: "name", After: str(`"foo"`)}}, created.Changes())
+
+	assert.Empty(t, Entry{}.Changes())
+}
@@ -0,0 +1,174 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// SearchOptions contains criteria for filtering audit log entries.
+type SearchOptions struct {
+	// Start and End, if set, restrict entries to those recorded within the given time range.
+	Start time.Time `json:"s,omitempty"`
+	End   time.Time `json:"e,omitempty"`
+
+	// FilterActorUserIDs restricts entries to changes made by the given users.
+	FilterActorUserIDs []string `json:"u,omitempty"`
+
+	// FilterActions restricts entries to the given action names.
+	FilterActions []string `json:"c,omitempty"`
+
+	// FilterTarget restricts entries to changes made to the given target.
+	FilterTarget *assignment.RawTarget `json:"t,omitempty"`
+
+	// Limit restricts the maximum number of rows returned. Default is 15.
+	Limit int `json:"-"`
+
+	After SearchCursor `json:"a,omitempty"`
+}
+
+// SearchCursor is used to indicate a position in a paginated list.
+type SearchCursor struct {
+	ID int `json:"i,omitempty"`
+}
+
+var searchTemplate = template.Must(template.New("search").Parse(`
+	SELECT
+		log.id,
+		log.timestamp,
+		log.actor_user_id,
+		log.actor_source,
+		log.action,
+		log.target_type,
+		log.target_id,
+		log.before,
+		log.after
+	FROM audit_logs log
+	WHERE TRUE
+	{{- if not .Start.IsZero}}
+		AND log.timestamp >= :start
+	{{- end}}
+	{{- if not .End.IsZero}}
+		AND log.timestamp < :end
+	{{- end}}
+	{{- if .FilterActorUserIDs}}
+		AND log.actor_user_id = ANY(:actorUserIDs)
+	{{- end}}
+	{{- if .FilterActions}}
+		AND log.action = ANY(:actions)
+	{{- end}}
+	{{- if .FilterTarget}}
+		AND log.target_type = :targetType AND log.target_id = :targetID
+	{{- end}}
+	{{- if .After.ID}}
+		AND log.id < :afterID
+	{{- end}}
+	ORDER BY log.id DESC
+	LIMIT {{.Limit}}
+`))
+
+type renderData SearchOptions
+
+func (opts renderData) Normalize() (*renderData, error) {
+	if opts.Limit == 0 {
+		opts.Limit = search.DefaultMaxResults
+	}
+
+	err := validate.Many(
+		validate.ManyUUID("FilterActorUserIDs", opts.FilterActorUserIDs, 50),
+		validate.Range("FilterActions", len(opts.FilterActions), 0, 50),
+		validate.Range("Limit", opts.Limit, 0, search.MaxResults),
+	)
+	if opts.FilterTarget != nil {
+		err = validate.Many(err, validate.UUID("FilterTarget.ID", opts.FilterTarget.ID))
+	}
+	if !opts.Start.IsZero() && !opts.End.IsZero() && !opts.End.After(opts.Start) {
+		err = validate.Many(err, validation.NewFieldError("End", "must be after Start"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &opts, nil
+}
+
+func (opts renderData) QueryArgs() []sql.NamedArg {
+	var tgtType, tgtID string
+	if opts.FilterTarget != nil {
+		data, _ := opts.FilterTarget.Type.MarshalText()
+		tgtType = string(data)
+		tgtID = opts.FilterTarget.ID
+	}
+	return []sql.NamedArg{
+		sql.Named("start", opts.Start),
+		sql.Named("end", opts.End),
+		sql.Named("actorUserIDs", sqlutil.UUIDArray(opts.FilterActorUserIDs)),
+		sql.Named("actions", sqlutil.StringArray(opts.FilterActions)),
+		sql.Named("targetType", tgtType),
+		sql.Named("targetID", tgtID),
+		sql.Named("afterID", opts.After.ID),
+	}
+}
+
+// Search will return a list of matching log entries, most recent first.
+func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]Entry, error) {
+	if opts == nil {
+		opts = &SearchOptions{}
+	}
+
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := (*renderData)(opts).Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := search.RenderQuery(ctx, searchTemplate, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "render query")
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Entry
+	for rows.Next() {
+		var e Entry
+		var userID, tgtType, tgtID sql.NullString
+		var before, after []byte
+		err = rows.Scan(&e.ID, &e.Timestamp, &userID, &e.ActorSource, &e.Action, &tgtType, &tgtID, &before, &after)
+		if err != nil {
+			return nil, err
+		}
+		e.ActorUserID = userID.String
+		if tgtType.Valid {
+			var tgt assignment.RawTarget
+			err = tgt.Type.UnmarshalText([]byte(tgtType.String))
+			if err != nil {
+				return nil, errors.Wrap(err, "parse target type")
+			}
+			tgt.ID = tgtID.String
+			e.Target = tgt
+		}
+		e.Before = before
+		e.After = after
+		result = append(result, e)
+	}
+
+	return result, rows.Err()
+}
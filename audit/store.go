@@ -0,0 +1,223 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store allows recording and searching audit log entries.
+type Store struct {
+	db *sql.DB
+
+	insert   *sql.Stmt
+	snapshot map[assignment.TargetType]*sql.Stmt
+}
+
+// snapshotQueries return a single JSON object representing the current state of a target.
+//
+// Related configuration (e.g. rotation participants) is included so that changes made through
+// separate mutations are captured on the parent target. Frequently-updated state (e.g.
+// last heartbeat time) is omitted.
+var snapshotQueries = map[assignment.TargetType]string{
+	assignment.TargetTypeService: `
+		select to_jsonb(svc) || jsonb_build_object(
+			'labels', (select jsonb_object_agg(l.key, l.value) from labels l where l.tgt_service_id = svc.id)
+		)
+		from services svc
+		where svc.id = $1
+	`,
+	assignment.TargetTypeEscalationPolicy: `
+		select to_jsonb(ep) || jsonb_build_object(
+			'steps', (
+				select jsonb_agg(to_jsonb(step) || jsonb_build_object(
+					'actions', (
+						select jsonb_agg(to_jsonb(act) - 'id' - 'escalation_policy_step_id' order by act.id)
+						from escalation_policy_actions act
+						where act.escalation_policy_step_id = step.id
+					)
+				) order by step.step_number)
+				from escalation_policy_steps step
+				where step.escalation_policy_id = ep.id
+			)
+		)
+		from escalation_policies ep
+		where ep.id = $1
+	`,
+	assignment.TargetTypeRotation: `
+		select to_jsonb(rot) || jsonb_build_object(
+			'participants', (
+				select jsonb_agg(p.user_id order by p.position)
+				from rotation_participants p
+				where p.rotation_id = rot.id
+			)
+		)
+		from rotations rot
+		where rot.id = $1
+	`,
+	assignment.TargetTypeSchedule: `
+		select to_jsonb(s) || jsonb_build_object(
+			'rules', (
+				select jsonb_agg(to_jsonb(r) - 'schedule_id' order by r.id)
+				from schedule_rules r
+				where r.schedule_id = s.id
+			),
+			'data', (select d.data from schedule_data d where d.schedule_id = s.id)
+		)
+		from schedules s
+		where s.id = $1
+	`,
+	assignment.TargetTypeUser: `
+		select to_jsonb(u) || jsonb_build_object(
+			'auth_subjects', (
+				select jsonb_agg(jsonb_build_object('provider_id', a.provider_id, 'subject_id', a.subject_id) order by a.provider_id, a.subject_id)
+				from auth_subjects a
+				where a.user_id = u.id
+			)
+		)
+		from users u
+		where u.id = $1
+	`,
+	assignment.TargetTypeIntegrationKey:       `select to_jsonb(k) from integration_keys k where k.id = $1`,
+	assignment.TargetTypeHeartbeatMonitor:     `select to_jsonb(hb) - 'last_state' - 'last_heartbeat' from heartbeat_monitors hb where hb.id = $1`,
+	assignment.TargetTypeUserOverride:         `select to_jsonb(o) from user_overrides o where o.id = $1`,
+	assignment.TargetTypeContactMethod:        `select to_jsonb(cm) from user_contact_methods cm where cm.id = $1`,
+	assignment.TargetTypeNotificationRule:     `select to_jsonb(nr) from user_notification_rules nr where nr.id = $1`,
+	assignment.TargetTypeCalendarSubscription: `select to_jsonb(cs) - 'last_access' from user_calendar_subscriptions cs where cs.id = $1`,
+	assignment.TargetTypeOutgoingWebhook:      `select to_jsonb(w) - 'secret' from outgoing_webhooks w where w.id = $1`,
+	assignment.TargetTypeSyntheticCheck:       `select to_jsonb(c) - 'last_state' - 'last_run_at' - 'last_error' from synthetic_checks c where c.id = $1`,
+}
+
+// NewStore will create a new Store with the given parameters.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{
+		DB:  db,
+		Ctx: ctx,
+	}
+
+	s := &Store{
+		db: db,
+
+		insert: p.P(`
+			insert into audit_logs (
+				actor_user_id, actor_source, action, target_type, target_id, before, after
+			) values ($1, $2, $3, $4, $5, $6, $7)
+		`),
+
+		snapshot: make(map[assignment.TargetType]*sql.Stmt, len(snapshotQueries)),
+	}
+	for typ, query := range snapshotQueries {
+		s.snapshot[typ] = p.P(query)
+	}
+
+	return s, p.Err
+}
+
+// actor returns the user ID and source description of the current context.
+func actor(ctx context.Context) (userID, source string) {
+	userID = permission.UserID(ctx)
+	if src := permission.Source(ctx); src != nil {
+		return userID, src.String()
+	}
+	if name := permission.SystemComponentName(ctx); name != "" {
+		return userID, "System{" + name + "}"
+	}
+	if svcID := permission.ServiceID(ctx); svcID != "" {
+		return userID, "Service{" + svcID + "}"
+	}
+
+	return userID, "Unknown"
+}
+
+// CanSnapshot returns true if SnapshotTx supports the given target type.
+func CanSnapshot(t assignment.TargetType) bool {
+	_, ok := snapshotQueries[t]
+	return ok
+}
+
+// SnapshotTx will return a JSON representation of the current state of the target. If the target
+// does not exist, or the target type is not supported, nil is returned.
+func (s *Store) SnapshotTx(ctx context.Context, tx *sql.Tx, tgt assignment.Target) (json.RawMessage, error) {
+	err := permission.LimitCheckAny(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tgt == nil {
+		return nil, nil
+	}
+	stmt, ok := s.snapshot[tgt.TargetType()]
+	if !ok {
+		return nil, nil
+	}
+	err = validate.UUID("TargetID", tgt.TargetID())
+	if err != nil {
+		return nil, err
+	}
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	var data []byte
+	err = stmt.QueryRowContext(ctx, tgt.TargetID()).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "snapshot %s", tgt.TargetType())
+	}
+
+	return data, nil
+}
+
+func nullJSON(data json.RawMessage) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return []byte(data)
+}
+
+// LogTx will record a new entry. The actor is determined from the provided context, and the
+// ID and Timestamp fields are ignored.
+func (s *Store) LogTx(ctx context.Context, tx *sql.Tx, e *Entry) error {
+	err := permission.LimitCheckAny(ctx)
+	if err != nil {
+		return err
+	}
+	err = validate.Text("Action", e.Action, 1, 255)
+	if err != nil {
+		return err
+	}
+
+	var tgtType, tgtID sql.NullString
+	if e.Target != nil {
+		typ, err := e.Target.TargetType().MarshalText()
+		if err != nil {
+			return err
+		}
+		tgtType = sql.NullString{String: string(typ), Valid: true}
+		tgtID = sql.NullString{String: e.Target.TargetID(), Valid: true}
+	}
+
+	userID, source := actor(ctx)
+
+	stmt := s.insert
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx,
+		sql.NullString{String: userID, Valid: userID != ""},
+		source,
+		e.Action,
+		tgtType,
+		tgtID,
+		nullJSON(e.Before),
+		nullJSON(e.After),
+	)
+	return err
+}
@@ -344,6 +344,8 @@ func (h *Handler) canCreateUser(ctx context.Context, providerID string) bool {
 		return cfg.OIDC.NewUsers
 	case "github":
 		return cfg.GitHub.NewUsers
+	case "ldap":
+		return cfg.LDAP.NewUsers
 	}
 
 	return false
@@ -427,8 +429,12 @@ func (h *Handler) handleProvider(id string, p IdentityProvider, refU *url.URL, w
 			return
 		}
 		defer tx.Rollback()
+		role := permission.RoleUser
+		if sub.Role != "" {
+			role = sub.Role
+		}
 		u := &user.User{
-			Role:  permission.RoleUser,
+			Role:  role,
 			Name:  validate.SanitizeName(sub.Name),
 			Email: validate.SanitizeEmail(sub.Email),
 		}
@@ -464,6 +470,12 @@ func (h *Handler) handleProvider(id string, p IdentityProvider, refU *url.URL, w
 		}
 	}
 
+	err = h.syncIdentity(ctx, userID, sub)
+	if err != nil {
+		errRedirect(err)
+		return
+	}
+
 	tok, err := h.CreateSession(ctx, req.UserAgent(), userID)
 	if err != nil {
 		errRedirect(err)
@@ -551,10 +563,18 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGeneric)
 	case "/v1/webhooks/grafana", "/api/v2/grafana/incoming":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGrafana)
+	case "/api/v2/generic/json":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGenericJSON)
 	case "/api/v2/site24x7/incoming":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeSite24x7)
 	case "/api/v2/prometheusalertmanager/incoming":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypePrometheusAlertmanager)
+	case "/api/v2/datadog/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeDatadog)
+	case "/api/v2/newrelic/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeNewRelic)
+	case "/api/v2/sns/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeAWSSNS)
 	case "/api/v2/calendar":
 		ctx, err = h.cfg.CalSubStore.Authorize(ctx, *tok)
 	default:
@@ -4,6 +4,7 @@ import (
 	"github.com/target/goalert/calendarsubscription"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/user"
 )
 
@@ -14,4 +15,5 @@ type HandlerConfig struct {
 	APIKeyring     keyring.Keyring
 	IntKeyStore    integrationkey.Store
 	CalSubStore    *calendarsubscription.Store
+	RotationStore  rotation.Store
 }
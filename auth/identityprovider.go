@@ -3,6 +3,8 @@ package auth
 import (
 	"context"
 	"net/http"
+
+	"github.com/target/goalert/permission"
 )
 
 // An IdentityProvider provides an option for a user to login (identify themselves).
@@ -21,6 +23,14 @@ type Identity struct {
 	Email         string
 	EmailVerified bool
 	Name          string
+
+	// Role, if set, will be assigned to the user on every login.
+	Role permission.Role
+
+	// Rotations, if set, maps rotation IDs to whether or not the user should
+	// be a participant. It is applied on every login; rotations not listed are
+	// left unchanged.
+	Rotations map[string]bool
 }
 
 // ProviderInfo holds the details for using a provider.
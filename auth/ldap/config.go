@@ -0,0 +1,14 @@
+package ldap
+
+import (
+	"context"
+
+	"github.com/target/goalert/config"
+)
+
+// Config configures the LDAP auth provider.
+type Config struct {
+	// Dial, if set, is used to establish new connections instead of connecting
+	// to the LDAP.URL from the current config.
+	Dial func(ctx context.Context, cfg config.Config) (Conn, error)
+}
@@ -0,0 +1,2 @@
+// Package ldap implements an auth provider that identifies a user by binding to an LDAP or Active Directory server.
+package ldap
@@ -0,0 +1,156 @@
+package ldap
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/auth"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/util/log"
+)
+
+var _ auth.IdentityProvider = &Provider{}
+
+func orDefault(val, def string) string {
+	if val == "" {
+		return def
+	}
+	return val
+}
+
+// Info implements the auth.Provider interface.
+func (Provider) Info(ctx context.Context) auth.ProviderInfo {
+	cfg := config.FromContext(ctx)
+	return auth.ProviderInfo{
+		Title: orDefault(cfg.LDAP.OverrideName, "LDAP"),
+		Fields: []auth.Field{
+			{ID: "username", Label: "Username", Required: true},
+			{ID: "password", Label: "Password", Password: true, Required: true},
+		},
+		Enabled: cfg.LDAP.Enable,
+	}
+}
+
+// attrValue returns the first value of the named attribute. Values that are not
+// valid UTF-8 (e.g. objectGUID) are hex-encoded.
+func attrValue(e *ldap.Entry, name string) string {
+	raw := e.GetRawAttributeValue(name)
+	if !utf8.Valid(raw) {
+		return hex.EncodeToString(raw)
+	}
+
+	return string(raw)
+}
+
+func containsDN(list []string, dn string) bool {
+	for _, s := range list {
+		if strings.EqualFold(strings.TrimSpace(s), strings.TrimSpace(dn)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtractIdentity implements the auth.IdentityProvider interface, providing identity based
+// on the given username and password fields.
+//
+// The user entry is located using the configured search filter and the password is verified
+// by binding as that entry.
+func (p *Provider) ExtractIdentity(route *auth.RouteInfo, w http.ResponseWriter, req *http.Request) (*auth.Identity, error) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+
+	username, password := req.FormValue("username"), req.FormValue("password")
+	if username == "" || password == "" {
+		return nil, auth.Error("Username and password are required.")
+	}
+	ctx = log.WithField(ctx, "username", username)
+
+	conn, err := p.dial(ctx, cfg)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "connect to LDAP server"))
+		return nil, auth.Error("Could not communicate with LDAP server.")
+	}
+	defer conn.Close()
+
+	if cfg.LDAP.BindDN != "" {
+		err = conn.Bind(cfg.LDAP.BindDN, cfg.LDAP.BindPassword)
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "bind as LDAP.BindDN"))
+			return nil, auth.Error("Could not communicate with LDAP server.")
+		}
+	}
+
+	nameAttr := orDefault(cfg.LDAP.NameAttribute, "cn")
+	emailAttr := orDefault(cfg.LDAP.EmailAttribute, "mail")
+	groupAttr := orDefault(cfg.LDAP.GroupAttribute, "memberOf")
+	attrs := []string{nameAttr, emailAttr, groupAttr}
+	if cfg.LDAP.SubjectAttribute != "" {
+		attrs = append(attrs, cfg.LDAP.SubjectAttribute)
+	}
+
+	filter := strings.ReplaceAll(orDefault(cfg.LDAP.UserSearchFilter, "(uid={username})"), "{username}", ldap.EscapeFilter(username))
+	res, err := conn.Search(ldap.NewSearchRequest(
+		cfg.LDAP.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, 0, false,
+		filter, attrs, nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		log.Log(ctx, errors.Wrap(err, "search for LDAP user"))
+		return nil, auth.Error("Could not communicate with LDAP server.")
+	}
+	if res == nil || len(res.Entries) != 1 {
+		if res != nil && len(res.Entries) > 1 {
+			log.Log(ctx, errors.New("LDAP user search returned multiple entries"))
+		}
+		auth.Delay(ctx)
+		return nil, auth.Error("unknown username/password")
+	}
+	entry := res.Entries[0]
+
+	err = conn.Bind(entry.DN, password)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		log.Debug(ctx, errors.Wrap(err, "ldap login"))
+		auth.Delay(ctx)
+		return nil, auth.Error("unknown username/password")
+	}
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "bind as LDAP user"))
+		return nil, auth.Error("Could not communicate with LDAP server.")
+	}
+
+	if len(cfg.LDAP.AllowedGroups) > 0 {
+		var inGroup bool
+		for _, g := range entry.GetAttributeValues(groupAttr) {
+			if containsDN(cfg.LDAP.AllowedGroups, g) {
+				inGroup = true
+				break
+			}
+		}
+		if !inGroup {
+			log.Debug(log.WithField(ctx, "DN", entry.DN), errors.New("not a member of any allowed group"))
+			return nil, auth.Error("Not a member of an allowed group.")
+		}
+	}
+
+	subjectID := entry.DN
+	if cfg.LDAP.SubjectAttribute != "" {
+		subjectID = attrValue(entry, cfg.LDAP.SubjectAttribute)
+	}
+	if subjectID == "" {
+		log.Log(ctx, errors.Errorf("LDAP user '%s' missing subject attribute '%s'", entry.DN, cfg.LDAP.SubjectAttribute))
+		return nil, auth.Error("Invalid response from LDAP server.")
+	}
+
+	return &auth.Identity{
+		SubjectID: subjectID,
+		Name:      entry.GetAttributeValue(nameAttr),
+		Email:     entry.GetAttributeValue(emailAttr),
+	}, nil
+}
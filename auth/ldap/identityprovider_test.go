@@ -0,0 +1,156 @@
+package ldap
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/auth"
+	"github.com/target/goalert/config"
+)
+
+type stubEntry struct {
+	password string
+	attrs    map[string][]string
+}
+
+// stubDirectory is an in-process LDAP server stub that supports simple
+// equality filters, e.g. (uid=bob).
+type stubDirectory struct {
+	entries map[string]stubEntry
+	bound   string
+}
+
+func (d *stubDirectory) Bind(dn, password string) error {
+	e, ok := d.entries[dn]
+	if !ok || e.password != password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, nil)
+	}
+	d.bound = dn
+	return nil
+}
+
+func (d *stubDirectory) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	parts := strings.SplitN(strings.Trim(req.Filter, "()"), "=", 2)
+	if len(parts) != 2 {
+		return nil, ldap.NewError(ldap.LDAPResultFilterError, nil)
+	}
+
+	var res ldap.SearchResult
+	for dn, e := range d.entries {
+		if !strings.HasSuffix(dn, req.BaseDN) {
+			continue
+		}
+		for _, v := range e.attrs[parts[0]] {
+			if v != parts[1] {
+				continue
+			}
+			entry := &ldap.Entry{DN: dn}
+			for _, name := range req.Attributes {
+				entry.Attributes = append(entry.Attributes, ldap.NewEntryAttribute(name, e.attrs[name]))
+			}
+			res.Entries = append(res.Entries, entry)
+		}
+	}
+
+	return &res, nil
+}
+
+func (d *stubDirectory) Close() {}
+
+func TestProvider_ExtractIdentity(t *testing.T) {
+	dir := &stubDirectory{entries: map[string]stubEntry{
+		"cn=svc,dc=example,dc=com": {password: "svcpass"},
+		"uid=bob,ou=people,dc=example,dc=com": {
+			password: "bobpass",
+			attrs: map[string][]string{
+				"uid":       {"bob"},
+				"cn":        {"Bob Smith"},
+				"mail":      {"bob@example.com"},
+				"entryUUID": {"e9b3a6b8-7f5b-4a49-9e0a-2c1e2d5f1a00"},
+				"memberOf":  {"cn=oncall,ou=groups,dc=example,dc=com"},
+			},
+		},
+		"uid=joe,ou=people,dc=example,dc=com": {
+			password: "joepass",
+			attrs: map[string][]string{
+				"uid": {"joe"},
+				"cn":  {"Joe"},
+			},
+		},
+	}}
+
+	var cfg config.Config
+	cfg.LDAP.Enable = true
+	cfg.LDAP.URL = "ldap://ldap.example.com"
+	cfg.LDAP.BaseDN = "dc=example,dc=com"
+	cfg.LDAP.BindDN = "cn=svc,dc=example,dc=com"
+	cfg.LDAP.BindPassword = "svcpass"
+
+	p, err := NewProvider(context.Background(), Config{
+		Dial: func(context.Context, config.Config) (Conn, error) { return dir, nil },
+	})
+	require.NoError(t, err)
+
+	login := func(cfg config.Config, username, password string) (*auth.Identity, error) {
+		form := url.Values{"username": {username}, "password": {password}}
+		req := httptest.NewRequest("POST", "/api/v2/identity/providers/ldap", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req = req.WithContext(cfg.Context(req.Context()))
+		return p.ExtractIdentity(&auth.RouteInfo{RelativePath: "/"}, httptest.NewRecorder(), req)
+	}
+
+	t.Run("valid login", func(t *testing.T) {
+		id, err := login(cfg, "bob", "bobpass")
+		require.NoError(t, err)
+		assert.Equal(t, &auth.Identity{
+			SubjectID: "uid=bob,ou=people,dc=example,dc=com",
+			Name:      "Bob Smith",
+			Email:     "bob@example.com",
+		}, id)
+		assert.Equal(t, "uid=bob,ou=people,dc=example,dc=com", dir.bound, "should verify password by binding as user")
+	})
+
+	t.Run("invalid password", func(t *testing.T) {
+		_, err := login(cfg, "bob", "wrong")
+		assert.Equal(t, auth.Error("unknown username/password"), err)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := login(cfg, "alice", "bobpass")
+		assert.Equal(t, auth.Error("unknown username/password"), err)
+	})
+
+	t.Run("filter injection", func(t *testing.T) {
+		_, err := login(cfg, "*", "bobpass")
+		assert.Equal(t, auth.Error("unknown username/password"), err)
+	})
+
+	t.Run("attribute mapping", func(t *testing.T) {
+		cfg := cfg
+		cfg.LDAP.SubjectAttribute = "entryUUID"
+		cfg.LDAP.NameAttribute = "uid"
+		id, err := login(cfg, "bob", "bobpass")
+		require.NoError(t, err)
+		assert.Equal(t, "e9b3a6b8-7f5b-4a49-9e0a-2c1e2d5f1a00", id.SubjectID)
+		assert.Equal(t, "bob", id.Name)
+
+		_, err = login(cfg, "joe", "joepass")
+		assert.Equal(t, auth.Error("Invalid response from LDAP server."), err, "missing subject attribute")
+	})
+
+	t.Run("allowed groups", func(t *testing.T) {
+		cfg := cfg
+		cfg.LDAP.AllowedGroups = []string{"CN=OnCall,OU=Groups,DC=example,DC=com"}
+		_, err := login(cfg, "bob", "bobpass")
+		assert.NoError(t, err)
+
+		_, err = login(cfg, "joe", "joepass")
+		assert.Equal(t, auth.Error("Not a member of an allowed group."), err)
+	})
+}
@@ -0,0 +1,69 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+)
+
+// Conn is the subset of LDAP operations required for authentication.
+//
+// It is implemented by *ldap.Conn.
+type Conn interface {
+	Bind(username, password string) error
+	Search(*ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close()
+}
+
+var _ Conn = &ldap.Conn{}
+
+// Provider implements the auth.IdentityProvider interface.
+type Provider struct {
+	dial func(context.Context, config.Config) (Conn, error)
+}
+
+// NewProvider creates a new Provider with the associated config.
+func NewProvider(ctx context.Context, c Config) (*Provider, error) {
+	p := &Provider{dial: c.Dial}
+	if p.dial == nil {
+		p.dial = dial
+	}
+	return p, nil
+}
+
+func dial(ctx context.Context, cfg config.Config) (Conn, error) {
+	u, err := url.Parse(cfg.LDAP.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse LDAP.URL")
+	}
+	tlsCfg := &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: cfg.LDAP.SkipVerify,
+	}
+
+	d := &net.Dialer{Timeout: 10 * time.Second}
+	if deadline, ok := ctx.Deadline(); ok {
+		d.Deadline = deadline
+	}
+
+	conn, err := ldap.DialURL(cfg.LDAP.URL, ldap.DialWithDialer(d), ldap.DialWithTLSConfig(tlsCfg))
+	if err != nil {
+		return nil, errors.Wrap(err, "dial")
+	}
+
+	if cfg.LDAP.StartTLS && u.Scheme == "ldap" {
+		err = conn.StartTLS(tlsCfg)
+		if err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "start TLS")
+		}
+	}
+
+	return conn, nil
+}
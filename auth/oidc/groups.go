@@ -0,0 +1,70 @@
+package oidc
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+)
+
+// groupNames will convert a JMESPath result into a list of group names.
+//
+// A single string is treated as a list of one group.
+func groupNames(res interface{}) ([]string, error) {
+	switch v := res.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		groups := make([]string, 0, len(v))
+		for _, g := range v {
+			s, ok := g.(string)
+			if !ok {
+				return nil, errors.Errorf("expected group name to be a string, got %T", g)
+			}
+			groups = append(groups, s)
+		}
+		return groups, nil
+	}
+
+	return nil, errors.Errorf("expected groups to be a list of strings, got %T", res)
+}
+
+// groupMapping returns the role and rotation membership for a user that is a member of
+// the provided groups, based on the current config.
+//
+// An empty role is returned if AdminGroups is not configured.
+func groupMapping(cfg config.Config, groups []string) (permission.Role, map[string]bool) {
+	isMember := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		isMember[g] = true
+	}
+
+	var role permission.Role
+	if len(cfg.OIDC.AdminGroups) > 0 {
+		role = permission.RoleUser
+		for _, g := range cfg.OIDC.AdminGroups {
+			if isMember[g] {
+				role = permission.RoleAdmin
+				break
+			}
+		}
+	}
+
+	var rotations map[string]bool
+	for _, str := range cfg.OIDC.RotationGroups {
+		parts := strings.SplitN(str, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if rotations == nil {
+			rotations = make(map[string]bool)
+		}
+		// a user may be granted membership to a rotation via more than one group
+		rotations[parts[1]] = rotations[parts[1]] || isMember[parts[0]]
+	}
+
+	return role, rotations
+}
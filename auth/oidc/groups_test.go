@@ -0,0 +1,60 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+)
+
+func TestGroupNames(t *testing.T) {
+	g, err := groupNames(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, g)
+
+	g, err = groupNames("oncall")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"oncall"}, g)
+
+	g, err = groupNames([]interface{}{"a", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, g)
+
+	_, err = groupNames([]interface{}{"a", 1.0})
+	assert.Error(t, err)
+
+	_, err = groupNames(map[string]interface{}{})
+	assert.Error(t, err)
+}
+
+func TestGroupMapping(t *testing.T) {
+	const (
+		rotA = "b51b4bd5-a5a0-4c8a-9a7b-d4e6b9c1a1a1"
+		rotB = "c2e3f9b8-1d4e-4c8a-9a7b-d4e6b9c1a1a2"
+	)
+	var cfg config.Config
+
+	role, rot := groupMapping(cfg, []string{"admins"})
+	assert.Empty(t, role, "no role without AdminGroups")
+	assert.Nil(t, rot, "no rotations without RotationGroups")
+
+	cfg.OIDC.AdminGroups = []string{"admins", "sre-leads"}
+	cfg.OIDC.RotationGroups = []string{
+		"sre=" + rotA,
+		"sre-leads=" + rotA,
+		"dba=" + rotB,
+	}
+
+	role, rot = groupMapping(cfg, []string{"sre-leads"})
+	assert.Equal(t, permission.RoleAdmin, role)
+	assert.Equal(t, map[string]bool{rotA: true, rotB: false}, rot)
+
+	role, rot = groupMapping(cfg, []string{"sre", "dba"})
+	assert.Equal(t, permission.RoleUser, role)
+	assert.Equal(t, map[string]bool{rotA: true, rotB: true}, rot)
+
+	role, rot = groupMapping(cfg, nil)
+	assert.Equal(t, permission.RoleUser, role, "admin role removed when not in any admin group")
+	assert.Equal(t, map[string]bool{rotA: false, rotB: false}, rot)
+}
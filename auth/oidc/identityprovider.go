@@ -349,6 +349,29 @@ func (p *Provider) ExtractIdentity(route *auth.RouteInfo, w http.ResponseWriter,
 	infoFieldBool("EmailVerified", cfg.OIDC.UserInfoEmailVerifiedPath, &id.EmailVerified)
 	infoFieldStr("Name", cfg.OIDC.UserInfoNamePath, &id.Name)
 
+	if cfg.OIDC.GroupsPath != "" {
+		var rawClaims interface{}
+		if err := idToken.Claims(&rawClaims); err != nil {
+			log.Log(ctx, errors.Wrap(err, "parse claims"))
+			return nil, auth.Error(fmt.Sprintf("Invalid response from %s server.", name))
+		}
+		res, searchErr := jmespath.Search(cfg.OIDC.GroupsPath, rawClaims)
+		if searchErr != nil {
+			log.Log(ctx, errors.Wrap(searchErr, "lookup Groups in claims"))
+		}
+		if res == nil {
+			// fall back to UserInfo
+			res = getInfo("Groups", cfg.OIDC.GroupsPath)
+		}
+		groups, groupErr := groupNames(res)
+		if groupErr != nil {
+			log.Log(ctx, errors.Wrap(groupErr, "read Groups"))
+			return nil, auth.Error(fmt.Sprintf("Invalid group information from %s server.", name))
+		}
+
+		id.Role, id.Rotations = groupMapping(cfg, groups)
+	}
+
 	return &id, nil
 }
 
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/permission"
+)
+
+// syncIdentity will apply the Role and Rotations of the provided Identity to the given user.
+func (h *Handler) syncIdentity(ctx context.Context, userID string, sub *Identity) error {
+	if sub.Role == "" && len(sub.Rotations) == 0 {
+		return nil
+	}
+
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	permission.SudoContext(ctx, func(ctx context.Context) {
+		err = h.syncIdentityTx(ctx, tx, userID, sub)
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (h *Handler) syncIdentityTx(ctx context.Context, tx *sql.Tx, userID string, sub *Identity) error {
+	if sub.Role != "" {
+		err := h.cfg.UserStore.SetUserRoleTx(ctx, tx, userID, sub.Role)
+		if err != nil {
+			return errors.Wrap(err, "set user role")
+		}
+	}
+	if len(sub.Rotations) > 0 && h.cfg.RotationStore == nil {
+		return errors.New("rotation membership requested but RotationStore is not configured")
+	}
+
+	// apply in a consistent order to avoid deadlocks with concurrent logins
+	rotIDs := make([]string, 0, len(sub.Rotations))
+	for id := range sub.Rotations {
+		rotIDs = append(rotIDs, id)
+	}
+	sort.Strings(rotIDs)
+
+	for _, rotID := range rotIDs {
+		_, err := h.cfg.RotationStore.FindRotationForUpdateTx(ctx, tx, rotID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// rotation was deleted, nothing to sync
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "lock rotation")
+		}
+
+		parts, err := h.cfg.RotationStore.FindAllParticipantsTx(ctx, tx, rotID)
+		if err != nil {
+			return errors.Wrap(err, "find rotation participants")
+		}
+		var partIDs []string
+		for _, p := range parts {
+			if p.Target != nil && p.Target.TargetID() == userID {
+				partIDs = append(partIDs, p.ID)
+			}
+		}
+
+		isMember := sub.Rotations[rotID]
+		switch {
+		case isMember && len(partIDs) == 0:
+			err = h.cfg.RotationStore.AddRotationUsersTx(ctx, tx, rotID, []string{userID})
+		case !isMember && len(partIDs) > 0:
+			// rotation state is advanced (or ended) by trigger on delete
+			err = h.cfg.RotationStore.DeleteRotationParticipantsTx(ctx, tx, partIDs)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "update participants for rotation %s", rotID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,283 @@
+package awssns
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// maxCerts is the maximum number of signing certificates kept in memory.
+const maxCerts = 100
+
+// snsHost matches the hostnames SNS uses for signing certificates and subscription URLs.
+var snsHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// Config contains the values needed to implement the SNS handler.
+type Config struct {
+	AlertStore alert.Store
+
+	// BaseURL, if set, replaces the check that signing certificate and subscription
+	// URLs belong to AWS; they must instead begin with BaseURL. It is intended for testing.
+	BaseURL string
+
+	// Client is used to fetch signing certificates and confirm subscriptions.
+	Client *http.Client
+}
+
+// Handler accepts notifications from an AWS SNS HTTP(S) subscription.
+type Handler struct {
+	cfg Config
+
+	mx    sync.Mutex
+	certs map[string]*rsa.PublicKey
+}
+
+// NewHandler creates a new Handler with the given config.
+func NewHandler(cfg Config) *Handler {
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Handler{
+		cfg:   cfg,
+		certs: make(map[string]*rsa.PublicKey),
+	}
+}
+
+// cloudWatchAlarm is the message body of a CloudWatch alarm state change.
+type cloudWatchAlarm struct {
+	AlarmName        string
+	AlarmDescription string
+	AlarmArn         string
+	NewStateValue    string
+	NewStateReason   string
+	Region           string
+}
+
+func (h *Handler) validURL(fname, rawURL string) error {
+	if h.cfg.BaseURL != "" {
+		if !strings.HasPrefix(rawURL, strings.TrimSuffix(h.cfg.BaseURL, "/")+"/") {
+			return errors.Errorf("%s must begin with %s", fname, h.cfg.BaseURL)
+		}
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrapf(err, "parse %s", fname)
+	}
+	if u.Scheme != "https" || !snsHost.MatchString(u.Hostname()) {
+		return errors.Errorf("%s is not an AWS SNS URL", fname)
+	}
+
+	return nil
+}
+
+func (h *Handler) get(ctx context.Context, urlStr string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.cfg.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("non-200 response: %s", resp.Status)
+	}
+
+	return ioutil.ReadAll(io.LimitReader(resp.Body, 64*1024))
+}
+
+// publicKey returns the public key of the signing certificate at the given URL.
+func (h *Handler) publicKey(ctx context.Context, certURL string) (*rsa.PublicKey, error) {
+	h.mx.Lock()
+	key, ok := h.certs[certURL]
+	h.mx.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	err := h.validURL("SigningCertURL", certURL)
+	if err != nil {
+		return nil, err
+	}
+	data, err := h.get(ctx, certURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch signing certificate")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid signing certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse signing certificate")
+	}
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, errors.New("signing certificate is expired or not yet valid")
+	}
+	key, ok = cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("signing certificate does not contain an RSA key")
+	}
+
+	h.mx.Lock()
+	if len(h.certs) >= maxCerts {
+		h.certs = make(map[string]*rsa.PublicKey)
+	}
+	h.certs[certURL] = key
+	h.mx.Unlock()
+
+	return key, nil
+}
+
+func (h *Handler) confirm(ctx context.Context, m Message) error {
+	err := h.validURL("SubscribeURL", m.SubscribeURL)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.get(ctx, m.SubscribeURL)
+	return err
+}
+
+// newAlert returns the alert for a notification, or nil if it should be ignored.
+//
+// CloudWatch alarms open and close alerts based on the alarm state, any other
+// notification will create an alert from its subject and message.
+func newAlert(m Message) *alert.Alert {
+	var cw cloudWatchAlarm
+	if json.Unmarshal([]byte(m.Message), &cw) != nil || cw.AlarmName == "" || cw.NewStateValue == "" {
+		summary := m.Subject
+		if summary == "" {
+			summary = strings.SplitN(strings.TrimSpace(m.Message), "\n", 2)[0]
+		}
+		return &alert.Alert{
+			Summary: validate.SanitizeText(summary, alert.MaxSummaryLength),
+			Details: validate.SanitizeText(m.Message, alert.MaxDetailsLength),
+			Status:  alert.StatusTriggered,
+		}
+	}
+
+	var status alert.Status
+	switch cw.NewStateValue {
+	case "ALARM":
+		status = alert.StatusTriggered
+	case "OK":
+		status = alert.StatusClosed
+	default:
+		// INSUFFICIENT_DATA
+		return nil
+	}
+
+	dedup := cw.AlarmArn
+	if dedup == "" {
+		dedup = cw.AlarmName
+	}
+
+	details := strings.TrimSpace(cw.NewStateReason + "\n\n" + cw.AlarmDescription)
+	if cw.Region != "" {
+		details += "\n\nRegion: " + cw.Region
+	}
+
+	return &alert.Alert{
+		Summary: validate.SanitizeText(cw.AlarmName, alert.MaxSummaryLength),
+		Details: validate.SanitizeText(details, alert.MaxDetailsLength),
+		Status:  status,
+		Dedup:   alert.NewUserDedup(dedup),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := permission.LimitCheckAny(ctx, permission.Service)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	serviceID := permission.ServiceID(ctx)
+
+	var m Message
+	err = json.NewDecoder(r.Body).Decode(&m)
+	if err != nil {
+		log.Logf(ctx, "bad request from aws sns: %v", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	ctx = log.WithFields(ctx, log.Fields{
+		"MessageID": m.MessageID,
+		"TopicArn":  m.TopicArn,
+		"Type":      m.Type,
+	})
+
+	key, err := h.publicKey(ctx, m.SigningCertURL)
+	if err == nil {
+		err = m.Verify(key)
+	}
+	if err != nil {
+		log.Logf(ctx, "bad request from aws sns: verify signature: %v", err)
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	switch m.Type {
+	case TypeSubscriptionConfirmation:
+		err = h.confirm(ctx, m)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "confirm aws sns subscription")) {
+			return
+		}
+		log.Logf(ctx, "Confirmed AWS SNS subscription.")
+		return
+	case TypeUnsubscribeConfirmation:
+		log.Logf(ctx, "AWS SNS subscription removed.")
+		return
+	case TypeNotification:
+	default:
+		log.Logf(ctx, "bad request from aws sns: unknown message type")
+		http.Error(w, "invalid type", http.StatusBadRequest)
+		return
+	}
+
+	a := newAlert(m)
+	if a == nil {
+		return
+	}
+	a.ServiceID = serviceID
+	a.Source = alert.SourceAWSSNS
+	if dedup := r.FormValue("dedup"); dedup != "" {
+		a.Dedup = alert.NewUserDedup(dedup)
+	}
+
+	err = retry.DoTemporaryError(func(int) error {
+		_, err = h.cfg.AlertStore.CreateOrUpdate(ctx, a)
+		return err
+	},
+		retry.Log(ctx),
+		retry.Limit(10),
+		retry.FibBackoff(time.Second),
+	)
+	if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for aws sns")) {
+		return
+	}
+}
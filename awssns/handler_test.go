@@ -0,0 +1,48 @@
+package awssns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/alert"
+)
+
+func TestHandler_ValidURL(t *testing.T) {
+	h := NewHandler(Config{})
+	assert.NoError(t, h.validURL("url", "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem"))
+	assert.NoError(t, h.validURL("url", "https://sns.cn-north-1.amazonaws.com.cn/SimpleNotificationService-abc.pem"))
+	assert.Error(t, h.validURL("url", "http://sns.us-east-1.amazonaws.com/cert.pem"))
+	assert.Error(t, h.validURL("url", "https://sns.us-east-1.amazonaws.com.example.com/cert.pem"))
+	assert.Error(t, h.validURL("url", "https://example.com/cert.pem"))
+
+	h = NewHandler(Config{BaseURL: "http://127.0.0.1:1234"})
+	assert.NoError(t, h.validURL("url", "http://127.0.0.1:1234/cert.pem"))
+	assert.Error(t, h.validURL("url", "http://127.0.0.1:12345/cert.pem"))
+	assert.Error(t, h.validURL("url", "https://sns.us-east-1.amazonaws.com/cert.pem"))
+}
+
+func TestNewAlert(t *testing.T) {
+	a := newAlert(Message{Subject: "Disk full", Message: "host db1 is out of space"})
+	assert.Equal(t, &alert.Alert{
+		Summary: "Disk full",
+		Details: "host db1 is out of space",
+		Status:  alert.StatusTriggered,
+	}, a)
+
+	a = newAlert(Message{Message: "first line\nsecond line"})
+	assert.Equal(t, "first line", a.Summary)
+
+	a = newAlert(Message{Message: `{"AlarmName":"cpu-high","AlarmArn":"arn:alarm","NewStateValue":"ALARM","NewStateReason":"Threshold crossed","Region":"US East"}`})
+	assert.Equal(t, &alert.Alert{
+		Summary: "cpu-high",
+		Details: "Threshold crossed\n\nRegion: US East",
+		Status:  alert.StatusTriggered,
+		Dedup:   alert.NewUserDedup("arn:alarm"),
+	}, a)
+
+	a = newAlert(Message{Message: `{"AlarmName":"cpu-high","NewStateValue":"OK"}`})
+	assert.Equal(t, alert.StatusClosed, a.Status)
+	assert.Equal(t, alert.NewUserDedup("cpu-high"), a.Dedup)
+
+	assert.Nil(t, newAlert(Message{Message: `{"AlarmName":"cpu-high","NewStateValue":"INSUFFICIENT_DATA"}`}))
+}
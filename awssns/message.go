@@ -0,0 +1,109 @@
+package awssns
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Message types sent by SNS to HTTP(S) endpoints.
+const (
+	TypeNotification             = "Notification"
+	TypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	TypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// Message is the body of a request made by SNS to an HTTP(S) subscription.
+type Message struct {
+	Type             string
+	MessageID        string `json:"MessageId"`
+	Token            string `json:",omitempty"`
+	TopicArn         string
+	Subject          string `json:",omitempty"`
+	Message          string
+	Timestamp        string
+	SignatureVersion string
+	Signature        string
+	SigningCertURL   string
+	SubscribeURL     string `json:",omitempty"`
+	UnsubscribeURL   string `json:",omitempty"`
+}
+
+// SigningString returns the canonical string that is signed by SNS for the message.
+func (m Message) SigningString() string {
+	var b strings.Builder
+	add := func(key, value string) {
+		b.WriteString(key)
+		b.WriteString("\n")
+		b.WriteString(value)
+		b.WriteString("\n")
+	}
+
+	add("Message", m.Message)
+	add("MessageId", m.MessageID)
+	if m.Type == TypeNotification {
+		if m.Subject != "" {
+			add("Subject", m.Subject)
+		}
+	} else {
+		add("SubscribeURL", m.SubscribeURL)
+	}
+	add("Timestamp", m.Timestamp)
+	if m.Type != TypeNotification {
+		add("Token", m.Token)
+	}
+	add("TopicArn", m.TopicArn)
+	add("Type", m.Type)
+
+	return b.String()
+}
+
+// hash returns the hash function and digest of the signing string for the message's SignatureVersion.
+func (m Message) hash() (crypto.Hash, []byte, error) {
+	data := []byte(m.SigningString())
+	switch m.SignatureVersion {
+	case "1":
+		sum := sha1.Sum(data)
+		return crypto.SHA1, sum[:], nil
+	case "2":
+		sum := sha256.Sum256(data)
+		return crypto.SHA256, sum[:], nil
+	}
+
+	return 0, nil, errors.Errorf("unsupported signature version '%s'", m.SignatureVersion)
+}
+
+// Verify will validate the message signature with the provided public key.
+func (m Message) Verify(key *rsa.PublicKey) error {
+	sig, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil {
+		return errors.Wrap(err, "decode signature")
+	}
+	h, sum, err := m.hash()
+	if err != nil {
+		return err
+	}
+
+	return rsa.VerifyPKCS1v15(key, h, sum, sig)
+}
+
+// Sign will set the Signature of the message using the provided private key. It is
+// intended for testing.
+func (m *Message) Sign(key *rsa.PrivateKey) error {
+	h, sum, err := m.hash()
+	if err != nil {
+		return err
+	}
+	sig, err := rsa.SignPKCS1v15(nil, key, h, sum)
+	if err != nil {
+		return err
+	}
+
+	m.Signature = base64.StdEncoding.EncodeToString(sig)
+	return nil
+}
@@ -0,0 +1,51 @@
+package awssns
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_SigningString(t *testing.T) {
+	m := Message{
+		Type:      TypeNotification,
+		MessageID: "id",
+		TopicArn:  "arn",
+		Subject:   "subj",
+		Message:   "msg",
+		Timestamp: "ts",
+		Token:     "ignored",
+	}
+	assert.Equal(t, "Message\nmsg\nMessageId\nid\nSubject\nsubj\nTimestamp\nts\nTopicArn\narn\nType\nNotification\n", m.SigningString())
+
+	m.Subject = ""
+	assert.Equal(t, "Message\nmsg\nMessageId\nid\nTimestamp\nts\nTopicArn\narn\nType\nNotification\n", m.SigningString())
+
+	m.Type = TypeSubscriptionConfirmation
+	m.SubscribeURL = "url"
+	assert.Equal(t, "Message\nmsg\nMessageId\nid\nSubscribeURL\nurl\nTimestamp\nts\nToken\nignored\nTopicArn\narn\nType\nSubscriptionConfirmation\n", m.SigningString())
+}
+
+func TestMessage_Verify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for _, version := range []string{"1", "2"} {
+		m := Message{Type: TypeNotification, MessageID: "id", Message: "hello", SignatureVersion: version}
+		require.NoError(t, m.Sign(key))
+
+		assert.NoError(t, m.Verify(&key.PublicKey), "version "+version)
+		assert.Error(t, m.Verify(&other.PublicKey), "wrong key")
+
+		m.Message = "changed"
+		assert.Error(t, m.Verify(&key.PublicKey), "modified message")
+	}
+
+	m := Message{SignatureVersion: "3"}
+	assert.Error(t, m.Sign(key), "unknown version")
+}
@@ -4,8 +4,9 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
-	"html/template"
+	"fmt"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/pkg/errors"
@@ -15,29 +16,108 @@ import (
 	"github.com/target/goalert/version"
 )
 
+// Scope determines which shifts are included in a CalendarSubscription.
+type Scope string
+
+const (
+	// ScopeMyShifts includes shifts for the subscribing user on a single schedule.
+	ScopeMyShifts Scope = "my_shifts"
+
+	// ScopeSchedule includes shifts for all users on a single schedule.
+	ScopeSchedule Scope = "schedule"
+
+	// ScopeUser includes shifts for the subscribing user on all schedules.
+	ScopeUser Scope = "user"
+
+	// ScopeService includes shifts for all users on all schedules and rotations of a service's escalation
+	// policy, as well as users the escalation policy targets directly.
+	ScopeService Scope = "service"
+)
+
+// DefaultLookaheadDays is used when a CalendarSubscription does not specify LookaheadDays.
+const DefaultLookaheadDays = 30
+
+// MaxLookaheadDays is the maximum number of days of shifts a CalendarSubscription can include.
+const MaxLookaheadDays = 365
+
 // CalendarSubscription stores the information from user subscriptions
 type CalendarSubscription struct {
 	ID         string
 	Name       string
 	UserID     string
+	Scope      Scope
 	ScheduleID string
+	ServiceID  string
 	LastAccess time.Time
 	Disabled   bool
 
 	// Config provides necessary parameters CalendarSubscription Config (i.e. ReminderMinutes)
 	Config struct {
 		ReminderMinutes []int
+
+		// LookaheadDays is the number of days of upcoming shifts to include, DefaultLookaheadDays if unset.
+		LookaheadDays int `json:",omitempty"`
+
+		// IncludeDetails will add the on-call user as an attendee, and the user and schedule
+		// names to the description of each event.
+		IncludeDetails bool `json:",omitempty"`
 	}
 
 	token string
 }
 
+// Lookahead returns the number of days of upcoming shifts to include.
+func (cs CalendarSubscription) Lookahead() int {
+	if cs.Config.LookaheadDays == 0 {
+		return DefaultLookaheadDays
+	}
+	return cs.Config.LookaheadDays
+}
+
+// A shiftInfo is an on-call shift along with details about the user and where the shift came from.
+type shiftInfo struct {
+	oncall.Shift
+
+	ScheduleID   string
+	ScheduleName string
+
+	// RotationID and RotationName are set instead of the schedule for shifts of a rotation
+	// targeted directly by an escalation policy.
+	RotationID   string
+	RotationName string
+
+	// EscalationPolicyName is set instead of the schedule for users targeted directly by an
+	// escalation policy.
+	EscalationPolicyName string
+
+	UserName  string
+	UserEmail string
+}
+
+// source will return the kind and name of what the shift came from.
+func (s shiftInfo) source() (kind, name string) {
+	switch {
+	case s.RotationID != "":
+		return "Rotation", s.RotationName
+	case s.EscalationPolicyName != "":
+		return "Escalation Policy", s.EscalationPolicyName
+	}
+	return "Schedule", s.ScheduleName
+}
+
+type iCalEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Attendee    string
+	Start, End  time.Time
+}
+
 type iCalRenderData struct {
-	Shifts          []oncall.Shift
+	Events          []iCalEvent
 	ReminderMinutes []int
 	Version         string
 	GeneratedAt     time.Time
-	EventUIDs       []string
 }
 
 // RFC can be found at https://tools.ietf.org/html/rfc5545
@@ -48,13 +128,16 @@ CALSCALE:GREGORIAN
 METHOD:PUBLISH
 {{- $mins := .ReminderMinutes }}
 {{- $genTime := .GeneratedAt }}
-{{- $eventUIDs := .EventUIDs}}
-{{- range $i, $s := .Shifts}}
+{{- range .Events}}
 BEGIN:VEVENT
-UID:{{index $eventUIDs $i}}
-SUMMARY:On-Call Shift{{if $s.Truncated}} Begins*
-DESCRIPTION:The end time of this shift is unknown and will continue beyond what is displayed.
-{{- end }}
+UID:{{.UID}}
+SUMMARY:{{.Summary}}
+{{- if .Description}}
+DESCRIPTION:{{.Description}}
+{{- end}}
+{{- if .Attendee}}
+{{.Attendee}}
+{{- end}}
 DTSTAMP:{{$genTime.UTC.Format "20060102T150405Z"}}
 DTSTART:{{.Start.UTC.Format "20060102T150405Z"}}
 DTEND:{{.End.UTC.Format "20060102T150405Z"}}
@@ -70,6 +153,9 @@ END:VEVENT
 END:VCALENDAR
 `, "\n", "\r\n")))
 
+// iCalTextEscaper escapes TEXT property values (RFC 5545 section 3.3.11).
+var iCalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r", "", "\n", `\n`)
+
 // Token returns the authorization token associated with this CalendarSubscription. It
 // is only available when calling CreateTx.
 func (cs CalendarSubscription) Token() string { return cs.token }
@@ -79,36 +165,100 @@ func (cs CalendarSubscription) Normalize() (*CalendarSubscription, error) {
 	if cs.ID == "" {
 		cs.ID = uuid.NewV4().String()
 	}
+	if cs.Scope == "" {
+		cs.Scope = ScopeMyShifts
+	}
 
 	err := validate.Many(
 		validate.Range("ReminderMinutes", len(cs.Config.ReminderMinutes), 0, 15),
+		validate.Range("LookaheadDays", cs.Config.LookaheadDays, 0, MaxLookaheadDays),
 		validate.IDName("Name", cs.Name),
 		validate.UUID("ID", cs.ID),
 		validate.UUID("UserID", cs.UserID),
+		validate.OneOf("Scope", cs.Scope, ScopeMyShifts, ScopeSchedule, ScopeUser, ScopeService),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	switch cs.Scope {
+	case ScopeMyShifts, ScopeSchedule:
+		cs.ServiceID = ""
+		err = validate.UUID("ScheduleID", cs.ScheduleID)
+	case ScopeService:
+		cs.ScheduleID = ""
+		err = validate.UUID("ServiceID", cs.ServiceID)
+	default:
+		cs.ScheduleID = ""
+		cs.ServiceID = ""
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	return &cs, nil
 }
 
 func (cs CalendarSubscription) renderICalFromShifts(shifts []oncall.Shift, generatedAt time.Time) ([]byte, error) {
-	var eventUIDs []string
+	infos := make([]shiftInfo, 0, len(shifts))
+	for _, s := range shifts {
+		infos = append(infos, shiftInfo{Shift: s, ScheduleID: cs.ScheduleID})
+	}
+	return cs.renderICal(infos, generatedAt)
+}
+
+func (cs CalendarSubscription) renderICal(shifts []shiftInfo, generatedAt time.Time) ([]byte, error) {
+	events := make([]iCalEvent, 0, len(shifts))
 	for _, s := range shifts {
 		t := s.End
 		if s.Truncated {
 			t = s.Start
 		}
-		sum := sha256.Sum256([]byte(s.UserID + cs.ScheduleID + t.Format(time.RFC3339)))
-		eventUIDs = append(eventUIDs, hex.EncodeToString(sum[:]))
+		sum := sha256.Sum256([]byte(s.UserID + s.ScheduleID + s.RotationID + t.Format(time.RFC3339)))
+		srcKind, srcName := s.source()
+
+		e := iCalEvent{
+			UID:     hex.EncodeToString(sum[:]),
+			Summary: "On-Call Shift",
+			Start:   s.Start,
+			End:     s.End,
+		}
+		if s.Truncated {
+			e.Summary += " Begins*"
+		}
+		switch cs.Scope {
+		case ScopeSchedule:
+			e.Summary += ": " + s.UserName
+		case ScopeUser:
+			e.Summary += ": " + s.ScheduleName
+		case ScopeService:
+			e.Summary += ": " + s.UserName + " (" + srcName + ")"
+		}
+
+		var desc []string
+		if cs.Config.IncludeDetails {
+			desc = append(desc, "User: "+s.UserName, srcKind+": "+srcName)
+			if s.UserEmail != "" {
+				e.Attendee = fmt.Sprintf("ATTENDEE;CN=\"%s\";ROLE=REQ-PARTICIPANT:mailto:%s",
+					strings.NewReplacer(`"`, "", "\r", "", "\n", "").Replace(s.UserName),
+					strings.NewReplacer("\r", "", "\n", "").Replace(s.UserEmail),
+				)
+			}
+		}
+		if s.Truncated {
+			desc = append(desc, "The end time of this shift is unknown and will continue beyond what is displayed.")
+		}
+		e.Summary = iCalTextEscaper.Replace(e.Summary)
+		e.Description = iCalTextEscaper.Replace(strings.Join(desc, "\n"))
+
+		events = append(events, e)
 	}
+
 	data := iCalRenderData{
-		Shifts:          shifts,
+		Events:          events,
 		ReminderMinutes: cs.Config.ReminderMinutes,
 		Version:         version.GitVersion(),
 		GeneratedAt:     generatedAt,
-		EventUIDs:       eventUIDs,
 	}
 	buf := bytes.NewBuffer(nil)
 
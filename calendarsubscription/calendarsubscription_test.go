@@ -72,3 +72,41 @@ func TestCalendarSubscription_RenderICalFromShifts(t *testing.T) {
 	}, "\r\n")
 	assert.Equal(t, expected, string(iCal))
 }
+
+func TestCalendarSubscription_RenderICal_Details(t *testing.T) {
+	cs := CalendarSubscription{Scope: ScopeService}
+	cs.Config.IncludeDetails = true
+	shifts := []shiftInfo{{
+		Shift: oncall.Shift{
+			UserID: "01020304-0506-0708-090a-0b0c0d0e0f10",
+			Start:  time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC),
+			End:    time.Date(2020, 1, 15, 8, 0, 0, 0, time.UTC),
+		},
+		ScheduleID:   "100f0e0d-0c0b-0a09-0807-060504030201",
+		ScheduleName: "Primary, Day",
+		UserName:     "Bob \"B\" Smith",
+		UserEmail:    "bob@example.com",
+	}}
+	generatedAt := time.Date(2020, 1, 1, 5, 0, 0, 0, time.UTC)
+	iCal, err := cs.renderICal(shifts, generatedAt)
+	assert.NoError(t, err)
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"PRODID:-//GoAlert//dev//EN",
+		"VERSION:2.0",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		"UID:4c7d37bf28d64eccc1e74a3889cfc97f6839a00fa781c91721058df915de27ce",
+		`SUMMARY:On-Call Shift: Bob "B" Smith (Primary\, Day)`,
+		`DESCRIPTION:User: Bob "B" Smith\nSchedule: Primary\, Day`,
+		`ATTENDEE;CN="Bob B Smith";ROLE=REQ-PARTICIPANT:mailto:bob@example.com`,
+		"DTSTAMP:20200101T050000Z",
+		"DTSTART:20200101T080000Z",
+		"DTEND:20200115T080000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	assert.Equal(t, expected, string(iCal))
+}
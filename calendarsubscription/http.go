@@ -30,21 +30,12 @@ func (s *Store) ServeICalData(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	shifts, err := s.oc.HistoryBySchedule(ctx, cs.ScheduleID, n, n.AddDate(0, 1, 0))
+	shifts, err := s.shifts(ctx, cs, n, n.AddDate(0, 0, cs.Lookahead()))
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
 
-	// filter out other users
-	filtered := shifts[:0]
-	for _, s := range shifts {
-		if s.UserID != cs.UserID {
-			continue
-		}
-		filtered = append(filtered, s)
-	}
-
-	calData, err := cs.renderICalFromShifts(filtered, n)
+	calData, err := cs.renderICal(shifts, n)
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
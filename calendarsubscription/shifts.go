@@ -0,0 +1,224 @@
+package calendarsubscription
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+func (s *Store) scheduleIDs(ctx context.Context, cs *CalendarSubscription) ([]string, error) {
+	var stmt *sql.Stmt
+	var arg string
+	switch cs.Scope {
+	case ScopeUser:
+		stmt, arg = s.userSchedules, cs.UserID
+	case ScopeService:
+		stmt, arg = s.serviceSchedules, cs.ServiceID
+	default:
+		return []string{cs.ScheduleID}, nil
+	}
+
+	rows, err := stmt.QueryContext(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// scheduleShifts will return the shifts of each schedule between start and end.
+func (s *Store) scheduleShifts(ctx context.Context, cs *CalendarSubscription, schedIDs []string, start, end time.Time) ([]shiftInfo, error) {
+	if len(schedIDs) == 0 {
+		return nil, nil
+	}
+
+	schedNames := make(map[string]string, len(schedIDs))
+	rows, err := s.scheduleNames.QueryContext(ctx, sqlutil.UUIDArray(schedIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, name string
+		err = rows.Scan(&id, &name)
+		if err != nil {
+			return nil, err
+		}
+		schedNames[id] = name
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// only the subscribing user's shifts are included for these scopes
+	filterUser := cs.Scope == ScopeMyShifts || cs.Scope == ScopeUser
+
+	var result []shiftInfo
+	for _, id := range schedIDs {
+		if _, ok := schedNames[id]; !ok {
+			// deleted
+			continue
+		}
+		shifts, err := s.oc.HistoryBySchedule(ctx, id, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, shift := range shifts {
+			if filterUser && shift.UserID != cs.UserID {
+				continue
+			}
+			result = append(result, shiftInfo{
+				Shift:        shift,
+				ScheduleID:   id,
+				ScheduleName: schedNames[id],
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// serviceRotationShifts will return the shifts of each rotation the service's escalation policy
+// targets directly, between start and end.
+func (s *Store) serviceRotationShifts(ctx context.Context, serviceID string, start, end time.Time) ([]shiftInfo, error) {
+	rows, err := s.serviceRots.QueryContext(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type rotInfo struct{ ID, Name string }
+	var rots []rotInfo
+	for rows.Next() {
+		var r rotInfo
+		err = rows.Scan(&r.ID, &r.Name)
+		if err != nil {
+			return nil, err
+		}
+		rots = append(rots, r)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var result []shiftInfo
+	for _, r := range rots {
+		shifts, err := s.oc.HistoryByRotation(ctx, r.ID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, shift := range shifts {
+			result = append(result, shiftInfo{
+				Shift:        shift,
+				RotationID:   r.ID,
+				RotationName: r.Name,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// serviceUserShifts will return a shift, lasting until end, for each user the service's escalation
+// policy targets directly.
+func (s *Store) serviceUserShifts(ctx context.Context, serviceID string, end time.Time) ([]shiftInfo, error) {
+	rows, err := s.serviceUsers.QueryContext(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []shiftInfo
+	for rows.Next() {
+		info := shiftInfo{Shift: oncall.Shift{End: end, Truncated: true}}
+		err = rows.Scan(&info.UserID, &info.Start, &info.EscalationPolicyName)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, info)
+	}
+
+	return result, rows.Err()
+}
+
+// shifts will return all shifts included in the subscription between start and end, ordered by start time.
+func (s *Store) shifts(ctx context.Context, cs *CalendarSubscription, start, end time.Time) ([]shiftInfo, error) {
+	schedIDs, err := s.scheduleIDs(ctx, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.scheduleShifts(ctx, cs, schedIDs, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	if cs.Scope == ScopeService {
+		rotShifts, err := s.serviceRotationShifts(ctx, cs.ServiceID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rotShifts...)
+
+		userShifts, err := s.serviceUserShifts(ctx, cs.ServiceID, end)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, userShifts...)
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	userIDs := make(map[string]struct{})
+	for _, info := range result {
+		userIDs[info.UserID] = struct{}{}
+	}
+	ids := make([]string, 0, len(userIDs))
+	for id := range userIDs {
+		ids = append(ids, id)
+	}
+	type userDetails struct{ Name, Email string }
+	users := make(map[string]userDetails, len(ids))
+	uRows, err := s.userInfo.QueryContext(ctx, sqlutil.UUIDArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer uRows.Close()
+	for uRows.Next() {
+		var id string
+		var u userDetails
+		err = uRows.Scan(&id, &u.Name, &u.Email)
+		if err != nil {
+			return nil, err
+		}
+		users[id] = u
+	}
+	if err = uRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, info := range result {
+		u := users[info.UserID]
+		result[i].UserName = u.Name
+		result[i].UserEmail = u.Email
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+
+	return result, nil
+}
@@ -31,6 +31,13 @@ type Store struct {
 	authUser   *sql.Stmt
 	now        *sql.Stmt
 
+	userSchedules    *sql.Stmt
+	serviceSchedules *sql.Stmt
+	serviceRots      *sql.Stmt
+	serviceUsers     *sql.Stmt
+	scheduleNames    *sql.Stmt
+	userInfo         *sql.Stmt
+
 	keys keyring.Keyring
 	oc   oncall.Store
 }
@@ -53,15 +60,15 @@ func NewStore(ctx context.Context, db *sql.DB, apiKeyring keyring.Keyring, oc on
 		`),
 		findOne: p.P(`
 			SELECT
-				id, name, user_id, disabled, schedule_id, config, last_access
+				id, name, user_id, disabled, scope, schedule_id, service_id, config, last_access
 			FROM user_calendar_subscriptions
 			WHERE id = $1
 		`),
 		create: p.P(`
 			INSERT INTO user_calendar_subscriptions (
-				id, name, user_id, disabled, schedule_id, config
+				id, name, user_id, disabled, scope, schedule_id, service_id, config
 			)
-			VALUES ($1, $2, $3, $4, $5, $6)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 			RETURNING created_at
 		`),
 		update: p.P(`
@@ -75,16 +82,66 @@ func NewStore(ctx context.Context, db *sql.DB, apiKeyring keyring.Keyring, oc on
 		`),
 		findAll: p.P(`
 			SELECT
-				id, name, user_id, disabled, schedule_id, config, last_access
+				id, name, user_id, disabled, scope, schedule_id, service_id, config, last_access
 			FROM user_calendar_subscriptions
 			WHERE user_id = $1
 		`),
 		findOneUpd: p.P(`
 			SELECT
-				id, name, user_id, disabled, schedule_id, config, last_access
+				id, name, user_id, disabled, scope, schedule_id, service_id, config, last_access
 			FROM user_calendar_subscriptions
 			WHERE id = $1 AND user_id = $2
 		`),
+
+		// Temporary schedules are stored as JSON, so matching on the user ID within
+		// the raw data is the simplest way to find them.
+		userSchedules: p.P(`
+			SELECT r.schedule_id
+			FROM schedule_rules r
+			LEFT JOIN rotation_participants part ON part.rotation_id = r.tgt_rotation_id
+			WHERE r.tgt_user_id = $1 OR part.user_id = $1
+			UNION
+			SELECT tgt_schedule_id
+			FROM user_overrides
+			WHERE add_user_id = $1 AND end_time > now()
+			UNION
+			SELECT schedule_id
+			FROM schedule_data
+			WHERE strpos(data::text, $1::text) > 0
+		`),
+		serviceSchedules: p.P(`
+			SELECT DISTINCT act.schedule_id
+			FROM services svc
+			JOIN escalation_policy_steps step ON step.escalation_policy_id = svc.escalation_policy_id
+			JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+			WHERE svc.id = $1 AND act.schedule_id NOTNULL
+		`),
+		serviceRots: p.P(`
+			SELECT DISTINCT rot.id, rot.name
+			FROM services svc
+			JOIN escalation_policy_steps step ON step.escalation_policy_id = svc.escalation_policy_id
+			JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+			JOIN rotations rot ON rot.id = act.rotation_id
+			WHERE svc.id = $1
+		`),
+
+		// Users targeted directly are on-call for as long as they remain on the
+		// escalation policy, so only the start of their current shift is known.
+		serviceUsers: p.P(`
+			SELECT DISTINCT ON (act.user_id) act.user_id, oc.start_time, ep.name
+			FROM services svc
+			JOIN escalation_policies ep ON ep.id = svc.escalation_policy_id
+			JOIN escalation_policy_steps step ON step.escalation_policy_id = ep.id
+			JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+			JOIN ep_step_on_call_users oc ON
+				oc.ep_step_id = step.id AND
+				oc.user_id = act.user_id AND
+				oc.end_time ISNULL
+			WHERE svc.id = $1
+			ORDER BY act.user_id, oc.start_time
+		`),
+		scheduleNames: p.P(`SELECT id, name FROM schedules WHERE id = any($1)`),
+		userInfo:      p.P(`SELECT id, name, email FROM users WHERE id = any($1)`),
 	}, p.Err
 }
 
@@ -97,13 +154,16 @@ func wrapTx(ctx context.Context, tx *sql.Tx, stmt *sql.Stmt) *sql.Stmt {
 
 func (cs *CalendarSubscription) scanFrom(scanFn func(...interface{}) error) error {
 	var lastAccess sql.NullTime
+	var schedID, svcID sql.NullString
 	var cfgData []byte
-	err := scanFn(&cs.ID, &cs.Name, &cs.UserID, &cs.Disabled, &cs.ScheduleID, &cfgData, &lastAccess)
+	err := scanFn(&cs.ID, &cs.Name, &cs.UserID, &cs.Disabled, &cs.Scope, &schedID, &svcID, &cfgData, &lastAccess)
 	if err != nil {
 		return err
 	}
 
 	cs.LastAccess = lastAccess.Time
+	cs.ScheduleID = schedID.String
+	cs.ServiceID = svcID.String
 	err = json.Unmarshal(cfgData, &cs.Config)
 	return err
 }
@@ -177,7 +237,7 @@ func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, cs *CalendarSubscripti
 	}
 
 	var now time.Time
-	row := wrapTx(ctx, tx, s.create).QueryRowContext(ctx, n.ID, n.Name, n.UserID, n.Disabled, n.ScheduleID, cfgData)
+	row := wrapTx(ctx, tx, s.create).QueryRowContext(ctx, n.ID, n.Name, n.UserID, n.Disabled, n.Scope, sql.NullString{String: n.ScheduleID, Valid: n.ScheduleID != ""}, sql.NullString{String: n.ServiceID, Valid: n.ServiceID != ""}, cfgData)
 	err = row.Scan(&now)
 	if err != nil {
 		return nil, err
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/target/goalert/validation"
@@ -35,6 +36,11 @@ type Config struct {
 		APIKeyExpireDays int `public:"true" info:"Unused calendar API keys will be disabled after this many days (0 means disable cleanup)."`
 	}
 
+	Alerts struct {
+		FlapThreshold     int `public:"true" info:"Number of open/close transitions of the same dedup key within the flap window that will hold the alert open as flapping (0 means disable flap detection)."`
+		FlapWindowMinutes int `public:"true" info:"Flap detection window in minutes; a flapping alert is released once it has been stable this long (defaults to 60)."`
+	}
+
 	Auth struct {
 		RefererURLs  []string `info:"Allowed referer URLs for auth and redirects."`
 		DisableBasic bool     `public:"true" info:"Disallow username/password login."`
@@ -68,6 +74,34 @@ type Config struct {
 		UserInfoEmailPath         string `info:"JMESPath expression to find email address in UserInfo. If set, the email claim will be ignored in favor of this. (suggestion: email)."`
 		UserInfoEmailVerifiedPath string `info:"JMESPath expression to find email verification state in UserInfo. If set, the email_verified claim will be ignored in favor of this. (suggestion: email_verified)."`
 		UserInfoNamePath          string `info:"JMESPath expression to find full name in UserInfo. If set, the name claim will be ignored in favor of this. (suggestion: name || cn || join(' ', [firstname, lastname]))"`
+
+		GroupsPath     string   `info:"JMESPath expression to find the list of group names in the ID token claims (or UserInfo, if not present in the claims). Required for AdminGroups and RotationGroups. (suggestion: groups)"`
+		AdminGroups    []string `info:"If set, members of any listed group will be given the admin role, and all others the user role, on every login."`
+		RotationGroups []string `info:"List of 'group=rotationID' pairs, members of the group will be added to the rotation, and non-members removed, on every login."`
+	}
+
+	LDAP struct {
+		Enable bool `public:"true" info:"Enable LDAP/Active Directory authentication."`
+
+		NewUsers     bool   `info:"Allow new user creation via LDAP authentication."`
+		OverrideName string `info:"Set the name/label on the login page to something other than LDAP."`
+
+		URL        string `info:"LDAP server URL (e.g. ldaps://ldap.example.com)."`
+		StartTLS   bool   `info:"Upgrade ldap:// connections to TLS using StartTLS."`
+		SkipVerify bool   `info:"Disables certificate validation for TLS/StartTLS (insecure)."`
+
+		BindDN       string `info:"DN used to search for users. If left blank, an anonymous bind will be used."`
+		BindPassword string `password:"true" info:"Password for BindDN."`
+
+		BaseDN           string `info:"Base DN to search for users."`
+		UserSearchFilter string `info:"LDAP filter to find a user, {username} will be replaced with the login name. If left blank, (uid={username}) will be used. (suggestion for AD: (sAMAccountName={username}))"`
+
+		SubjectAttribute string `info:"Attribute that uniquely and permanently identifies a user. If left blank, the entry DN will be used. (suggestion: entryUUID or objectGUID)"`
+		NameAttribute    string `info:"Attribute containing the full name of a user. If left blank, cn will be used."`
+		EmailAttribute   string `info:"Attribute containing the email address of a user. If left blank, mail will be used."`
+
+		GroupAttribute string   `info:"Attribute on the user entry listing group DNs. If left blank, memberOf will be used."`
+		AllowedGroups  []string `info:"If set, only members of at least one of the listed group DNs will be allowed to authenticate."`
 	}
 
 	Mailgun struct {
@@ -247,6 +281,14 @@ func validateEnable(prefix string, isEnabled bool, vals ...string) error {
 	return err
 }
 
+// FlapWindow returns the window used for alert flap detection.
+func (cfg Config) FlapWindow() time.Duration {
+	if cfg.Alerts.FlapWindowMinutes == 0 {
+		return time.Hour
+	}
+	return time.Duration(cfg.Alerts.FlapWindowMinutes) * time.Minute
+}
+
 // Validate will check that the Config values are valid.
 func (cfg Config) Validate() error {
 	var err error
@@ -284,10 +326,13 @@ func (cfg Config) Validate() error {
 		validateKey("Slack.AccessToken", cfg.Slack.AccessToken),
 		validate.Range("Maintenance.AlertCleanupDays", cfg.Maintenance.AlertCleanupDays, 0, 9000),
 		validate.Range("Maintenance.APIKeyExpireDays", cfg.Maintenance.APIKeyExpireDays, 0, 9000),
+		validate.Range("Alerts.FlapThreshold", cfg.Alerts.FlapThreshold, 0, 1000),
+		validate.Range("Alerts.FlapWindowMinutes", cfg.Alerts.FlapWindowMinutes, 0, 10080),
 		validateScopes("OIDC.Scopes", cfg.OIDC.Scopes),
 		validatePath("OIDC.UserInfoEmailPath", cfg.OIDC.UserInfoEmailPath),
 		validatePath("OIDC.UserInfoEmailVerifiedPath", cfg.OIDC.UserInfoEmailVerifiedPath),
 		validatePath("OIDC.UserInfoNamePath", cfg.OIDC.UserInfoNamePath),
+		validatePath("OIDC.GroupsPath", cfg.OIDC.GroupsPath),
 	)
 
 	if cfg.OIDC.IssuerURL != "" {
@@ -296,6 +341,12 @@ func (cfg Config) Validate() error {
 	if cfg.OIDC.Scopes != "" {
 		err = validate.Many(err, validateScopes("OIDC.Scopes", cfg.OIDC.Scopes))
 	}
+	if cfg.LDAP.URL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("LDAP.URL", cfg.LDAP.URL))
+	}
+	if cfg.LDAP.UserSearchFilter != "" && !strings.Contains(cfg.LDAP.UserSearchFilter, "{username}") {
+		err = validate.Many(err, validation.NewFieldError("LDAP.UserSearchFilter", "must contain {username}"))
+	}
 	if cfg.GitHub.EnterpriseURL != "" {
 		err = validate.Many(err, validate.AbsoluteURL("GitHub.EnterpriseURL", cfg.GitHub.EnterpriseURL))
 	}
@@ -338,6 +389,10 @@ func (cfg Config) Validate() error {
 			"ClientID", cfg.OIDC.ClientID,
 			"ClientSecret", cfg.OIDC.ClientSecret,
 		),
+		validateEnable("LDAP", cfg.LDAP.Enable,
+			"URL", cfg.LDAP.URL,
+			"BaseDN", cfg.LDAP.BaseDN,
+		),
 		validateEnable("SMTP", cfg.SMTP.Enable,
 			"From", cfg.SMTP.From,
 			"Address", cfg.SMTP.Address,
@@ -366,6 +421,28 @@ func (cfg Config) Validate() error {
 		)
 	}
 
+	if cfg.OIDC.GroupsPath == "" && len(cfg.OIDC.AdminGroups) > 0 {
+		err = validate.Many(err, validation.NewFieldError("OIDC.AdminGroups", "requires OIDC.GroupsPath to be set"))
+	}
+	if cfg.OIDC.GroupsPath == "" && len(cfg.OIDC.RotationGroups) > 0 {
+		err = validate.Many(err, validation.NewFieldError("OIDC.RotationGroups", "requires OIDC.GroupsPath to be set"))
+	}
+	for i, str := range cfg.OIDC.RotationGroups {
+		parts := strings.SplitN(str, "=", 2)
+		fname := fmt.Sprintf("OIDC.RotationGroups[%d]", i)
+		if len(parts) != 2 {
+			err = validate.Many(err, validation.NewFieldError(
+				fname,
+				"must be in the format 'group=rotationID'",
+			))
+			continue
+		}
+		err = validate.Many(err,
+			validate.Text(fname+".Group", parts[0], 1, 255),
+			validate.UUID(fname+".RotationID", parts[1]),
+		)
+	}
+
 	m := make(map[string]bool)
 	for i, str := range cfg.Twilio.SMSFromNumberOverride {
 		parts := strings.SplitN(str, "=", 2)
@@ -264,7 +264,7 @@ func (s *Store) UpdateConfig(ctx context.Context, fn func(Config) (Config, error
 		return err
 	}
 	defer tx.Rollback()
-	id, err := s.updateConfigTx(ctx, tx, fn)
+	id, err := s.UpdateConfigTx(ctx, tx, fn)
 	if err != nil {
 		return err
 	}
@@ -283,8 +283,15 @@ func (s *Store) SetConfig(ctx context.Context, cfg Config) error {
 	return s.UpdateConfig(ctx, func(Config) (Config, error) { return cfg, nil })
 }
 
-func (s *Store) updateConfigTx(ctx context.Context, tx *sql.Tx, fn func(Config) (Config, error)) (int, error) {
-	_, err := tx.StmtContext(ctx, s.lock).ExecContext(ctx)
+// UpdateConfigTx will update the configuration within tx and return the new config version. Changes
+// are not applied until tx is committed and Reload is called.
+func (s *Store) UpdateConfigTx(ctx context.Context, tx *sql.Tx, fn func(Config) (Config, error)) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.StmtContext(ctx, s.lock).ExecContext(ctx)
 	if err != nil {
 		return 0, err
 	}
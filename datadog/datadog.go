@@ -0,0 +1,134 @@
+package datadog
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// post is the payload of a Datadog webhook, using the JSON template from the integration key docs.
+type post struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	Transition string `json:"transition"`
+	AlertID    string `json:"alert_id"`
+	AggregKey  string `json:"aggreg_key"`
+	Link       string `json:"link"`
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// dedup returns the dedup key for the payload. The aggregation key identifies
+// a monitor & group (e.g. a single host of a multi-alert), falling back to the
+// monitor ID.
+func (p post) dedup() string {
+	if p.AggregKey != "" {
+		return p.AggregKey
+	}
+	return p.AlertID
+}
+
+// summary returns the event title without the transition prefix Datadog adds
+// (e.g. `[Triggered on {host:a}] CPU high` becomes `CPU high`), so that
+// re-triggered and recovered events share the same summary.
+func (p post) summary() string {
+	title := strings.TrimSpace(p.Title)
+	if strings.HasPrefix(title, "[") {
+		if idx := strings.Index(title, "] "); idx != -1 {
+			title = title[idx+2:]
+		}
+	}
+	return title
+}
+
+func DatadogToEventsAPI(aDB alert.Store, intDB integrationkey.Store) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		var p post
+		err = json.NewDecoder(r.Body).Decode(&p)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from datadog: %v", err)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"EventID":    p.ID,
+			"AlertID":    p.AlertID,
+			"Transition": p.Transition,
+		})
+
+		var status alert.Status
+		switch strings.ToLower(p.Transition) {
+		case "triggered", "re-triggered", "warn", "re-warn", "renotify":
+			status = alert.StatusTriggered
+		case "recovered":
+			status = alert.StatusClosed
+		case "no data", "re-no data":
+			// no data..
+			return
+		default:
+			log.Logf(ctx, "bad request from datadog: missing or invalid transition")
+			http.Error(w, "invalid transition", http.StatusBadRequest)
+			return
+		}
+
+		var urlStr string
+		if validate.AbsoluteURL("Link", p.Link) == nil {
+			urlStr = p.Link
+		}
+		body := strings.TrimSpace(urlStr + "\n\n" + p.Body)
+
+		dedup := r.FormValue("dedup")
+		if dedup == "" {
+			dedup = p.dedup()
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(p.summary(), alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(body, alert.MaxDetailsLength),
+			Status:    status,
+			Source:    alert.SourceDatadog,
+			ServiceID: serviceID,
+			Dedup:     alert.NewUserDedup(dedup),
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for datadog")) {
+			return
+		}
+	}
+}
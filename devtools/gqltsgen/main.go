@@ -47,7 +47,7 @@ func main() {
 		switch n.Name() {
 		case "String", "ID":
 			result = "string"
-		case "Int":
+		case "Int", "Float":
 			result = "number"
 		case "Boolean":
 			result = "boolean"
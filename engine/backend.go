@@ -30,6 +30,7 @@ func newBackend(db *sql.DB) (*backend, error) {
 				id,
 				alert_id,
 				service_id,
+				shift_request_id,
 				contact_method_id
 			FROM outgoing_messages
 			WHERE id = $1
@@ -45,12 +46,13 @@ func (b *backend) FindOne(ctx context.Context, id string) (*callback, error) {
 
 	var c callback
 	var alertID sql.NullInt64
-	var serviceID sql.NullString
-	err = b.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &alertID, &serviceID, &c.ContactMethodID)
+	var serviceID, shiftRequestID sql.NullString
+	err = b.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &alertID, &serviceID, &shiftRequestID, &c.ContactMethodID)
 	if err != nil {
 		return nil, err
 	}
 	c.AlertID = int(alertID.Int64)
 	c.ServiceID = serviceID.String
+	c.ShiftRequestID = shiftRequestID.String
 	return &c, nil
 }
@@ -10,6 +10,7 @@ type callback struct {
 	ID              string
 	AlertID         int
 	ServiceID       string
+	ShiftRequestID  string
 	ContactMethodID string
 }
 
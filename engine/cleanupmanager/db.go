@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	alertlog "github.com/target/goalert/alert/log"
 	"github.com/target/goalert/engine/processinglock"
 	"github.com/target/goalert/util"
 )
@@ -13,6 +14,8 @@ type DB struct {
 	db   *sql.DB
 	lock *processinglock.Lock
 
+	logStore alertlog.Store
+
 	now *sql.Stmt
 
 	userIDs        *sql.Stmt
@@ -27,6 +30,8 @@ type DB struct {
 
 	cleanupAlertLogs *sql.Stmt
 
+	autoResolveAlerts *sql.Stmt
+
 	logIndex int
 }
 
@@ -34,7 +39,7 @@ type DB struct {
 func (db *DB) Name() string { return "Engine.CleanupManager" }
 
 // NewDB creates a new DB.
-func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
+func NewDB(ctx context.Context, db *sql.DB, logStore alertlog.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Version: 1,
 		Type:    processinglock.TypeCleanup,
@@ -49,6 +54,8 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 		db:   db,
 		lock: lock,
 
+		logStore: logStore,
+
 		now:     p.P(`select now()`),
 		userIDs: p.P(`select id from users`),
 
@@ -68,6 +75,33 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 		setSchedData:    p.P(`update schedule_data set last_cleanup_at = now(), data = $2 where schedule_id = $1`),
 		cleanupSessions: p.P(`DELETE FROM auth_user_sessions WHERE id = any(select id from auth_user_sessions where last_access_at < (now() - '30 days'::interval) LIMIT 100 for update skip locked)`),
 
+		// Notifications and escalations are not considered activity.
+		autoResolveAlerts: p.P(`
+			with to_close as (
+				select a.id, svc.auto_resolve_minutes
+				from alerts a
+				join services svc on svc.id = a.service_id and svc.auto_resolve_minutes > 0
+				where
+					a.status != 'closed' and
+					a.created_at < now() - svc.auto_resolve_minutes * '1 minute'::interval and
+					not exists (
+						select 1 from alert_logs log
+						where
+							log.alert_id = a.id and
+							log.event not in ('notification_sent', 'no_notification_sent', 'escalated', 'policy_updated') and
+							log.timestamp >= now() - svc.auto_resolve_minutes * '1 minute'::interval
+					)
+				order by a.id
+				limit 100
+				for update of a skip locked
+			)
+			update alerts a
+			set status = 'closed'
+			from to_close
+			where a.id = to_close.id
+			returning a.id, to_close.auto_resolve_minutes
+		`),
+
 		cleanupAlertLogs: p.P(`
 			with
 				scope as (select id from alert_logs where id > $1 order by id limit 1000),
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgtype"
+	alertlog "github.com/target/goalert/alert/log"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
@@ -51,6 +52,11 @@ func (db *DB) update(ctx context.Context) error {
 		return fmt.Errorf("cleanup sessions: %w", err)
 	}
 
+	err = db.autoResolve(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("auto-resolve alerts: %w", err)
+	}
+
 	cfg := config.FromContext(ctx)
 	if cfg.Maintenance.AlertCleanupDays > 0 {
 		var dur pgtype.Interval
@@ -130,6 +136,35 @@ func (db *DB) update(ctx context.Context) error {
 	return tx.Commit()
 }
 
+// autoResolve will close alerts that have been inactive longer than their service's AutoResolveMinutes.
+func (db *DB) autoResolve(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.StmtContext(ctx, db.autoResolveAlerts).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	closed := make(map[int]int)
+	for rows.Next() {
+		var id, minutes int
+		err = rows.Scan(&id, &minutes)
+		if err != nil {
+			return err
+		}
+		closed[id] = minutes
+	}
+	rows.Close()
+
+	for id, minutes := range closed {
+		err = db.logStore.LogTx(ctx, tx, id, alertlog.TypeClosed, &alertlog.ClosedMetaData{AutoResolveMinutes: minutes})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func lookupMap(users []string) map[string]struct{} {
 	userLookup := make(map[string]struct{}, len(users))
 	for _, id := range users {
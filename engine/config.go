@@ -7,6 +7,10 @@ import (
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notificationchannel"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/shiftrequest"
+	"github.com/target/goalert/timeoff"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
 )
@@ -20,6 +24,10 @@ type Config struct {
 	UserStore           user.Store
 	NotificationStore   notification.Store
 	NCStore             notificationchannel.Store
+	ShiftRequestStore   *shiftrequest.Store
+	OnCallStore         oncall.Store
+	ScheduleStore       *schedule.Store
+	TimeOffStore        *timeoff.Store
 
 	ConfigSource config.Source
 
@@ -0,0 +1,93 @@
+package coveragemanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/util"
+)
+
+// DB notifies schedule owners of upcoming gaps in on-call coverage.
+type DB struct {
+	lock *processinglock.Lock
+
+	oncallStore oncall.Store
+
+	findDue   *sql.Stmt
+	cleanup   *sql.Stmt
+	insertGap *sql.Stmt
+	notifyGap *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.CoverageManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, oncallStore oncall.Store) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeCoverage,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:        lock,
+		oncallStore: oncallStore,
+
+		findDue: p.P(`
+			with due as (
+				select s.id, s.gap_notify_days
+				from schedules s
+				left join schedule_coverage_checks c on c.schedule_id = s.id
+				where
+					s.gap_notify_days > 0 and
+					(c.last_check_at isnull or c.last_check_at < now() - '15 minutes'::interval)
+				order by c.last_check_at nulls first
+				limit 10
+			), mark as (
+				insert into schedule_coverage_checks (schedule_id)
+				select id from due
+				on conflict (schedule_id) do update
+				set last_check_at = now()
+			)
+			select id, gap_notify_days, now() from due
+		`),
+		cleanup: p.P(`
+			delete from schedule_coverage_gaps
+			where id = any(
+				select id from schedule_coverage_gaps
+				where end_time < now() - '30 days'::interval
+				order by id
+				limit 100
+			)
+		`),
+		insertGap: p.P(`
+			insert into schedule_coverage_gaps (schedule_id, start_time, end_time)
+			values ($1, $2, $3)
+			on conflict (schedule_id, start_time) do nothing
+			returning id
+		`),
+		notifyGap: p.P(`
+			insert into outgoing_messages (message_type, contact_method_id, user_id, schedule_coverage_gap_id)
+			select 'schedule_coverage_gap', cm.id, cm.user_id, $2
+			from user_contact_methods cm
+			join schedules s on s.id = $1
+			where
+				cm.type in ('SMS', 'EMAIL') and
+				not cm.disabled and
+				(
+					cm.user_id = s.gap_notify_user_id or
+					(
+						s.gap_notify_user_id isnull and
+						cm.user_id in (select user_id from user_favorites where tgt_schedule_id = s.id)
+					)
+				)
+		`),
+	}, p.Err
+}
@@ -0,0 +1,90 @@
+package coveragemanager
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+// UpdateAll will check schedules with gap notifications enabled for upcoming
+// coverage gaps, notifying the configured contact (or users that have favorited
+// the schedule) once for each new gap.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Checking schedules for coverage gaps.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "start transaction")
+	}
+	defer tx.Rollback()
+
+	_, err = tx.StmtContext(ctx, db.cleanup).ExecContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "cleanup old coverage gaps")
+	}
+
+	rows, err := tx.StmtContext(ctx, db.findDue).QueryContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "find schedules due for coverage check")
+	}
+	defer rows.Close()
+
+	type dueSchedule struct {
+		ID   string
+		Days int
+		Now  time.Time
+	}
+	var due []dueSchedule
+	for rows.Next() {
+		var s dueSchedule
+		err = rows.Scan(&s.ID, &s.Days, &s.Now)
+		if err != nil {
+			return errors.Wrap(err, "scan due schedule")
+		}
+		due = append(due, s)
+	}
+	rows.Close()
+
+	for _, s := range due {
+		schedCtx := log.WithField(ctx, "ScheduleID", s.ID)
+		end := s.Now.AddDate(0, 0, s.Days)
+		shifts, err := db.oncallStore.HistoryBySchedule(schedCtx, s.ID, s.Now, end)
+		if err != nil {
+			return errors.Wrapf(err, "calculate shifts for schedule %s", s.ID)
+		}
+
+		for _, gap := range oncall.CoverageGaps(shifts, s.Now, end) {
+			if !gap.Start.After(s.Now) {
+				// already in progress, only upcoming gaps are notified
+				continue
+			}
+
+			var gapID int
+			err = tx.StmtContext(ctx, db.insertGap).QueryRowContext(ctx, s.ID, gap.Start, gap.End).Scan(&gapID)
+			if errors.Is(err, sql.ErrNoRows) {
+				// already notified
+				continue
+			}
+			if err != nil {
+				return errors.Wrapf(err, "record coverage gap for schedule %s", s.ID)
+			}
+
+			log.Logf(schedCtx, "Coverage gap detected from %s to %s.", gap.Start, gap.End)
+			_, err = tx.StmtContext(ctx, db.notifyGap).ExecContext(ctx, s.ID, gapID)
+			if err != nil {
+				return errors.Wrapf(err, "notify coverage gap for schedule %s", s.ID)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
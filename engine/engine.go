@@ -10,7 +10,10 @@ import (
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/app/lifecycle"
 	"github.com/target/goalert/engine/cleanupmanager"
+	"github.com/target/goalert/engine/coveragemanager"
 	"github.com/target/goalert/engine/escalationmanager"
+	"github.com/target/goalert/engine/flapmanager"
+	"github.com/target/goalert/engine/handoffmanager"
 	"github.com/target/goalert/engine/heartbeatmanager"
 	"github.com/target/goalert/engine/message"
 	"github.com/target/goalert/engine/npcyclemanager"
@@ -18,7 +21,10 @@ import (
 	"github.com/target/goalert/engine/rotationmanager"
 	"github.com/target/goalert/engine/schedulemanager"
 	"github.com/target/goalert/engine/statusupdatemanager"
+	"github.com/target/goalert/engine/syntheticmanager"
+	"github.com/target/goalert/engine/timeoffmanager"
 	"github.com/target/goalert/engine/verifymanager"
+	"github.com/target/goalert/engine/webhookmanager"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/user"
@@ -119,13 +125,39 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "heartbeat processing backend")
 	}
-	cleanMgr, err := cleanupmanager.NewDB(ctx, db)
+	cleanMgr, err := cleanupmanager.NewDB(ctx, db, c.AlertLogStore)
 	if err != nil {
 		return nil, errors.Wrap(err, "cleanup backend")
 	}
+	webhookMgr, err := webhookmanager.NewDB(ctx, db, c.Keys)
+	if err != nil {
+		return nil, errors.Wrap(err, "outgoing webhook backend")
+	}
+
+	syntheticMgr, err := syntheticmanager.NewDB(ctx, db, c.AlertStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "synthetic check backend")
+	}
+	flapMgr, err := flapmanager.NewDB(ctx, db, c.AlertStore, c.AlertLogStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "alert flapping backend")
+	}
+	coverageMgr, err := coveragemanager.NewDB(ctx, db, c.OnCallStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "schedule coverage backend")
+	}
+	handoffMgr, err := handoffmanager.NewDB(ctx, db, c.OnCallStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "on-call handoff backend")
+	}
+	timeOffMgr, err := timeoffmanager.NewDB(ctx, db, c.TimeOffStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "time off backend")
+	}
 
 	p.modules = []updater{
 		rotMgr,
+		timeOffMgr,
 		schedMgr,
 		epMgr,
 		ncMgr,
@@ -133,6 +165,11 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 		verifyMgr,
 		hbMgr,
 		cleanMgr,
+		webhookMgr,
+		syntheticMgr,
+		flapMgr,
+		coverageMgr,
+		handoffMgr,
 	}
 
 	p.msg, err = message.NewDB(ctx, db, c.AlertLogStore, p.mgr)
@@ -321,6 +358,9 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 	if cb.AlertID != 0 {
 		ctx = log.WithField(ctx, "AlertID", cb.AlertID)
 	}
+	if cb.ShiftRequestID != "" {
+		ctx = log.WithField(ctx, "ShiftRequestID", cb.ShiftRequestID)
+	}
 
 	var usr *user.User
 	permission.SudoContext(ctx, func(ctx context.Context) {
@@ -344,6 +384,11 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 
 	var newStatus alert.Status
 	switch result {
+	case notification.ResultAccept, notification.ResultDecline:
+		if cb.ShiftRequestID == "" {
+			return errors.New("unknown callback type")
+		}
+		return errors.Wrap(p.respondShiftRequest(ctx, cb.ShiftRequestID, result == notification.ResultAccept), "respond to shift request")
 	case notification.ResultAcknowledge:
 		newStatus = alert.StatusActive
 	case notification.ResultResolve:
@@ -362,6 +407,21 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 	return errors.New("unknown callback type")
 }
 
+func (p *Engine) respondShiftRequest(ctx context.Context, id string, accept bool) error {
+	tx, err := p.b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = p.cfg.ShiftRequestStore.RespondTx(ctx, tx, id, accept)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 // Start will enable all associated contact methods of `value` with type `t`. This should
 // be invoked if a user, for example, responds with `START` via sms.
 func (p *Engine) Start(ctx context.Context, d notification.Dest) error {
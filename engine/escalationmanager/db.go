@@ -18,9 +18,12 @@ type DB struct {
 	lockStmt     *sql.Stmt
 	updateOnCall *sql.Stmt
 
+	ackTimeout *sql.Stmt
+
 	newPolicies      *sql.Stmt
 	deletedSteps     *sql.Stmt
 	normalEscalation *sql.Stmt
+	fallback         *sql.Stmt
 
 	log alertlog.Store
 }
@@ -31,7 +34,7 @@ func (db *DB) Name() string { return "Engine.EscalationManager" }
 // NewDB creates a new DB.
 func NewDB(ctx context.Context, db *sql.DB, log alertlog.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
-		Version: 3,
+		Version: 5,
 		Type:    processinglock.TypeEscalation,
 	})
 	if err != nil {
@@ -91,6 +94,39 @@ func NewDB(ctx context.Context, db *sql.DB, log alertlog.Store) (*DB, error) {
 				pol.step_count = 0
 		`),
 
+		// ackTimeout will revert acknowledged alerts to triggered once their policy's ack timeout
+		// has passed. Clearing the step ID will cause deletedSteps to re-notify the current step.
+		ackTimeout: p.P(`
+			with to_reset as (
+				select a.id, ep.ack_timeout_minutes
+				from alerts a
+				join escalation_policy_state state on state.alert_id = a.id
+				join escalation_policies ep on
+					ep.id = state.escalation_policy_id and
+					ep.ack_timeout_minutes > 0
+				where
+					a.status = 'active' and
+					coalesce(
+						(select max(log.timestamp) from alert_logs log where log.alert_id = a.id and log.event = 'acknowledged'),
+						a.created_at
+					) < now() - (cast(ep.ack_timeout_minutes as text)||' minutes')::interval
+				for update of a, state skip locked
+				limit 100
+			), _state as (
+				update escalation_policy_state state
+				set
+					escalation_policy_step_id = null,
+					force_escalation = false
+				from to_reset
+				where state.alert_id = to_reset.id
+			)
+			update alerts a
+			set status = 'triggered'
+			from to_reset
+			where a.id = to_reset.id
+			returning a.id, to_reset.ack_timeout_minutes
+		`),
+
 		newPolicies: p.P(`
 			with to_escalate as (
 				select alert_id, step.id ep_step_id, step.delay, step.escalation_policy_id, a.service_id
@@ -138,7 +174,8 @@ func NewDB(ctx context.Context, db *sql.DB, log alertlog.Store) (*DB, error) {
 					last_escalation = now(),
 					next_escalation = now() + (cast(esc.delay as text)||' minutes')::interval,
 					escalation_policy_step_id = esc.ep_step_id,
-					force_escalation = false
+					force_escalation = false,
+					fallback_notified = false
 				from
 					to_escalate esc
 				where
@@ -231,7 +268,10 @@ func NewDB(ctx context.Context, db *sql.DB, log alertlog.Store) (*DB, error) {
 					nextStep.delay,
 					nextStep.step_number,
 					force_escalation forced,
-					oldStep.delay old_delay,
+					oldStep.delay + CASE
+						WHEN oldStep.step_number + 1 >= ep.step_count AND NOT force_escalation THEN ep.repeat_delay_minutes
+						ELSE 0
+					END old_delay,
 					oldStep.step_number + 1 >= ep.step_count repeated,
 					nextStep.escalation_policy_id,
 					a.service_id
@@ -251,7 +291,13 @@ func NewDB(ctx context.Context, db *sql.DB, log alertlog.Store) (*DB, error) {
 				where
 					state.last_escalation notnull and
 					escalation_policy_step_id notnull and
-					(next_escalation < now() or force_escalation)
+					(
+						force_escalation or
+						next_escalation + CASE
+							WHEN oldStep.step_number + 1 >= ep.step_count THEN (cast(ep.repeat_delay_minutes as text)||' minutes')::interval
+							ELSE interval '0 minutes'
+						END < now()
+					)
 				order by next_escalation - now()
 				for update skip locked
 				limit 500
@@ -305,5 +351,54 @@ func NewDB(ctx context.Context, db *sql.DB, log alertlog.Store) (*DB, error) {
 			left join _step_cycles step on step.alert_id = esc.alert_id
 			left join _step_channels chan on chan.alert_id = esc.alert_id
 		`),
+		fallback: p.P(`
+			with to_escalate as (
+				select
+					alert_id,
+					a.service_id,
+					ep.id escalation_policy_id,
+					ep.fallback_user_id,
+					ep.fallback_channel_id,
+					step.step_number,
+					step.delay old_delay
+				from escalation_policy_state state
+				join alerts a on a.id = state.alert_id and a.status = 'triggered'
+				join escalation_policies ep on
+					ep.id = state.escalation_policy_id and
+					(ep.fallback_user_id notnull or ep.fallback_channel_id notnull)
+				join escalation_policy_steps step on step.id = state.escalation_policy_step_id
+				where
+					not state.fallback_notified and
+					not state.force_escalation and
+					ep.repeat != -1 and
+					state.loop_count >= ep.repeat and
+					step.step_number + 1 >= ep.step_count and
+					next_escalation < now()
+				for update of state skip locked
+				limit 100
+			), _cycles as (
+				insert into notification_policy_cycles (alert_id, user_id)
+				select alert_id, fallback_user_id
+				from to_escalate
+				where fallback_user_id notnull
+			), _channels as (
+				insert into outgoing_messages (message_type, alert_id, service_id, escalation_policy_id, channel_id)
+				select
+					cast('alert_notification' as enum_outgoing_messages_type),
+					alert_id,
+					service_id,
+					escalation_policy_id,
+					fallback_channel_id
+				from to_escalate
+				where fallback_channel_id notnull
+			), _update as (
+				update escalation_policy_state state
+				set fallback_notified = true
+				from to_escalate esc
+				where state.alert_id = esc.alert_id
+			)
+			select alert_id, step_number, old_delay
+			from to_escalate
+		`),
 	}, p.Err
 }
@@ -48,6 +48,11 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 		return errors.Wrap(err, "end policies with no steps")
 	}
 
+	err = db.resetAckTimeout(ctx)
+	if err != nil {
+		return errors.Wrap(err, "reset expired acknowledgements")
+	}
+
 	err = db.processEscalations(ctx, db.newPolicies, func(rows *sql.Rows) (int, *alertlog.EscalationMetaData, error) {
 		var id int
 		var meta alertlog.EscalationMetaData
@@ -78,6 +83,16 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 		return errors.Wrap(err, "escalate forced or expired")
 	}
 
+	err = db.processEscalations(ctx, db.fallback, func(rows *sql.Rows) (int, *alertlog.EscalationMetaData, error) {
+		var id int
+		meta := alertlog.EscalationMetaData{Fallback: true}
+		err := rows.Scan(&id, &meta.NewStepIndex, &meta.OldDelayMinutes)
+		return id, &meta, err
+	})
+	if err != nil {
+		return errors.Wrap(err, "escalate to fallback target")
+	}
+
 	return nil
 }
 
@@ -113,3 +128,38 @@ func (db *DB) processEscalations(ctx context.Context, stmt *sql.Stmt, scan func(
 
 	return tx.Commit()
 }
+
+// resetAckTimeout will revert expired acknowledgements to triggered, so that escalation resumes.
+func (db *DB) resetAckTimeout(ctx context.Context) error {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.StmtContext(ctx, db.ackTimeout).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	batch := make(map[alertlog.AckTimeoutMetaData][]int)
+	for rows.Next() {
+		var id int
+		var meta alertlog.AckTimeoutMetaData
+		err = rows.Scan(&id, &meta.TimeoutMinutes)
+		if err != nil {
+			return err
+		}
+		batch[meta] = append(batch[meta], id)
+	}
+
+	for meta, ids := range batch {
+		err = db.log.LogManyTx(ctx, tx, ids, alertlog.TypeAckTimeout, meta)
+		if err != nil {
+			return errors.Wrap(err, "log ack timeout")
+		}
+	}
+
+	return tx.Commit()
+}
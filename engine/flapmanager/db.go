@@ -0,0 +1,70 @@
+package flapmanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/alert"
+	alertlog "github.com/target/goalert/alert/log"
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/util"
+)
+
+// DB releases flapping alerts once they have been stable for the flap window.
+type DB struct {
+	lock *processinglock.Lock
+
+	alertStore alert.Store
+	logStore   alertlog.Store
+
+	deleteClosed       *sql.Stmt
+	findStable         *sql.Stmt
+	release            *sql.Stmt
+	cleanupTransitions *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.FlapManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, a alert.Store, logStore alertlog.Store) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeFlap,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:       lock,
+		alertStore: a,
+		logStore:   logStore,
+
+		deleteClosed: p.P(`
+			delete from alert_flapping f
+			using alerts a
+			where a.id = f.alert_id and a.status = 'closed'
+		`),
+		findStable: p.P(`
+			select f.alert_id, f.source_closed
+			from alert_flapping f
+			where f.last_transition_at < now() - $1::int * '1 minute'::interval
+			order by f.last_transition_at
+			limit 100
+			for update skip locked
+		`),
+		release: p.P(`delete from alert_flapping where alert_id = $1`),
+		cleanupTransitions: p.P(`
+			delete from alert_dedup_transitions
+			where id = any(
+				select id from alert_dedup_transitions
+				where created_at < now() - $1::int * '1 minute'::interval
+				order by id
+				limit 1000
+			)
+		`),
+	}, p.Err
+}
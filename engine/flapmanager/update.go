@@ -0,0 +1,83 @@
+package flapmanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	alertlog "github.com/target/goalert/alert/log"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+// UpdateAll will release flapping alerts that have been stable for the flap window,
+// closing them if the last transition from the source was a close.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Releasing stable flapping alerts.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "start transaction")
+	}
+	defer tx.Rollback()
+
+	_, err = tx.StmtContext(ctx, db.deleteClosed).ExecContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "delete flapping state for closed alerts")
+	}
+
+	windowMinutes := int(config.FromContext(ctx).FlapWindow() / time.Minute)
+	_, err = tx.StmtContext(ctx, db.cleanupTransitions).ExecContext(ctx, windowMinutes)
+	if err != nil {
+		return errors.Wrap(err, "cleanup old transitions")
+	}
+
+	rows, err := tx.StmtContext(ctx, db.findStable).QueryContext(ctx, windowMinutes)
+	if err != nil {
+		return errors.Wrap(err, "find stable alerts")
+	}
+	defer rows.Close()
+
+	type stableAlert struct {
+		ID           int
+		SourceClosed bool
+	}
+	var stable []stableAlert
+	for rows.Next() {
+		var s stableAlert
+		err = rows.Scan(&s.ID, &s.SourceClosed)
+		if err != nil {
+			return errors.Wrap(err, "scan stable alert")
+		}
+		stable = append(stable, s)
+	}
+	rows.Close()
+
+	for _, s := range stable {
+		alertCtx := log.WithField(ctx, "AlertID", s.ID)
+		_, err = tx.StmtContext(ctx, db.release).ExecContext(ctx, s.ID)
+		if err != nil {
+			return errors.Wrapf(err, "release flapping alert %d", s.ID)
+		}
+		err = db.logStore.LogTx(alertCtx, tx, s.ID, alertlog.TypeFlapping, &alertlog.FlappingMetaData{Stable: true, SourceClosed: s.SourceClosed})
+		if err != nil {
+			return errors.Wrapf(err, "log stable alert %d", s.ID)
+		}
+		if !s.SourceClosed {
+			continue
+		}
+
+		err = db.alertStore.UpdateStatusTx(alertCtx, tx, s.ID, alert.StatusClosed)
+		if err != nil {
+			return errors.Wrapf(err, "close stable alert %d", s.ID)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,129 @@
+package handoffmanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/util"
+)
+
+// DB notifies users before their on-call shifts start and after they end.
+type DB struct {
+	lock *processinglock.Lock
+
+	oncallStore oncall.Store
+
+	cleanup     *sql.Stmt
+	notifyEnded *sql.Stmt
+	maxLead     *sql.Stmt
+	findDue     *sql.Stmt
+	findUsers   *sql.Stmt
+	insertStart *sql.Stmt
+	notify      *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.HandoffManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, oncallStore oncall.Store) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeHandoff,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:        lock,
+		oncallStore: oncallStore,
+
+		cleanup: p.P(`
+			delete from user_handoff_notices
+			where id = any(
+				select id from user_handoff_notices
+				where coalesce(shift_end, shift_start) < now() - '30 days'::interval
+				order by id
+				limit 100
+			)
+		`),
+		notifyEnded: p.P(`
+			with ended as (
+				insert into user_handoff_notices (schedule_id, user_id, shift_ended, shift_start, shift_end)
+				select oc.schedule_id, oc.user_id, true, oc.start_time, oc.end_time
+				from schedule_on_call_users oc
+				join users u on u.id = oc.user_id and u.handoff_notify
+				where
+					oc.end_time > now() - '1 hour'::interval and
+					oc.end_time - oc.start_time > '1 minute'::interval
+				on conflict (schedule_id, user_id, shift_ended, shift_start) do nothing
+				returning id, user_id
+			)
+			insert into outgoing_messages (message_type, contact_method_id, user_id, user_handoff_notice_id)
+			select 'shift_handoff', cm.id, cm.user_id, ended.id
+			from ended
+			join user_contact_methods cm on
+				cm.user_id = ended.user_id and
+				cm.type in ('SMS', 'EMAIL') and
+				not cm.disabled
+		`),
+		maxLead: p.P(`select coalesce(max(handoff_notify_minutes), 0) from users where handoff_notify`),
+		findDue: p.P(`
+			with due as (
+				select s.id
+				from schedules s
+				left join schedule_handoff_checks c on c.schedule_id = s.id
+				where
+					(c.last_check_at isnull or c.last_check_at < now() - '5 minutes'::interval) and
+					s.id in (
+						select rule.schedule_id
+						from schedule_rules rule
+						join users u on u.id = rule.tgt_user_id and u.handoff_notify
+						union
+						select rule.schedule_id
+						from schedule_rules rule
+						join rotation_participants part on part.rotation_id = rule.tgt_rotation_id
+						join users u on u.id = part.user_id and u.handoff_notify
+						union
+						select o.tgt_schedule_id
+						from user_overrides o
+						join users u on u.id = o.add_user_id and u.handoff_notify
+						where o.tgt_schedule_id notnull
+					)
+				order by c.last_check_at nulls first
+				limit 10
+			), mark as (
+				insert into schedule_handoff_checks (schedule_id)
+				select id from due
+				on conflict (schedule_id) do update
+				set last_check_at = now()
+			)
+			select id, now() from due
+		`),
+		findUsers: p.P(`
+			select id, handoff_notify_minutes
+			from users
+			where handoff_notify and id = any($1)
+		`),
+		insertStart: p.P(`
+			insert into user_handoff_notices (schedule_id, user_id, shift_ended, shift_start, shift_end)
+			values ($1, $2, false, $3, $4)
+			on conflict (schedule_id, user_id, shift_ended, shift_start) do nothing
+			returning id
+		`),
+		notify: p.P(`
+			insert into outgoing_messages (message_type, contact_method_id, user_id, user_handoff_notice_id)
+			select 'shift_handoff', cm.id, cm.user_id, $2
+			from user_contact_methods cm
+			where
+				cm.user_id = $1 and
+				cm.type in ('SMS', 'EMAIL') and
+				not cm.disabled
+		`),
+	}, p.Err
+}
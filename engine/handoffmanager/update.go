@@ -0,0 +1,137 @@
+package handoffmanager
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// checkInterval is how often each schedule is checked for upcoming shifts, it must
+// match the interval used by the findDue query.
+const checkInterval = 5 * time.Minute
+
+// UpdateAll will notify users with handoff notifications enabled of shifts that
+// recently ended, and of shifts starting within their configured lead time.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Sending on-call handoff notifications.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "start transaction")
+	}
+	defer tx.Rollback()
+
+	_, err = tx.StmtContext(ctx, db.cleanup).ExecContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "cleanup old handoff notices")
+	}
+
+	_, err = tx.StmtContext(ctx, db.notifyEnded).ExecContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "notify ended shifts")
+	}
+
+	var maxLead int
+	err = tx.StmtContext(ctx, db.maxLead).QueryRowContext(ctx).Scan(&maxLead)
+	if err != nil {
+		return errors.Wrap(err, "lookup max handoff lead time")
+	}
+
+	rows, err := tx.StmtContext(ctx, db.findDue).QueryContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "find schedules due for handoff check")
+	}
+	defer rows.Close()
+
+	var schedIDs []string
+	var now time.Time
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id, &now)
+		if err != nil {
+			return errors.Wrap(err, "scan due schedule")
+		}
+		schedIDs = append(schedIDs, id)
+	}
+	rows.Close()
+
+	for _, schedID := range schedIDs {
+		err = db.notifyUpcoming(log.WithField(ctx, "ScheduleID", schedID), tx, schedID, now, now.Add(time.Duration(maxLead)*time.Minute+checkInterval))
+		if err != nil {
+			return errors.Wrapf(err, "notify upcoming shifts for schedule %s", schedID)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) notifyUpcoming(ctx context.Context, tx *sql.Tx, schedID string, now, end time.Time) error {
+	shifts, err := db.oncallStore.HistoryBySchedule(ctx, schedID, now, end)
+	if err != nil {
+		return errors.Wrap(err, "calculate shifts")
+	}
+
+	userIDs := make([]string, 0, len(shifts))
+	for _, s := range shifts {
+		userIDs = append(userIDs, s.UserID)
+	}
+	rows, err := tx.StmtContext(ctx, db.findUsers).QueryContext(ctx, sqlutil.UUIDArray(userIDs))
+	if err != nil {
+		return errors.Wrap(err, "lookup user handoff settings")
+	}
+	defer rows.Close()
+
+	lead := make(map[string]time.Duration)
+	for rows.Next() {
+		var id string
+		var minutes int
+		err = rows.Scan(&id, &minutes)
+		if err != nil {
+			return errors.Wrap(err, "scan user handoff settings")
+		}
+		lead[id] = time.Duration(minutes) * time.Minute
+	}
+	rows.Close()
+
+	for _, s := range shifts {
+		l, ok := lead[s.UserID]
+		// Notify early rather than late, since the schedule won't be checked
+		// again until the next interval.
+		if !ok || !s.Start.After(now) || now.Add(checkInterval).Before(s.Start.Add(-l)) {
+			// not enabled, already started, or not yet within the lead time
+			continue
+		}
+
+		var shiftEnd sqlutil.NullTime
+		if !s.Truncated {
+			shiftEnd.Valid = true
+			shiftEnd.Time = s.End
+		}
+
+		var noticeID int
+		err = tx.StmtContext(ctx, db.insertStart).QueryRowContext(ctx, schedID, s.UserID, s.Start, shiftEnd).Scan(&noticeID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// already notified
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "record handoff notice")
+		}
+
+		_, err = tx.StmtContext(ctx, db.notify).ExecContext(ctx, s.UserID, noticeID)
+		if err != nil {
+			return errors.Wrap(err, "notify user")
+		}
+	}
+
+	return nil
+}
@@ -17,6 +17,9 @@ type DB struct {
 
 	fetchFailed  *sql.Stmt
 	fetchHealthy *sql.Stmt
+
+	fetchCron   *sql.Stmt
+	setDeadline *sql.Stmt
 }
 
 // Name returns the name of the module.
@@ -26,7 +29,7 @@ func (db *DB) Name() string { return "Engine.HeartbeatManager" }
 func NewDB(ctx context.Context, db *sql.DB, a alert.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeHeartbeat,
-		Version: 1,
+		Version: 2,
 	})
 	if err != nil {
 		return nil, err
@@ -44,8 +47,14 @@ func NewDB(ctx context.Context, db *sql.DB, a alert.Store) (*DB, error) {
 				select id
 				from heartbeat_monitors
 				where
-					last_state != 'unhealthy' and
-					now() - last_heartbeat >= heartbeat_interval
+					last_state != 'unhealthy' and (
+						last_report_failed or
+						case when cron_schedule isnull then
+							now() - last_heartbeat >= heartbeat_interval * alert_after_misses + grace_period
+						else
+							deadline_from = last_heartbeat and next_deadline <= now()
+						end
+					)
 				limit 250
 				for update skip locked
 			)
@@ -53,7 +62,7 @@ func NewDB(ctx context.Context, db *sql.DB, a alert.Store) (*DB, error) {
 			set last_state = 'unhealthy'
 			from rows
 			where mon.id = rows.id
-			returning mon.id, name, service_id, last_heartbeat
+			returning mon.id, name, service_id, last_heartbeat, last_failure, last_report_failed, last_message
 		`),
 		fetchHealthy: p.P(`
 			with rows as (
@@ -61,7 +70,12 @@ func NewDB(ctx context.Context, db *sql.DB, a alert.Store) (*DB, error) {
 				from heartbeat_monitors
 				where
 					last_state != 'healthy' and
-					now() - last_heartbeat < heartbeat_interval
+					not last_report_failed and
+					case when cron_schedule isnull then
+						now() - last_heartbeat < heartbeat_interval * alert_after_misses + grace_period
+					else
+						deadline_from = last_heartbeat and (next_deadline isnull or next_deadline > now())
+					end
 				limit 250
 				for update skip locked
 			)
@@ -71,5 +85,22 @@ func NewDB(ctx context.Context, db *sql.DB, a alert.Store) (*DB, error) {
 			where mon.id = rows.id
 			returning mon.id, service_id
 		`),
+
+		// cron-based monitors whose deadline has not been calculated since the last heartbeat
+		fetchCron: p.P(`
+			select id, cron_schedule, time_zone, grace_period, alert_after_misses, last_heartbeat
+			from heartbeat_monitors
+			where
+				cron_schedule notnull and
+				last_heartbeat notnull and
+				deadline_from is distinct from last_heartbeat
+			limit 250
+			for update skip locked
+		`),
+		setDeadline: p.P(`
+			update heartbeat_monitors
+			set next_deadline = $2, deadline_from = $3
+			where id = $1
+		`),
 	}, p.Err
 }
@@ -4,11 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
+
 	"github.com/target/goalert/alert"
+	"github.com/target/goalert/heartbeat"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/log"
-	"time"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
 
+	"github.com/jackc/pgtype"
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
 )
@@ -32,6 +38,11 @@ func (db *DB) processAll(ctx context.Context) error {
 	}
 	defer tx.Rollback()
 
+	err = db.updateDeadlines(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "update cron deadlines")
+	}
+
 	var newAlertCtx []context.Context
 	var newAlerts []alert.Alert
 	bad, err := db.unhealthy(ctx, tx)
@@ -40,8 +51,8 @@ func (db *DB) processAll(ctx context.Context) error {
 	}
 	for _, row := range bad {
 		a, isNew, err := db.alertStore.CreateOrUpdateTx(row.Context(ctx), tx, &alert.Alert{
-			Summary:   fmt.Sprintf("Heartbeat monitor '%s' expired.", row.Name),
-			Details:   "Last heartbeat: " + row.LastHeartbeat.Format(time.UnixDate),
+			Summary:   row.Summary(),
+			Details:   row.Details(),
 			Status:    alert.StatusTriggered,
 			ServiceID: row.ServiceID,
 			Dedup: &alert.DedupID{
@@ -108,6 +119,32 @@ type row struct {
 	Name          string
 	ServiceID     string
 	LastHeartbeat time.Time
+	LastFailure   time.Time
+	Failed        bool
+	Message       string
+}
+
+// Summary returns the alert summary for an unhealthy heartbeat.
+func (r row) Summary() string {
+	if r.Failed {
+		return fmt.Sprintf("Heartbeat monitor '%s' reported failure.", r.Name)
+	}
+	return fmt.Sprintf("Heartbeat monitor '%s' expired.", r.Name)
+}
+
+// Details returns the alert details for an unhealthy heartbeat, including the last reported message.
+func (r row) Details() string {
+	details := "Last heartbeat: Never"
+	if !r.LastHeartbeat.IsZero() {
+		details = "Last heartbeat: " + r.LastHeartbeat.Format(time.UnixDate)
+	}
+	if r.Failed {
+		details += "\nFailure reported: " + r.LastFailure.Format(time.UnixDate)
+	}
+	if r.Message != "" {
+		details += "\n\nLast message: " + r.Message
+	}
+	return validate.SanitizeText(details, alert.MaxDetailsLength)
 }
 
 func (r row) Context(ctx context.Context) context.Context {
@@ -117,6 +154,63 @@ func (r row) Context(ctx context.Context) context.Context {
 	})
 }
 
+// updateDeadlines will calculate the next expected heartbeat deadline for cron-based
+// monitors that have received a heartbeat since it was last calculated.
+func (db *DB) updateDeadlines(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.Stmt(db.fetchCron).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type deadline struct {
+		ID       string
+		From     time.Time
+		Deadline sql.NullTime
+	}
+	var deadlines []deadline
+	for rows.Next() {
+		var m heartbeat.Monitor
+		var tz string
+		var grace pgtype.Interval
+		var d deadline
+		err = rows.Scan(&d.ID, &m.CronSchedule, &tz, &grace, &m.AlertAfterMisses, &d.From)
+		if err != nil {
+			return err
+		}
+		err = grace.AssignTo(&m.GracePeriod)
+		if err != nil {
+			return err
+		}
+		m.TimeZone, err = util.LoadLocation(tz)
+		if err != nil {
+			log.Log(log.WithField(ctx, "MonitorID", d.ID), errors.Wrap(err, "load time zone"))
+			continue
+		}
+
+		t, err := m.CronDeadline(d.From)
+		if err != nil {
+			log.Log(log.WithField(ctx, "MonitorID", d.ID), errors.Wrap(err, "calculate deadline"))
+			continue
+		}
+		d.Deadline = sql.NullTime{Time: t, Valid: !t.IsZero()}
+		deadlines = append(deadlines, d)
+	}
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	for _, d := range deadlines {
+		_, err = tx.Stmt(db.setDeadline).ExecContext(ctx, d.ID, d.Deadline, d.From)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (db *DB) unhealthy(ctx context.Context, tx *sql.Tx) ([]row, error) {
 	rows, err := tx.Stmt(db.fetchFailed).QueryContext(ctx)
 	if err != nil {
@@ -126,10 +220,13 @@ func (db *DB) unhealthy(ctx context.Context, tx *sql.Tx) ([]row, error) {
 	var result []row
 	for rows.Next() {
 		var r row
-		err = rows.Scan(&r.ID, &r.Name, &r.ServiceID, &r.LastHeartbeat)
+		var last, failed sqlutil.NullTime
+		err = rows.Scan(&r.ID, &r.Name, &r.ServiceID, &last, &failed, &r.Failed, &r.Message)
 		if err != nil {
 			return nil, err
 		}
+		r.LastHeartbeat = last.Time
+		r.LastFailure = failed.Time
 		result = append(result, r)
 	}
 	return result, nil
@@ -71,7 +71,7 @@ type DB struct {
 func NewDB(ctx context.Context, db *sql.DB, a alertlog.Store, pausable lifecycle.Pausable) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeMessage,
-		Version: 7,
+		Version: 10,
 	})
 	if err != nil {
 		return nil, err
@@ -350,7 +350,10 @@ func NewDB(ctx context.Context, db *sql.DB, a alertlog.Store, pausable lifecycle
 				msg.service_id,
 				msg.created_at,
 				msg.sent_at,
-				msg.status_alert_ids
+				msg.status_alert_ids,
+				msg.shift_request_id,
+				msg.schedule_coverage_gap_id,
+				msg.user_handoff_notice_id
 			from outgoing_messages msg
 			left join user_contact_methods cm on cm.id = msg.contact_method_id
 			left join notification_channels chan on chan.id = msg.channel_id
@@ -386,8 +389,8 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 
 	for rows.Next() {
 		var msg Message
-		var destID, destValue, verifyID, userID, serviceID, cmType, chanType sql.NullString
-		var alertID, logID sql.NullInt64
+		var destID, destValue, verifyID, userID, serviceID, cmType, chanType, shiftReqID sql.NullString
+		var alertID, logID, gapID, handoffID sql.NullInt64
 		var statusAlertIDs sqlutil.IntArray
 		var createdAt, sentAt sql.NullTime
 		err = rows.Scan(
@@ -405,6 +408,9 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 			&createdAt,
 			&sentAt,
 			&statusAlertIDs,
+			&shiftReqID,
+			&gapID,
+			&handoffID,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan row")
@@ -419,6 +425,9 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 		msg.Dest.ID = destID.String
 		msg.Dest.Value = destValue.String
 		msg.StatusAlertIDs = statusAlertIDs
+		msg.ShiftRequestID = shiftReqID.String
+		msg.CoverageGapID = int(gapID.Int64)
+		msg.HandoffNoticeID = int(handoffID.Int64)
 		switch {
 		case cmType.String == string(contactmethod.TypeSMS):
 			msg.Dest.Type = notification.DestTypeSMS
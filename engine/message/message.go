@@ -15,6 +15,10 @@ type Message struct {
 	AlertLogID int
 	VerifyID   string
 
+	ShiftRequestID  string
+	CoverageGapID   int
+	HandoffNoticeID int
+
 	UserID    string
 	ServiceID string
 	CreatedAt time.Time
@@ -18,6 +18,10 @@ var typePriority = map[notification.MessageType]int{
 
 	notification.MessageTypeAlertStatus:       4,
 	notification.MessageTypeAlertStatusBundle: 4,
+
+	notification.MessageTypeShiftRequest:        5,
+	notification.MessageTypeScheduleCoverageGap: 5,
+	notification.MessageTypeShiftHandoff:        5,
 }
 
 type queue struct {
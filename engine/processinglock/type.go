@@ -20,6 +20,12 @@ const (
 	TypeVerify       Type = "verify"
 	TypeMessage      Type = "message"
 	TypeCleanup      Type = "cleanup"
+	TypeWebhook      Type = "webhook"
+	TypeSynthetic    Type = "synthetic"
+	TypeFlap         Type = "flap"
+	TypeCoverage     Type = "coverage"
+	TypeHandoff      Type = "handoff"
+	TypeTimeOff      Type = "timeoff"
 )
 
 func (t Type) validate() error {
@@ -33,6 +39,12 @@ func (t Type) validate() error {
 		TypeVerify,
 		TypeMessage,
 		TypeCleanup,
+		TypeWebhook,
+		TypeSynthetic,
+		TypeFlap,
+		TypeCoverage,
+		TypeHandoff,
+		TypeTimeOff,
 	)
 }
 
@@ -75,6 +87,18 @@ func (t Type) LockID() int {
 		return 0x1070 // 4208
 	case TypeCleanup:
 		return 0x1080 // 4224
+	case TypeWebhook:
+		return 0x1090 // 4240
+	case TypeSynthetic:
+		return 0x10A0 // 4256
+	case TypeFlap:
+		return 0x10B0 // 4272
+	case TypeCoverage:
+		return 0x10C0 // 4288
+	case TypeHandoff:
+		return 0x10D0 // 4304
+	case TypeTimeOff:
+		return 0x10E0 // 4320
 	}
 
 	panic("invalid type")
@@ -13,18 +13,28 @@ type advance struct {
 	id          string
 	newPosition int
 
+	// resumePosition is where the rotation continues from after a credited turn, or -1.
+	resumePosition int
+
+	// credits holds updated skip credits, by position.
+	credits map[int]int
+
 	silent bool
 }
 
 type rotState struct {
 	rotation.State
 	Version int
+
+	// ResumePosition is where the rotation continues from after a credited turn, or -1.
+	ResumePosition int
 }
 
 // calcAdvance will calculate rotation advancement if it is required. If not, nil is returned
-func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state rotState, partCount int) *advance {
+func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state rotState, parts []rotation.Availability) *advance {
 	var mustUpdate bool
 	origPos := state.Position
+	partCount := len(parts)
 
 	// get next shift start time
 	newStart := rot.EndTime(state.ShiftStart)
@@ -42,8 +52,9 @@ func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state
 	if newStart.After(t) || state.Version == 1 {
 		if mustUpdate {
 			return &advance{
-				id:          rot.ID,
-				newPosition: state.Position,
+				id:             rot.ID,
+				newPosition:    state.Position,
+				resumePosition: state.ResumePosition,
 
 				// If migrating from version 1 to 2 without changing
 				// who's on-call do so silently.
@@ -60,6 +71,11 @@ func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state
 
 	state.ShiftStart = newStart
 
+	origCredits := make([]int, partCount)
+	for i, p := range parts {
+		origCredits[i] = p.SkipCredit
+	}
+
 	c := 0
 	for {
 		c++
@@ -67,7 +83,7 @@ func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state
 			panic("too many rotation advances")
 		}
 
-		state.Position = (state.Position + 1) % partCount
+		state.Position, state.ResumePosition = rotation.NextPosition(parts, state.Position, state.ResumePosition, state.ShiftStart)
 		end := rot.EndTime(state.ShiftStart)
 		if end.After(t) {
 			break
@@ -75,8 +91,21 @@ func calcAdvance(ctx context.Context, t time.Time, rot *rotation.Rotation, state
 		state.ShiftStart = end
 	}
 
+	var credits map[int]int
+	for i, p := range parts {
+		if p.SkipCredit == origCredits[i] {
+			continue
+		}
+		if credits == nil {
+			credits = make(map[int]int)
+		}
+		credits[i] = p.SkipCredit
+	}
+
 	return &advance{
-		id:          rot.ID,
-		newPosition: state.Position,
+		id:             rot.ID,
+		newPosition:    state.Position,
+		resumePosition: state.ResumePosition,
+		credits:        credits,
 	}
 }
@@ -0,0 +1,34 @@
+package rotationmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/schedule/rotation"
+)
+
+func TestCalcAdvance_Skip(t *testing.T) {
+	rot := &rotation.Rotation{
+		ID:          "rot",
+		Type:        rotation.TypeDaily,
+		ShiftLength: 1,
+		Start:       time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	until := rot.Start.AddDate(0, 0, 2)
+	parts := []rotation.Availability{{}, {UnavailableUntil: &until}, {}}
+	state := rotState{
+		State:          rotation.State{Position: 0, ShiftStart: rot.Start},
+		Version:        2,
+		ResumePosition: -1,
+	}
+
+	adv := calcAdvance(context.Background(), rot.Start.Add(25*time.Hour), rot, state, parts)
+	assert.Equal(t, &advance{
+		id:             "rot",
+		newPosition:    2,
+		resumePosition: -1,
+		credits:        map[int]int{1: 1},
+	}, adv)
+}
@@ -16,6 +16,7 @@ type DB struct {
 
 	rotate     *sql.Stmt
 	rotateData *sql.Stmt
+	setCredit  *sql.Stmt
 }
 
 // Name returns the name of the module.
@@ -25,7 +26,7 @@ func (db *DB) Name() string { return "Engine.RotationManager" }
 func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeRotation,
-		Version: 2,
+		Version: 3,
 	})
 	if err != nil {
 		return nil, err
@@ -41,9 +42,15 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 			set
 				shift_start = now(),
 				rotation_participant_id = (select id from rotation_participants where rotation_id = $1 and position = $2),
+				resume_position = $3,
 				version = 2
 			where rotation_id = $1
 		`),
+		setCredit: p.P(`
+			update rotation_participants
+			set skip_credit = $3
+			where rotation_id = $1 and position = $2
+		`),
 		rotateData: p.P(`
 			select
 				rot.id,
@@ -53,7 +60,15 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				rot.time_zone,
 				state.shift_start,
 				state."position",
-				rot.participant_count,
+				state.resume_position,
+				coalesce((
+					select json_agg(json_build_object(
+						'unavailable_until', part.unavailable_until,
+						'skip_credit', part.skip_credit
+					) order by part.position)
+					from rotation_participants part
+					where part.rotation_id = rot.id
+				), '[]'),
 				state.version
 			from rotations rot
 			join rotation_state state on state.rotation_id = rot.id
@@ -3,6 +3,7 @@ package rotationmanager
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/target/goalert/permission"
@@ -58,6 +59,7 @@ func (db *DB) update(ctx context.Context, all bool, rotID *string) error {
 	}
 
 	updateStmt := tx.Stmt(db.rotate)
+	creditStmt := tx.Stmt(db.setCredit)
 	for _, adv := range needsAdvance {
 		fctx := log.WithFields(ctx, log.Fields{
 			"RotationID": adv.id,
@@ -67,10 +69,17 @@ func (db *DB) update(ctx context.Context, all bool, rotID *string) error {
 		if !adv.silent {
 			log.Debugf(fctx, "Advancing rotation.")
 		}
-		_, err = updateStmt.ExecContext(fctx, adv.id, adv.newPosition)
+		resume := sql.NullInt64{Int64: int64(adv.resumePosition), Valid: adv.resumePosition >= 0}
+		_, err = updateStmt.ExecContext(fctx, adv.id, adv.newPosition, resume)
 		if err != nil {
 			return errors.Wrap(err, "advance rotation")
 		}
+		for pos, credit := range adv.credits {
+			_, err = creditStmt.ExecContext(fctx, adv.id, pos, credit)
+			if err != nil {
+				return errors.Wrap(err, "update skip credit")
+			}
+		}
 	}
 
 	return errors.Wrap(tx.Commit(), "commit transaction")
@@ -91,7 +100,9 @@ func (db *DB) calcAdvances(ctx context.Context, tx *sql.Tx, all bool, rotID *str
 
 	var rot rotation.Rotation
 	var state rotState
-	var partCount int
+	var resume sql.NullInt64
+	var partData []byte
+	var parts []rotation.Availability
 	var tzName string
 	var adv *advance
 	var loc *time.Location
@@ -108,18 +119,28 @@ func (db *DB) calcAdvances(ctx context.Context, tx *sql.Tx, all bool, rotID *str
 			&tzName,
 			&state.ShiftStart,
 			&state.Position,
-			&partCount,
+			&resume,
+			&partData,
 			&state.Version,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan rotation data")
 		}
+		state.ResumePosition = -1
+		if resume.Valid {
+			state.ResumePosition = int(resume.Int64)
+		}
+		parts = nil
+		err = json.Unmarshal(partData, &parts)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse rotation participants")
+		}
 		loc, err = util.LoadLocation(tzName)
 		if err != nil {
 			return nil, errors.Wrap(err, "load timezone")
 		}
 		rot.Start = rot.Start.In(loc)
-		adv = calcAdvance(ctx, t, &rot, state, partCount)
+		adv = calcAdvance(ctx, t, &rot, state, parts)
 		if adv != nil {
 			needsAdvance = append(needsAdvance, *adv)
 			if len(needsAdvance) == 150 {
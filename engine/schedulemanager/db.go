@@ -62,7 +62,15 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				start_time,
 				end_time,
 				sched.time_zone,
-				coalesce(rule.tgt_user_id, part.user_id)
+				coalesce(rule.tgt_user_id, part.user_id),
+				rule.holiday_mode,
+				exists (
+					select 1
+					from holidays h
+					where
+						h.calendar_id = sched.holiday_calendar_id and
+						h.date = (now() at time zone sched.time_zone)::date
+				)
 			from schedule_rules rule
 			join schedules sched on sched.id = rule.schedule_id
 			left join rotation_state rState on rState.rotation_id = rule.tgt_rotation_id
@@ -96,7 +96,8 @@ func (db *DB) update(ctx context.Context) error {
 
 	type userRule struct {
 		rule.Rule
-		UserID string
+		UserID    string
+		IsHoliday bool
 	}
 
 	var rules []userRule
@@ -111,6 +112,8 @@ func (db *DB) update(ctx context.Context) error {
 			&r.End,
 			&tzName,
 			&r.UserID,
+			&r.HolidayMode,
+			&r.IsHoliday,
 		)
 		if err != nil {
 			return errors.Wrap(err, "scan rule")
@@ -165,7 +168,7 @@ func (db *DB) update(ctx context.Context) error {
 			// temp schedule active for this ID, skip
 			continue
 		}
-		if r.IsActive(now.In(tz[r.ScheduleID])) {
+		if r.IsActiveHoliday(now.In(tz[r.ScheduleID]), r.IsHoliday) {
 			newOnCall[onCall{ScheduleID: r.ScheduleID, UserID: r.UserID}] = true
 		}
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/target/goalert/engine/message"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/shiftrequest"
 	"github.com/target/goalert/util/log"
 	"go.opencensus.io/trace"
 )
@@ -121,6 +122,72 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			CallbackID: msg.ID,
 			Code:       code,
 		}
+	case notification.MessageTypeShiftRequest:
+		req, err := p.cfg.ShiftRequestStore.FindOne(ctx, msg.ShiftRequestID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup shift request")
+		}
+		forRequester := msg.UserID == req.RequesterID
+		if !forRequester && req.Status != shiftrequest.StatusPending {
+			// already cancelled or answered, no need to ask
+			return &notification.SendResult{
+				ID: msg.ID,
+				Status: notification.Status{
+					Details: "shift request no longer pending",
+					State:   notification.StateFailedPerm,
+				},
+			}, nil
+		}
+		tz := req.ScheduleTimeZone
+		notifMsg = notification.ShiftRequest{
+			Dest:          msg.Dest,
+			CallbackID:    msg.ID,
+			RequestID:     req.ID,
+			ScheduleID:    req.ScheduleID,
+			ScheduleName:  req.ScheduleName,
+			RequesterName: req.RequesterName,
+			UserName:      req.UserName,
+			Start:         req.Start.In(tz),
+			End:           req.End.In(tz),
+			SwapStart:     req.SwapStart.In(tz),
+			SwapEnd:       req.SwapEnd.In(tz),
+			Status:        string(req.Status),
+			ForRequester:  forRequester,
+		}
+	case notification.MessageTypeScheduleCoverageGap:
+		gap, err := p.cfg.ScheduleStore.FindCoverageGap(ctx, msg.CoverageGapID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup coverage gap")
+		}
+		notifMsg = notification.ScheduleCoverageGap{
+			Dest:         msg.Dest,
+			CallbackID:   msg.ID,
+			ScheduleID:   gap.ScheduleID,
+			ScheduleName: gap.ScheduleName,
+			Start:        gap.Start.In(gap.TimeZone),
+			End:          gap.End.In(gap.TimeZone),
+		}
+	case notification.MessageTypeShiftHandoff:
+		n, err := p.cfg.OnCallStore.FindHandoffNotice(ctx, msg.HandoffNoticeID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup handoff notice")
+		}
+		alerts := make([]notification.ShiftHandoffAlert, len(n.Alerts))
+		for i, a := range n.Alerts {
+			alerts[i] = notification.ShiftHandoffAlert(a)
+		}
+		notifMsg = notification.ShiftHandoff{
+			Dest:           msg.Dest,
+			CallbackID:     msg.ID,
+			ScheduleID:     n.ScheduleID,
+			ScheduleName:   n.ScheduleName,
+			Start:          n.Start.In(n.TimeZone),
+			End:            n.End.In(n.TimeZone),
+			Ended:          n.Ended,
+			AlertCount:     n.AlertCount,
+			OpenAlertCount: n.OpenAlertCount,
+			Alerts:         alerts,
+		}
 	default:
 		log.Log(ctx, errors.New("SEND NOT IMPLEMENTED FOR MESSAGE TYPE"))
 		return &notification.SendResult{ID: msg.ID, Status: notification.Status{State: notification.StateFailedPerm}}, nil
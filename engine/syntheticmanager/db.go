@@ -0,0 +1,96 @@
+package syntheticmanager
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/util"
+)
+
+// checkTimeout is the maximum duration of a single check.
+const checkTimeout = 10 * time.Second
+
+// DB runs synthetic checks.
+type DB struct {
+	lock *processinglock.Lock
+
+	alertStore alert.Store
+
+	client *http.Client
+	dialer *net.Dialer
+
+	claimDue  *sql.Stmt
+	release   *sql.Stmt
+	lockCheck *sql.Stmt
+	setResult *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.SyntheticManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, a alert.Store) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeSynthetic,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:       lock,
+		alertStore: a,
+
+		client: &http.Client{
+			Timeout: checkTimeout,
+			Transport: &http.Transport{
+				Proxy:             http.ProxyFromEnvironment,
+				DisableKeepAlives: true,
+			},
+		},
+		dialer: &net.Dialer{Timeout: checkTimeout},
+
+		// Claiming a check sets last_run_at, so it is not due again until its interval (at
+		// least a minute) has passed, which outlasts the module deadline.
+		claimDue: p.P(`
+			with due as (
+				select id, last_run_at
+				from synthetic_checks
+				where
+					not disabled and
+					(last_run_at isnull or last_run_at + check_interval <= now())
+				order by last_run_at nulls first
+				limit 50
+				for update skip locked
+			)
+			update synthetic_checks c
+			set last_run_at = now()
+			from due
+			where c.id = due.id
+			returning
+				c.id, c.service_id, c.name, c.check_type, c.target,
+				c.expected_status, c.body_contains, c.tls_expiry_days, due.last_run_at
+		`),
+		release: p.P(`update synthetic_checks set last_run_at = $2 where id = $1`),
+
+		lockCheck: p.P(`
+			select last_state
+			from synthetic_checks
+			where id = $1 and not disabled
+			for update
+		`),
+		setResult: p.P(`
+			update synthetic_checks
+			set last_run_at = now(), last_state = $2, last_error = $3
+			where id = $1
+		`),
+	}, p.Err
+}
@@ -0,0 +1,65 @@
+package syntheticmanager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/target/goalert/syntheticcheck"
+)
+
+// maxBodyBytes is the maximum amount of an HTTP response body that is searched for BodyContains.
+const maxBodyBytes = 1024 * 1024
+
+func (db *DB) runHTTP(ctx context.Context, c syntheticcheck.Check) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.Target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "GoAlert-SyntheticCheck")
+
+	resp, err := db.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if c.ExpectedStatus == 0 && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
+		return fmt.Errorf("non-2xx response: %s", resp.Status)
+	}
+	if c.ExpectedStatus != 0 && resp.StatusCode != c.ExpectedStatus {
+		return fmt.Errorf("expected status %d but got: %s", c.ExpectedStatus, resp.Status)
+	}
+
+	if c.TLSExpiryDays > 0 && resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		expires := resp.TLS.PeerCertificates[0].NotAfter
+		if time.Until(expires) < time.Duration(c.TLSExpiryDays)*24*time.Hour {
+			return fmt.Errorf("TLS certificate expires %s", expires.UTC().Format(time.RFC1123))
+		}
+	}
+
+	if c.BodyContains != "" {
+		data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+		if err != nil {
+			return fmt.Errorf("read body: %w", err)
+		}
+		if !bytes.Contains(data, []byte(c.BodyContains)) {
+			return fmt.Errorf("response body does not contain '%s'", c.BodyContains)
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) runTCP(ctx context.Context, c syntheticcheck.Check) error {
+	conn, err := db.dialer.DialContext(ctx, "tcp", c.Target)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
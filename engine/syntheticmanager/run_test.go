@@ -0,0 +1,77 @@
+package syntheticmanager
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/syntheticcheck"
+)
+
+func TestDB_RunHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte("status: all good"))
+		case "/created":
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer srv.Close()
+
+	db := &DB{client: srv.Client()}
+	run := func(c syntheticcheck.Check) error {
+		t.Helper()
+		c.Type = syntheticcheck.TypeHTTP
+		return db.run(context.Background(), c)
+	}
+
+	assert.NoError(t, run(syntheticcheck.Check{Target: srv.URL + "/ok"}))
+	assert.NoError(t, run(syntheticcheck.Check{Target: srv.URL + "/created"}))
+	assert.Error(t, run(syntheticcheck.Check{Target: srv.URL + "/missing"}))
+	assert.NoError(t, run(syntheticcheck.Check{Target: srv.URL + "/missing", ExpectedStatus: 404}))
+	assert.Error(t, run(syntheticcheck.Check{Target: srv.URL + "/ok", ExpectedStatus: 201}))
+
+	assert.NoError(t, run(syntheticcheck.Check{Target: srv.URL + "/ok", BodyContains: "all good"}))
+	err := run(syntheticcheck.Check{Target: srv.URL + "/ok", BodyContains: "degraded"})
+	if assert.Error(t, err) {
+		assert.True(t, strings.Contains(err.Error(), "degraded"))
+	}
+}
+
+func TestDB_RunHTTP_TLSExpiry(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	defer srv.Close()
+
+	db := &DB{client: srv.Client()}
+	expires := srv.Certificate().NotAfter
+	check := syntheticcheck.Check{Type: syntheticcheck.TypeHTTP, Target: srv.URL}
+
+	check.TLSExpiryDays = int(time.Until(expires)/(24*time.Hour)) - 1
+	assert.NoError(t, db.run(context.Background(), check))
+
+	check.TLSExpiryDays = int(time.Until(expires)/(24*time.Hour)) + 1
+	assert.Error(t, db.run(context.Background(), check))
+}
+
+func TestDB_RunTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+
+	db := &DB{dialer: &net.Dialer{Timeout: time.Second}}
+	check := syntheticcheck.Check{Type: syntheticcheck.TypeTCP, Target: addr}
+	assert.NoError(t, db.run(context.Background(), check))
+
+	l.Close()
+	assert.Error(t, db.run(context.Background(), check))
+}
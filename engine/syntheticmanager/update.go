@@ -0,0 +1,222 @@
+package syntheticmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/syntheticcheck"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+type check struct {
+	syntheticcheck.Check
+
+	// PrevRun is the value of last_run_at before the check was claimed.
+	PrevRun sqlutil.NullTime
+
+	// Err is set to the result of the check.
+	Err error
+
+	// Ran indicates the check completed (successfully or not) before the deadline.
+	Ran bool
+}
+
+// UpdateAll will run all checks that are due, opening and closing alerts as needed.
+//
+// Due checks are claimed in a short transaction, and run after it has been committed,
+// so that slow or unresponsive targets never hold the engine's processing lock. Results
+// are then recorded in a second transaction.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Running synthetic checks.")
+
+	due, err := db.claim(ctx)
+	if err != nil {
+		return err
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	// Leave time to record results before the module's deadline; anything
+	// not completed is released and will be run on the next cycle.
+	runCtx := ctx
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithDeadline(ctx, deadline.Add(-5*time.Second))
+		defer cancel()
+	}
+	db.runAll(runCtx, due)
+
+	return db.record(ctx, due)
+}
+
+// claim will fetch due checks and mark them as run, so they are not picked up again
+// while they are in progress.
+func (db *DB) claim(ctx context.Context) ([]*check, error) {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "start transaction")
+	}
+	defer tx.Rollback()
+
+	due, err := db.due(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch due checks")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "commit claim")
+	}
+
+	return due, nil
+}
+
+// record will store the result of each check, opening or closing alerts on a state
+// change, and release the claim on any that did not complete.
+func (db *DB) record(ctx context.Context, due []*check) error {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "start transaction")
+	}
+	defer tx.Rollback()
+
+	var newAlertCtx []context.Context
+	for _, c := range due {
+		if !c.Ran {
+			_, err = tx.StmtContext(ctx, db.release).ExecContext(ctx, c.ID, c.PrevRun)
+			if err != nil {
+				return errors.Wrap(err, "release check")
+			}
+			continue
+		}
+
+		// the check may have been disabled, deleted, or changed state while it was running
+		var lastState syntheticcheck.State
+		err = tx.StmtContext(ctx, db.lockCheck).QueryRowContext(ctx, c.ID).Scan(&lastState)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "lock check")
+		}
+
+		state := syntheticcheck.StateHealthy
+		var errMsg string
+		if c.Err != nil {
+			state = syntheticcheck.StateUnhealthy
+			errMsg = validate.SanitizeText(c.Err.Error(), 1024)
+		}
+
+		if state != lastState {
+			checkCtx := log.WithField(ctx, "SyntheticCheckID", c.ID)
+			a, isNew, err := db.alertStore.CreateOrUpdateTx(checkCtx, tx, c.alert(state, errMsg))
+			if err != nil {
+				return errors.Wrapf(err, "update alert for check %s", c.ID)
+			}
+			if isNew {
+				newAlertCtx = append(newAlertCtx, log.WithFields(checkCtx, log.Fields{
+					"AlertID":   a.ID,
+					"ServiceID": a.ServiceID,
+				}))
+			}
+		}
+
+		_, err = tx.StmtContext(ctx, db.setResult).ExecContext(ctx, c.ID, state, errMsg)
+		if err != nil {
+			return errors.Wrap(err, "record check result")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	// log new alert creations, after the tx was committed without err.
+	for _, ctx := range newAlertCtx {
+		log.Logf(ctx, "Alert created.")
+	}
+
+	return nil
+}
+
+// alert returns the alert that should be created or updated for the given check state.
+func (c check) alert(state syntheticcheck.State, errMsg string) *alert.Alert {
+	a := &alert.Alert{
+		ServiceID: c.ServiceID,
+		Status:    alert.StatusClosed,
+		Dedup: &alert.DedupID{
+			Type:    alert.DedupTypeCheck,
+			Version: 1,
+			Payload: c.ID,
+		},
+	}
+	if state == syntheticcheck.StateUnhealthy {
+		a.Status = alert.StatusTriggered
+		a.Summary = validate.SanitizeText(fmt.Sprintf("Check '%s' failed: %s", c.Name, errMsg), alert.MaxSummaryLength)
+		a.Details = validate.SanitizeText(fmt.Sprintf("Target: %s\n\n%s", c.Target, errMsg), alert.MaxDetailsLength)
+	}
+
+	return a
+}
+
+func (db *DB) due(ctx context.Context, tx *sql.Tx) ([]*check, error) {
+	rows, err := tx.StmtContext(ctx, db.claimDue).QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*check
+	for rows.Next() {
+		var c check
+		err = rows.Scan(
+			&c.ID, &c.ServiceID, &c.Name, &c.Type, &c.Target,
+			&c.ExpectedStatus, &c.BodyContains, &c.TLSExpiryDays, &c.PrevRun,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &c)
+	}
+
+	return result, rows.Err()
+}
+
+// runAll will run all checks concurrently.
+func (db *DB) runAll(ctx context.Context, checks []*check) {
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c *check) {
+			defer wg.Done()
+			c.Err = db.run(ctx, c.Check)
+			c.Ran = ctx.Err() == nil
+		}(c)
+	}
+	wg.Wait()
+}
+
+func (db *DB) run(ctx context.Context, c syntheticcheck.Check) error {
+	switch c.Type {
+	case syntheticcheck.TypeHTTP:
+		return db.runHTTP(ctx, c)
+	case syntheticcheck.TypeTCP:
+		return db.runTCP(ctx, c)
+	}
+
+	return errors.Errorf("unknown check type '%s'", c.Type)
+}
@@ -0,0 +1,35 @@
+package timeoffmanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/timeoff"
+)
+
+// DB applies user time off to schedules by generating overrides.
+type DB struct {
+	lock *processinglock.Lock
+
+	timeOffStore *timeoff.Store
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.TimeOffManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, timeOffStore *timeoff.Store) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeTimeOff,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DB{
+		lock:         lock,
+		timeOffStore: timeOffStore,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package timeoffmanager
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+// UpdateAll will generate overrides for any time off that has not yet been applied
+// to the schedules the user belongs to.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Applying user time off.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "start transaction")
+	}
+	defer tx.Rollback()
+
+	_, err = db.timeOffStore.ApplyOverridesTx(ctx, tx)
+	if err != nil {
+		return errors.Wrap(err, "apply time off overrides")
+	}
+
+	return tx.Commit()
+}
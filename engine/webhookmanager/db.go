@@ -0,0 +1,127 @@
+package webhookmanager
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/util"
+)
+
+// maxAttempts is the number of times a delivery is attempted before it is marked as failed.
+const maxAttempts = 10
+
+// maxConcurrentWebhooks is the number of webhooks that are sent to at the same time.
+const maxConcurrentWebhooks = 10
+
+// DB handles delivering events to outgoing webhooks.
+type DB struct {
+	lock *processinglock.Lock
+	keys keyring.Keys
+
+	client *http.Client
+
+	cleanup      *sql.Stmt
+	fetchPending *sql.Stmt
+	claimDel     *sql.Stmt
+	release      *sql.Stmt
+	setSent      *sql.Stmt
+	setError     *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.WebhookManager" }
+
+// NewDB creates a new DB. Webhook secrets are decrypted with the provided keys.
+func NewDB(ctx context.Context, db *sql.DB, keys keyring.Keys) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeWebhook,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock: lock,
+		keys: keys,
+
+		client: &http.Client{Timeout: 5 * time.Second},
+
+		cleanup: p.P(`
+			delete from outgoing_webhook_deliveries
+			where created_at < now() - '7 days'::interval
+		`),
+
+		// Later deliveries are held while an earlier one for the same webhook is waiting
+		// to be retried (or is claimed by another instance), so each webhook receives
+		// events in order.
+		fetchPending: p.P(`
+			select
+				d.id,
+				d.webhook_id,
+				w.url,
+				w.secret,
+				d.event_type,
+				d.payload,
+				d.created_at
+			from outgoing_webhook_deliveries d
+			join outgoing_webhooks w on w.id = d.webhook_id and not w.disabled
+			where
+				d.sent_at isnull and
+				d.failed_at isnull and
+				d.next_attempt_at <= now() and
+				not exists (
+					select 1
+					from outgoing_webhook_deliveries prev
+					where
+						prev.webhook_id = d.webhook_id and
+						prev.id < d.id and
+						prev.sent_at isnull and
+						prev.failed_at isnull and
+						prev.next_attempt_at > now()
+				)
+			order by d.id
+			limit 100
+			for update of d skip locked
+		`),
+
+		// The claim must outlast the module deadline, so an engine that stops mid-send
+		// doesn't cause duplicate deliveries from another instance.
+		claimDel: p.P(`
+			update outgoing_webhook_deliveries
+			set next_attempt_at = now() + '2 minutes'::interval
+			where id = any($1)
+		`),
+		release: p.P(`
+			update outgoing_webhook_deliveries
+			set next_attempt_at = now()
+			where id = any($1)
+		`),
+
+		setSent: p.P(`
+			update outgoing_webhook_deliveries
+			set
+				attempts = attempts + 1,
+				sent_at = now(),
+				last_error = null
+			where id = $1
+		`),
+
+		// back off exponentially, starting at 1 minute up to 1 hour between attempts
+		setError: p.P(`
+			update outgoing_webhook_deliveries
+			set
+				attempts = attempts + 1,
+				last_error = $2,
+				next_attempt_at = now() + least('1 minute'::interval * power(2, attempts), '1 hour'::interval),
+				failed_at = case when attempts + 1 >= $3 then now() end
+			where id = $1
+		`),
+	}, p.Err
+}
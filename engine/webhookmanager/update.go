@@ -0,0 +1,245 @@
+package webhookmanager
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/outgoingwebhook"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+type delivery struct {
+	ID        int64
+	WebhookID string
+	URL       string
+	Secret    []byte
+	Type      string
+	Payload   json.RawMessage
+	CreatedAt time.Time
+
+	// Err is set to the result of the delivery attempt.
+	Err error
+
+	// Attempted indicates a request was made.
+	Attempted bool
+}
+
+// body is the JSON document sent as the request body.
+type body struct {
+	ID   int64           `json:"id"`
+	Type string          `json:"type"`
+	Time time.Time       `json:"time"`
+	Data json.RawMessage `json:"data"`
+}
+
+// UpdateAll will attempt to send all pending deliveries.
+//
+// Due deliveries are claimed in a short transaction, and requests are made after it has been
+// committed, so that slow or unresponsive receivers never hold the engine's processing lock.
+// Results are then recorded in a second transaction.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Sending outgoing webhooks.")
+
+	pending, err := db.claim(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// Leave time to record results before the module's deadline; anything
+	// not attempted is released and will be picked up on the next cycle.
+	sendCtx := ctx
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		sendCtx, cancel = context.WithDeadline(ctx, deadline.Add(-5*time.Second))
+		defer cancel()
+	}
+	db.sendAll(sendCtx, pending)
+
+	return db.record(ctx, pending)
+}
+
+// claim will fetch pending deliveries and delay their next attempt for the duration of
+// the claim, so they are not picked up again while requests are in flight.
+func (db *DB) claim(ctx context.Context) ([]*delivery, error) {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "start transaction")
+	}
+	defer tx.Rollback()
+
+	_, err = tx.StmtContext(ctx, db.cleanup).ExecContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "cleanup old deliveries")
+	}
+
+	pending, err := db.pending(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch pending deliveries")
+	}
+	if len(pending) == 0 {
+		return nil, tx.Commit()
+	}
+
+	ids := make(sqlutil.IntArray, len(pending))
+	for i, d := range pending {
+		ids[i] = int(d.ID)
+	}
+	_, err = tx.StmtContext(ctx, db.claimDel).ExecContext(ctx, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "claim deliveries")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "commit claim")
+	}
+
+	return pending, nil
+}
+
+// record will update the status of each delivery after an attempt, releasing the claim
+// on any that were not attempted.
+func (db *DB) record(ctx context.Context, pending []*delivery) error {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "start transaction")
+	}
+	defer tx.Rollback()
+
+	var release sqlutil.IntArray
+	for _, d := range pending {
+		if !d.Attempted {
+			release = append(release, int(d.ID))
+			continue
+		}
+		if d.Err == nil {
+			_, err = tx.StmtContext(ctx, db.setSent).ExecContext(ctx, d.ID)
+		} else {
+			log.Debug(log.WithFields(ctx, log.Fields{
+				"WebhookID":  d.WebhookID,
+				"DeliveryID": d.ID,
+			}), errors.Wrap(d.Err, "send outgoing webhook"))
+			_, err = tx.StmtContext(ctx, db.setError).ExecContext(ctx, d.ID, d.Err.Error(), maxAttempts)
+		}
+		if err != nil {
+			return errors.Wrap(err, "update delivery status")
+		}
+	}
+	if len(release) > 0 {
+		_, err = tx.StmtContext(ctx, db.release).ExecContext(ctx, release)
+		if err != nil {
+			return errors.Wrap(err, "release deliveries")
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) pending(ctx context.Context, tx *sql.Tx) ([]*delivery, error) {
+	rows, err := tx.StmtContext(ctx, db.fetchPending).QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*delivery
+	for rows.Next() {
+		var d delivery
+		var secret []byte
+		err = rows.Scan(&d.ID, &d.WebhookID, &d.URL, &secret, &d.Type, &d.Payload, &d.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		d.Secret, _, err = db.keys.Decrypt(secret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decrypt secret for webhook %s", d.WebhookID)
+		}
+		result = append(result, &d)
+	}
+
+	return result, rows.Err()
+}
+
+// sendAll will send deliveries for up to maxConcurrentWebhooks webhooks at a time. Deliveries for a single
+// webhook are sent in order, stopping at the first failure.
+func (db *DB) sendAll(ctx context.Context, pending []*delivery) {
+	byWebhook := make(map[string][]*delivery)
+	for _, d := range pending {
+		byWebhook[d.WebhookID] = append(byWebhook[d.WebhookID], d)
+	}
+
+	sem := make(chan struct{}, maxConcurrentWebhooks)
+	var wg sync.WaitGroup
+	for _, list := range byWebhook {
+		wg.Add(1)
+		go func(list []*delivery) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			for _, d := range list {
+				if ctx.Err() != nil {
+					return
+				}
+				d.Err = db.send(ctx, d)
+				d.Attempted = ctx.Err() == nil || d.Err == nil
+				if d.Err != nil {
+					return
+				}
+			}
+		}(list)
+	}
+	wg.Wait()
+}
+
+func (db *DB) send(ctx context.Context, d *delivery) error {
+	data, err := json.Marshal(body{
+		ID:   d.ID,
+		Type: d.Type,
+		Time: d.CreatedAt,
+		Data: d.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", d.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(outgoingwebhook.HeaderEvent, d.Type)
+	req.Header.Set(outgoingwebhook.HeaderDelivery, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(outgoingwebhook.HeaderSignature, outgoingwebhook.Signature(d.Secret, time.Now(), data))
+
+	resp, err := db.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1024*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("non-2xx response: %s", resp.Status)
+	}
+
+	return nil
+}
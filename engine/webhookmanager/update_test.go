@@ -0,0 +1,122 @@
+package webhookmanager
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/outgoingwebhook"
+)
+
+func TestDB_sendAll(t *testing.T) {
+	var mx sync.Mutex
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+
+		sig := req.Header.Get(outgoingwebhook.HeaderSignature)
+		unix, _ := strconv.ParseInt(strings.TrimPrefix(strings.SplitN(sig, ",", 2)[0], "t="), 10, 64)
+		if sig != outgoingwebhook.Signature([]byte("secret"), time.Unix(unix, 0), data) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var b body
+		_ = json.Unmarshal(data, &b)
+
+		mx.Lock()
+		got = append(got, req.URL.Path+":"+req.Header.Get(outgoingwebhook.HeaderDelivery)+":"+b.Type)
+		mx.Unlock()
+
+		if req.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	newDelivery := func(id int64, webhookID, path, secret, typ string) *delivery {
+		return &delivery{
+			ID:        id,
+			WebhookID: webhookID,
+			URL:       srv.URL + path,
+			Secret:    []byte(secret),
+			Type:      typ,
+			Payload:   json.RawMessage(`{}`),
+		}
+	}
+
+	db := &DB{client: srv.Client()}
+	pending := []*delivery{
+		newDelivery(1, "a", "/ok", "secret", "alert.created"),
+		newDelivery(2, "b", "/fail", "secret", "alert.created"),
+		newDelivery(3, "a", "/ok", "secret", "alert.closed"),
+		newDelivery(4, "b", "/fail", "secret", "alert.closed"),
+		newDelivery(5, "c", "/ok", "wrong", "alert.created"),
+	}
+	db.sendAll(context.Background(), pending)
+
+	assert.True(t, pending[0].Attempted)
+	assert.NoError(t, pending[0].Err)
+	assert.True(t, pending[2].Attempted)
+	assert.NoError(t, pending[2].Err)
+
+	assert.True(t, pending[1].Attempted)
+	assert.Error(t, pending[1].Err)
+	assert.False(t, pending[3].Attempted, "should stop after first failure for a webhook")
+
+	assert.True(t, pending[4].Attempted)
+	assert.Error(t, pending[4].Err, "invalid signature")
+
+	assert.ElementsMatch(t, []string{
+		"/ok:1:alert.created",
+		"/ok:3:alert.closed",
+		"/fail:2:alert.created",
+	}, got)
+}
+
+func TestDB_sendAll_Concurrency(t *testing.T) {
+	var mx sync.Mutex
+	var cur, max int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mx.Lock()
+		cur++
+		if cur > max {
+			max = cur
+		}
+		mx.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mx.Lock()
+		cur--
+		mx.Unlock()
+	}))
+	defer srv.Close()
+
+	var pending []*delivery
+	for i := 0; i < maxConcurrentWebhooks*3; i++ {
+		pending = append(pending, &delivery{
+			ID:        int64(i),
+			WebhookID: strconv.Itoa(i),
+			URL:       srv.URL,
+			Payload:   json.RawMessage(`{}`),
+		})
+	}
+
+	db := &DB{client: srv.Client()}
+	db.sendAll(context.Background(), pending)
+
+	for _, d := range pending {
+		assert.True(t, d.Attempted)
+		assert.NoError(t, d.Err)
+	}
+	assert.LessOrEqual(t, max, maxConcurrentWebhooks)
+}
@@ -4,11 +4,26 @@ import (
 	"github.com/target/goalert/validation/validate"
 )
 
+// MaxAckTimeoutMinutes is the maximum value of AckTimeoutMinutes (7 days).
+const MaxAckTimeoutMinutes = 7 * 24 * 60
+
+// MaxRepeatDelayMinutes is the maximum value of RepeatDelayMinutes, matching the maximum step delay.
+const MaxRepeatDelayMinutes = 9000
+
 type Policy struct {
-	ID             string `json:"id"`
-	Name           string `json:"name"`
-	Description    string `json:"description"`
-	Repeat         int    `json:"repeat"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Repeat      int    `json:"repeat"`
+
+	// AckTimeoutMinutes, if non-zero, is the number of minutes after which an acknowledged
+	// alert will revert to unacknowledged and resume escalation.
+	AckTimeoutMinutes int `json:"ack_timeout_minutes"`
+
+	// RepeatDelayMinutes is the number of minutes to wait, after the last step, before
+	// repeating the policy from the first step.
+	RepeatDelayMinutes int `json:"repeat_delay_minutes"`
+
 	isUserFavorite bool
 }
 
@@ -17,6 +32,8 @@ func (p Policy) Normalize() (*Policy, error) {
 		validate.IDName("Name", p.Name),
 		validate.Text("Description", p.Description, 1, 255),
 		validate.Range("Repeat", p.Repeat, 0, 5),
+		validate.Range("AckTimeoutMinutes", p.AckTimeoutMinutes, 0, MaxAckTimeoutMinutes),
+		validate.Range("RepeatDelayMinutes", p.RepeatDelayMinutes, 0, MaxRepeatDelayMinutes),
 	)
 	if err != nil {
 		return nil, err
@@ -23,9 +23,12 @@ func TestPolicy_Normalize(t *testing.T) {
 
 	valid := []Policy{
 		{Name: "SampleEscPolicy", Description: "Sample Escalation Policy", Repeat: 1},
+		{Name: "SampleEscPolicy", Description: "Sample Escalation Policy", Repeat: 1, AckTimeoutMinutes: 30, RepeatDelayMinutes: 15},
 	}
 	invalid := []Policy{
 		{Name: "SampleEscPolicy", Description: "Sample Escalation Policy", Repeat: -5},
+		{Name: "SampleEscPolicy", Description: "Sample Escalation Policy", Repeat: 1, AckTimeoutMinutes: -1},
+		{Name: "SampleEscPolicy", Description: "Sample Escalation Policy", Repeat: 1, RepeatDelayMinutes: 9001},
 	}
 	for _, p := range valid {
 		test(true, p)
@@ -45,6 +45,8 @@ var searchTemplate = template.Must(template.New("search").Parse(`
 		pol.name,
 		pol.description,
 		pol.repeat,
+		pol.ack_timeout_minutes,
+		pol.repeat_delay_minutes,
 		fav IS DISTINCT FROM NULL
 	FROM escalation_policies pol
 	{{if not .FavoritesOnly }}
@@ -157,7 +159,7 @@ func (db *DB) Search(ctx context.Context, opts *SearchOptions) ([]Policy, error)
 	var result []Policy
 	var p Policy
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.isUserFavorite)
+		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.AckTimeoutMinutes, &p.RepeatDelayMinutes, &p.isUserFavorite)
 		if err != nil {
 			return nil, err
 		}
@@ -14,6 +14,9 @@ type ActiveStep struct {
 	LoopCount       int
 	ForceEscalation bool
 	StepNumber      int
+
+	// FallbackNotified is set once the policy's fallback target has been notified.
+	FallbackNotified bool
 }
 
 type Step struct {
@@ -46,6 +46,13 @@ type PolicyStore interface {
 	FindManyPolicies(ctx context.Context, ids []string) ([]Policy, error)
 	DeleteManyPoliciesTx(ctx context.Context, tx *sql.Tx, ids []string) error
 
+	// FallbackTarget will return the target notified once all repeats of a policy are
+	// exhausted, or nil if none is set.
+	FallbackTarget(ctx context.Context, policyID string) (assignment.Target, error)
+
+	// SetFallbackTargetTx will set the fallback target of a policy. A nil target will clear it.
+	SetFallbackTargetTx(ctx context.Context, tx *sql.Tx, policyID string, tgt assignment.Target) error
+
 	Search(context.Context, *SearchOptions) ([]Policy, error)
 }
 
@@ -115,6 +122,8 @@ type DB struct {
 	createPolicy              *sql.Stmt
 	updatePolicy              *sql.Stmt
 	deletePolicy              *sql.Stmt
+	findFallbackTarget        *sql.Stmt
+	setFallbackTarget         *sql.Stmt
 
 	findOneStep          *sql.Stmt
 	findOneStepForUpdate *sql.Stmt
@@ -157,6 +166,8 @@ func NewDB(ctx context.Context, db *sql.DB, cfg Config) (*DB, error) {
 				e.name,
 				e.description,
 				e.repeat,
+				e.ack_timeout_minutes,
+				e.repeat_delay_minutes,
 				fav is distinct from null
 			FROM
 				escalation_policies e
@@ -164,13 +175,15 @@ func NewDB(ctx context.Context, db *sql.DB, cfg Config) (*DB, error) {
 				fav.tgt_escalation_policy_id = e.id AND fav.user_id = $2
 			WHERE e.id = $1
 		`),
-		findOnePolicyForUpdate: p.P(`SELECT id, name, description, repeat FROM escalation_policies WHERE id = $1 FOR UPDATE`),
+		findOnePolicyForUpdate: p.P(`SELECT id, name, description, repeat, ack_timeout_minutes, repeat_delay_minutes FROM escalation_policies WHERE id = $1 FOR UPDATE`),
 		findManyPolicies: p.P(`
             SELECT
                 e.id,
                 e.name,
                 e.description,
                 e.repeat,
+                e.ack_timeout_minutes,
+                e.repeat_delay_minutes,
                 fav is distinct from null
             FROM
                 escalation_policies e
@@ -178,13 +191,15 @@ func NewDB(ctx context.Context, db *sql.DB, cfg Config) (*DB, error) {
                 fav.tgt_escalation_policy_id = e.id AND fav.user_id = $2
             WHERE e.id = any($1)
         `),
-		findAllPolicies: p.P(`SELECT id, name, description, repeat FROM escalation_policies`),
+		findAllPolicies: p.P(`SELECT id, name, description, repeat, ack_timeout_minutes, repeat_delay_minutes FROM escalation_policies`),
 		findAllPoliciesBySchedule: p.P(`
 			SELECT DISTINCT
 				step.escalation_policy_id,
 				pol.name,
 				pol.description,
-				pol.repeat
+				pol.repeat,
+				pol.ack_timeout_minutes,
+				pol.repeat_delay_minutes
 			FROM
 				escalation_policy_actions as act
 			JOIN
@@ -194,9 +209,22 @@ func NewDB(ctx context.Context, db *sql.DB, cfg Config) (*DB, error) {
 			WHERE
 				act.schedule_id = $1
 		`),
-		createPolicy: p.P(`INSERT INTO escalation_policies (id, name, description, repeat) VALUES ($1, $2, $3, $4)`),
-		updatePolicy: p.P(`UPDATE escalation_policies SET name = $2, description = $3, repeat = $4 WHERE id = $1`),
+		createPolicy: p.P(`INSERT INTO escalation_policies (id, name, description, repeat, ack_timeout_minutes, repeat_delay_minutes) VALUES ($1, $2, $3, $4, $5, $6)`),
+		updatePolicy: p.P(`UPDATE escalation_policies SET name = $2, description = $3, repeat = $4, ack_timeout_minutes = $5, repeat_delay_minutes = $6 WHERE id = $1`),
 		deletePolicy: p.P(`DELETE FROM escalation_policies WHERE id = any($1)`),
+		findFallbackTarget: p.P(`
+			SELECT
+				pol.fallback_user_id,
+				pol.fallback_channel_id,
+				chan.type,
+				chan.value,
+				COALESCE(users.name, chan.name, '')
+			FROM escalation_policies pol
+			LEFT JOIN users ON users.id = pol.fallback_user_id
+			LEFT JOIN notification_channels chan ON chan.id = pol.fallback_channel_id
+			WHERE pol.id = $1
+		`),
+		setFallbackTarget: p.P(`UPDATE escalation_policies SET fallback_user_id = $2, fallback_channel_id = $3 WHERE id = $1`),
 
 		addStepTarget: p.P(`
 			INSERT INTO escalation_policy_actions (id, escalation_policy_step_id, user_id, schedule_id, rotation_id, channel_id)
@@ -289,7 +317,8 @@ func NewDB(ctx context.Context, db *sql.DB, cfg Config) (*DB, error) {
 				last_escalation,
 				loop_count,
 				force_escalation,
-				escalation_policy_step_number
+				escalation_policy_step_number,
+				fallback_notified
 			FROM escalation_policy_state
 			WHERE alert_id = $1 AND escalation_policy_id = $2
 		`),
@@ -373,7 +402,7 @@ func (db *DB) FindManyPolicies(ctx context.Context, ids []string) ([]Policy, err
 	var result []Policy
 	var p Policy
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.isUserFavorite)
+		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.AckTimeoutMinutes, &p.RepeatDelayMinutes, &p.isUserFavorite)
 		if err != nil {
 			return nil, err
 		}
@@ -539,7 +568,7 @@ func (db *DB) ActiveStep(ctx context.Context, alertID int, policyID string) (*Ac
 	row := db.activeStep.QueryRowContext(ctx, alertID, policyID)
 	var step ActiveStep
 	var stepID sql.NullString
-	err = row.Scan(&stepID, &step.LastEscalation, &step.LoopCount, &step.ForceEscalation, &step.StepNumber)
+	err = row.Scan(&stepID, &step.LastEscalation, &step.LoopCount, &step.ForceEscalation, &step.StepNumber, &step.FallbackNotified)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -571,7 +600,7 @@ func (db *DB) CreatePolicyTx(ctx context.Context, tx *sql.Tx, p *Policy) (*Polic
 
 	n.ID = uuid.NewV4().String()
 
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Repeat)
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Repeat, n.AckTimeoutMinutes, n.RepeatDelayMinutes)
 	if err != nil {
 		return nil, err
 	}
@@ -602,7 +631,7 @@ func (db *DB) UpdatePolicyTx(ctx context.Context, tx *sql.Tx, p *Policy) error {
 		stmt = tx.StmtContext(ctx, stmt)
 	}
 
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Repeat)
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.Repeat, n.AckTimeoutMinutes, n.RepeatDelayMinutes)
 	if err != nil {
 		return err
 	}
@@ -612,6 +641,97 @@ func (db *DB) UpdatePolicyTx(ctx context.Context, tx *sql.Tx, p *Policy) error {
 	return nil
 }
 
+func (db *DB) FallbackTarget(ctx context.Context, policyID string) (assignment.Target, error) {
+	err := validate.UUID("EscalationPolicyID", policyID)
+	if err != nil {
+		return nil, err
+	}
+	err = permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	var usr, ch, chValue sql.NullString
+	var chType *notificationchannel.Type
+	var tgt assignment.RawTarget
+	err = db.findFallbackTarget.QueryRowContext(ctx, policyID).Scan(&usr, &ch, &chType, &chValue, &tgt.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case usr.Valid:
+		tgt.ID = usr.String
+		tgt.Type = assignment.TargetTypeUser
+	case ch.Valid && chType != nil && *chType == notificationchannel.TypeSlack:
+		tgt.ID = chValue.String
+		tgt.Type = assignment.TargetTypeSlackChannel
+	case ch.Valid:
+		tgt.ID = ch.String
+		tgt.Type = assignment.TargetTypeNotificationChannel
+	default:
+		return nil, nil
+	}
+
+	return tgt, nil
+}
+
+func (db *DB) SetFallbackTargetTx(ctx context.Context, tx *sql.Tx, policyID string, tgt assignment.Target) error {
+	err := validate.UUID("EscalationPolicyID", policyID)
+	if err != nil {
+		return err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	if tgt != nil && tgt.TargetType() == assignment.TargetTypeSlackChannel {
+		tgt, err = db.newSlackChannel(ctx, tx, tgt.TargetID())
+		if err != nil {
+			return err
+		}
+	}
+
+	var usr, ch sql.NullString
+	if tgt != nil {
+		err = validate.Many(
+			validate.UUID("TargetID", tgt.TargetID()),
+			validate.OneOf("TargetType", tgt.TargetType(),
+				assignment.TargetTypeUser,
+				assignment.TargetTypeNotificationChannel,
+			),
+		)
+		if err != nil {
+			return err
+		}
+		switch tgt.TargetType() {
+		case assignment.TargetTypeUser:
+			usr.Valid = true
+			usr.String = tgt.TargetID()
+		case assignment.TargetTypeNotificationChannel:
+			ch.Valid = true
+			ch.String = tgt.TargetID()
+		}
+	}
+
+	stmt := db.setFallbackTarget
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx, policyID, usr, ch)
+	if err != nil {
+		return err
+	}
+
+	db.logChange(ctx, tx, policyID)
+
+	return nil
+}
+
 func (db *DB) DeletePolicy(ctx context.Context, id string) error {
 	return db.DeletePolicyTx(ctx, nil, id)
 }
@@ -660,7 +780,7 @@ func (db *DB) FindOnePolicyTx(ctx context.Context, tx *sql.Tx, id string) (*Poli
 
 	row := stmt.QueryRowContext(ctx, id)
 	var p Policy
-	err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat)
+	err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.AckTimeoutMinutes, &p.RepeatDelayMinutes)
 	return &p, err
 }
 
@@ -682,7 +802,7 @@ func (db *DB) FindOnePolicyForUpdateTx(ctx context.Context, tx *sql.Tx, id strin
 
 	row := stmt.QueryRowContext(ctx, id)
 	var p Policy
-	err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat)
+	err = row.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.AckTimeoutMinutes, &p.RepeatDelayMinutes)
 	return &p, err
 }
 
@@ -701,7 +821,7 @@ func (db *DB) FindAllPolicies(ctx context.Context) ([]Policy, error) {
 	var p Policy
 	policies := []Policy{}
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat)
+		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.AckTimeoutMinutes, &p.RepeatDelayMinutes)
 		if err != nil {
 			return nil, err
 		}
@@ -730,7 +850,7 @@ func (db *DB) FindAllPoliciesBySchedule(ctx context.Context, scheduleID string)
 	var p Policy
 	var policies []Policy
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat)
+		err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.Repeat, &p.AckTimeoutMinutes, &p.RepeatDelayMinutes)
 		if err != nil {
 			return nil, err
 		}
@@ -2,6 +2,8 @@ package genericapi
 
 import (
 	"database/sql"
+	"encoding/json"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -9,9 +11,11 @@ import (
 	"github.com/pkg/errors"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/auth"
+	"github.com/target/goalert/heartbeat"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -44,7 +48,40 @@ func (h *Handler) ServeUserAvatar(w http.ResponseWriter, req *http.Request) {
 	http.Redirect(w, req, u.ResolveAvatarURL(fullSize), http.StatusFound)
 }
 
+// heartbeatReport parses the optional status and message of a heartbeat check-in
+// from either a JSON body or form values.
+func heartbeatReport(r *http.Request) (heartbeat.Report, error) {
+	var body struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return heartbeat.Report{}, validation.NewGenericError("invalid JSON body")
+		}
+	} else {
+		body.Status = r.FormValue("status")
+		body.Message = r.FormValue("message")
+	}
+
+	var rep heartbeat.Report
+	switch strings.ToLower(body.Status) {
+	case "", "ok":
+	case "fail":
+		rep.Failed = true
+	default:
+		return rep, validation.NewFieldError("status", "must be 'ok' or 'fail'")
+	}
+	rep.Message = validate.SanitizeText(body.Message, heartbeat.MaxMessageLength)
+
+	return rep, nil
+}
+
 // ServeHeartbeatCheck serves the heartbeat check-in endpoint.
+//
+// A check-in may include a status of `ok` (the default) or `fail`, and a message. A
+// `fail` status opens an alert immediately, rather than waiting for the monitor to expire.
 func (h *Handler) ServeHeartbeatCheck(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	if r.Method != "POST" {
@@ -55,8 +92,13 @@ func (h *Handler) ServeHeartbeatCheck(w http.ResponseWriter, r *http.Request) {
 	parts := strings.Split(r.URL.Path, "/")
 	monitorID := parts[len(parts)-1]
 
-	err := retry.DoTemporaryError(func(_ int) error {
-		return h.c.HeartbeatStore.Heartbeat(ctx, monitorID)
+	rep, err := heartbeatReport(r)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	err = retry.DoTemporaryError(func(_ int) error {
+		return h.c.HeartbeatStore.Heartbeat(ctx, monitorID, rep)
 	},
 		retry.Log(ctx),
 		retry.Limit(12),
@@ -116,3 +158,66 @@ func (h *Handler) ServeCreateAlert(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(204)
 }
+
+// ServeCreateAlertJSON allows creating or closing an alert from an arbitrary JSON payload,
+// using the JSONMapping configured on the integration key to extract alert fields.
+func (h *Handler) ServeCreateAlertJSON(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := permission.LimitCheckAny(ctx, permission.Service)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	serviceID := permission.ServiceID(ctx)
+
+	src := permission.Source(ctx)
+	if src == nil || src.Type != permission.SourceTypeIntegrationKey {
+		errutil.HTTPError(ctx, w, permission.NewAccessDenied("integration key required"))
+		return
+	}
+
+	mapping, err := h.c.IntegrationKeyStore.FindJSONMapping(ctx, src.ID)
+	if errutil.HTTPError(ctx, w, errors.Wrap(err, "lookup json mapping")) {
+		return
+	}
+
+	var data interface{}
+	err = json.NewDecoder(r.Body).Decode(&data)
+	if err != nil {
+		errutil.HTTPError(ctx, w, validation.NewFieldError("Body", "invalid JSON: "+err.Error()))
+		return
+	}
+
+	res, err := mapping.Apply(data)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	status := alert.StatusTriggered
+	if res.Close {
+		status = alert.StatusClosed
+	}
+
+	a := &alert.Alert{
+		Summary:   validate.SanitizeText(res.Summary, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(res.Details, alert.MaxDetailsLength),
+		Source:    alert.SourceGeneric,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup(res.Dedup),
+		Status:    status,
+	}
+
+	err = retry.DoTemporaryError(func(int) error {
+		_, err = h.c.AlertStore.CreateOrUpdate(ctx, a)
+		return err
+	},
+		retry.Log(ctx),
+		retry.Limit(10),
+		retry.FibBackoff(time.Second),
+	)
+	if errutil.HTTPError(ctx, w, errors.Wrap(err, "create alert")) {
+		return
+	}
+
+	w.WriteHeader(204)
+}
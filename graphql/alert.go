@@ -62,6 +62,8 @@ var alertLogEventType = g.NewEnum(g.EnumConfig{
 		"no_notification_sent": &g.EnumValueConfig{Value: alertlog.TypeNoNotificationSent},
 		"policy_updated":       &g.EnumValueConfig{Value: alertlog.TypePolicyUpdated},
 		"duplicate_suppressed": &g.EnumValueConfig{Value: alertlog.TypeDuplicateSupressed},
+		"flapping":             &g.EnumValueConfig{Value: alertlog.TypeFlapping},
+		"ack_timeout":          &g.EnumValueConfig{Value: alertlog.TypeAckTimeout},
 	},
 })
 
@@ -15,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/target/goalert/alert"
 	alertlog "github.com/target/goalert/alert/log"
+	"github.com/target/goalert/audit"
 	"github.com/target/goalert/auth"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/calendarsubscription"
@@ -22,6 +23,7 @@ import (
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/holiday"
 	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
@@ -31,12 +33,19 @@ import (
 	"github.com/target/goalert/notification/twilio"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/outgoingwebhook"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/schedule/shiftexport"
+	"github.com/target/goalert/schedule/shiftimport"
+	"github.com/target/goalert/schedule/shiftreport"
 	"github.com/target/goalert/service"
+	"github.com/target/goalert/shiftrequest"
+	"github.com/target/goalert/syntheticcheck"
+	"github.com/target/goalert/timeoff"
 	"github.com/target/goalert/timezone"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
@@ -44,7 +53,9 @@ import (
 	"github.com/target/goalert/user/notificationrule"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/pubsub"
 	"github.com/target/goalert/validation"
+	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 	"go.opencensus.io/trace"
 )
@@ -74,6 +85,18 @@ type App struct {
 	SlackStore     *slack.ChannelSender
 	HeartbeatStore heartbeat.Store
 	NoticeStore    notice.Store
+	AuditStore     *audit.Store
+	WebhookStore   *outgoingwebhook.Store
+	SyntheticStore *syntheticcheck.Store
+	ShiftReqStore  *shiftrequest.Store
+	TimeOffStore   *timeoff.Store
+	ShiftImport    *shiftimport.Store
+	ShiftExport    *shiftexport.Store
+	ShiftReport    *shiftreport.Store
+	HolidayStore   *holiday.Store
+
+	// Events delivers database notifications to GraphQL subscriptions.
+	Events *pubsub.Broker
 
 	AuthHandler *auth.Handler
 
@@ -168,6 +191,17 @@ func (a *App) Handler() http.Handler {
 		return ok && enabled
 	}})
 
+	h.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		if graphql.GetOperationContext(ctx).Operation.Operation != ast.Subscription {
+			return next(ctx)
+		}
+
+		// Subscriptions share the connection's request context, so each gets its
+		// own auth check count (reset for every event, see Subscription.listen).
+		_, max := permission.AuthCheckCount(ctx)
+		return next(permission.NewAuthCheckCountContext(ctx, max))
+	})
+
 	h.AroundFields(func(ctx context.Context, next graphql.Resolver) (res interface{}, err error) {
 		defer func() {
 			err := recover()
@@ -184,7 +218,11 @@ func (a *App) Handler() http.Handler {
 			trace.StringAttribute("graphql.field.name", fieldCtx.Field.Name),
 		)
 		start := time.Now()
-		res, err = next(ctx)
+		if fieldCtx.Object == "Mutation" {
+			res, err = a.recordMutation(ctx, fieldCtx, next)
+		} else {
+			res, err = next(ctx)
+		}
 		errVal := "0"
 		if err != nil {
 			errVal = "1"
@@ -264,8 +302,11 @@ func (a *App) Handler() http.Handler {
 			return
 		}
 
-		ctx = a.registerLoaders(ctx)
-		defer a.closeLoaders(ctx)
+		// WebSocket connections are long-lived, so cached loader results would go stale.
+		if !IsWebSocketUpgrade(req) {
+			ctx = a.registerLoaders(ctx)
+			defer a.closeLoaders(ctx)
+		}
 
 		if req.URL.Query().Get("trace") == "1" && permission.Admin(ctx) {
 			ctx = context.WithValue(ctx, hasTraceKey(1), true)
@@ -0,0 +1,340 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/audit"
+	"github.com/target/goalert/calendarsubscription"
+	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/outgoingwebhook"
+	"github.com/target/goalert/override"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/syntheticcheck"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/util/log"
+)
+
+type AuditLogEntry App
+
+func (a *App) AuditLogEntry() graphql2.AuditLogEntryResolver { return (*AuditLogEntry)(a) }
+
+func (a *AuditLogEntry) ActorUser(ctx context.Context, e *audit.Entry) (*user.User, error) {
+	if e.ActorUserID == "" {
+		return nil, nil
+	}
+	return (*App)(a).FindOneUser(ctx, e.ActorUserID)
+}
+
+func (a *AuditLogEntry) Target(ctx context.Context, e *audit.Entry) (*assignment.RawTarget, error) {
+	if e.Target == nil {
+		return nil, nil
+	}
+	tgt := assignment.NewRawTarget(e.Target)
+	return &tgt, nil
+}
+
+func rawJSONString(data json.RawMessage) *string {
+	if len(data) == 0 {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
+
+func (a *AuditLogEntry) Before(ctx context.Context, e *audit.Entry) (*string, error) {
+	return rawJSONString(e.Before), nil
+}
+
+func (a *AuditLogEntry) After(ctx context.Context, e *audit.Entry) (*string, error) {
+	return rawJSONString(e.After), nil
+}
+
+func (a *AuditLogEntry) Changes(ctx context.Context, e *audit.Entry) ([]audit.Change, error) {
+	return e.Changes(), nil
+}
+
+func (q *Query) AuditLogs(ctx context.Context, opts *graphql2.AuditLogSearchOptions) (conn *graphql2.AuditLogConnection, err error) {
+	if opts == nil {
+		opts = &graphql2.AuditLogSearchOptions{}
+	}
+
+	var searchOpts audit.SearchOptions
+	if opts.Start != nil {
+		searchOpts.Start = *opts.Start
+	}
+	if opts.End != nil {
+		searchOpts.End = *opts.End
+	}
+	searchOpts.FilterActorUserIDs = opts.ActorUserIDs
+	searchOpts.FilterActions = opts.Actions
+	searchOpts.FilterTarget = opts.Target
+	if opts.After != nil && *opts.After != "" {
+		err = search.ParseCursor(*opts.After, &searchOpts)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse cursor")
+		}
+	}
+	if opts.First != nil {
+		searchOpts.Limit = *opts.First
+	}
+	if searchOpts.Limit == 0 {
+		searchOpts.Limit = 15
+	}
+
+	searchOpts.Limit++
+	entries, err := q.AuditStore.Search(ctx, &searchOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	conn = new(graphql2.AuditLogConnection)
+	conn.PageInfo = &graphql2.PageInfo{}
+	if len(entries) == searchOpts.Limit {
+		entries = entries[:len(entries)-1]
+		conn.PageInfo.HasNextPage = true
+	}
+	if len(entries) > 0 {
+		searchOpts.After.ID = entries[len(entries)-1].ID
+		cur, err := search.Cursor(searchOpts)
+		if err != nil {
+			return conn, err
+		}
+		conn.PageInfo.EndCursor = &cur
+	}
+	conn.Nodes = entries
+	return conn, nil
+}
+
+// auditSkipMutations are not recorded by recordMutation, either because they make no
+// persistent changes or because they record their own audit entries.
+var auditSkipMutations = map[string]bool{
+	"debugCarrierInfo":              true,
+	"debugSendSMS":                  true,
+	"testContactMethod":             true,
+	"sendContactMethodVerification": true,
+	"setConfig":                     true,
+	"setSystemLimits":               true,
+}
+
+// auditStepTarget returns the escalation policy for the given step ID.
+func (a *App) auditStepTarget(ctx context.Context, stepID string) ([]assignment.Target, error) {
+	step, err := a.PolicyStore.FindOneStep(ctx, stepID)
+	if err != nil {
+		return nil, err
+	}
+	return []assignment.Target{assignment.EscalationPolicyTarget(step.PolicyID)}, nil
+}
+
+// auditArgTargets returns the list of targets to be changed by a mutation, based on its arguments.
+func (a *App) auditArgTargets(ctx context.Context, name string, args map[string]interface{}) ([]assignment.Target, error) {
+	switch input := args["input"].(type) {
+	case graphql2.SetTemporaryScheduleInput:
+		return []assignment.Target{assignment.ScheduleTarget(input.ScheduleID)}, nil
+	case graphql2.ClearTemporarySchedulesInput:
+		return []assignment.Target{assignment.ScheduleTarget(input.ScheduleID)}, nil
+	case user.AuthSubject:
+		return []assignment.Target{assignment.UserTarget(input.UserID)}, nil
+	case graphql2.UpdateUserInput:
+		return []assignment.Target{assignment.UserTarget(input.ID)}, nil
+	case graphql2.UpdateRotationInput:
+		return []assignment.Target{assignment.RotationTarget(input.ID)}, nil
+	case graphql2.UpdateServiceInput:
+		return []assignment.Target{assignment.ServiceTarget(input.ID)}, nil
+	case graphql2.UpdateEscalationPolicyInput:
+		return []assignment.Target{assignment.EscalationPolicyTarget(input.ID)}, nil
+	case graphql2.UpdateEscalationPolicyStepInput:
+		return a.auditStepTarget(ctx, input.ID)
+	case graphql2.CreateEscalationPolicyStepInput:
+		if input.EscalationPolicyID != nil {
+			return []assignment.Target{assignment.EscalationPolicyTarget(*input.EscalationPolicyID)}, nil
+		}
+	case []assignment.RawTarget:
+		if name != "deleteAll" {
+			break
+		}
+		tgts := make([]assignment.Target, len(input))
+		for i, tgt := range input {
+			tgts[i] = tgt
+		}
+		return tgts, nil
+	case graphql2.SetLabelInput:
+		if input.Target != nil {
+			return []assignment.Target{*input.Target}, nil
+		}
+	case graphql2.UpdateUserCalendarSubscriptionInput:
+		return []assignment.Target{assignment.CalendarSubscriptionTarget(input.ID)}, nil
+	case graphql2.ScheduleTargetInput:
+		if input.ScheduleID != nil {
+			return []assignment.Target{assignment.ScheduleTarget(*input.ScheduleID)}, nil
+		}
+	case graphql2.UpdateUserContactMethodInput:
+		return []assignment.Target{assignment.ContactMethodTarget(input.ID)}, nil
+	case graphql2.VerifyContactMethodInput:
+		return []assignment.Target{assignment.ContactMethodTarget(input.ContactMethodID)}, nil
+	case graphql2.UpdateScheduleInput:
+		return []assignment.Target{assignment.ScheduleTarget(input.ID)}, nil
+	case graphql2.UpdateUserOverrideInput:
+		return []assignment.Target{assignment.UserOverrideTarget(input.ID)}, nil
+	case graphql2.UpdateHeartbeatMonitorInput:
+		return []assignment.Target{assignment.HeartbeatMonitorTarget(input.ID)}, nil
+	case graphql2.UpdateOutgoingWebhookInput:
+		return []assignment.Target{assignment.OutgoingWebhookTarget(input.ID)}, nil
+	case graphql2.UpdateIntegrationKeyInput:
+		return []assignment.Target{assignment.IntegrationKeyTarget(input.ID)}, nil
+	case graphql2.UpdateSyntheticCheckInput:
+		return []assignment.Target{assignment.SyntheticCheckTarget(input.ID)}, nil
+	}
+
+	if name == "endAllAuthSessionsByCurrentUser" {
+		return []assignment.Target{assignment.UserTarget(permission.UserID(ctx))}, nil
+	}
+
+	return nil, nil
+}
+
+// auditResultTarget returns the target created by a mutation, if any.
+func auditResultTarget(res interface{}) assignment.Target {
+	switch r := res.(type) {
+	case *service.Service:
+		if r != nil {
+			return assignment.ServiceTarget(r.ID)
+		}
+	case *escalation.Policy:
+		if r != nil {
+			return assignment.EscalationPolicyTarget(r.ID)
+		}
+	case *escalation.Step:
+		if r != nil {
+			return assignment.EscalationPolicyTarget(r.PolicyID)
+		}
+	case *rotation.Rotation:
+		if r != nil {
+			return assignment.RotationTarget(r.ID)
+		}
+	case *integrationkey.IntegrationKey:
+		if r != nil {
+			return assignment.IntegrationKeyTarget(r.ID)
+		}
+	case *heartbeat.Monitor:
+		if r != nil {
+			return assignment.HeartbeatMonitorTarget(r.ID)
+		}
+	case *schedule.Schedule:
+		if r != nil {
+			return assignment.ScheduleTarget(r.ID)
+		}
+	case *user.User:
+		if r != nil {
+			return assignment.UserTarget(r.ID)
+		}
+	case *calendarsubscription.CalendarSubscription:
+		if r != nil {
+			return assignment.CalendarSubscriptionTarget(r.ID)
+		}
+	case *override.UserOverride:
+		if r != nil {
+			return assignment.UserOverrideTarget(r.ID)
+		}
+	case *contactmethod.ContactMethod:
+		if r != nil {
+			return assignment.ContactMethodTarget(r.ID)
+		}
+	case *notificationrule.NotificationRule:
+		if r != nil {
+			return assignment.NotificationRuleTarget(r.ID)
+		}
+	case *outgoingwebhook.Webhook:
+		if r != nil {
+			return assignment.OutgoingWebhookTarget(r.ID)
+		}
+	case *syntheticcheck.Check:
+		if r != nil {
+			return assignment.SyntheticCheckTarget(r.ID)
+		}
+	}
+
+	return nil
+}
+
+// recordMutation will execute the mutation resolver, recording an audit log entry for each affected
+// target with snapshots taken before and after the change.
+//
+// Entries are written in the same transaction as the mutation (for resolvers using withContextTx),
+// and the mutation fails if they cannot be recorded. If the affected targets cannot be determined
+// an entry is still recorded, without snapshots.
+func (a *App) recordMutation(ctx context.Context, fieldCtx *graphql.FieldContext, next graphql.Resolver) (interface{}, error) {
+	name := fieldCtx.Field.Name
+	if a.AuditStore == nil || auditSkipMutations[name] {
+		return next(ctx)
+	}
+
+	var res interface{}
+	err := withContextTx(ctx, a.DB, func(ctx context.Context, tx *sql.Tx) error {
+		tgts, err := a.auditArgTargets(ctx, name, fieldCtx.Args)
+		if err != nil {
+			// let the mutation surface any errors (e.g. invalid ID), the entry is still recorded
+			log.Debug(ctx, errors.Wrapf(err, "lookup audit targets for %s", name))
+			tgts = nil
+		}
+		before := make([]json.RawMessage, len(tgts))
+		for i, tgt := range tgts {
+			before[i], err = a.AuditStore.SnapshotTx(ctx, tx, tgt)
+			if err != nil {
+				return errors.Wrapf(err, "record audit log entry for %s", name)
+			}
+		}
+
+		res, err = next(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(tgts) == 0 {
+			if tgt := auditResultTarget(res); tgt != nil {
+				tgts = append(tgts, tgt)
+				before = append(before, nil)
+			}
+		}
+		if len(tgts) == 0 {
+			return errors.Wrapf(a.AuditStore.LogTx(ctx, tx, &audit.Entry{Action: name}), "record audit log entry for %s", name)
+		}
+
+		for i, tgt := range tgts {
+			after, err := a.AuditStore.SnapshotTx(ctx, tx, tgt)
+			if err != nil {
+				return errors.Wrapf(err, "record audit log entry for %s", name)
+			}
+
+			err = a.AuditStore.LogTx(ctx, tx, &audit.Entry{
+				Action: name,
+				Target: tgt,
+				Before: before[i],
+				After:  after,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "record audit log entry for %s", name)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
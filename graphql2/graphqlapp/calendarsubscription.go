@@ -10,6 +10,7 @@ import (
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/service"
 )
 
 type UserCalendarSubscription App
@@ -21,9 +22,36 @@ func (a *App) UserCalendarSubscription() graphql2.UserCalendarSubscriptionResolv
 func (a *UserCalendarSubscription) ReminderMinutes(ctx context.Context, obj *calendarsubscription.CalendarSubscription) ([]int, error) {
 	return obj.Config.ReminderMinutes, nil
 }
+func (a *UserCalendarSubscription) LookaheadDays(ctx context.Context, obj *calendarsubscription.CalendarSubscription) (int, error) {
+	return obj.Lookahead(), nil
+}
+func (a *UserCalendarSubscription) IncludeDetails(ctx context.Context, obj *calendarsubscription.CalendarSubscription) (bool, error) {
+	return obj.Config.IncludeDetails, nil
+}
+func (a *UserCalendarSubscription) ScheduleID(ctx context.Context, obj *calendarsubscription.CalendarSubscription) (*string, error) {
+	if obj.ScheduleID == "" {
+		return nil, nil
+	}
+	return &obj.ScheduleID, nil
+}
 func (a *UserCalendarSubscription) Schedule(ctx context.Context, obj *calendarsubscription.CalendarSubscription) (*schedule.Schedule, error) {
+	if obj.ScheduleID == "" {
+		return nil, nil
+	}
 	return a.ScheduleStore.FindOne(ctx, obj.ScheduleID)
 }
+func (a *UserCalendarSubscription) ServiceID(ctx context.Context, obj *calendarsubscription.CalendarSubscription) (*string, error) {
+	if obj.ServiceID == "" {
+		return nil, nil
+	}
+	return &obj.ServiceID, nil
+}
+func (a *UserCalendarSubscription) Service(ctx context.Context, obj *calendarsubscription.CalendarSubscription) (*service.Service, error) {
+	if obj.ServiceID == "" {
+		return nil, nil
+	}
+	return (*App)(a).FindOneService(ctx, obj.ServiceID)
+}
 func (a *UserCalendarSubscription) URL(ctx context.Context, obj *calendarsubscription.CalendarSubscription) (*string, error) {
 	tok := obj.Token()
 	if tok == "" {
@@ -45,13 +73,27 @@ func (q *Query) UserCalendarSubscription(ctx context.Context, id string) (*calen
 // todo: return UserCalendarSubscription with generated url once endpoint has been created
 func (m *Mutation) CreateUserCalendarSubscription(ctx context.Context, input graphql2.CreateUserCalendarSubscriptionInput) (cs *calendarsubscription.CalendarSubscription, err error) {
 	cs = &calendarsubscription.CalendarSubscription{
-		Name:       input.Name,
-		ScheduleID: input.ScheduleID,
-		UserID:     permission.UserID(ctx),
+		Name:   input.Name,
+		UserID: permission.UserID(ctx),
+	}
+	if input.Scope != nil {
+		cs.Scope = *input.Scope
+	}
+	if input.ScheduleID != nil {
+		cs.ScheduleID = *input.ScheduleID
+	}
+	if input.ServiceID != nil {
+		cs.ServiceID = *input.ServiceID
 	}
 	if input.Disabled != nil {
 		cs.Disabled = *input.Disabled
 	}
+	if input.LookaheadDays != nil {
+		cs.Config.LookaheadDays = *input.LookaheadDays
+	}
+	if input.IncludeDetails != nil {
+		cs.Config.IncludeDetails = *input.IncludeDetails
+	}
 	cs.Config.ReminderMinutes = input.ReminderMinutes
 	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
@@ -77,6 +119,12 @@ func (m *Mutation) UpdateUserCalendarSubscription(ctx context.Context, input gra
 		if input.ReminderMinutes != nil {
 			cs.Config.ReminderMinutes = input.ReminderMinutes
 		}
+		if input.LookaheadDays != nil {
+			cs.Config.LookaheadDays = *input.LookaheadDays
+		}
+		if input.IncludeDetails != nil {
+			cs.Config.IncludeDetails = *input.IncludeDetails
+		}
 		return m.CalSubStore.UpdateTx(ctx, tx, cs)
 	})
 	return err == nil, err
@@ -2,10 +2,14 @@ package graphqlapp
 
 import (
 	"context"
+	"database/sql"
 
+	"github.com/pkg/errors"
+	"github.com/target/goalert/audit"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
 )
 
 func (q *Query) Config(ctx context.Context, all *bool) ([]graphql2.ConfigValue, error) {
@@ -37,8 +41,33 @@ func (q *Query) ConfigHints(ctx context.Context) ([]graphql2.ConfigHint, error)
 }
 
 func (m *Mutation) SetConfig(ctx context.Context, input []graphql2.ConfigValueInput) (bool, error) {
-	err := m.ConfigStore.UpdateConfig(ctx, func(cfg config.Config) (config.Config, error) {
-		return graphql2.ApplyConfigValues(cfg, input)
+	var id int
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		var before, after config.Config
+		var err error
+		id, err = m.ConfigStore.UpdateConfigTx(ctx, tx, func(cfg config.Config) (config.Config, error) {
+			before = cfg
+			var err error
+			after, err = graphql2.ApplyConfigValues(cfg, input)
+			return after, err
+		})
+		if err != nil {
+			return err
+		}
+
+		e := audit.Entry{Action: "setConfig"}
+		e.Before, e.After, err = audit.ConfigSnapshots(before, after)
+		if err != nil {
+			return errors.Wrap(err, "record audit log entry")
+		}
+
+		return m.AuditStore.LogTx(ctx, tx, &e)
 	})
+	if err != nil {
+		return false, err
+	}
+	log.Logf(ctx, "Set configuration to version %d (schema version %d)", id, config.SchemaVersion)
+
+	err = m.ConfigStore.Reload(ctx)
 	return err == nil, err
 }
@@ -123,6 +123,12 @@ func (m *Mutation) CreateEscalationPolicy(ctx context.Context, input graphql2.Cr
 		if input.Repeat != nil {
 			p.Repeat = *input.Repeat
 		}
+		if input.AckTimeoutMinutes != nil {
+			p.AckTimeoutMinutes = *input.AckTimeoutMinutes
+		}
+		if input.RepeatDelayMinutes != nil {
+			p.RepeatDelayMinutes = *input.RepeatDelayMinutes
+		}
 		if input.Description != nil {
 			p.Description = *input.Description
 		}
@@ -131,6 +137,12 @@ func (m *Mutation) CreateEscalationPolicy(ctx context.Context, input graphql2.Cr
 		if err != nil {
 			return err
 		}
+		if input.FallbackTarget != nil {
+			err = m.PolicyStore.SetFallbackTargetTx(ctx, tx, pol.ID, input.FallbackTarget)
+			if err != nil {
+				return validation.AddPrefix("fallbackTarget.", err)
+			}
+		}
 		if input.Favorite != nil && *input.Favorite {
 			err = m.FavoriteStore.SetTx(ctx, tx, permission.UserID(ctx), assignment.EscalationPolicyTarget(pol.ID))
 			if err != nil {
@@ -170,6 +182,14 @@ func (m *Mutation) UpdateEscalationPolicy(ctx context.Context, input graphql2.Up
 			ep.Repeat = *input.Repeat
 		}
 
+		if input.AckTimeoutMinutes != nil {
+			ep.AckTimeoutMinutes = *input.AckTimeoutMinutes
+		}
+
+		if input.RepeatDelayMinutes != nil {
+			ep.RepeatDelayMinutes = *input.RepeatDelayMinutes
+		}
+
 		err = m.PolicyStore.UpdatePolicyTx(ctx, tx, ep)
 		if err != nil {
 			return err
@@ -217,6 +237,25 @@ func (m *Mutation) UpdateEscalationPolicy(ctx context.Context, input graphql2.Up
 	return true, err
 }
 
+func (m *Mutation) SetEscalationPolicyFallbackTarget(ctx context.Context, input graphql2.SetEscalationPolicyFallbackTargetInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		// lock the policy to ensure it exists
+		_, err := m.PolicyStore.FindOnePolicyForUpdateTx(ctx, tx, input.EscalationPolicyID)
+		if err != nil {
+			return err
+		}
+
+		var tgt assignment.Target
+		if input.Target != nil {
+			tgt = input.Target
+		}
+
+		return m.PolicyStore.SetFallbackTargetTx(ctx, tx, input.EscalationPolicyID, tgt)
+	})
+
+	return true, err
+}
+
 func (m *Mutation) UpdateEscalationPolicyStep(ctx context.Context, input graphql2.UpdateEscalationPolicyStepInput) (bool, error) {
 	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		step, err := m.PolicyStore.FindOneStepForUpdateTx(ctx, tx, input.ID) // get delay
@@ -334,6 +373,19 @@ func (ep *EscalationPolicy) Steps(ctx context.Context, raw *escalation.Policy) (
 	return ep.PolicyStore.FindAllSteps(ctx, raw.ID)
 }
 
+func (ep *EscalationPolicy) FallbackTarget(ctx context.Context, raw *escalation.Policy) (*assignment.RawTarget, error) {
+	tgt, err := ep.PolicyStore.FallbackTarget(ctx, raw.ID)
+	if err != nil || tgt == nil {
+		return nil, err
+	}
+
+	res, ok := tgt.(assignment.RawTarget)
+	if !ok {
+		res = assignment.NewRawTarget(tgt)
+	}
+	return &res, nil
+}
+
 func (ep *EscalationPolicy) Notices(ctx context.Context, raw *escalation.Policy) ([]notice.Notice, error) {
 	return ep.NoticeStore.FindAllPolicyNotices(ctx, raw.ID)
 }
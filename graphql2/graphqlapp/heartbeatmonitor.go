@@ -9,6 +9,8 @@ import (
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
 )
 
 type HeartbeatMonitor App
@@ -18,6 +20,15 @@ func (a *App) HeartbeatMonitor() graphql2.HeartbeatMonitorResolver { return (*He
 func (a *HeartbeatMonitor) TimeoutMinutes(ctx context.Context, hb *heartbeat.Monitor) (int, error) {
 	return int(hb.Timeout / time.Minute), nil
 }
+func (a *HeartbeatMonitor) TimeZone(ctx context.Context, hb *heartbeat.Monitor) (string, error) {
+	if hb.TimeZone == nil {
+		return "", nil
+	}
+	return hb.TimeZone.String(), nil
+}
+func (a *HeartbeatMonitor) GracePeriodMinutes(ctx context.Context, hb *heartbeat.Monitor) (int, error) {
+	return int(hb.GracePeriod / time.Minute), nil
+}
 func (a *HeartbeatMonitor) Href(ctx context.Context, hb *heartbeat.Monitor) (string, error) {
 	cfg := config.FromContext(ctx)
 	return cfg.CallbackURL("/api/v2/heartbeat/" + url.PathEscape(hb.ID)), nil
@@ -31,7 +42,25 @@ func (m *Mutation) CreateHeartbeatMonitor(ctx context.Context, input graphql2.Cr
 	hb := &heartbeat.Monitor{
 		ServiceID: input.ServiceID,
 		Name:      input.Name,
-		Timeout:   time.Duration(input.TimeoutMinutes) * time.Minute,
+	}
+	if input.TimeoutMinutes != nil {
+		hb.Timeout = time.Duration(*input.TimeoutMinutes) * time.Minute
+	}
+	if input.CronSchedule != nil {
+		hb.CronSchedule = *input.CronSchedule
+	}
+	if input.TimeZone != nil {
+		loc, err := util.LoadLocation(*input.TimeZone)
+		if err != nil {
+			return nil, validation.NewFieldError("TimeZone", err.Error())
+		}
+		hb.TimeZone = loc
+	}
+	if input.GracePeriodMinutes != nil {
+		hb.GracePeriod = time.Duration(*input.GracePeriodMinutes) * time.Minute
+	}
+	if input.AlertAfterMisses != nil {
+		hb.AlertAfterMisses = *input.AlertAfterMisses
 	}
 	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
@@ -53,6 +82,21 @@ func (m *Mutation) UpdateHeartbeatMonitor(ctx context.Context, input graphql2.Up
 		if input.TimeoutMinutes != nil {
 			hb.Timeout = time.Duration(*input.TimeoutMinutes) * time.Minute
 		}
+		if input.CronSchedule != nil {
+			hb.CronSchedule = *input.CronSchedule
+		}
+		if input.TimeZone != nil {
+			hb.TimeZone, err = util.LoadLocation(*input.TimeZone)
+			if err != nil {
+				return validation.NewFieldError("TimeZone", err.Error())
+			}
+		}
+		if input.GracePeriodMinutes != nil {
+			hb.GracePeriod = time.Duration(*input.GracePeriodMinutes) * time.Minute
+		}
+		if input.AlertAfterMisses != nil {
+			hb.AlertAfterMisses = *input.AlertAfterMisses
+		}
 
 		return m.HeartbeatStore.UpdateTx(ctx, tx, hb)
 	})
@@ -0,0 +1,115 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"strings"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/holiday"
+	"github.com/target/goalert/validation"
+)
+
+type HolidayCalendar App
+
+func (a *App) HolidayCalendar() graphql2.HolidayCalendarResolver { return (*HolidayCalendar)(a) }
+
+func (c *HolidayCalendar) Holidays(ctx context.Context, raw *holiday.Calendar) ([]holiday.Holiday, error) {
+	return c.HolidayStore.FindHolidays(ctx, raw.ID)
+}
+
+func (q *Query) HolidayCalendars(ctx context.Context) ([]holiday.Calendar, error) {
+	return q.HolidayStore.FindAllCalendars(ctx)
+}
+
+func (q *Query) HolidayCalendar(ctx context.Context, id string) (*holiday.Calendar, error) {
+	c, err := q.HolidayStore.FindOneCalendar(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return c, err
+}
+
+func (m *Mutation) CreateHolidayCalendar(ctx context.Context, input graphql2.CreateHolidayCalendarInput) (*holiday.Calendar, error) {
+	c := &holiday.Calendar{Name: input.Name}
+	if input.Description != nil {
+		c.Description = *input.Description
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		c, err = m.HolidayStore.CreateCalendarTx(ctx, tx, c)
+		return err
+	})
+	return c, err
+}
+
+func (m *Mutation) UpdateHolidayCalendar(ctx context.Context, input graphql2.UpdateHolidayCalendarInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		c, err := m.HolidayStore.FindOneCalendar(ctx, input.ID)
+		if err == sql.ErrNoRows {
+			return validation.NewFieldError("ID", "not found")
+		}
+		if err != nil {
+			return err
+		}
+		if input.Name != nil {
+			c.Name = *input.Name
+		}
+		if input.Description != nil {
+			c.Description = *input.Description
+		}
+
+		return m.HolidayStore.UpdateCalendarTx(ctx, tx, c)
+	})
+	return err == nil, err
+}
+
+func (m *Mutation) DeleteHolidayCalendar(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.HolidayStore.DeleteCalendarTx(ctx, tx, id)
+	})
+	return err == nil, err
+}
+
+func (m *Mutation) SetHolidays(ctx context.Context, input graphql2.SetHolidaysInput) (bool, error) {
+	holidays := make([]holiday.Holiday, len(input.Holidays))
+	for i, h := range input.Holidays {
+		holidays[i].Date = h.Date
+		if h.Name != nil {
+			holidays[i].Name = *h.Name
+		}
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.HolidayStore.SetHolidaysTx(ctx, tx, input.CalendarID, holidays, input.Replace != nil && *input.Replace)
+	})
+	return err == nil, err
+}
+
+func (m *Mutation) DeleteHolidays(ctx context.Context, input graphql2.DeleteHolidaysInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.HolidayStore.DeleteHolidaysTx(ctx, tx, input.CalendarID, input.Dates)
+	})
+	return err == nil, err
+}
+
+func (m *Mutation) ImportHolidays(ctx context.Context, input graphql2.ImportHolidaysInput) (int, error) {
+	if len(input.Data) > holiday.MaxImportSize {
+		return 0, validation.NewFieldError("Data", "must not be larger than 1MiB")
+	}
+
+	holidays, err := holiday.ParseICal(strings.NewReader(input.Data))
+	if err != nil {
+		return 0, validation.NewFieldError("Data", err.Error())
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.HolidayStore.SetHolidaysTx(ctx, tx, input.CalendarID, holidays, input.Replace != nil && *input.Replace)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(holidays), nil
+}
@@ -27,12 +27,31 @@ func (m *Mutation) CreateIntegrationKey(ctx context.Context, input graphql2.Crea
 			ServiceID: serviceID,
 			Name:      input.Name,
 			Type:      integrationkey.Type(input.Type),
+
+			JSONMapping: input.JSONMapping,
 		}
 		key, err = m.IntKeyStore.CreateKeyTx(ctx, tx, key)
 		return err
 	})
 	return key, err
 }
+func (m *Mutation) UpdateIntegrationKey(ctx context.Context, input graphql2.UpdateIntegrationKeyInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		key, err := m.IntKeyStore.FindOne(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if input.Name != nil {
+			key.Name = *input.Name
+		}
+		if input.JSONMapping != nil {
+			key.JSONMapping = input.JSONMapping
+		}
+
+		return m.IntKeyStore.UpdateTx(ctx, tx, key)
+	})
+	return err == nil, err
+}
 func (key *IntegrationKey) Type(ctx context.Context, raw *integrationkey.IntegrationKey) (graphql2.IntegrationKeyType, error) {
 	return graphql2.IntegrationKeyType(raw.Type), nil
 }
@@ -43,12 +62,20 @@ func (key *IntegrationKey) Href(ctx context.Context, raw *integrationkey.Integra
 	switch raw.Type {
 	case integrationkey.TypeGeneric:
 		return cfg.CallbackURL("/api/v2/generic/incoming", q), nil
+	case integrationkey.TypeGenericJSON:
+		return cfg.CallbackURL("/api/v2/generic/json", q), nil
 	case integrationkey.TypeGrafana:
 		return cfg.CallbackURL("/api/v2/grafana/incoming", q), nil
 	case integrationkey.TypeSite24x7:
 		return cfg.CallbackURL("/api/v2/site24x7/incoming", q), nil
 	case integrationkey.TypePrometheusAlertmanager:
 		return cfg.CallbackURL("/api/v2/prometheusalertmanager/incoming", q), nil
+	case integrationkey.TypeDatadog:
+		return cfg.CallbackURL("/api/v2/datadog/incoming", q), nil
+	case integrationkey.TypeNewRelic:
+		return cfg.CallbackURL("/api/v2/newrelic/incoming", q), nil
+	case integrationkey.TypeAWSSNS:
+		return cfg.CallbackURL("/api/v2/sns/incoming", q), nil
 	case integrationkey.TypeEmail:
 		if !cfg.Mailgun.Enable || cfg.Mailgun.EmailDomain == "" {
 			return "", nil
@@ -3,7 +3,9 @@ package graphqlapp
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
+	"github.com/target/goalert/audit"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/limit"
 )
@@ -16,20 +18,41 @@ func (q *Query) SystemLimits(ctx context.Context) ([]graphql2.SystemLimit, error
 	return graphql2.MapLimitValues(limits), nil
 }
 func (m *Mutation) SetSystemLimits(ctx context.Context, input []graphql2.SystemLimitInput) (bool, error) {
-	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+	before, err := m.LimitStore.All(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		l := limit.Limits{}
 		l, err := graphql2.ApplyLimitValues(l, input)
 		if err != nil {
 			return err
 		}
 
+		after := make(limit.Limits, len(before))
+		for id, max := range before {
+			after[id] = max
+		}
 		for id, max := range l {
 			err = m.LimitStore.UpdateLimitsTx(ctx, tx, string(id), max)
 			if err != nil {
 				return err
 			}
+			after[id] = max
+		}
+
+		e := audit.Entry{Action: "setSystemLimits"}
+		e.Before, err = json.Marshal(before)
+		if err != nil {
+			return err
+		}
+		e.After, err = json.Marshal(after)
+		if err != nil {
+			return err
 		}
-		return err
+
+		return m.AuditStore.LogTx(ctx, tx, &e)
 	})
 	return err == nil, err
 }
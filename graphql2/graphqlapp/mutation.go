@@ -113,11 +113,13 @@ func (a *Mutation) DeleteAll(ctx context.Context, input []assignment.RawTarget)
 		assignment.TargetTypeUser,
 		assignment.TargetTypeIntegrationKey,
 		assignment.TargetTypeHeartbeatMonitor,
+		assignment.TargetTypeSyntheticCheck,
 		assignment.TargetTypeService,
 		assignment.TargetTypeEscalationPolicy,
 		assignment.TargetTypeNotificationRule,
 		assignment.TargetTypeContactMethod,
 		assignment.TargetTypeUserSession,
+		assignment.TargetTypeOutgoingWebhook,
 	}
 
 	for _, typ := range order {
@@ -148,8 +150,12 @@ func (a *Mutation) DeleteAll(ctx context.Context, input []assignment.RawTarget)
 			err = errors.Wrap(a.NRStore.DeleteTx(ctx, tx, ids...), "delete notification rules")
 		case assignment.TargetTypeHeartbeatMonitor:
 			err = errors.Wrap(a.HeartbeatStore.DeleteTx(ctx, tx, ids...), "delete heartbeat monitors")
+		case assignment.TargetTypeSyntheticCheck:
+			err = errors.Wrap(a.SyntheticStore.DeleteTx(ctx, tx, ids...), "delete synthetic checks")
 		case assignment.TargetTypeUserSession:
 			err = errors.Wrap(a.AuthHandler.EndUserSessionTx(ctx, tx, ids...), "end user sessions")
+		case assignment.TargetTypeOutgoingWebhook:
+			err = errors.Wrap(a.WebhookStore.DeleteTx(ctx, tx, ids...), "delete outgoing webhooks")
 		default:
 			return false, validation.NewFieldError("type", "unsupported type "+typ.String())
 		}
@@ -0,0 +1,81 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/outgoingwebhook"
+)
+
+type OutgoingWebhook App
+
+func (a *App) OutgoingWebhook() graphql2.OutgoingWebhookResolver { return (*OutgoingWebhook)(a) }
+
+func (a *OutgoingWebhook) EventTypes(ctx context.Context, w *outgoingwebhook.Webhook) ([]string, error) {
+	types := make([]string, len(w.EventTypes))
+	for i, t := range w.EventTypes {
+		types[i] = string(t)
+	}
+	return types, nil
+}
+
+func (a *OutgoingWebhook) Secret(ctx context.Context, w *outgoingwebhook.Webhook) (*string, error) {
+	if w.Secret == "" {
+		return nil, nil
+	}
+	return &w.Secret, nil
+}
+
+func eventTypes(types []string) []outgoingwebhook.EventType {
+	res := make([]outgoingwebhook.EventType, len(types))
+	for i, t := range types {
+		res[i] = outgoingwebhook.EventType(t)
+	}
+	return res
+}
+
+func (q *Query) OutgoingWebhooks(ctx context.Context) ([]outgoingwebhook.Webhook, error) {
+	return q.WebhookStore.FindAll(ctx)
+}
+
+func (m *Mutation) CreateOutgoingWebhook(ctx context.Context, input graphql2.CreateOutgoingWebhookInput) (w *outgoingwebhook.Webhook, err error) {
+	w = &outgoingwebhook.Webhook{
+		Name:       input.Name,
+		URL:        input.URL,
+		EventTypes: eventTypes(input.EventTypes),
+	}
+	if input.Disabled != nil {
+		w.Disabled = *input.Disabled
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		w, err = m.WebhookStore.CreateTx(ctx, tx, w)
+		return err
+	})
+	return w, err
+}
+
+func (m *Mutation) UpdateOutgoingWebhook(ctx context.Context, input graphql2.UpdateOutgoingWebhookInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		w, err := m.WebhookStore.FindOneForUpdateTx(ctx, tx, input.ID)
+		if err != nil {
+			return err
+		}
+		if input.Name != nil {
+			w.Name = *input.Name
+		}
+		if input.URL != nil {
+			w.URL = *input.URL
+		}
+		if input.EventTypes != nil {
+			w.EventTypes = eventTypes(input.EventTypes)
+		}
+		if input.Disabled != nil {
+			w.Disabled = *input.Disabled
+		}
+
+		return m.WebhookStore.UpdateTx(ctx, tx, w)
+	})
+	return err == nil, err
+}
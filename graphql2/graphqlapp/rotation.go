@@ -412,3 +412,52 @@ func (a *Query) CalcRotationHandoffTimes(ctx context.Context, input *graphql2.Ca
 
 	return result, nil
 }
+
+type RotationParticipant App
+type RotationParticipantHours App
+
+func (a *App) RotationParticipant() graphql2.RotationParticipantResolver {
+	return (*RotationParticipant)(a)
+}
+func (a *App) RotationParticipantHours() graphql2.RotationParticipantHoursResolver {
+	return (*RotationParticipantHours)(a)
+}
+
+func (r *Rotation) Participants(ctx context.Context, rot *rotation.Rotation) ([]rotation.Participant, error) {
+	return r.RotationStore.FindAllParticipants(ctx, rot.ID)
+}
+
+func (r *Rotation) ParticipantHours(ctx context.Context, rot *rotation.Rotation, start, end time.Time) ([]rotation.ParticipantHours, error) {
+	if end.After(start.AddDate(1, 0, 0)) {
+		return nil, validation.NewFieldError("End", "cannot be more than 1 year past Start")
+	}
+	return r.RotationStore.FindParticipantHours(ctx, rot.ID, start, end)
+}
+
+func (p *RotationParticipant) UserID(ctx context.Context, part *rotation.Participant) (string, error) {
+	return part.Target.TargetID(), nil
+}
+
+func (p *RotationParticipant) User(ctx context.Context, part *rotation.Participant) (*user.User, error) {
+	return (*App)(p).FindOneUser(ctx, part.Target.TargetID())
+}
+
+func (h *RotationParticipantHours) User(ctx context.Context, raw *rotation.ParticipantHours) (*user.User, error) {
+	return (*App)(h).FindOneUser(ctx, raw.UserID)
+}
+
+func (h *RotationParticipantHours) Hours(ctx context.Context, raw *rotation.ParticipantHours) (float64, error) {
+	return raw.Duration.Hours(), nil
+}
+
+func (m *Mutation) SetRotationParticipantUnavailable(ctx context.Context, input graphql2.SetRotationParticipantUnavailableInput) (bool, error) {
+	var until time.Time
+	if input.Until != nil {
+		until = *input.Until
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.RotationStore.SetParticipantUnavailableTx(ctx, tx, input.RotationID, input.UserIndex, until)
+	})
+	return err == nil, err
+}
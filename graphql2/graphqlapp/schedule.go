@@ -11,11 +11,13 @@ import (
 
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/holiday"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/search"
+	"github.com/target/goalert/user"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
@@ -52,6 +54,39 @@ func (s *Schedule) Shifts(ctx context.Context, raw *schedule.Schedule, start, en
 	return s.OnCallStore.HistoryBySchedule(ctx, raw.ID, start, end)
 }
 
+func (s *Schedule) CoverageGaps(ctx context.Context, raw *schedule.Schedule, start, end time.Time) ([]oncall.Gap, error) {
+	shifts, err := s.Shifts(ctx, raw, start, end)
+	if err != nil {
+		return nil, err
+	}
+	gaps := oncall.CoverageGaps(shifts, start, end)
+	if gaps == nil {
+		gaps = []oncall.Gap{}
+	}
+	return gaps, nil
+}
+
+func (s *Schedule) GapNotifyUser(ctx context.Context, raw *schedule.Schedule) (*user.User, error) {
+	if raw.GapNotifyUserID == "" {
+		return nil, nil
+	}
+	return (*App)(s).FindOneUser(ctx, raw.GapNotifyUserID)
+}
+
+func (s *Schedule) HolidayCalendarID(ctx context.Context, raw *schedule.Schedule) (*string, error) {
+	if raw.HolidayCalendarID == "" {
+		return nil, nil
+	}
+	return &raw.HolidayCalendarID, nil
+}
+
+func (s *Schedule) HolidayCalendar(ctx context.Context, raw *schedule.Schedule) (*holiday.Calendar, error) {
+	if raw.HolidayCalendarID == "" {
+		return nil, nil
+	}
+	return s.HolidayStore.FindOneCalendar(ctx, raw.HolidayCalendarID)
+}
+
 func (s *Schedule) TemporarySchedules(ctx context.Context, raw *schedule.Schedule) ([]schedule.TemporarySchedule, error) {
 	id, err := parseUUID("ScheduleID", raw.ID)
 	if err != nil {
@@ -138,6 +173,18 @@ func (m *Mutation) UpdateSchedule(ctx context.Context, input graphql2.UpdateSche
 		if loc != nil {
 			sched.TimeZone = loc
 		}
+		if input.GapNotifyDays != nil {
+			sched.GapNotifyDays = *input.GapNotifyDays
+		}
+		if input.GapNotifyUserID != nil {
+			sched.GapNotifyUserID = *input.GapNotifyUserID
+		}
+		if input.TimeOffStrategy != nil {
+			sched.TimeOffStrategy = *input.TimeOffStrategy
+		}
+		if input.HolidayCalendarID != nil {
+			sched.HolidayCalendarID = *input.HolidayCalendarID
+		}
 
 		return m.ScheduleStore.UpdateTx(ctx, tx, sched)
 	})
@@ -191,6 +238,18 @@ func (m *Mutation) CreateSchedule(ctx context.Context, input graphql2.CreateSche
 		if input.Description != nil {
 			s.Description = *input.Description
 		}
+		if input.GapNotifyDays != nil {
+			s.GapNotifyDays = *input.GapNotifyDays
+		}
+		if input.GapNotifyUserID != nil {
+			s.GapNotifyUserID = *input.GapNotifyUserID
+		}
+		if input.TimeOffStrategy != nil {
+			s.TimeOffStrategy = *input.TimeOffStrategy
+		}
+		if input.HolidayCalendarID != nil {
+			s.HolidayCalendarID = *input.HolidayCalendarID
+		}
 		sched, err = m.ScheduleStore.CreateScheduleTx(ctx, tx, s)
 		if err != nil {
 			return err
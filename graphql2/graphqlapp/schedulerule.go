@@ -61,6 +61,9 @@ func (m *Mutation) UpdateScheduleTarget(ctx context.Context, input graphql2.Sche
 			if inputRule.WeekdayFilter != nil {
 				r.WeekdayFilter = *inputRule.WeekdayFilter
 			}
+			if inputRule.HolidayMode != nil {
+				r.HolidayMode = *inputRule.HolidayMode
+			}
 			if ruleIndex < len(rules) {
 				r.ID = rules[ruleIndex].ID
 				err = errors.Wrap(m.RuleStore.UpdateTx(ctx, tx, r), "update rule")
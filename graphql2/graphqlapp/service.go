@@ -120,6 +120,9 @@ func (m *Mutation) CreateService(ctx context.Context, input graphql2.CreateServi
 		if input.Description != nil {
 			svc.Description = *input.Description
 		}
+		if input.AutoResolveMinutes != nil {
+			svc.AutoResolveMinutes = *input.AutoResolveMinutes
+		}
 		if input.NewEscalationPolicy != nil {
 			// Set tempUUID so that Normalize won't fail on the yet-to-be-created
 			// escalation policy.
@@ -212,6 +215,9 @@ func (a *Mutation) UpdateService(ctx context.Context, input graphql2.UpdateServi
 	if input.EscalationPolicyID != nil {
 		svc.EscalationPolicyID = *input.EscalationPolicyID
 	}
+	if input.AutoResolveMinutes != nil {
+		svc.AutoResolveMinutes = *input.AutoResolveMinutes
+	}
 
 	err = a.ServiceStore.UpdateTx(ctx, tx, svc)
 	if err != nil {
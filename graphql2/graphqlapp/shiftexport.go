@@ -0,0 +1,84 @@
+package graphqlapp
+
+import (
+	"bytes"
+	context "context"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/schedule/shiftexport"
+)
+
+type ScheduleExportShift App
+
+func (a *App) ScheduleExportShift() graphql2.ScheduleExportShiftResolver {
+	return (*ScheduleExportShift)(a)
+}
+
+func (s *ScheduleExportShift) Source(ctx context.Context, raw *shiftexport.Shift) (oncall.ShiftSourceType, error) {
+	if raw.Source == nil {
+		return oncall.ShiftSourceHistory, nil
+	}
+
+	return raw.Source.Type, nil
+}
+
+func sourceField(raw *shiftexport.Shift, fn func(oncall.ShiftSource) string) *string {
+	if raw.Source == nil {
+		return nil
+	}
+	val := fn(*raw.Source)
+	if val == "" {
+		return nil
+	}
+
+	return &val
+}
+
+func (s *ScheduleExportShift) OverrideID(ctx context.Context, raw *shiftexport.Shift) (*string, error) {
+	return sourceField(raw, func(src oncall.ShiftSource) string { return src.OverrideID }), nil
+}
+
+func (s *ScheduleExportShift) RuleID(ctx context.Context, raw *shiftexport.Shift) (*string, error) {
+	return sourceField(raw, func(src oncall.ShiftSource) string { return src.RuleID }), nil
+}
+
+func (s *ScheduleExportShift) RotationID(ctx context.Context, raw *shiftexport.Shift) (*string, error) {
+	return sourceField(raw, func(src oncall.ShiftSource) string { return src.RotationID }), nil
+}
+
+func (s *ScheduleExportShift) RotationName(ctx context.Context, raw *shiftexport.Shift) (*string, error) {
+	if raw.RotationName == "" {
+		return nil, nil
+	}
+
+	return &raw.RotationName, nil
+}
+
+func (q *Query) ExportScheduleShifts(ctx context.Context, input graphql2.ExportScheduleShiftsInput) (*graphql2.ScheduleShiftExport, error) {
+	format := shiftexport.FormatCSV
+	if input.Format != nil {
+		format = *input.Format
+	}
+
+	shifts, err := q.ShiftExport.Export(ctx, shiftexport.Options{
+		ScheduleIDs: input.ScheduleIDs,
+		Start:       input.Start,
+		End:         input.End,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = shiftexport.Write(&buf, format, shifts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &graphql2.ScheduleShiftExport{
+		Format: format,
+		Data:   buf.String(),
+		Shifts: shifts,
+	}, nil
+}
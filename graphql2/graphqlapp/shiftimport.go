@@ -0,0 +1,72 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/schedule/shiftimport"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+)
+
+type ScheduleImportShift App
+
+func (a *App) ScheduleImportShift() graphql2.ScheduleImportShiftResolver {
+	return (*ScheduleImportShift)(a)
+}
+
+func (s *ScheduleImportShift) User(ctx context.Context, raw *shiftimport.Shift) (*user.User, error) {
+	return (*App)(s).FindOneUser(ctx, raw.UserID)
+}
+
+func (m *Mutation) ImportSchedule(ctx context.Context, input graphql2.ImportScheduleInput) (*shiftimport.Result, error) {
+	if len(input.Data) > shiftimport.MaxDataSize {
+		return nil, validation.NewFieldError("Data", "must not be larger than 1MiB")
+	}
+
+	var loc *time.Location
+	if input.TimeZone != nil && *input.TimeZone != "" {
+		var err error
+		loc, err = util.LoadLocation(*input.TimeZone)
+		if err != nil {
+			return nil, validation.NewFieldError("TimeZone", err.Error())
+		}
+	} else {
+		sched, err := m.ScheduleStore.FindOne(ctx, input.ScheduleID)
+		if err != nil {
+			return nil, err
+		}
+		loc = sched.TimeZone
+	}
+
+	rows, err := shiftimport.Parse(input.Format, strings.NewReader(input.Data), loc)
+	if err != nil {
+		return nil, validation.NewFieldError("Data", err.Error())
+	}
+
+	opts := shiftimport.Options{
+		ScheduleID: input.ScheduleID,
+		Rows:       rows,
+	}
+	if input.Mode != nil {
+		opts.Mode = *input.Mode
+	}
+	if input.DryRun != nil {
+		opts.DryRun = *input.DryRun
+	}
+
+	var res *shiftimport.Result
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		res, err = m.ShiftImport.ImportTx(ctx, tx, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
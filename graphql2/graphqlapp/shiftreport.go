@@ -0,0 +1,65 @@
+package graphqlapp
+
+import (
+	context "context"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/shiftreport"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util/timeutil"
+)
+
+type OnCallHoursReportUser App
+type OnCallHoursReportSchedule App
+
+func (a *App) OnCallHoursReportUser() graphql2.OnCallHoursReportUserResolver {
+	return (*OnCallHoursReportUser)(a)
+}
+
+func (a *App) OnCallHoursReportSchedule() graphql2.OnCallHoursReportScheduleResolver {
+	return (*OnCallHoursReportSchedule)(a)
+}
+
+func (u *OnCallHoursReportUser) User(ctx context.Context, raw *shiftreport.UserReport) (*user.User, error) {
+	return (*App)(u).FindOneUser(ctx, raw.UserID)
+}
+
+func (s *OnCallHoursReportSchedule) Schedule(ctx context.Context, raw *shiftreport.ScheduleHours) (*schedule.Schedule, error) {
+	return (*App)(s).FindOneSchedule(ctx, raw.ScheduleID)
+}
+
+func (q *Query) OnCallHoursReport(ctx context.Context, input graphql2.OnCallHoursReportInput) (*shiftreport.Report, error) {
+	opts := shiftreport.Options{
+		ScheduleIDs:   input.ScheduleIDs,
+		UserIDs:       input.UserIDs,
+		Start:         input.Start,
+		End:           input.End,
+		Holidays:      input.Holidays,
+		BusinessStart: timeutil.NewClock(9, 0),
+		BusinessEnd:   timeutil.NewClock(17, 0),
+	}
+	if input.TimeZone != nil {
+		opts.TimeZone = *input.TimeZone
+	}
+	if input.BusinessStart != nil {
+		opts.BusinessStart = *input.BusinessStart
+	}
+	if input.BusinessEnd != nil {
+		opts.BusinessEnd = *input.BusinessEnd
+	}
+	if input.IncludePages != nil {
+		opts.IncludePages = *input.IncludePages
+	}
+	if input.HolidayCalendarID != nil && *input.HolidayCalendarID != "" {
+		holidays, err := q.HolidayStore.FindHolidays(ctx, *input.HolidayCalendarID)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range holidays {
+			opts.Holidays = append(opts.Holidays, h.Date)
+		}
+	}
+
+	return q.ShiftReport.Report(ctx, opts)
+}
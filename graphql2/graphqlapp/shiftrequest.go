@@ -0,0 +1,95 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/shiftrequest"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/validation"
+)
+
+type ShiftRequest App
+
+func (a *App) ShiftRequest() graphql2.ShiftRequestResolver { return (*ShiftRequest)(a) }
+
+func (r *ShiftRequest) Schedule(ctx context.Context, raw *shiftrequest.Request) (*schedule.Schedule, error) {
+	return (*App)(r).FindOneSchedule(ctx, raw.ScheduleID)
+}
+func (r *ShiftRequest) Requester(ctx context.Context, raw *shiftrequest.Request) (*user.User, error) {
+	return (*App)(r).FindOneUser(ctx, raw.RequesterID)
+}
+func (r *ShiftRequest) User(ctx context.Context, raw *shiftrequest.Request) (*user.User, error) {
+	return (*App)(r).FindOneUser(ctx, raw.UserID)
+}
+
+func (q *Query) ShiftRequests(ctx context.Context, input *graphql2.ShiftRequestSearchOptions) ([]shiftrequest.Request, error) {
+	if input == nil {
+		input = &graphql2.ShiftRequestSearchOptions{}
+	}
+
+	var opts shiftrequest.SearchOptions
+	if input.ScheduleID != nil {
+		opts.ScheduleID = *input.ScheduleID
+	}
+	if input.UserID != nil {
+		opts.UserID = *input.UserID
+	}
+	opts.Status = input.Status
+
+	return q.ShiftReqStore.FindAll(ctx, opts)
+}
+
+func (m *Mutation) CreateShiftRequest(ctx context.Context, input graphql2.CreateShiftRequestInput) (*shiftrequest.Request, error) {
+	r := &shiftrequest.Request{
+		ScheduleID:  input.ScheduleID,
+		RequesterID: permission.UserID(ctx),
+		UserID:      input.UserID,
+		Start:       input.Start,
+		End:         input.End,
+	}
+	if input.RequesterID != nil {
+		r.RequesterID = *input.RequesterID
+	}
+	if input.SwapStart != nil {
+		r.SwapStart = *input.SwapStart
+	}
+	if input.SwapEnd != nil {
+		r.SwapEnd = *input.SwapEnd
+	}
+	if input.Note != nil {
+		r.Note = *input.Note
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		r, err = m.ShiftReqStore.CreateTx(ctx, tx, r)
+		return err
+	})
+	return r, err
+}
+
+func (m *Mutation) RespondShiftRequest(ctx context.Context, input graphql2.RespondShiftRequestInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		_, err := m.ShiftReqStore.RespondTx(ctx, tx, input.ID, input.Accept)
+		if err == sql.ErrNoRows {
+			return validation.NewFieldError("ID", "not found")
+		}
+		return err
+	})
+	return err == nil, err
+}
+
+func (m *Mutation) CancelShiftRequest(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		err := m.ShiftReqStore.CancelTx(ctx, tx, id)
+		if err == sql.ErrNoRows {
+			return validation.NewFieldError("ID", "not found")
+		}
+		return err
+	})
+	return err == nil, err
+}
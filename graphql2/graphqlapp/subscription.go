@@ -0,0 +1,272 @@
+package graphqlapp
+
+import (
+	context "context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	alertlog "github.com/target/goalert/alert/log"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Postgres notification channels used to drive GraphQL subscriptions.
+const (
+	NotifyAlertStatus      = "/goalert/alert-status"
+	NotifyAlertLog         = "/goalert/alert-log"
+	NotifyScheduleOnCall   = "/goalert/schedule-on-call"
+	NotifyEscalationOnCall = "/goalert/ep-on-call"
+)
+
+// NotifyChannels is the list of Postgres notification channels that should be passed to Notify.
+var NotifyChannels = []string{
+	NotifyAlertStatus,
+	NotifyAlertLog,
+	NotifyScheduleOnCall,
+	NotifyEscalationOnCall,
+}
+
+func topic(channel, id string) string { return channel + "/" + id }
+
+// Notify will deliver a Postgres notification to any active subscriptions.
+func (a *App) Notify(channel, payload string) {
+	if a.Events == nil {
+		return
+	}
+
+	switch channel {
+	case NotifyAlertStatus:
+		// payload is `<service_id>/<alert_id>`
+		parts := strings.SplitN(payload, "/", 2)
+		if len(parts) != 2 {
+			return
+		}
+		a.Events.Publish(topic(channel, parts[0]), parts[1])
+	case NotifyAlertLog, NotifyScheduleOnCall, NotifyEscalationOnCall:
+		a.Events.Publish(topic(channel, payload), payload)
+	}
+}
+
+// IsWebSocketUpgrade will return true if req is a WebSocket handshake (RFC 6455 section 4.1).
+func IsWebSocketUpgrade(req *http.Request) bool {
+	return req.Method == http.MethodGet &&
+		hasHeaderToken(req.Header, "Connection", "upgrade") &&
+		hasHeaderToken(req.Header, "Upgrade", "websocket")
+}
+
+// hasHeaderToken will return true if any comma-separated value of the named header matches token.
+func hasHeaderToken(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type Subscription App
+
+func (a *App) Subscription() graphql2.SubscriptionResolver { return (*Subscription)(a) }
+
+// listen will return a channel of payloads for the given topics that is closed once ctx is done.
+//
+// The auth check count is reset before each payload is delivered, so that the request limit
+// applies to each event rather than the lifetime of the subscription.
+func (s *Subscription) listen(ctx context.Context, topics ...string) (<-chan string, error) {
+	if s.Events == nil {
+		return nil, errors.New("subscriptions are not available")
+	}
+
+	sub := s.Events.Subscribe(topics...)
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		defer sub.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case payload := <-sub.Messages():
+				permission.ResetAuthCheckCount(ctx)
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- payload:
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (s *Subscription) AlertStatusChanged(ctx context.Context, serviceIDs []string) (<-chan *alert.Alert, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.ManyUUID("ServiceIDs", serviceIDs, 50)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.ServiceStore.FindMany(ctx, serviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	topics := make([]string, len(serviceIDs))
+	for i, id := range serviceIDs {
+		topics[i] = topic(NotifyAlertStatus, strings.ToLower(id))
+	}
+	payloads, err := s.listen(ctx, topics...)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *alert.Alert)
+	go func() {
+		defer close(ch)
+		for payload := range payloads {
+			id, err := strconv.Atoi(payload)
+			if err != nil {
+				log.Log(ctx, errors.Wrap(err, "parse alert ID"))
+				continue
+			}
+			a, err := s.AlertStore.FindOne(ctx, id)
+			if err != nil {
+				log.Log(ctx, errors.Wrap(err, "lookup alert"))
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- a:
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (s *Subscription) AlertLogEntryAdded(ctx context.Context, alertID int) (<-chan *alertlog.Entry, error) {
+	// ensures the alert exists and is visible to the current user
+	_, err := s.AlertStore.FindOne(ctx, alertID)
+	if err != nil {
+		return nil, err
+	}
+
+	// only entries after the most recent one at subscription time are sent
+	var lastID int
+	logs, err := s.AlertLogStore.Search(ctx, &alertlog.SearchOptions{FilterAlertIDs: []int{alertID}, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) > 0 {
+		lastID = logs[0].ID()
+	}
+
+	payloads, err := s.listen(ctx, topic(NotifyAlertLog, strconv.Itoa(alertID)))
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *alertlog.Entry)
+	go func() {
+		defer close(ch)
+		for range payloads {
+			logs, err := s.AlertLogStore.Search(ctx, &alertlog.SearchOptions{FilterAlertIDs: []int{alertID}, Limit: 50})
+			if err != nil {
+				log.Log(ctx, errors.Wrap(err, "lookup alert log entries"))
+				continue
+			}
+
+			// search results are newest-first
+			sort.Slice(logs, func(i, j int) bool { return logs[i].ID() < logs[j].ID() })
+			for i := range logs {
+				if logs[i].ID() <= lastID {
+					continue
+				}
+				lastID = logs[i].ID()
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- &logs[i]:
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (s *Subscription) ScheduleOnCallChanged(ctx context.Context, scheduleID string) (<-chan *schedule.Schedule, error) {
+	_, err := s.ScheduleStore.FindOne(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	payloads, err := s.listen(ctx, topic(NotifyScheduleOnCall, strings.ToLower(scheduleID)))
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *schedule.Schedule)
+	go func() {
+		defer close(ch)
+		for range payloads {
+			sched, err := s.ScheduleStore.FindOne(ctx, scheduleID)
+			if err != nil {
+				log.Log(ctx, errors.Wrap(err, "lookup schedule"))
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- sched:
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (s *Subscription) ServiceOnCallChanged(ctx context.Context, serviceID string) (<-chan *service.Service, error) {
+	svc, err := s.ServiceStore.FindOne(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	payloads, err := s.listen(ctx, topic(NotifyEscalationOnCall, strings.ToLower(svc.EscalationPolicyID)))
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *service.Service)
+	go func() {
+		defer close(ch)
+		for range payloads {
+			svc, err := s.ServiceStore.FindOne(ctx, serviceID)
+			if err != nil {
+				log.Log(ctx, errors.Wrap(err, "lookup service"))
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- svc:
+			}
+		}
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,106 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"time"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/service"
+	"github.com/target/goalert/syntheticcheck"
+	"github.com/target/goalert/validation"
+)
+
+type SyntheticCheck App
+
+func (a *App) SyntheticCheck() graphql2.SyntheticCheckResolver { return (*SyntheticCheck)(a) }
+
+func (a *SyntheticCheck) IntervalMinutes(ctx context.Context, c *syntheticcheck.Check) (int, error) {
+	return int(c.Interval / time.Minute), nil
+}
+
+func (q *Query) SyntheticCheck(ctx context.Context, id string) (*syntheticcheck.Check, error) {
+	checks, err := q.SyntheticStore.FindMany(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(checks) == 0 {
+		return nil, nil
+	}
+	return &checks[0], nil
+}
+
+func (s *Service) SyntheticChecks(ctx context.Context, raw *service.Service) ([]syntheticcheck.Check, error) {
+	return s.SyntheticStore.FindAllByService(ctx, raw.ID)
+}
+
+func (m *Mutation) CreateSyntheticCheck(ctx context.Context, input graphql2.CreateSyntheticCheckInput) (*syntheticcheck.Check, error) {
+	c := &syntheticcheck.Check{
+		ServiceID: input.ServiceID,
+		Name:      input.Name,
+		Type:      input.Type,
+		Target:    input.Target,
+		Interval:  5 * time.Minute,
+	}
+	if input.IntervalMinutes != nil {
+		c.Interval = time.Duration(*input.IntervalMinutes) * time.Minute
+	}
+	if input.ExpectedStatus != nil {
+		c.ExpectedStatus = *input.ExpectedStatus
+	}
+	if input.BodyContains != nil {
+		c.BodyContains = *input.BodyContains
+	}
+	if input.TLSExpiryDays != nil {
+		c.TLSExpiryDays = *input.TLSExpiryDays
+	}
+	if input.Disabled != nil {
+		c.Disabled = *input.Disabled
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		c, err = m.SyntheticStore.CreateTx(ctx, tx, c)
+		return err
+	})
+	return c, err
+}
+
+func (m *Mutation) UpdateSyntheticCheck(ctx context.Context, input graphql2.UpdateSyntheticCheckInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		c, err := m.SyntheticStore.FindOneForUpdateTx(ctx, tx, input.ID)
+		if err == sql.ErrNoRows {
+			return validation.NewFieldError("ID", "not found")
+		}
+		if err != nil {
+			return err
+		}
+		if input.Name != nil {
+			c.Name = *input.Name
+		}
+		if input.Type != nil {
+			c.Type = *input.Type
+		}
+		if input.Target != nil {
+			c.Target = *input.Target
+		}
+		if input.IntervalMinutes != nil {
+			c.Interval = time.Duration(*input.IntervalMinutes) * time.Minute
+		}
+		if input.ExpectedStatus != nil {
+			c.ExpectedStatus = *input.ExpectedStatus
+		}
+		if input.BodyContains != nil {
+			c.BodyContains = *input.BodyContains
+		}
+		if input.TLSExpiryDays != nil {
+			c.TLSExpiryDays = *input.TLSExpiryDays
+		}
+		if input.Disabled != nil {
+			c.Disabled = *input.Disabled
+		}
+
+		return m.SyntheticStore.UpdateTx(ctx, tx, c)
+	})
+	return err == nil, err
+}
@@ -0,0 +1,85 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"fmt"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/notice"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/timeoff"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/validation"
+)
+
+type TimeOff App
+
+func (a *App) TimeOff() graphql2.TimeOffResolver { return (*TimeOff)(a) }
+
+func (t *TimeOff) User(ctx context.Context, raw *timeoff.TimeOff) (*user.User, error) {
+	return (*App)(t).FindOneUser(ctx, raw.UserID)
+}
+
+func (a *User) TimeOff(ctx context.Context, obj *user.User) ([]timeoff.TimeOff, error) {
+	return a.TimeOffStore.FindAllByUser(ctx, obj.ID)
+}
+
+const timeOffNoticeFmt = "Mon Jan 2 3:04pm MST"
+
+func (s *Schedule) Notices(ctx context.Context, raw *schedule.Schedule) ([]notice.Notice, error) {
+	conflicts, err := s.TimeOffStore.FindScheduleConflicts(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	notices := make([]notice.Notice, 0, len(conflicts))
+	for _, c := range conflicts {
+		n := notice.Notice{
+			Message: fmt.Sprintf("Time off for %s was not applied", c.UserName),
+		}
+		when := fmt.Sprintf("%s to %s", c.Start.In(raw.TimeZone).Format(timeOffNoticeFmt), c.End.In(raw.TimeZone).Format(timeOffNoticeFmt))
+		switch c.Reason {
+		case timeoff.ConflictLimit:
+			n.Details = fmt.Sprintf("%s is unavailable from %s, but this schedule has reached the maximum number of overrides.", c.UserName, when)
+		default:
+			n.Details = fmt.Sprintf("%s is unavailable from %s, but an existing override overlaps; update or remove it to apply the time off.", c.UserName, when)
+		}
+		notices = append(notices, n)
+	}
+
+	return notices, nil
+}
+
+func (m *Mutation) CreateTimeOff(ctx context.Context, input graphql2.CreateTimeOffInput) (*timeoff.TimeOff, error) {
+	t := &timeoff.TimeOff{
+		UserID: permission.UserID(ctx),
+		Start:  input.Start,
+		End:    input.End,
+	}
+	if input.UserID != nil {
+		t.UserID = *input.UserID
+	}
+	if input.Note != nil {
+		t.Note = *input.Note
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		t, err = m.TimeOffStore.CreateTx(ctx, tx, t)
+		return err
+	})
+	return t, err
+}
+
+func (m *Mutation) DeleteTimeOff(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		err := m.TimeOffStore.DeleteTx(ctx, tx, id)
+		if err == sql.ErrNoRows {
+			return validation.NewFieldError("ID", "not found")
+		}
+		return err
+	})
+	return err == nil, err
+}
@@ -135,6 +135,12 @@ func (a *Mutation) UpdateUser(ctx context.Context, input graphql2.UpdateUserInpu
 		if input.StatusUpdateContactMethodID != nil {
 			usr.AlertStatusCMID = *input.StatusUpdateContactMethodID
 		}
+		if input.HandoffNotify != nil {
+			usr.HandoffNotify = *input.HandoffNotify
+		}
+		if input.HandoffNotifyMinutes != nil {
+			usr.HandoffNotifyMinutes = *input.HandoffNotifyMinutes
+		}
 		return a.UserStore.UpdateTx(ctx, tx, usr)
 	})
 	return err == nil, err
@@ -35,6 +35,8 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "General.EnableV1GraphQL", Type: ConfigTypeBoolean, Description: "Enables the deprecated /v1/graphql endpoint (replaced by /api/graphql).", Value: fmt.Sprintf("%t", cfg.General.EnableV1GraphQL)},
 		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
 		{ID: "Maintenance.APIKeyExpireDays", Type: ConfigTypeInteger, Description: "Unused calendar API keys will be disabled after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.APIKeyExpireDays)},
+		{ID: "Alerts.FlapThreshold", Type: ConfigTypeInteger, Description: "Number of open/close transitions of the same dedup key within the flap window that will hold the alert open as flapping (0 means disable flap detection).", Value: fmt.Sprintf("%d", cfg.Alerts.FlapThreshold)},
+		{ID: "Alerts.FlapWindowMinutes", Type: ConfigTypeInteger, Description: "Flap detection window in minutes; a flapping alert is released once it has been stable this long (defaults to 60).", Value: fmt.Sprintf("%d", cfg.Alerts.FlapWindowMinutes)},
 		{ID: "Auth.RefererURLs", Type: ConfigTypeStringList, Description: "Allowed referer URLs for auth and redirects.", Value: strings.Join(cfg.Auth.RefererURLs, "\n")},
 		{ID: "Auth.DisableBasic", Type: ConfigTypeBoolean, Description: "Disallow username/password login.", Value: fmt.Sprintf("%t", cfg.Auth.DisableBasic)},
 		{ID: "GitHub.Enable", Type: ConfigTypeBoolean, Description: "Enable GitHub authentication.", Value: fmt.Sprintf("%t", cfg.GitHub.Enable)},
@@ -54,6 +56,24 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "OIDC.UserInfoEmailPath", Type: ConfigTypeString, Description: "JMESPath expression to find email address in UserInfo. If set, the email claim will be ignored in favor of this. (suggestion: email).", Value: cfg.OIDC.UserInfoEmailPath},
 		{ID: "OIDC.UserInfoEmailVerifiedPath", Type: ConfigTypeString, Description: "JMESPath expression to find email verification state in UserInfo. If set, the email_verified claim will be ignored in favor of this. (suggestion: email_verified).", Value: cfg.OIDC.UserInfoEmailVerifiedPath},
 		{ID: "OIDC.UserInfoNamePath", Type: ConfigTypeString, Description: "JMESPath expression to find full name in UserInfo. If set, the name claim will be ignored in favor of this. (suggestion: name || cn || join(' ', [firstname, lastname]))", Value: cfg.OIDC.UserInfoNamePath},
+		{ID: "OIDC.GroupsPath", Type: ConfigTypeString, Description: "JMESPath expression to find the list of group names in the ID token claims (or UserInfo, if not present in the claims). Required for AdminGroups and RotationGroups. (suggestion: groups)", Value: cfg.OIDC.GroupsPath},
+		{ID: "OIDC.AdminGroups", Type: ConfigTypeStringList, Description: "If set, members of any listed group will be given the admin role, and all others the user role, on every login.", Value: strings.Join(cfg.OIDC.AdminGroups, "\n")},
+		{ID: "OIDC.RotationGroups", Type: ConfigTypeStringList, Description: "List of 'group=rotationID' pairs, members of the group will be added to the rotation, and non-members removed, on every login.", Value: strings.Join(cfg.OIDC.RotationGroups, "\n")},
+		{ID: "LDAP.Enable", Type: ConfigTypeBoolean, Description: "Enable LDAP/Active Directory authentication.", Value: fmt.Sprintf("%t", cfg.LDAP.Enable)},
+		{ID: "LDAP.NewUsers", Type: ConfigTypeBoolean, Description: "Allow new user creation via LDAP authentication.", Value: fmt.Sprintf("%t", cfg.LDAP.NewUsers)},
+		{ID: "LDAP.OverrideName", Type: ConfigTypeString, Description: "Set the name/label on the login page to something other than LDAP.", Value: cfg.LDAP.OverrideName},
+		{ID: "LDAP.URL", Type: ConfigTypeString, Description: "LDAP server URL (e.g. ldaps://ldap.example.com).", Value: cfg.LDAP.URL},
+		{ID: "LDAP.StartTLS", Type: ConfigTypeBoolean, Description: "Upgrade ldap:// connections to TLS using StartTLS.", Value: fmt.Sprintf("%t", cfg.LDAP.StartTLS)},
+		{ID: "LDAP.SkipVerify", Type: ConfigTypeBoolean, Description: "Disables certificate validation for TLS/StartTLS (insecure).", Value: fmt.Sprintf("%t", cfg.LDAP.SkipVerify)},
+		{ID: "LDAP.BindDN", Type: ConfigTypeString, Description: "DN used to search for users. If left blank, an anonymous bind will be used.", Value: cfg.LDAP.BindDN},
+		{ID: "LDAP.BindPassword", Type: ConfigTypeString, Description: "Password for BindDN.", Value: cfg.LDAP.BindPassword, Password: true},
+		{ID: "LDAP.BaseDN", Type: ConfigTypeString, Description: "Base DN to search for users.", Value: cfg.LDAP.BaseDN},
+		{ID: "LDAP.UserSearchFilter", Type: ConfigTypeString, Description: "LDAP filter to find a user, {username} will be replaced with the login name. If left blank, (uid={username}) will be used. (suggestion for AD: (sAMAccountName={username}))", Value: cfg.LDAP.UserSearchFilter},
+		{ID: "LDAP.SubjectAttribute", Type: ConfigTypeString, Description: "Attribute that uniquely and permanently identifies a user. If left blank, the entry DN will be used. (suggestion: entryUUID or objectGUID)", Value: cfg.LDAP.SubjectAttribute},
+		{ID: "LDAP.NameAttribute", Type: ConfigTypeString, Description: "Attribute containing the full name of a user. If left blank, cn will be used.", Value: cfg.LDAP.NameAttribute},
+		{ID: "LDAP.EmailAttribute", Type: ConfigTypeString, Description: "Attribute containing the email address of a user. If left blank, mail will be used.", Value: cfg.LDAP.EmailAttribute},
+		{ID: "LDAP.GroupAttribute", Type: ConfigTypeString, Description: "Attribute on the user entry listing group DNs. If left blank, memberOf will be used.", Value: cfg.LDAP.GroupAttribute},
+		{ID: "LDAP.AllowedGroups", Type: ConfigTypeStringList, Description: "If set, only members of at least one of the listed group DNs will be allowed to authenticate.", Value: strings.Join(cfg.LDAP.AllowedGroups, "\n")},
 		{ID: "Mailgun.Enable", Type: ConfigTypeBoolean, Description: "", Value: fmt.Sprintf("%t", cfg.Mailgun.Enable)},
 		{ID: "Mailgun.APIKey", Type: ConfigTypeString, Description: "", Value: cfg.Mailgun.APIKey, Password: true},
 		{ID: "Mailgun.EmailDomain", Type: ConfigTypeString, Description: "The TO address for all incoming alerts.", Value: cfg.Mailgun.EmailDomain},
@@ -93,9 +113,12 @@ func MapPublicConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "General.DisableCalendarSubscriptions", Type: ConfigTypeBoolean, Description: "If set, disables all active calendar subscriptions as well as the ability to create new calendar subscriptions.", Value: fmt.Sprintf("%t", cfg.General.DisableCalendarSubscriptions)},
 		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
 		{ID: "Maintenance.APIKeyExpireDays", Type: ConfigTypeInteger, Description: "Unused calendar API keys will be disabled after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.APIKeyExpireDays)},
+		{ID: "Alerts.FlapThreshold", Type: ConfigTypeInteger, Description: "Number of open/close transitions of the same dedup key within the flap window that will hold the alert open as flapping (0 means disable flap detection).", Value: fmt.Sprintf("%d", cfg.Alerts.FlapThreshold)},
+		{ID: "Alerts.FlapWindowMinutes", Type: ConfigTypeInteger, Description: "Flap detection window in minutes; a flapping alert is released once it has been stable this long (defaults to 60).", Value: fmt.Sprintf("%d", cfg.Alerts.FlapWindowMinutes)},
 		{ID: "Auth.DisableBasic", Type: ConfigTypeBoolean, Description: "Disallow username/password login.", Value: fmt.Sprintf("%t", cfg.Auth.DisableBasic)},
 		{ID: "GitHub.Enable", Type: ConfigTypeBoolean, Description: "Enable GitHub authentication.", Value: fmt.Sprintf("%t", cfg.GitHub.Enable)},
 		{ID: "OIDC.Enable", Type: ConfigTypeBoolean, Description: "Enable OpenID Connect authentication.", Value: fmt.Sprintf("%t", cfg.OIDC.Enable)},
+		{ID: "LDAP.Enable", Type: ConfigTypeBoolean, Description: "Enable LDAP/Active Directory authentication.", Value: fmt.Sprintf("%t", cfg.LDAP.Enable)},
 		{ID: "Mailgun.Enable", Type: ConfigTypeBoolean, Description: "", Value: fmt.Sprintf("%t", cfg.Mailgun.Enable)},
 		{ID: "Slack.Enable", Type: ConfigTypeBoolean, Description: "", Value: fmt.Sprintf("%t", cfg.Slack.Enable)},
 		{ID: "Twilio.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of Voice and SMS messages through the Twilio notification provider.", Value: fmt.Sprintf("%t", cfg.Twilio.Enable)},
@@ -187,6 +210,18 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 				return cfg, err
 			}
 			cfg.Maintenance.APIKeyExpireDays = val
+		case "Alerts.FlapThreshold":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Alerts.FlapThreshold = val
+		case "Alerts.FlapWindowMinutes":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Alerts.FlapWindowMinutes = val
 		case "Auth.RefererURLs":
 			cfg.Auth.RefererURLs = parseStringList(v.Value)
 		case "Auth.DisableBasic":
@@ -245,6 +280,58 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.OIDC.UserInfoEmailVerifiedPath = v.Value
 		case "OIDC.UserInfoNamePath":
 			cfg.OIDC.UserInfoNamePath = v.Value
+		case "OIDC.GroupsPath":
+			cfg.OIDC.GroupsPath = v.Value
+		case "OIDC.AdminGroups":
+			cfg.OIDC.AdminGroups = parseStringList(v.Value)
+		case "OIDC.RotationGroups":
+			cfg.OIDC.RotationGroups = parseStringList(v.Value)
+		case "LDAP.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.LDAP.Enable = val
+		case "LDAP.NewUsers":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.LDAP.NewUsers = val
+		case "LDAP.OverrideName":
+			cfg.LDAP.OverrideName = v.Value
+		case "LDAP.URL":
+			cfg.LDAP.URL = v.Value
+		case "LDAP.StartTLS":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.LDAP.StartTLS = val
+		case "LDAP.SkipVerify":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.LDAP.SkipVerify = val
+		case "LDAP.BindDN":
+			cfg.LDAP.BindDN = v.Value
+		case "LDAP.BindPassword":
+			cfg.LDAP.BindPassword = v.Value
+		case "LDAP.BaseDN":
+			cfg.LDAP.BaseDN = v.Value
+		case "LDAP.UserSearchFilter":
+			cfg.LDAP.UserSearchFilter = v.Value
+		case "LDAP.SubjectAttribute":
+			cfg.LDAP.SubjectAttribute = v.Value
+		case "LDAP.NameAttribute":
+			cfg.LDAP.NameAttribute = v.Value
+		case "LDAP.EmailAttribute":
+			cfg.LDAP.EmailAttribute = v.Value
+		case "LDAP.GroupAttribute":
+			cfg.LDAP.GroupAttribute = v.Value
+		case "LDAP.AllowedGroups":
+			cfg.LDAP.AllowedGroups = parseStringList(v.Value)
 		case "Mailgun.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -11,7 +11,10 @@ import (
 	"github.com/target/goalert/alert"
 	alertlog "github.com/target/goalert/alert/log"
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/audit"
+	"github.com/target/goalert/calendarsubscription"
 	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/integrationkey"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
 	"github.com/target/goalert/notification/slack"
@@ -19,7 +22,11 @@ import (
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/schedule/shiftexport"
+	"github.com/target/goalert/schedule/shiftimport"
 	"github.com/target/goalert/service"
+	"github.com/target/goalert/shiftrequest"
+	"github.com/target/goalert/syntheticcheck"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/util/timeutil"
@@ -54,6 +61,21 @@ type AlertSearchOptions struct {
 	NotCreatedBefore  *time.Time       `json:"notCreatedBefore"`
 }
 
+type AuditLogConnection struct {
+	Nodes    []audit.Entry `json:"nodes"`
+	PageInfo *PageInfo     `json:"pageInfo"`
+}
+
+type AuditLogSearchOptions struct {
+	First        *int                  `json:"first"`
+	After        *string               `json:"after"`
+	Start        *time.Time            `json:"start"`
+	End          *time.Time            `json:"end"`
+	ActorUserIDs []string              `json:"actorUserIDs"`
+	Actions      []string              `json:"actions"`
+	Target       *assignment.RawTarget `json:"target"`
+}
+
 type AuthSubjectConnection struct {
 	Nodes    []user.AuthSubject `json:"nodes"`
 	PageInfo *PageInfo          `json:"pageInfo"`
@@ -99,11 +121,14 @@ type CreateAlertInput struct {
 }
 
 type CreateEscalationPolicyInput struct {
-	Name        string                            `json:"name"`
-	Description *string                           `json:"description"`
-	Repeat      *int                              `json:"repeat"`
-	Favorite    *bool                             `json:"favorite"`
-	Steps       []CreateEscalationPolicyStepInput `json:"steps"`
+	Name               string                            `json:"name"`
+	Description        *string                           `json:"description"`
+	Repeat             *int                              `json:"repeat"`
+	AckTimeoutMinutes  *int                              `json:"ackTimeoutMinutes"`
+	RepeatDelayMinutes *int                              `json:"repeatDelayMinutes"`
+	FallbackTarget     *assignment.RawTarget             `json:"fallbackTarget"`
+	Favorite           *bool                             `json:"favorite"`
+	Steps              []CreateEscalationPolicyStepInput `json:"steps"`
 }
 
 type CreateEscalationPolicyStepInput struct {
@@ -115,15 +140,32 @@ type CreateEscalationPolicyStepInput struct {
 }
 
 type CreateHeartbeatMonitorInput struct {
-	ServiceID      string `json:"serviceID"`
-	Name           string `json:"name"`
-	TimeoutMinutes int    `json:"timeoutMinutes"`
+	ServiceID          string  `json:"serviceID"`
+	Name               string  `json:"name"`
+	TimeoutMinutes     *int    `json:"timeoutMinutes"`
+	CronSchedule       *string `json:"cronSchedule"`
+	TimeZone           *string `json:"timeZone"`
+	GracePeriodMinutes *int    `json:"gracePeriodMinutes"`
+	AlertAfterMisses   *int    `json:"alertAfterMisses"`
+}
+
+type CreateHolidayCalendarInput struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
 }
 
 type CreateIntegrationKeyInput struct {
-	ServiceID *string            `json:"serviceID"`
-	Type      IntegrationKeyType `json:"type"`
-	Name      string             `json:"name"`
+	ServiceID   *string                     `json:"serviceID"`
+	Type        IntegrationKeyType          `json:"type"`
+	Name        string                      `json:"name"`
+	JSONMapping *integrationkey.JSONMapping `json:"jsonMapping"`
+}
+
+type CreateOutgoingWebhookInput struct {
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Disabled   *bool    `json:"disabled"`
 }
 
 type CreateRotationInput struct {
@@ -138,12 +180,16 @@ type CreateRotationInput struct {
 }
 
 type CreateScheduleInput struct {
-	Name             string                    `json:"name"`
-	Description      *string                   `json:"description"`
-	TimeZone         string                    `json:"timeZone"`
-	Favorite         *bool                     `json:"favorite"`
-	Targets          []ScheduleTargetInput     `json:"targets"`
-	NewUserOverrides []CreateUserOverrideInput `json:"newUserOverrides"`
+	Name              string                    `json:"name"`
+	Description       *string                   `json:"description"`
+	TimeZone          string                    `json:"timeZone"`
+	Favorite          *bool                     `json:"favorite"`
+	GapNotifyDays     *int                      `json:"gapNotifyDays"`
+	GapNotifyUserID   *string                   `json:"gapNotifyUserID"`
+	TimeOffStrategy   *schedule.TimeOffStrategy `json:"timeOffStrategy"`
+	HolidayCalendarID *string                   `json:"holidayCalendarID"`
+	Targets           []ScheduleTargetInput     `json:"targets"`
+	NewUserOverrides  []CreateUserOverrideInput `json:"newUserOverrides"`
 }
 
 type CreateServiceInput struct {
@@ -151,17 +197,52 @@ type CreateServiceInput struct {
 	Description          *string                       `json:"description"`
 	Favorite             *bool                         `json:"favorite"`
 	EscalationPolicyID   *string                       `json:"escalationPolicyID"`
+	AutoResolveMinutes   *int                          `json:"autoResolveMinutes"`
 	NewEscalationPolicy  *CreateEscalationPolicyInput  `json:"newEscalationPolicy"`
 	NewIntegrationKeys   []CreateIntegrationKeyInput   `json:"newIntegrationKeys"`
 	Labels               []SetLabelInput               `json:"labels"`
 	NewHeartbeatMonitors []CreateHeartbeatMonitorInput `json:"newHeartbeatMonitors"`
 }
 
+type CreateShiftRequestInput struct {
+	ScheduleID  string     `json:"scheduleID"`
+	RequesterID *string    `json:"requesterID"`
+	UserID      string     `json:"userID"`
+	Start       time.Time  `json:"start"`
+	End         time.Time  `json:"end"`
+	SwapStart   *time.Time `json:"swapStart"`
+	SwapEnd     *time.Time `json:"swapEnd"`
+	Note        *string    `json:"note"`
+}
+
+type CreateSyntheticCheckInput struct {
+	ServiceID       string              `json:"serviceID"`
+	Name            string              `json:"name"`
+	Type            syntheticcheck.Type `json:"type"`
+	Target          string              `json:"target"`
+	IntervalMinutes *int                `json:"intervalMinutes"`
+	ExpectedStatus  *int                `json:"expectedStatus"`
+	BodyContains    *string             `json:"bodyContains"`
+	TLSExpiryDays   *int                `json:"tlsExpiryDays"`
+	Disabled        *bool               `json:"disabled"`
+}
+
+type CreateTimeOffInput struct {
+	UserID *string   `json:"userID"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Note   *string   `json:"note"`
+}
+
 type CreateUserCalendarSubscriptionInput struct {
-	Name            string `json:"name"`
-	ReminderMinutes []int  `json:"reminderMinutes"`
-	ScheduleID      string `json:"scheduleID"`
-	Disabled        *bool  `json:"disabled"`
+	Name            string                      `json:"name"`
+	ReminderMinutes []int                       `json:"reminderMinutes"`
+	Scope           *calendarsubscription.Scope `json:"scope"`
+	ScheduleID      *string                     `json:"scheduleID"`
+	ServiceID       *string                     `json:"serviceID"`
+	Disabled        *bool                       `json:"disabled"`
+	LookaheadDays   *int                        `json:"lookaheadDays"`
+	IncludeDetails  *bool                       `json:"includeDetails"`
 }
 
 type CreateUserContactMethodInput struct {
@@ -209,6 +290,11 @@ type DebugSendSMSInput struct {
 	Body string `json:"body"`
 }
 
+type DeleteHolidaysInput struct {
+	CalendarID string   `json:"calendarID"`
+	Dates      []string `json:"dates"`
+}
+
 type EscalationPolicyConnection struct {
 	Nodes    []escalation.Policy `json:"nodes"`
 	PageInfo *PageInfo           `json:"pageInfo"`
@@ -223,6 +309,33 @@ type EscalationPolicySearchOptions struct {
 	FavoritesFirst *bool    `json:"favoritesFirst"`
 }
 
+type ExportScheduleShiftsInput struct {
+	ScheduleIDs []string            `json:"scheduleIDs"`
+	Start       time.Time           `json:"start"`
+	End         time.Time           `json:"end"`
+	Format      *shiftexport.Format `json:"format"`
+}
+
+type HolidayInput struct {
+	Date string  `json:"date"`
+	Name *string `json:"name"`
+}
+
+type ImportHolidaysInput struct {
+	CalendarID string `json:"calendarID"`
+	Data       string `json:"data"`
+	Replace    *bool  `json:"replace"`
+}
+
+type ImportScheduleInput struct {
+	ScheduleID string             `json:"scheduleID"`
+	Format     shiftimport.Format `json:"format"`
+	Mode       *shiftimport.Mode  `json:"mode"`
+	Data       string             `json:"data"`
+	TimeZone   *string            `json:"timeZone"`
+	DryRun     *bool              `json:"dryRun"`
+}
+
 type LabelConnection struct {
 	Nodes    []label.Label `json:"nodes"`
 	PageInfo *PageInfo     `json:"pageInfo"`
@@ -256,6 +369,19 @@ type NotificationState struct {
 	Status  *NotificationStatus `json:"status"`
 }
 
+type OnCallHoursReportInput struct {
+	ScheduleIDs       []string        `json:"scheduleIDs"`
+	UserIDs           []string        `json:"userIDs"`
+	Start             time.Time       `json:"start"`
+	End               time.Time       `json:"end"`
+	TimeZone          *string         `json:"timeZone"`
+	Holidays          []string        `json:"holidays"`
+	HolidayCalendarID *string         `json:"holidayCalendarID"`
+	BusinessStart     *timeutil.Clock `json:"businessStart"`
+	BusinessEnd       *timeutil.Clock `json:"businessEnd"`
+	IncludePages      *bool           `json:"includePages"`
+}
+
 type PageInfo struct {
 	EndCursor   *string `json:"endCursor"`
 	HasNextPage bool    `json:"hasNextPage"`
@@ -270,6 +396,11 @@ type PhoneNumberInfo struct {
 	Error       string `json:"error"`
 }
 
+type RespondShiftRequestInput struct {
+	ID     string `json:"id"`
+	Accept bool   `json:"accept"`
+}
+
 type RotationConnection struct {
 	Nodes    []rotation.Rotation `json:"nodes"`
 	PageInfo *PageInfo           `json:"pageInfo"`
@@ -294,6 +425,7 @@ type ScheduleRuleInput struct {
 	Start         *timeutil.Clock         `json:"start"`
 	End           *timeutil.Clock         `json:"end"`
 	WeekdayFilter *timeutil.WeekdayFilter `json:"weekdayFilter"`
+	HolidayMode   *rule.HolidayMode       `json:"holidayMode"`
 }
 
 type ScheduleSearchOptions struct {
@@ -305,6 +437,12 @@ type ScheduleSearchOptions struct {
 	FavoritesFirst *bool    `json:"favoritesFirst"`
 }
 
+type ScheduleShiftExport struct {
+	Format shiftexport.Format  `json:"format"`
+	Data   string              `json:"data"`
+	Shifts []shiftexport.Shift `json:"shifts"`
+}
+
 type ScheduleTarget struct {
 	ScheduleID string                `json:"scheduleID"`
 	Target     *assignment.RawTarget `json:"target"`
@@ -336,17 +474,34 @@ type ServiceSearchOptions struct {
 	FavoritesFirst *bool    `json:"favoritesFirst"`
 }
 
+type SetEscalationPolicyFallbackTargetInput struct {
+	EscalationPolicyID string                `json:"escalationPolicyID"`
+	Target             *assignment.RawTarget `json:"target"`
+}
+
 type SetFavoriteInput struct {
 	Target   *assignment.RawTarget `json:"target"`
 	Favorite bool                  `json:"favorite"`
 }
 
+type SetHolidaysInput struct {
+	CalendarID string         `json:"calendarID"`
+	Holidays   []HolidayInput `json:"holidays"`
+	Replace    *bool          `json:"replace"`
+}
+
 type SetLabelInput struct {
 	Target *assignment.RawTarget `json:"target"`
 	Key    string                `json:"key"`
 	Value  string                `json:"value"`
 }
 
+type SetRotationParticipantUnavailableInput struct {
+	RotationID string     `json:"rotationID"`
+	UserIndex  int        `json:"userIndex"`
+	Until      *time.Time `json:"until"`
+}
+
 type SetTemporaryScheduleInput struct {
 	ScheduleID string                `json:"scheduleID"`
 	Start      time.Time             `json:"start"`
@@ -354,6 +509,12 @@ type SetTemporaryScheduleInput struct {
 	Shifts     []schedule.FixedShift `json:"shifts"`
 }
 
+type ShiftRequestSearchOptions struct {
+	ScheduleID *string               `json:"scheduleID"`
+	UserID     *string               `json:"userID"`
+	Status     []shiftrequest.Status `json:"status"`
+}
+
 type SlackChannelConnection struct {
 	Nodes    []slack.Channel `json:"nodes"`
 	PageInfo *PageInfo       `json:"pageInfo"`
@@ -409,11 +570,13 @@ type UpdateAlertsInput struct {
 }
 
 type UpdateEscalationPolicyInput struct {
-	ID          string   `json:"id"`
-	Name        *string  `json:"name"`
-	Description *string  `json:"description"`
-	Repeat      *int     `json:"repeat"`
-	StepIDs     []string `json:"stepIDs"`
+	ID                 string   `json:"id"`
+	Name               *string  `json:"name"`
+	Description        *string  `json:"description"`
+	Repeat             *int     `json:"repeat"`
+	AckTimeoutMinutes  *int     `json:"ackTimeoutMinutes"`
+	RepeatDelayMinutes *int     `json:"repeatDelayMinutes"`
+	StepIDs            []string `json:"stepIDs"`
 }
 
 type UpdateEscalationPolicyStepInput struct {
@@ -423,9 +586,33 @@ type UpdateEscalationPolicyStepInput struct {
 }
 
 type UpdateHeartbeatMonitorInput struct {
-	ID             string  `json:"id"`
-	Name           *string `json:"name"`
-	TimeoutMinutes *int    `json:"timeoutMinutes"`
+	ID                 string  `json:"id"`
+	Name               *string `json:"name"`
+	TimeoutMinutes     *int    `json:"timeoutMinutes"`
+	CronSchedule       *string `json:"cronSchedule"`
+	TimeZone           *string `json:"timeZone"`
+	GracePeriodMinutes *int    `json:"gracePeriodMinutes"`
+	AlertAfterMisses   *int    `json:"alertAfterMisses"`
+}
+
+type UpdateHolidayCalendarInput struct {
+	ID          string  `json:"id"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+type UpdateIntegrationKeyInput struct {
+	ID          string                      `json:"id"`
+	Name        *string                     `json:"name"`
+	JSONMapping *integrationkey.JSONMapping `json:"jsonMapping"`
+}
+
+type UpdateOutgoingWebhookInput struct {
+	ID         string   `json:"id"`
+	Name       *string  `json:"name"`
+	URL        *string  `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Disabled   *bool    `json:"disabled"`
 }
 
 type UpdateRotationInput struct {
@@ -441,10 +628,14 @@ type UpdateRotationInput struct {
 }
 
 type UpdateScheduleInput struct {
-	ID          string  `json:"id"`
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
-	TimeZone    *string `json:"timeZone"`
+	ID                string                    `json:"id"`
+	Name              *string                   `json:"name"`
+	Description       *string                   `json:"description"`
+	TimeZone          *string                   `json:"timeZone"`
+	GapNotifyDays     *int                      `json:"gapNotifyDays"`
+	GapNotifyUserID   *string                   `json:"gapNotifyUserID"`
+	TimeOffStrategy   *schedule.TimeOffStrategy `json:"timeOffStrategy"`
+	HolidayCalendarID *string                   `json:"holidayCalendarID"`
 }
 
 type UpdateServiceInput struct {
@@ -452,6 +643,19 @@ type UpdateServiceInput struct {
 	Name               *string `json:"name"`
 	Description        *string `json:"description"`
 	EscalationPolicyID *string `json:"escalationPolicyID"`
+	AutoResolveMinutes *int    `json:"autoResolveMinutes"`
+}
+
+type UpdateSyntheticCheckInput struct {
+	ID              string               `json:"id"`
+	Name            *string              `json:"name"`
+	Type            *syntheticcheck.Type `json:"type"`
+	Target          *string              `json:"target"`
+	IntervalMinutes *int                 `json:"intervalMinutes"`
+	ExpectedStatus  *int                 `json:"expectedStatus"`
+	BodyContains    *string              `json:"bodyContains"`
+	TLSExpiryDays   *int                 `json:"tlsExpiryDays"`
+	Disabled        *bool                `json:"disabled"`
 }
 
 type UpdateUserCalendarSubscriptionInput struct {
@@ -459,6 +663,8 @@ type UpdateUserCalendarSubscriptionInput struct {
 	Name            *string `json:"name"`
 	ReminderMinutes []int   `json:"reminderMinutes"`
 	Disabled        *bool   `json:"disabled"`
+	LookaheadDays   *int    `json:"lookaheadDays"`
+	IncludeDetails  *bool   `json:"includeDetails"`
 }
 
 type UpdateUserContactMethodInput struct {
@@ -473,6 +679,8 @@ type UpdateUserInput struct {
 	Email                       *string   `json:"email"`
 	Role                        *UserRole `json:"role"`
 	StatusUpdateContactMethodID *string   `json:"statusUpdateContactMethodID"`
+	HandoffNotify               *bool     `json:"handoffNotify"`
+	HandoffNotifyMinutes        *int      `json:"handoffNotifyMinutes"`
 }
 
 type UpdateUserOverrideInput struct {
@@ -658,6 +866,10 @@ const (
 	IntegrationKeyTypeSite24x7               IntegrationKeyType = "site24x7"
 	IntegrationKeyTypePrometheusAlertmanager IntegrationKeyType = "prometheusAlertmanager"
 	IntegrationKeyTypeEmail                  IntegrationKeyType = "email"
+	IntegrationKeyTypeGenericJSON            IntegrationKeyType = "genericJSON"
+	IntegrationKeyTypeDatadog                IntegrationKeyType = "datadog"
+	IntegrationKeyTypeNewRelic               IntegrationKeyType = "newRelic"
+	IntegrationKeyTypeAwsSns                 IntegrationKeyType = "awsSNS"
 )
 
 var AllIntegrationKeyType = []IntegrationKeyType{
@@ -666,11 +878,15 @@ var AllIntegrationKeyType = []IntegrationKeyType{
 	IntegrationKeyTypeSite24x7,
 	IntegrationKeyTypePrometheusAlertmanager,
 	IntegrationKeyTypeEmail,
+	IntegrationKeyTypeGenericJSON,
+	IntegrationKeyTypeDatadog,
+	IntegrationKeyTypeNewRelic,
+	IntegrationKeyTypeAwsSns,
 }
 
 func (e IntegrationKeyType) IsValid() bool {
 	switch e {
-	case IntegrationKeyTypeGeneric, IntegrationKeyTypeGrafana, IntegrationKeyTypeSite24x7, IntegrationKeyTypePrometheusAlertmanager, IntegrationKeyTypeEmail:
+	case IntegrationKeyTypeGeneric, IntegrationKeyTypeGrafana, IntegrationKeyTypeSite24x7, IntegrationKeyTypePrometheusAlertmanager, IntegrationKeyTypeEmail, IntegrationKeyTypeGenericJSON, IntegrationKeyTypeDatadog, IntegrationKeyTypeNewRelic, IntegrationKeyTypeAwsSns:
 		return true
 	}
 	return false
@@ -1,22 +1,53 @@
 package heartbeat
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/jackc/pgtype"
+	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
-// A Monitor will generate an alert if it does not receive a heartbeat within the configured TimeoutMinutes.
+// MaxMessageLength is the maximum length of a message reported with a heartbeat.
+const MaxMessageLength = 1024
+
+// A Monitor will generate an alert if it does not receive a heartbeat within the configured TimeoutMinutes
+// (or by the next run of its CronSchedule), or if a heartbeat reports a failure.
 type Monitor struct {
 	ID        string        `json:"id,omitempty"`
 	Name      string        `json:"name,omitempty"`
 	ServiceID string        `json:"service_id,omitempty"`
 	Timeout   time.Duration `json:"timeout,omitempty"`
 
+	// CronSchedule, if set, is used instead of Timeout to determine when the next
+	// heartbeat is expected. It is evaluated in TimeZone.
+	CronSchedule string         `json:"cron_schedule,omitempty"`
+	TimeZone     *time.Location `json:"time_zone,omitempty"`
+
+	// GracePeriod is additional time allowed after the last missed interval before alerting.
+	// For cron-based monitors, it is the allowed lateness after a scheduled run.
+	GracePeriod time.Duration `json:"grace_period,omitempty"`
+
+	// AlertAfterMisses is the number of consecutive intervals that must be missed before alerting.
+	AlertAfterMisses int `json:"alert_after_misses,omitempty"`
+
 	lastState     State
 	lastHeartbeat time.Time
+	lastFailure   time.Time
+	lastMessage   string
+}
+
+// A Report is the result sent with a heartbeat.
+type Report struct {
+	// Failed indicates the monitored process reported a failure, and an alert should be opened immediately.
+	Failed bool
+
+	// Message is an optional description of the current status.
+	Message string
 }
 
 // LastState returns the last known state.
@@ -25,34 +56,107 @@ func (m Monitor) LastState() State { return m.lastState }
 // LastHeartbeat returns the timestamp of the last successful heartbeat.
 func (m Monitor) LastHeartbeat() time.Time { return m.lastHeartbeat }
 
+// LastFailure returns the timestamp of the last heartbeat that reported a failure.
+func (m Monitor) LastFailure() time.Time { return m.lastFailure }
+
+// LastMessage returns the message sent with the last heartbeat.
+func (m Monitor) LastMessage() string { return m.lastMessage }
+
 // Normalize performs validation and returns a new copy.
 func (m Monitor) Normalize() (*Monitor, error) {
+	if m.AlertAfterMisses == 0 {
+		m.AlertAfterMisses = 1
+	}
 	err := validate.Many(
 		validate.UUID("ServiceID", m.ServiceID),
 		validate.IDName("Name", m.Name),
-		validate.Duration("Timeout", m.Timeout, 5*time.Minute, 9000*time.Minute),
+		validate.Duration("GracePeriod", m.GracePeriod, 0, 1440*time.Minute),
+		validate.Range("AlertAfterMisses", m.AlertAfterMisses, 1, 100),
 	)
+	if m.CronSchedule == "" {
+		m.TimeZone = nil
+		err = validate.Many(err, validate.Duration("Timeout", m.Timeout, 5*time.Minute, 9000*time.Minute))
+	} else {
+		m.Timeout = 0
+		err = validate.Many(err, validateCron(m.CronSchedule))
+		if m.TimeZone == nil {
+			err = validate.Many(err, validation.NewFieldError("TimeZone", "must be specified"))
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	m.Timeout = m.Timeout.Truncate(time.Minute)
+	m.GracePeriod = m.GracePeriod.Truncate(time.Minute)
 
 	return &m, nil
 }
 
+func validateCron(expr string) error {
+	c, err := timeutil.ParseCron(expr)
+	if err != nil {
+		return validation.NewFieldError("CronSchedule", err.Error())
+	}
+	if c.Next(time.Now()).IsZero() {
+		return validation.NewFieldError("CronSchedule", "never matches")
+	}
+	return nil
+}
+
+// CronDeadline returns the time by which a heartbeat is expected for a cron-based monitor, given the
+// time of the last heartbeat. It is the AlertAfterMisses-th scheduled run after lastHeartbeat, plus the
+// GracePeriod. The zero time is returned if the schedule will never run.
+func (m Monitor) CronDeadline(lastHeartbeat time.Time) (time.Time, error) {
+	c, err := timeutil.ParseCron(m.CronSchedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc := m.TimeZone
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	t := lastHeartbeat.In(loc)
+	misses := m.AlertAfterMisses
+	if misses < 1 {
+		misses = 1
+	}
+	for i := 0; i < misses; i++ {
+		t = c.Next(t)
+		if t.IsZero() {
+			return t, nil
+		}
+	}
+
+	return t.Add(m.GracePeriod), nil
+}
+
 func (m *Monitor) scanFrom(scanFn func(...interface{}) error) error {
-	var t sqlutil.NullTime
+	var t, failed sqlutil.NullTime
 
-	var timeout pgtype.Interval
-	err := scanFn(&m.ID, &m.Name, &m.ServiceID, &timeout, &m.lastState, &t)
+	var timeout, grace pgtype.Interval
+	var cron, tz sql.NullString
+	err := scanFn(&m.ID, &m.Name, &m.ServiceID, &timeout, &cron, &tz, &grace, &m.AlertAfterMisses, &m.lastState, &t, &failed, &m.lastMessage)
 	if err != nil {
 		return err
 	}
+	m.CronSchedule = cron.String
+	if tz.Valid {
+		m.TimeZone, err = util.LoadLocation(tz.String)
+		if err != nil {
+			return err
+		}
+	}
 	err = timeout.AssignTo(&m.Timeout)
 	if err != nil {
 		return err
 	}
+	err = grace.AssignTo(&m.GracePeriod)
+	if err != nil {
+		return err
+	}
 	m.lastHeartbeat = t.Time
+	m.lastFailure = failed.Time
 	return nil
 }
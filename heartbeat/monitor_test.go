@@ -0,0 +1,101 @@
+package heartbeat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonitor_Normalize(t *testing.T) {
+	valid := Monitor{
+		Name:      "Test",
+		ServiceID: "00000000-0000-0000-0000-000000000001",
+		Timeout:   5 * time.Minute,
+	}
+
+	m, err := valid.Normalize()
+	if err != nil {
+		t.Fatalf("err = %v; want nil", err)
+	}
+	if m.AlertAfterMisses != 1 {
+		t.Errorf("AlertAfterMisses = %d; want 1", m.AlertAfterMisses)
+	}
+
+	check := func(name string, m Monitor) {
+		t.Run(name, func(t *testing.T) {
+			_, err := m.Normalize()
+			if err == nil {
+				t.Error("err = nil; want validation error")
+			}
+		})
+	}
+
+	m2 := valid
+	m2.GracePeriod = -time.Minute
+	check("negative grace", m2)
+
+	m2 = valid
+	m2.GracePeriod = 25 * time.Hour
+	check("long grace", m2)
+
+	m2 = valid
+	m2.AlertAfterMisses = 101
+	check("misses", m2)
+}
+
+func TestMonitor_NormalizeCron(t *testing.T) {
+	m := Monitor{
+		Name:         "Test",
+		ServiceID:    "00000000-0000-0000-0000-000000000001",
+		Timeout:      5 * time.Minute,
+		CronSchedule: "0 2 * * 1-5",
+	}
+	_, err := m.Normalize()
+	if err == nil {
+		t.Error("err = nil; want error for missing time zone")
+	}
+
+	m.TimeZone = time.UTC
+	n, err := m.Normalize()
+	if err != nil {
+		t.Fatalf("err = %v; want nil", err)
+	}
+	if n.Timeout != 0 {
+		t.Errorf("Timeout = %s; want 0", n.Timeout)
+	}
+
+	m.CronSchedule = "0 0 30 2 *"
+	_, err = m.Normalize()
+	if err == nil {
+		t.Error("err = nil; want error for schedule that never runs")
+	}
+}
+
+func TestMonitor_CronDeadline(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Monitor{
+		CronSchedule:     "0 2 * * 1-5",
+		TimeZone:         loc,
+		GracePeriod:      30 * time.Minute,
+		AlertAfterMisses: 1,
+	}
+
+	// Friday check-in is next expected Monday
+	last := time.Date(2026, 10, 16, 2, 1, 0, 0, loc)
+	check := func(exp time.Time) {
+		t.Helper()
+		d, err := m.CronDeadline(last)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !d.Equal(exp) {
+			t.Errorf("deadline = %s; want %s", d, exp)
+		}
+	}
+	check(time.Date(2026, 10, 19, 2, 30, 0, 0, loc))
+
+	m.AlertAfterMisses = 2
+	check(time.Date(2026, 10, 20, 2, 30, 0, 0, loc))
+}
@@ -15,8 +15,8 @@ import (
 
 // Store manages heartbeat checks and recording heartbeats.
 type Store interface {
-	// Heartbeat records a heartbeat for the given heartbeat ID.
-	Heartbeat(context.Context, string) error
+	// Heartbeat records a heartbeat, and the reported result, for the given heartbeat ID.
+	Heartbeat(context.Context, string, Report) error
 
 	// CreateTx creates a new heartbeat check within the transaction.
 	CreateTx(context.Context, *sql.Tx, *Monitor) (*Monitor, error)
@@ -60,24 +60,24 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 
 		create: p.P(`
 			insert into heartbeat_monitors (
-				id, name, service_id, heartbeat_interval
-			) values ($1, $2, $3, $4)
+				id, name, service_id, heartbeat_interval, cron_schedule, time_zone, grace_period, alert_after_misses
+			) values ($1, $2, $3, $4, $5, $6, $7, $8)
 		`),
 		findAll: p.P(`
 			select
-				id, name, service_id, heartbeat_interval, last_state, last_heartbeat
+				id, name, service_id, heartbeat_interval, cron_schedule, time_zone, grace_period, alert_after_misses, last_state, last_heartbeat, last_failure, last_message
 			from heartbeat_monitors
 			where service_id = $1
 		`),
 		findMany: p.P(`
 			select
-				id, name, service_id, heartbeat_interval, last_state, last_heartbeat
+				id, name, service_id, heartbeat_interval, cron_schedule, time_zone, grace_period, alert_after_misses, last_state, last_heartbeat, last_failure, last_message
 			from heartbeat_monitors
 			where id = any($1)
 		`),
 		findOneUpd: p.P(`
 			select
-				id, name, service_id, heartbeat_interval, last_state, last_heartbeat
+				id, name, service_id, heartbeat_interval, cron_schedule, time_zone, grace_period, alert_after_misses, last_state, last_heartbeat, last_failure, last_message
 			from heartbeat_monitors
 			where id = $1
 			for update
@@ -90,14 +90,23 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 			update heartbeat_monitors
 			set
 				name = $2,
-				heartbeat_interval = $3
+				heartbeat_interval = $3,
+				cron_schedule = $4,
+				time_zone = $5,
+				grace_period = $6,
+				alert_after_misses = $7,
+				deadline_from = null
 			where id = $1
 		`),
 		getSvcID: p.P(`select service_id from heartbeat_monitors where id = $1`),
 
 		heartbeat: p.P(`
 			update heartbeat_monitors
-			set last_heartbeat = now()
+			set
+				last_heartbeat = case when $2 then last_heartbeat else now() end,
+				last_failure = case when $2 then now() else last_failure end,
+				last_report_failed = $2,
+				last_message = $3
 			where id = $1
 		`),
 	}, p.Err
@@ -114,21 +123,28 @@ func (db *DB) CreateTx(ctx context.Context, tx *sql.Tx, m *Monitor) (*Monitor, e
 	}
 	n.ID = uuid.NewV4().String()
 	n.lastState = StateInactive
-	var timeout pgtype.Interval
+	var timeout, grace pgtype.Interval
 	err = timeout.Set(n.Timeout)
 	if err != nil {
 		return nil, err
 	}
-	_, err = tx.StmtContext(ctx, db.create).ExecContext(ctx, n.ID, n.Name, n.ServiceID, &timeout)
+	err = grace.Set(n.GracePeriod)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.StmtContext(ctx, db.create).ExecContext(ctx, n.ID, n.Name, n.ServiceID, &timeout, n.cronArg(), n.tzArg(), &grace, n.AlertAfterMisses)
 	return n, err
 }
-func (db *DB) Heartbeat(ctx context.Context, id string) error {
-	err := validate.UUID("MonitorID", id)
+func (db *DB) Heartbeat(ctx context.Context, id string, r Report) error {
+	err := validate.Many(
+		validate.UUID("MonitorID", id),
+		validate.Text("Message", r.Message, 0, MaxMessageLength),
+	)
 	if err != nil {
 		return err
 	}
 
-	_, err = db.heartbeat.ExecContext(ctx, id)
+	_, err = db.heartbeat.ExecContext(ctx, id, r.Failed, r.Message)
 	return err
 }
 func (db *DB) DeleteTx(ctx context.Context, tx *sql.Tx, ids ...string) error {
@@ -166,12 +182,16 @@ func (db *DB) UpdateTx(ctx context.Context, tx *sql.Tx, m *Monitor) error {
 	if tx != nil {
 		stmt = tx.StmtContext(ctx, stmt)
 	}
-	var timeout pgtype.Interval
+	var timeout, grace pgtype.Interval
 	err = timeout.Set(n.Timeout)
 	if err != nil {
 		return err
 	}
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, &timeout)
+	err = grace.Set(n.GracePeriod)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, &timeout, n.cronArg(), n.tzArg(), &grace, n.AlertAfterMisses)
 	return err
 }
 
@@ -254,3 +274,14 @@ func (db *DB) FindAllByService(ctx context.Context, serviceID string) ([]Monitor
 
 	return monitors, nil
 }
+
+func (m Monitor) cronArg() sql.NullString {
+	return sql.NullString{String: m.CronSchedule, Valid: m.CronSchedule != ""}
+}
+
+func (m Monitor) tzArg() sql.NullString {
+	if m.TimeZone == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: m.TimeZone.String(), Valid: true}
+}
@@ -0,0 +1,80 @@
+package holiday
+
+import (
+	"time"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// DateLayout is the format used for holiday dates.
+const DateLayout = "2006-01-02"
+
+// MaxHolidays is the maximum number of holidays that can be set on a calendar at once.
+const MaxHolidays = 1000
+
+// A Calendar is a named set of holidays that schedules can reference. Schedule rules
+// can then be suppressed (or activated) for the entirety of each holiday.
+type Calendar struct {
+	ID          string
+	Name        string
+	Description string
+}
+
+// Normalize will validate fields and return a normalized copy.
+func (c Calendar) Normalize() (*Calendar, error) {
+	err := validate.Many(
+		validate.IDName("Name", c.Name),
+		validate.Text("Description", c.Description, 0, 255),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// A Holiday is a single day on a calendar. It covers the whole day in the time zone
+// of the schedule referencing the calendar.
+type Holiday struct {
+	CalendarID string
+
+	// Date is the day of the holiday in YYYY-MM-DD format.
+	Date string
+	Name string
+}
+
+// Normalize will validate fields and return a normalized copy.
+func (h Holiday) Normalize() (*Holiday, error) {
+	err := validate.Many(
+		validate.UUID("CalendarID", h.CalendarID),
+		validate.Text("Name", h.Name, 0, 255),
+	)
+	d, dErr := time.Parse(DateLayout, h.Date)
+	if dErr != nil {
+		err = validate.Many(err, validation.NewFieldError("Date", "must be in YYYY-MM-DD format"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	h.Date = d.Format(DateLayout)
+
+	return &h, nil
+}
+
+// Set is a set of holiday dates in YYYY-MM-DD format.
+type Set map[string]bool
+
+// NewSet will return a Set containing the date of every holiday.
+func NewSet(holidays []Holiday) Set {
+	s := make(Set, len(holidays))
+	for _, h := range holidays {
+		s[h.Date] = true
+	}
+	return s
+}
+
+// Contains will return true if the local date of t (in t's location) is a holiday.
+func (s Set) Contains(t time.Time) bool {
+	return s[t.Format(DateLayout)]
+}
@@ -0,0 +1,104 @@
+package holiday
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/util/ical"
+)
+
+// MaxImportSize is the maximum size, in bytes, of iCalendar data that can be imported at once.
+const MaxImportSize = 1024 * 1024
+
+var icalEscape = strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+var icalDaysRx = regexp.MustCompile(`^\+?P(?:(\d+)W|(\d+)D)$`)
+
+func parseICalDate(s string) (time.Time, error) {
+	// only the date portion is relevant, holidays always cover the whole day
+	if len(s) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date '%s'", s)
+	}
+	return time.Parse("20060102", s[:8])
+}
+
+// ParseICal will parse each VEVENT as a holiday, using the SUMMARY as its name. Events
+// spanning multiple days (DTEND is exclusive) result in a holiday for each day.
+//
+// Recurring events are not supported and will result in an error.
+func ParseICal(r io.Reader) ([]Holiday, error) {
+	events, err := ical.ReadEvents(r, MaxImportSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Holiday
+	for _, ev := range events {
+		// parameters are irrelevant for the properties used here
+		var summary, start, end, dur string
+		for _, p := range ev.Properties {
+			switch p.Name {
+			case "RRULE", "RDATE":
+				return nil, fmt.Errorf("line %d: recurring events are not supported", p.Line)
+			case "SUMMARY":
+				summary = strings.TrimSpace(icalEscape.Replace(p.Value))
+			case "DTSTART":
+				start = p.Value
+			case "DTEND":
+				end = p.Value
+			case "DURATION":
+				dur = p.Value
+			}
+		}
+
+		if start == "" {
+			return nil, fmt.Errorf("line %d: event is missing DTSTART", ev.Line)
+		}
+		s, err := parseICalDate(start)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: DTSTART: %w", ev.Line, err)
+		}
+		days := 1
+		switch {
+		case end != "":
+			e, err := parseICalDate(end)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DTEND: %w", ev.Line, err)
+			}
+			days = int(e.Sub(s).Hours() / 24)
+		case dur != "":
+			m := icalDaysRx.FindStringSubmatch(dur)
+			if m == nil {
+				return nil, fmt.Errorf("line %d: DURATION: must be a whole number of days or weeks", ev.Line)
+			}
+			if m[1] != "" {
+				days, _ = strconv.Atoi(m[1])
+				days *= 7
+			} else {
+				days, _ = strconv.Atoi(m[2])
+			}
+		}
+		if days < 1 {
+			days = 1
+		}
+		if days > 366 {
+			return nil, fmt.Errorf("line %d: event is longer than a year", ev.Line)
+		}
+		if r := []rune(summary); len(r) > 255 {
+			summary = string(r[:255])
+		}
+
+		for i := 0; i < days; i++ {
+			result = append(result, Holiday{
+				Date: s.AddDate(0, 0, i).Format(DateLayout),
+				Name: summary,
+			})
+		}
+	}
+
+	return result, nil
+}
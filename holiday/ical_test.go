@@ -0,0 +1,64 @@
+package holiday
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseICal(t *testing.T) {
+	const data = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:New Year\\, Observed\r\n" +
+		"DTSTART;VALUE=DATE:20261231\r\n" +
+		"DTEND;VALUE=DATE:20270102\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"TRIGGER:-PT15M\r\n" +
+		"DTSTART:20200101\r\n" +
+		"END:VALARM\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Independence\r\n" +
+		"  Day\r\n" +
+		"DTSTART;VALUE=DATE:20260704\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Thanksgiving\r\n" +
+		"DTSTART;VALUE=DATE:20261126\r\n" +
+		"DURATION:P2D\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	h, err := ParseICal(strings.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Holiday{
+		{Date: "2026-12-31", Name: "New Year, Observed"},
+		{Date: "2027-01-01", Name: "New Year, Observed"},
+		{Date: "2026-07-04", Name: "Independence Day"},
+		{Date: "2026-11-26", Name: "Thanksgiving"},
+		{Date: "2026-11-27", Name: "Thanksgiving"},
+	}, h)
+
+	_, err = ParseICal(strings.NewReader("BEGIN:VEVENT\nDTSTART:20261225\nRRULE:FREQ=YEARLY\nEND:VEVENT\n"))
+	assert.EqualError(t, err, "line 3: recurring events are not supported")
+
+	_, err = ParseICal(strings.NewReader("BEGIN:VEVENT\nSUMMARY:Nothing\nEND:VEVENT\n"))
+	assert.EqualError(t, err, "line 1: event is missing DTSTART")
+}
+
+func TestHoliday_Normalize(t *testing.T) {
+	const id = "00000000-0000-0000-0000-000000000001"
+
+	h, err := Holiday{CalendarID: id, Date: "2026-12-25", Name: "Christmas"}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, "2026-12-25", h.Date)
+
+	_, err = Holiday{CalendarID: id, Date: "12/25/2026"}.Normalize()
+	assert.Error(t, err)
+
+	_, err = Holiday{CalendarID: id, Date: "2026-02-30"}.Normalize()
+	assert.Error(t, err)
+}
@@ -0,0 +1,280 @@
+package holiday
+
+import (
+	"context"
+	"database/sql"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store allows the lookup and management of holiday calendars.
+type Store struct {
+	createCal  *sql.Stmt
+	updateCal  *sql.Stmt
+	deleteCal  *sql.Stmt
+	findOneCal *sql.Stmt
+	findAllCal *sql.Stmt
+
+	findHolidays   *sql.Stmt
+	clearHolidays  *sql.Stmt
+	setHolidays    *sql.Stmt
+	deleteHolidays *sql.Stmt
+}
+
+// NewStore will create a new Store with the given parameters.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		createCal: p.P(`insert into holiday_calendars (id, name, description) values ($1, $2, $3)`),
+		updateCal: p.P(`update holiday_calendars set name = $2, description = $3 where id = $1`),
+		deleteCal: p.P(`delete from holiday_calendars where id = any($1)`),
+		findOneCal: p.P(`
+			select id, name, description
+			from holiday_calendars
+			where id = $1
+		`),
+		findAllCal: p.P(`
+			select id, name, description
+			from holiday_calendars
+			order by lower(name)
+		`),
+
+		findHolidays: p.P(`
+			select calendar_id, to_char(date, 'YYYY-MM-DD'), name
+			from holidays
+			where calendar_id = $1
+			order by date
+		`),
+		clearHolidays: p.P(`delete from holidays where calendar_id = $1`),
+		setHolidays: p.P(`
+			insert into holidays (calendar_id, date, name)
+			select $1::uuid, d.date, d.name
+			from unnest($2::date[], $3::text[]) d (date, name)
+			on conflict (calendar_id, date) do update
+			set name = excluded.name
+		`),
+		deleteHolidays: p.P(`delete from holidays where calendar_id = $1 and date = any($2::date[])`),
+	}, p.Err
+}
+
+func (c *Calendar) scanFrom(scanFn func(...interface{}) error) error {
+	return scanFn(&c.ID, &c.Name, &c.Description)
+}
+
+func stmt(ctx context.Context, tx *sql.Tx, s *sql.Stmt) *sql.Stmt {
+	if tx == nil {
+		return s
+	}
+	return tx.StmtContext(ctx, s)
+}
+
+// CreateCalendarTx will create a new, empty, holiday calendar.
+func (s *Store) CreateCalendarTx(ctx context.Context, tx *sql.Tx, c *Calendar) (*Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	n, err := c.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	n.ID = uuid.NewV4().String()
+
+	_, err = stmt(ctx, tx, s.createCal).ExecContext(ctx, n.ID, n.Name, n.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// UpdateCalendarTx will update the name and description of a holiday calendar.
+func (s *Store) UpdateCalendarTx(ctx context.Context, tx *sql.Tx, c *Calendar) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	n, err := c.Normalize()
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ID", n.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt(ctx, tx, s.updateCal).ExecContext(ctx, n.ID, n.Name, n.Description)
+	return err
+}
+
+// DeleteCalendarTx will delete the given holiday calendars. Schedules referencing them
+// will no longer have a holiday calendar.
+func (s *Store) DeleteCalendarTx(ctx context.Context, tx *sql.Tx, ids ...string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	err = validate.ManyUUID("ID", ids, 50)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt(ctx, tx, s.deleteCal).ExecContext(ctx, sqlutil.UUIDArray(ids))
+	return err
+}
+
+// FindOneCalendar will return the holiday calendar with the given ID.
+func (s *Store) FindOneCalendar(ctx context.Context, id string) (*Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Calendar
+	err = c.scanFrom(s.findOneCal.QueryRowContext(ctx, id).Scan)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// FindAllCalendars will return all holiday calendars, ordered by name.
+func (s *Store) FindAllCalendars(ctx context.Context) ([]Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAllCal.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Calendar
+	for rows.Next() {
+		var c Calendar
+		err = c.scanFrom(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+
+	return result, rows.Err()
+}
+
+// FindHolidays will return all holidays of the given calendar, in order.
+func (s *Store) FindHolidays(ctx context.Context, calendarID string) ([]Holiday, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("CalendarID", calendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findHolidays.QueryContext(ctx, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Holiday
+	for rows.Next() {
+		var h Holiday
+		err = rows.Scan(&h.CalendarID, &h.Date, &h.Name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, h)
+	}
+
+	return result, rows.Err()
+}
+
+// SetHolidaysTx will add the given holidays to a calendar, updating the name of any that
+// already exist. If replace is true, all other holidays on the calendar are removed.
+func (s *Store) SetHolidaysTx(ctx context.Context, tx *sql.Tx, calendarID string, holidays []Holiday, replace bool) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("CalendarID", calendarID)
+	if err != nil {
+		return err
+	}
+	if len(holidays) > MaxHolidays {
+		return validation.NewFieldError("Holidays", "too many holidays")
+	}
+
+	// the same date may only appear once per statement, the last one wins
+	idx := make(map[string]int, len(holidays))
+	var dates, names sqlutil.StringArray
+	for _, h := range holidays {
+		h.CalendarID = calendarID
+		n, err := h.Normalize()
+		if err != nil {
+			return err
+		}
+		if i, ok := idx[n.Date]; ok {
+			names[i] = n.Name
+			continue
+		}
+		idx[n.Date] = len(dates)
+		dates = append(dates, n.Date)
+		names = append(names, n.Name)
+	}
+
+	if replace {
+		_, err = stmt(ctx, tx, s.clearHolidays).ExecContext(ctx, calendarID)
+		if err != nil {
+			return err
+		}
+	}
+	if len(dates) == 0 {
+		return nil
+	}
+
+	_, err = stmt(ctx, tx, s.setHolidays).ExecContext(ctx, calendarID, dates, names)
+	return err
+}
+
+// DeleteHolidaysTx will remove the given dates (YYYY-MM-DD) from a calendar.
+func (s *Store) DeleteHolidaysTx(ctx context.Context, tx *sql.Tx, calendarID string, dates []string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("CalendarID", calendarID)
+	if err != nil {
+		return err
+	}
+	for _, d := range dates {
+		_, err = (Holiday{CalendarID: calendarID, Date: d}).Normalize()
+		if err != nil {
+			return err
+		}
+	}
+	if len(dates) == 0 {
+		return nil
+	}
+
+	_, err = stmt(ctx, tx, s.deleteHolidays).ExecContext(ctx, calendarID, sqlutil.StringArray(dates))
+	return err
+}
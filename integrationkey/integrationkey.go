@@ -1,6 +1,7 @@
 package integrationkey
 
 import (
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -9,17 +10,32 @@ type IntegrationKey struct {
 	Name      string `json:"name"`
 	Type      Type   `json:"type"`
 	ServiceID string `json:"service_id"`
+
+	// JSONMapping is required for TypeGenericJSON keys, and must be nil for all other types.
+	JSONMapping *JSONMapping `json:"json_mapping,omitempty"`
 }
 
 func (i IntegrationKey) Normalize() (*IntegrationKey, error) {
 	err := validate.Many(
 		validate.IDName("Name", i.Name),
 		validate.UUID("ServiceID", i.ServiceID),
-		validate.OneOf("Type", i.Type, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeGeneric, TypeEmail),
+		validate.OneOf("Type", i.Type, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeGeneric, TypeEmail, TypeGenericJSON, TypeDatadog, TypeNewRelic, TypeAWSSNS),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if i.Type != TypeGenericJSON {
+		i.JSONMapping = nil
+		return &i, nil
+	}
+	if i.JSONMapping == nil {
+		return nil, validation.NewFieldError("JSONMapping", "required for genericJSON keys")
+	}
+	i.JSONMapping, err = i.JSONMapping.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
 	return &i, nil
 }
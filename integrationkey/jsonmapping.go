@@ -0,0 +1,134 @@
+package integrationkey
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxExpressionLength is the maximum length of a single JSONMapping expression.
+const MaxExpressionLength = 1024
+
+// JSONMapping configures how alert fields are extracted from arbitrary JSON payloads
+// sent to a TypeGenericJSON integration key. Each field is a JMESPath expression.
+type JSONMapping struct {
+	Summary string `json:"summary"`
+	Details string `json:"details,omitempty"`
+	Dedup   string `json:"dedup,omitempty"`
+
+	// Action determines if the alert should be closed. A result of `close`, `closed`,
+	// `resolve`, `resolved` or `ok` (case-insensitive) will close the alert, anything
+	// else will create or update it.
+	Action string `json:"action,omitempty"`
+}
+
+// MappedAlert contains the values extracted from a payload by a JSONMapping.
+type MappedAlert struct {
+	Summary string
+	Details string
+	Dedup   string
+	Close   bool
+}
+
+var closeActions = map[string]bool{
+	"close":    true,
+	"closed":   true,
+	"resolve":  true,
+	"resolved": true,
+	"ok":       true,
+}
+
+func validateExpr(fname, expr string, required bool) error {
+	min := 0
+	if required {
+		min = 1
+	}
+	err := validate.RequiredText(fname, expr, min, MaxExpressionLength)
+	if err != nil || expr == "" {
+		return err
+	}
+
+	return validate.JMESPath(fname, expr)
+}
+
+// Normalize will validate the JSONMapping and return a new copy.
+func (m JSONMapping) Normalize() (*JSONMapping, error) {
+	m.Summary = strings.TrimSpace(m.Summary)
+	m.Details = strings.TrimSpace(m.Details)
+	m.Dedup = strings.TrimSpace(m.Dedup)
+	m.Action = strings.TrimSpace(m.Action)
+
+	err := validate.Many(
+		validateExpr("Summary", m.Summary, true),
+		validateExpr("Details", m.Details, false),
+		validateExpr("Dedup", m.Dedup, false),
+		validateExpr("Action", m.Action, false),
+	)
+	if err != nil {
+		return nil, validation.AddPrefix("JSONMapping.", err)
+	}
+
+	return &m, nil
+}
+
+// search will evaluate expr against data, returning the result as a string.
+//
+// Strings are returned as-is, null results are returned as an empty string, and any other
+// value is returned in its JSON representation.
+func search(expr string, data interface{}) (string, error) {
+	if expr == "" {
+		return "", nil
+	}
+
+	res, err := jmespath.Search(expr, data)
+	if err != nil {
+		return "", err
+	}
+
+	switch v := res.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+
+	buf, err := json.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// Apply will evaluate the mapping against data, which should be a decoded JSON document.
+func (m JSONMapping) Apply(data interface{}) (*MappedAlert, error) {
+	var res MappedAlert
+	var err error
+
+	res.Summary, err = search(m.Summary, data)
+	if err != nil {
+		return nil, validation.NewFieldError("Summary", fmt.Sprintf("evaluate expression: %v", err))
+	}
+	res.Details, err = search(m.Details, data)
+	if err != nil {
+		return nil, validation.NewFieldError("Details", fmt.Sprintf("evaluate expression: %v", err))
+	}
+	res.Dedup, err = search(m.Dedup, data)
+	if err != nil {
+		return nil, validation.NewFieldError("Dedup", fmt.Sprintf("evaluate expression: %v", err))
+	}
+	action, err := search(m.Action, data)
+	if err != nil {
+		return nil, validation.NewFieldError("Action", fmt.Sprintf("evaluate expression: %v", err))
+	}
+	res.Close = closeActions[strings.ToLower(strings.TrimSpace(action))]
+
+	if !res.Close && strings.TrimSpace(res.Summary) == "" {
+		return nil, validation.NewFieldError("Summary", "expression returned an empty value")
+	}
+
+	return &res, nil
+}
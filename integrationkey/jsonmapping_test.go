@@ -0,0 +1,52 @@
+package integrationkey
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONMapping_Normalize(t *testing.T) {
+	_, err := JSONMapping{Summary: "title"}.Normalize()
+	assert.NoError(t, err)
+
+	_, err = JSONMapping{}.Normalize()
+	assert.Error(t, err, "summary is required")
+
+	_, err = JSONMapping{Summary: "title", Dedup: "a.[b"}.Normalize()
+	assert.Error(t, err, "invalid expression")
+}
+
+func TestJSONMapping_Apply(t *testing.T) {
+	m := JSONMapping{
+		Summary: "event.title",
+		Details: "event.tags",
+		Dedup:   "join('/', [event.host, event.check])",
+		Action:  "event.state",
+	}
+
+	decode := func(s string) interface{} {
+		t.Helper()
+		var data interface{}
+		require.NoError(t, json.Unmarshal([]byte(s), &data))
+		return data
+	}
+
+	res, err := m.Apply(decode(`{"event":{"title":"Disk full","tags":["a","b"],"host":"db1","check":"disk","state":"FIRING"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, &MappedAlert{
+		Summary: "Disk full",
+		Details: `["a","b"]`,
+		Dedup:   "db1/disk",
+	}, res)
+
+	res, err = m.Apply(decode(`{"event":{"host":"db1","check":"disk","state":"Resolved"}}`))
+	require.NoError(t, err)
+	assert.True(t, res.Close)
+	assert.Equal(t, "db1/disk", res.Dedup)
+
+	_, err = m.Apply(decode(`{"event":{"host":"db1","check":"disk"}}`))
+	assert.Error(t, err, "summary is required when creating an alert")
+}
@@ -3,6 +3,7 @@ package integrationkey
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/target/goalert/auth/authtoken"
 	"github.com/target/goalert/permission"
@@ -22,6 +23,8 @@ type Store interface {
 	CreateKeyTx(context.Context, *sql.Tx, *IntegrationKey) (*IntegrationKey, error)
 	FindOne(ctx context.Context, id string) (*IntegrationKey, error)
 	FindAllByService(ctx context.Context, id string) ([]IntegrationKey, error)
+	FindJSONMapping(ctx context.Context, id string) (*JSONMapping, error)
+	UpdateTx(ctx context.Context, tx *sql.Tx, i *IntegrationKey) error
 	Delete(ctx context.Context, id string) error
 	DeleteTx(ctx context.Context, tx *sql.Tx, id string) error
 	DeleteManyTx(ctx context.Context, tx *sql.Tx, ids []string) error
@@ -34,6 +37,8 @@ type DB struct {
 	create           *sql.Stmt
 	findOne          *sql.Stmt
 	findAllByService *sql.Stmt
+	findJSONMapping  *sql.Stmt
+	update           *sql.Stmt
 	delete           *sql.Stmt
 }
 
@@ -44,9 +49,11 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 		db: db,
 
 		getServiceID:     p.P("SELECT service_id FROM integration_keys WHERE id = $1 AND type = $2"),
-		create:           p.P("INSERT INTO integration_keys (id, name, type, service_id) VALUES ($1, $2, $3, $4)"),
-		findOne:          p.P("SELECT id, name, type, service_id FROM integration_keys WHERE id = $1"),
-		findAllByService: p.P("SELECT id, name, type, service_id FROM integration_keys WHERE service_id = $1"),
+		create:           p.P("INSERT INTO integration_keys (id, name, type, service_id, json_mapping) VALUES ($1, $2, $3, $4, $5)"),
+		findOne:          p.P("SELECT id, name, type, service_id, json_mapping FROM integration_keys WHERE id = $1"),
+		findAllByService: p.P("SELECT id, name, type, service_id, json_mapping FROM integration_keys WHERE service_id = $1"),
+		findJSONMapping:  p.P("SELECT json_mapping FROM integration_keys WHERE id = $1 AND type = 'genericJSON'"),
+		update:           p.P("UPDATE integration_keys SET name = $2, json_mapping = $3 WHERE id = $1"),
 		delete:           p.P("DELETE FROM integration_keys WHERE id = any($1)"),
 	}, p.Err
 }
@@ -73,7 +80,7 @@ func (db *DB) Authorize(ctx context.Context, tok authtoken.Token, t Type) (conte
 func (db *DB) GetServiceID(ctx context.Context, id string, t Type) (string, error) {
 	err := validate.Many(
 		validate.UUID("IntegrationKeyID", id),
-		validate.OneOf("IntegrationType", t, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeGeneric, TypeEmail),
+		validate.OneOf("IntegrationType", t, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeGeneric, TypeEmail, TypeGenericJSON, TypeDatadog, TypeNewRelic, TypeAWSSNS),
 	)
 	if err != nil {
 		return "", err
@@ -117,14 +124,87 @@ func (db *DB) CreateKeyTx(ctx context.Context, tx *sql.Tx, i *IntegrationKey) (*
 		stmt = tx.Stmt(stmt)
 	}
 
+	mapping, err := jsonMappingArg(n.JSONMapping)
+	if err != nil {
+		return nil, err
+	}
+
 	n.ID = uuid.NewV4().String()
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Type, n.ServiceID)
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Type, n.ServiceID, mapping)
 	if err != nil {
 		return nil, err
 	}
 	return n, nil
 }
 
+// UpdateTx will update the name and JSONMapping of an existing integration key. The type
+// and service cannot be changed.
+func (db *DB) UpdateTx(ctx context.Context, tx *sql.Tx, i *IntegrationKey) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := i.Normalize()
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("IntegrationKeyID", n.ID)
+	if err != nil {
+		return err
+	}
+
+	mapping, err := jsonMappingArg(n.JSONMapping)
+	if err != nil {
+		return err
+	}
+
+	stmt := db.update
+	if tx != nil {
+		stmt = tx.Stmt(stmt)
+	}
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, mapping)
+	return err
+}
+
+// FindJSONMapping will return the JSONMapping of a genericJSON integration key.
+func (db *DB) FindJSONMapping(ctx context.Context, id string) (*JSONMapping, error) {
+	err := validate.UUID("IntegrationKeyID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.User, permission.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	err = db.findJSONMapping.QueryRowContext(ctx, id).Scan(&data)
+	if err != nil {
+		return nil, err
+	}
+
+	var m JSONMapping
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode json mapping")
+	}
+
+	return &m, nil
+}
+
+func jsonMappingArg(m *JSONMapping) (interface{}, error) {
+	if m == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
 func (db *DB) Delete(ctx context.Context, id string) error {
 	return db.DeleteTx(ctx, nil, id)
 }
@@ -191,12 +271,22 @@ func (db *DB) FindAllByService(ctx context.Context, serviceID string) ([]Integra
 }
 
 func scanFrom(i *IntegrationKey, f func(args ...interface{}) error) error {
-	return f(&i.ID, &i.Name, &i.Type, &i.ServiceID)
+	var mapping []byte
+	err := f(&i.ID, &i.Name, &i.Type, &i.ServiceID, &mapping)
+	if err != nil {
+		return err
+	}
+	if mapping == nil {
+		return nil
+	}
+
+	i.JSONMapping = new(JSONMapping)
+	return json.Unmarshal(mapping, i.JSONMapping)
 }
 
 func scanAllFrom(rows *sql.Rows) (integrationKeys []IntegrationKey, err error) {
-	var i IntegrationKey
 	for rows.Next() {
+		var i IntegrationKey
 		err = scanFrom(&i, rows.Scan)
 		if err != nil {
 			return nil, err
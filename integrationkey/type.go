@@ -15,6 +15,12 @@ const (
 	TypePrometheusAlertmanager Type = "prometheusAlertmanager"
 	TypeGeneric                Type = "generic"
 	TypeEmail                  Type = "email"
+	TypeDatadog                Type = "datadog"
+	TypeNewRelic               Type = "newRelic"
+	TypeAWSSNS                 Type = "awsSNS"
+
+	// TypeGenericJSON accepts arbitrary JSON payloads, using a JSONMapping to extract alert fields.
+	TypeGenericJSON Type = "genericJSON"
 )
 
 func (s Type) Value() (driver.Value, error) {
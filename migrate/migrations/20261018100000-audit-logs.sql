@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    timestamp TIMESTAMPTZ NOT NULL DEFAULT now(),
+    actor_user_id UUID,
+    actor_source TEXT NOT NULL,
+    action TEXT NOT NULL,
+    target_type TEXT,
+    target_id TEXT,
+    before JSONB,
+    after JSONB
+);
+
+CREATE INDEX idx_audit_logs_timestamp ON audit_logs (timestamp);
+CREATE INDEX idx_audit_logs_target ON audit_logs (target_type, target_id);
+CREATE INDEX idx_audit_logs_actor_user ON audit_logs (actor_user_id);
+
+-- +migrate Down
+DROP TABLE audit_logs;
@@ -0,0 +1,135 @@
+-- +migrate Up
+
+CREATE TABLE outgoing_webhooks (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    url TEXT NOT NULL,
+    secret BYTEA NOT NULL,
+    event_types TEXT[] NOT NULL,
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE outgoing_webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id UUID NOT NULL REFERENCES outgoing_webhooks (id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT,
+    sent_at TIMESTAMPTZ,
+    failed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_outgoing_webhook_deliveries_pending ON outgoing_webhook_deliveries (next_attempt_at)
+    WHERE sent_at ISNULL AND failed_at ISNULL;
+CREATE INDEX idx_outgoing_webhook_deliveries_webhook ON outgoing_webhook_deliveries (webhook_id);
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_enqueue_outgoing_webhook(_type TEXT, _payload JSONB) RETURNS VOID AS
+    $$
+    BEGIN
+        INSERT INTO outgoing_webhook_deliveries (webhook_id, event_type, payload)
+        SELECT id, _type, _payload
+        FROM outgoing_webhooks
+        WHERE NOT disabled AND _type = ANY (event_types);
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_outgoing_webhook_alert_log() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        PERFORM fn_enqueue_outgoing_webhook('alert.' || NEW.event::TEXT, jsonb_build_object(
+            'alert_id', a.id,
+            'service_id', a.service_id,
+            'summary', a.summary,
+            'details', a.details,
+            'status', a.status,
+            'log_id', NEW.id,
+            'message', NEW.message,
+            'user_id', NEW.sub_user_id,
+            'timestamp', NEW.timestamp
+        ))
+        FROM alerts a
+        WHERE a.id = NEW.alert_id;
+        RETURN NEW;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_outgoing_webhook_alert_log
+    AFTER INSERT ON alert_logs
+    FOR EACH ROW
+    WHEN (NEW.event IN ('created', 'acknowledged', 'escalated', 'closed'))
+    EXECUTE PROCEDURE fn_outgoing_webhook_alert_log();
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_outgoing_webhook_on_call() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        PERFORM fn_enqueue_outgoing_webhook(
+            CASE WHEN NEW.end_time ISNULL THEN 'oncall.started' ELSE 'oncall.ended' END,
+            jsonb_build_object(
+                'schedule_id', NEW.schedule_id,
+                'user_id', NEW.user_id,
+                'start_time', NEW.start_time,
+                'end_time', NEW.end_time
+            )
+        );
+        RETURN NEW;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_outgoing_webhook_on_call_start
+    AFTER INSERT ON schedule_on_call_users
+    FOR EACH ROW
+    EXECUTE PROCEDURE fn_outgoing_webhook_on_call();
+
+CREATE TRIGGER trg_outgoing_webhook_on_call_end
+    AFTER UPDATE ON schedule_on_call_users
+    FOR EACH ROW
+    WHEN (OLD.end_time ISNULL AND NEW.end_time NOTNULL)
+    EXECUTE PROCEDURE fn_outgoing_webhook_on_call();
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_outgoing_webhook_override() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        IF TG_OP = 'DELETE' THEN
+            PERFORM fn_enqueue_outgoing_webhook('override.deleted', to_jsonb(OLD));
+            RETURN OLD;
+        END IF;
+
+        PERFORM fn_enqueue_outgoing_webhook(
+            CASE WHEN TG_OP = 'INSERT' THEN 'override.created' ELSE 'override.updated' END,
+            to_jsonb(NEW)
+        );
+        RETURN NEW;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_outgoing_webhook_override
+    AFTER INSERT OR UPDATE OR DELETE ON user_overrides
+    FOR EACH ROW
+    EXECUTE PROCEDURE fn_outgoing_webhook_override();
+
+-- +migrate Down
+
+DROP TRIGGER trg_outgoing_webhook_override ON user_overrides;
+DROP TRIGGER trg_outgoing_webhook_on_call_end ON schedule_on_call_users;
+DROP TRIGGER trg_outgoing_webhook_on_call_start ON schedule_on_call_users;
+DROP TRIGGER trg_outgoing_webhook_alert_log ON alert_logs;
+
+DROP FUNCTION fn_outgoing_webhook_override();
+DROP FUNCTION fn_outgoing_webhook_on_call();
+DROP FUNCTION fn_outgoing_webhook_alert_log();
+DROP FUNCTION fn_enqueue_outgoing_webhook(TEXT, JSONB);
+
+DROP TABLE outgoing_webhook_deliveries;
+DROP TABLE outgoing_webhooks;
@@ -0,0 +1,6 @@
+-- +migrate Up notransaction
+ALTER TYPE engine_processing_type ADD VALUE IF NOT EXISTS 'webhook';
+INSERT INTO engine_processing_versions (type_id) VALUES ('webhook');
+
+-- +migrate Down
+DELETE FROM engine_processing_versions WHERE type_id = 'webhook';
@@ -0,0 +1,170 @@
+-- +migrate Up
+
+-- statement-level triggers send one notification per distinct payload, rather than
+-- calling pg_notify for every affected row
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_notify_alert_status_insert() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        PERFORM pg_notify('/goalert/alert-status', payload)
+        FROM (
+            SELECT DISTINCT n.service_id::TEXT || '/' || n.id::TEXT payload
+            FROM new_rows n
+        ) p;
+        RETURN NULL;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_notify_alert_status_update() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        PERFORM pg_notify('/goalert/alert-status', payload)
+        FROM (
+            SELECT DISTINCT n.service_id::TEXT || '/' || n.id::TEXT payload
+            FROM new_rows n
+            JOIN old_rows o ON o.id = n.id
+            WHERE o.status <> n.status
+        ) p;
+        RETURN NULL;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_notify_alert_status_insert
+    AFTER INSERT ON alerts
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE PROCEDURE fn_notify_alert_status_insert();
+
+CREATE TRIGGER trg_notify_alert_status_update
+    AFTER UPDATE ON alerts
+    REFERENCING OLD TABLE AS old_rows NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE PROCEDURE fn_notify_alert_status_update();
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_notify_alert_log() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        PERFORM pg_notify('/goalert/alert-log', payload)
+        FROM (
+            SELECT DISTINCT n.alert_id::TEXT payload
+            FROM new_rows n
+        ) p;
+        RETURN NULL;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_notify_alert_log
+    AFTER INSERT ON alert_logs
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE PROCEDURE fn_notify_alert_log();
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_notify_schedule_on_call_start() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        PERFORM pg_notify('/goalert/schedule-on-call', payload)
+        FROM (
+            SELECT DISTINCT n.schedule_id::TEXT payload
+            FROM new_rows n
+        ) p;
+        RETURN NULL;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_notify_schedule_on_call_end() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        PERFORM pg_notify('/goalert/schedule-on-call', payload)
+        FROM (
+            SELECT DISTINCT n.schedule_id::TEXT payload
+            FROM new_rows n
+            JOIN old_rows o ON o.id = n.id
+            WHERE o.end_time ISNULL AND n.end_time NOTNULL
+        ) p;
+        RETURN NULL;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_notify_schedule_on_call_start
+    AFTER INSERT ON schedule_on_call_users
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE PROCEDURE fn_notify_schedule_on_call_start();
+
+CREATE TRIGGER trg_notify_schedule_on_call_end
+    AFTER UPDATE ON schedule_on_call_users
+    REFERENCING OLD TABLE AS old_rows NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE PROCEDURE fn_notify_schedule_on_call_end();
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_notify_ep_step_on_call_start() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        PERFORM pg_notify('/goalert/ep-on-call', payload)
+        FROM (
+            SELECT DISTINCT step.escalation_policy_id::TEXT payload
+            FROM new_rows n
+            JOIN escalation_policy_steps step ON step.id = n.ep_step_id
+        ) p;
+        RETURN NULL;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION fn_notify_ep_step_on_call_end() RETURNS TRIGGER AS
+    $$
+    BEGIN
+        PERFORM pg_notify('/goalert/ep-on-call', payload)
+        FROM (
+            SELECT DISTINCT step.escalation_policy_id::TEXT payload
+            FROM new_rows n
+            JOIN old_rows o ON o.id = n.id
+            JOIN escalation_policy_steps step ON step.id = n.ep_step_id
+            WHERE o.end_time ISNULL AND n.end_time NOTNULL
+        ) p;
+        RETURN NULL;
+    END;
+    $$ LANGUAGE 'plpgsql';
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_notify_ep_step_on_call_start
+    AFTER INSERT ON ep_step_on_call_users
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE PROCEDURE fn_notify_ep_step_on_call_start();
+
+CREATE TRIGGER trg_notify_ep_step_on_call_end
+    AFTER UPDATE ON ep_step_on_call_users
+    REFERENCING OLD TABLE AS old_rows NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE PROCEDURE fn_notify_ep_step_on_call_end();
+
+-- +migrate Down
+
+DROP TRIGGER trg_notify_ep_step_on_call_end ON ep_step_on_call_users;
+DROP TRIGGER trg_notify_ep_step_on_call_start ON ep_step_on_call_users;
+DROP TRIGGER trg_notify_schedule_on_call_end ON schedule_on_call_users;
+DROP TRIGGER trg_notify_schedule_on_call_start ON schedule_on_call_users;
+DROP TRIGGER trg_notify_alert_log ON alert_logs;
+DROP TRIGGER trg_notify_alert_status_update ON alerts;
+DROP TRIGGER trg_notify_alert_status_insert ON alerts;
+
+DROP FUNCTION fn_notify_ep_step_on_call_end();
+DROP FUNCTION fn_notify_ep_step_on_call_start();
+DROP FUNCTION fn_notify_schedule_on_call_end();
+DROP FUNCTION fn_notify_schedule_on_call_start();
+DROP FUNCTION fn_notify_alert_log();
+DROP FUNCTION fn_notify_alert_status_update();
+DROP FUNCTION fn_notify_alert_status_insert();
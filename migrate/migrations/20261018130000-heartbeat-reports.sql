@@ -0,0 +1,15 @@
+-- +migrate Up
+
+ALTER TABLE heartbeat_monitors
+    ADD COLUMN grace_period INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN alert_after_misses INT NOT NULL DEFAULT 1 CHECK (alert_after_misses > 0),
+    ADD COLUMN last_report_failed BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN last_message TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+
+ALTER TABLE heartbeat_monitors
+    DROP COLUMN grace_period,
+    DROP COLUMN alert_after_misses,
+    DROP COLUMN last_report_failed,
+    DROP COLUMN last_message;
@@ -0,0 +1,25 @@
+-- +migrate Up
+
+ALTER TABLE heartbeat_monitors
+    ADD COLUMN cron_schedule TEXT,
+    ADD COLUMN time_zone TEXT,
+    ADD COLUMN next_deadline TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN deadline_from TIMESTAMP WITH TIME ZONE,
+    ADD CONSTRAINT heartbeat_monitors_cron_time_zone CHECK ((cron_schedule ISNULL) = (time_zone ISNULL));
+
+UPDATE engine_processing_versions
+SET version = 2
+WHERE type_id = 'heartbeat';
+
+-- +migrate Down
+
+UPDATE engine_processing_versions
+SET version = 1
+WHERE type_id = 'heartbeat';
+
+ALTER TABLE heartbeat_monitors
+    DROP CONSTRAINT heartbeat_monitors_cron_time_zone,
+    DROP COLUMN cron_schedule,
+    DROP COLUMN time_zone,
+    DROP COLUMN next_deadline,
+    DROP COLUMN deadline_from;
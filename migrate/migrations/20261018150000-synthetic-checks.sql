@@ -0,0 +1,37 @@
+-- +migrate Up
+
+CREATE TYPE enum_synthetic_check_type AS ENUM (
+    'http',
+    'tcp'
+);
+
+CREATE TYPE enum_synthetic_check_state AS ENUM (
+    'inactive',
+    'healthy',
+    'unhealthy'
+);
+
+CREATE TABLE synthetic_checks (
+    id UUID PRIMARY KEY,
+    service_id UUID NOT NULL REFERENCES services (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    check_type enum_synthetic_check_type NOT NULL,
+    target TEXT NOT NULL,
+    check_interval INTERVAL NOT NULL,
+    expected_status INT NOT NULL DEFAULT 0,
+    body_contains TEXT NOT NULL DEFAULT '',
+    tls_expiry_days INT NOT NULL DEFAULT 0,
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_state enum_synthetic_check_state NOT NULL DEFAULT 'inactive',
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX synthetic_check_name_service_id ON synthetic_checks (lower(name), service_id);
+CREATE INDEX idx_synthetic_check_service ON synthetic_checks (service_id);
+
+-- +migrate Down
+
+DROP TABLE synthetic_checks;
+DROP TYPE enum_synthetic_check_state;
+DROP TYPE enum_synthetic_check_type;
@@ -0,0 +1,9 @@
+-- +migrate Up notransaction
+-- Add new integration key type 'genericJSON'
+
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'genericJSON';
+ALTER TABLE integration_keys ADD COLUMN IF NOT EXISTS json_mapping JSONB;
+
+-- +migrate Down
+
+ALTER TABLE integration_keys DROP COLUMN json_mapping;
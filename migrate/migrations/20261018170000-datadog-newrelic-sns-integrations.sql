@@ -0,0 +1,11 @@
+-- +migrate Up notransaction
+-- Add new integration key and alert source types for Datadog, New Relic and AWS SNS
+
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'datadog';
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'newRelic';
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'awsSNS';
+ALTER TYPE enum_alert_source ADD VALUE IF NOT EXISTS 'datadog';
+ALTER TYPE enum_alert_source ADD VALUE IF NOT EXISTS 'newRelic';
+ALTER TYPE enum_alert_source ADD VALUE IF NOT EXISTS 'awsSNS';
+
+-- +migrate Down
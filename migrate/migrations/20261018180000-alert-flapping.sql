@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE alert_dedup_transitions (
+    id BIGSERIAL PRIMARY KEY,
+    service_id UUID NOT NULL REFERENCES services (id) ON DELETE CASCADE,
+    dedup_key TEXT NOT NULL,
+    closed BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_alert_dedup_transitions ON alert_dedup_transitions (service_id, dedup_key, created_at);
+
+CREATE TABLE alert_flapping (
+    alert_id BIGINT PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    since TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_transition_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    source_closed BOOLEAN NOT NULL DEFAULT false
+);
+
+-- +migrate Down
+DROP TABLE alert_flapping;
+DROP TABLE alert_dedup_transitions;
@@ -0,0 +1,7 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_alert_log_event ADD VALUE IF NOT EXISTS 'flapping';
+ALTER TYPE engine_processing_type ADD VALUE IF NOT EXISTS 'flap';
+INSERT INTO engine_processing_versions (type_id) VALUES ('flap');
+
+-- +migrate Down
+DELETE FROM engine_processing_versions WHERE type_id = 'flap';
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE services
+    ADD COLUMN auto_resolve_minutes INT NOT NULL DEFAULT 0 CHECK (auto_resolve_minutes >= 0);
+
+-- +migrate Down
+ALTER TABLE services
+    DROP COLUMN auto_resolve_minutes;
@@ -0,0 +1,15 @@
+-- +migrate Up
+ALTER TABLE escalation_policies
+    ADD COLUMN ack_timeout_minutes INT NOT NULL DEFAULT 0 CHECK (ack_timeout_minutes >= 0);
+
+UPDATE engine_processing_versions
+SET "version" = 4
+WHERE type_id = 'escalation';
+
+-- +migrate Down
+UPDATE engine_processing_versions
+SET "version" = 3
+WHERE type_id = 'escalation';
+
+ALTER TABLE escalation_policies
+    DROP COLUMN ack_timeout_minutes;
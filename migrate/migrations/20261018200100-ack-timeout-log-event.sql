@@ -0,0 +1,4 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_alert_log_event ADD VALUE IF NOT EXISTS 'ack_timeout';
+
+-- +migrate Down
@@ -0,0 +1,27 @@
+-- +migrate Up
+ALTER TABLE escalation_policies
+    ADD COLUMN repeat_delay_minutes INT NOT NULL DEFAULT 0 CHECK (repeat_delay_minutes >= 0),
+    ADD COLUMN fallback_user_id UUID REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN fallback_channel_id UUID REFERENCES notification_channels (id) ON DELETE SET NULL,
+    ADD CONSTRAINT ep_single_fallback_target CHECK (fallback_user_id ISNULL OR fallback_channel_id ISNULL);
+
+ALTER TABLE escalation_policy_state
+    ADD COLUMN fallback_notified BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE engine_processing_versions
+SET "version" = 5
+WHERE type_id = 'escalation';
+
+-- +migrate Down
+UPDATE engine_processing_versions
+SET "version" = 4
+WHERE type_id = 'escalation';
+
+ALTER TABLE escalation_policy_state
+    DROP COLUMN fallback_notified;
+
+ALTER TABLE escalation_policies
+    DROP CONSTRAINT ep_single_fallback_target,
+    DROP COLUMN repeat_delay_minutes,
+    DROP COLUMN fallback_user_id,
+    DROP COLUMN fallback_channel_id;
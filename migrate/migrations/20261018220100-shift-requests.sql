@@ -0,0 +1,53 @@
+-- +migrate Up
+CREATE TYPE enum_shift_request_status AS ENUM (
+    'pending',
+    'accepted',
+    'declined',
+    'cancelled'
+);
+
+CREATE TABLE shift_requests (
+    id UUID PRIMARY KEY,
+    schedule_id UUID NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+    requester_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    swap_start_time TIMESTAMP WITH TIME ZONE,
+    swap_end_time TIMESTAMP WITH TIME ZONE,
+    note TEXT NOT NULL DEFAULT '',
+    status enum_shift_request_status NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    responded_at TIMESTAMP WITH TIME ZONE,
+
+    CHECK (requester_id != user_id),
+    CHECK (end_time > start_time),
+    CHECK ((swap_start_time ISNULL) = (swap_end_time ISNULL)),
+    CHECK (swap_end_time > swap_start_time)
+);
+
+CREATE INDEX idx_shift_requests_schedule ON shift_requests (schedule_id);
+CREATE INDEX idx_shift_requests_requester ON shift_requests (requester_id);
+CREATE INDEX idx_shift_requests_user ON shift_requests (user_id);
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN shift_request_id UUID REFERENCES shift_requests (id) ON DELETE CASCADE,
+    ADD CONSTRAINT om_shift_request_id CHECK (message_type != 'shift_request' OR shift_request_id NOTNULL);
+
+UPDATE engine_processing_versions
+SET "version" = 8
+WHERE type_id = 'message';
+
+-- +migrate Down
+UPDATE engine_processing_versions
+SET "version" = 7
+WHERE type_id = 'message';
+
+DELETE FROM outgoing_messages WHERE message_type = 'shift_request';
+
+ALTER TABLE outgoing_messages
+    DROP CONSTRAINT om_shift_request_id,
+    DROP COLUMN shift_request_id;
+
+DROP TABLE shift_requests;
+DROP TYPE enum_shift_request_status;
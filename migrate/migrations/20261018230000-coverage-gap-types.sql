@@ -0,0 +1,7 @@
+-- +migrate Up notransaction
+ALTER TYPE enum_outgoing_messages_type ADD VALUE IF NOT EXISTS 'schedule_coverage_gap';
+ALTER TYPE engine_processing_type ADD VALUE IF NOT EXISTS 'coverage';
+INSERT INTO engine_processing_versions (type_id) VALUES ('coverage');
+
+-- +migrate Down
+DELETE FROM engine_processing_versions WHERE type_id = 'coverage';
@@ -0,0 +1,46 @@
+-- +migrate Up
+ALTER TABLE schedules
+    ADD COLUMN gap_notify_days INT NOT NULL DEFAULT 0 CHECK (gap_notify_days BETWEEN 0 AND 30),
+    ADD COLUMN gap_notify_user_id UUID REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE TABLE schedule_coverage_checks (
+    schedule_id UUID PRIMARY KEY REFERENCES schedules (id) ON DELETE CASCADE,
+    last_check_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE TABLE schedule_coverage_gaps (
+    id BIGSERIAL PRIMARY KEY,
+    schedule_id UUID NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    UNIQUE (schedule_id, start_time),
+    CHECK (end_time > start_time)
+);
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN schedule_coverage_gap_id BIGINT REFERENCES schedule_coverage_gaps (id) ON DELETE CASCADE,
+    ADD CONSTRAINT om_schedule_coverage_gap_id CHECK (message_type != 'schedule_coverage_gap' OR schedule_coverage_gap_id NOTNULL);
+
+UPDATE engine_processing_versions
+SET "version" = 9
+WHERE type_id = 'message';
+
+-- +migrate Down
+UPDATE engine_processing_versions
+SET "version" = 8
+WHERE type_id = 'message';
+
+DELETE FROM outgoing_messages WHERE message_type = 'schedule_coverage_gap';
+
+ALTER TABLE outgoing_messages
+    DROP CONSTRAINT om_schedule_coverage_gap_id,
+    DROP COLUMN schedule_coverage_gap_id;
+
+DROP TABLE schedule_coverage_gaps;
+DROP TABLE schedule_coverage_checks;
+
+ALTER TABLE schedules
+    DROP COLUMN gap_notify_days,
+    DROP COLUMN gap_notify_user_id;
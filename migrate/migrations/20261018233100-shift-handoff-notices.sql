@@ -0,0 +1,48 @@
+-- +migrate Up
+ALTER TABLE users
+    ADD COLUMN handoff_notify BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN handoff_notify_minutes INT NOT NULL DEFAULT 0 CHECK (handoff_notify_minutes BETWEEN 0 AND 10080);
+
+CREATE TABLE schedule_handoff_checks (
+    schedule_id UUID PRIMARY KEY REFERENCES schedules (id) ON DELETE CASCADE,
+    last_check_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE TABLE user_handoff_notices (
+    id BIGSERIAL PRIMARY KEY,
+    schedule_id UUID NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    shift_ended BOOLEAN NOT NULL,
+    shift_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    shift_end TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    UNIQUE (schedule_id, user_id, shift_ended, shift_start),
+    CHECK (NOT shift_ended OR shift_end NOTNULL)
+);
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN user_handoff_notice_id BIGINT REFERENCES user_handoff_notices (id) ON DELETE CASCADE,
+    ADD CONSTRAINT om_user_handoff_notice_id CHECK (message_type != 'shift_handoff' OR user_handoff_notice_id NOTNULL);
+
+UPDATE engine_processing_versions
+SET "version" = 10
+WHERE type_id = 'message';
+
+-- +migrate Down
+UPDATE engine_processing_versions
+SET "version" = 9
+WHERE type_id = 'message';
+
+DELETE FROM outgoing_messages WHERE message_type = 'shift_handoff';
+
+ALTER TABLE outgoing_messages
+    DROP CONSTRAINT om_user_handoff_notice_id,
+    DROP COLUMN user_handoff_notice_id;
+
+DROP TABLE user_handoff_notices;
+DROP TABLE schedule_handoff_checks;
+
+ALTER TABLE users
+    DROP COLUMN handoff_notify,
+    DROP COLUMN handoff_notify_minutes;
@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE user_time_off (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+
+    CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_user_time_off_user ON user_time_off (user_id);
+CREATE INDEX idx_user_time_off_end ON user_time_off (end_time);
+
+ALTER TABLE user_overrides
+    ADD COLUMN time_off_id UUID REFERENCES user_time_off (id) ON DELETE CASCADE;
+
+ALTER TABLE schedules
+    ADD COLUMN time_off_strategy TEXT NOT NULL DEFAULT 'remove' CHECK (time_off_strategy IN ('remove', 'replace_next'));
+
+-- +migrate Down
+ALTER TABLE schedules
+    DROP COLUMN time_off_strategy;
+
+ALTER TABLE user_overrides
+    DROP COLUMN time_off_id;
+
+DROP TABLE user_time_off;
@@ -0,0 +1,87 @@
+-- +migrate Up
+UPDATE engine_processing_versions
+SET version = 3
+WHERE type_id = 'rotation';
+
+ALTER TABLE rotation_participants
+    ADD COLUMN unavailable_until TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN skip_credit INT NOT NULL DEFAULT 0 CHECK (skip_credit >= 0);
+
+ALTER TABLE rotation_state
+    ADD COLUMN resume_position INT;
+
+CREATE TABLE rotation_shift_history (
+    id BIGSERIAL PRIMARY KEY,
+    rotation_id UUID NOT NULL REFERENCES rotations (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    end_time TIMESTAMP WITH TIME ZONE,
+
+    CHECK (end_time ISNULL OR end_time >= start_time)
+);
+
+CREATE INDEX idx_rotation_shift_history_rot ON rotation_shift_history (rotation_id, start_time);
+CREATE UNIQUE INDEX idx_rotation_shift_history_open ON rotation_shift_history (rotation_id) WHERE end_time ISNULL;
+
+INSERT INTO rotation_shift_history (rotation_id, user_id, start_time)
+SELECT state.rotation_id, part.user_id, state.shift_start
+FROM rotation_state state
+JOIN rotation_participants part ON part.id = state.rotation_participant_id;
+
+-- +migrate StatementBegin
+CREATE FUNCTION fn_track_rotation_shift() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE rotation_shift_history
+        SET end_time = now()
+        WHERE rotation_id = OLD.rotation_id AND end_time ISNULL;
+
+        RETURN OLD;
+    END IF;
+
+    UPDATE rotation_shift_history
+    SET end_time = now()
+    WHERE rotation_id = NEW.rotation_id AND end_time ISNULL;
+
+    INSERT INTO rotation_shift_history (rotation_id, user_id)
+    SELECT NEW.rotation_id, part.user_id
+    FROM rotation_participants part
+    WHERE part.id = NEW.rotation_participant_id;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_track_rotation_shift_insert
+AFTER INSERT ON rotation_state
+FOR EACH ROW EXECUTE PROCEDURE fn_track_rotation_shift();
+
+CREATE TRIGGER trg_track_rotation_shift_update
+AFTER UPDATE ON rotation_state
+FOR EACH ROW
+WHEN (NEW.rotation_participant_id != OLD.rotation_participant_id)
+EXECUTE PROCEDURE fn_track_rotation_shift();
+
+CREATE TRIGGER trg_track_rotation_shift_delete
+AFTER DELETE ON rotation_state
+FOR EACH ROW EXECUTE PROCEDURE fn_track_rotation_shift();
+
+-- +migrate Down
+DROP TRIGGER trg_track_rotation_shift_delete ON rotation_state;
+DROP TRIGGER trg_track_rotation_shift_update ON rotation_state;
+DROP TRIGGER trg_track_rotation_shift_insert ON rotation_state;
+DROP FUNCTION fn_track_rotation_shift();
+
+DROP TABLE rotation_shift_history;
+
+ALTER TABLE rotation_state
+    DROP COLUMN resume_position;
+
+ALTER TABLE rotation_participants
+    DROP COLUMN unavailable_until,
+    DROP COLUMN skip_credit;
+
+UPDATE engine_processing_versions
+SET version = 2
+WHERE type_id = 'rotation';
@@ -0,0 +1,22 @@
+-- +migrate Up
+ALTER TABLE user_calendar_subscriptions
+    ADD COLUMN scope TEXT NOT NULL DEFAULT 'my_shifts',
+    ADD COLUMN service_id UUID REFERENCES services (id) ON DELETE CASCADE,
+    ALTER COLUMN schedule_id DROP NOT NULL,
+    ADD CONSTRAINT user_calendar_subscriptions_scope_check CHECK (
+        (scope IN ('my_shifts', 'schedule') AND schedule_id NOTNULL AND service_id ISNULL) OR
+        (scope = 'user' AND schedule_id ISNULL AND service_id ISNULL) OR
+        (scope = 'service' AND schedule_id ISNULL AND service_id NOTNULL)
+    );
+
+CREATE INDEX idx_calendar_subscriptions_service_id ON user_calendar_subscriptions (service_id);
+
+-- +migrate Down
+DELETE FROM user_calendar_subscriptions
+WHERE scope != 'my_shifts';
+
+ALTER TABLE user_calendar_subscriptions
+    DROP CONSTRAINT user_calendar_subscriptions_scope_check,
+    DROP COLUMN scope,
+    DROP COLUMN service_id,
+    ALTER COLUMN schedule_id SET NOT NULL;
@@ -0,0 +1,35 @@
+-- +migrate Up
+CREATE TABLE holiday_calendars (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE TABLE holidays (
+    id BIGSERIAL PRIMARY KEY,
+    calendar_id UUID NOT NULL REFERENCES holiday_calendars (id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    UNIQUE (calendar_id, date)
+);
+
+ALTER TABLE schedules
+    ADD COLUMN holiday_calendar_id UUID REFERENCES holiday_calendars (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_schedules_holiday_calendar_id ON schedules (holiday_calendar_id);
+
+ALTER TABLE schedule_rules
+    ADD COLUMN holiday_mode TEXT NOT NULL DEFAULT 'ignore',
+    ADD CONSTRAINT schedule_rules_holiday_mode_check CHECK (holiday_mode IN ('ignore', 'exclude', 'include'));
+
+-- +migrate Down
+ALTER TABLE schedule_rules
+    DROP CONSTRAINT schedule_rules_holiday_mode_check,
+    DROP COLUMN holiday_mode;
+
+ALTER TABLE schedules
+    DROP COLUMN holiday_calendar_id;
+
+DROP TABLE holidays;
+DROP TABLE holiday_calendars;
@@ -0,0 +1,14 @@
+-- +migrate Up
+
+ALTER TABLE heartbeat_monitors
+    ADD COLUMN last_failure TIMESTAMP WITH TIME ZONE;
+
+-- failure reports previously updated last_heartbeat
+UPDATE heartbeat_monitors
+SET last_failure = last_heartbeat
+WHERE last_report_failed;
+
+-- +migrate Down
+
+ALTER TABLE heartbeat_monitors
+    DROP COLUMN last_failure;
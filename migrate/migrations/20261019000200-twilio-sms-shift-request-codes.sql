@@ -0,0 +1,11 @@
+-- +migrate Up
+
+ALTER TABLE twilio_sms_callbacks
+    ADD shift_request_id UUID REFERENCES shift_requests (id) ON DELETE CASCADE;
+
+CREATE INDEX idx_twilio_sms_shift_request_id ON twilio_sms_callbacks (shift_request_id);
+
+-- +migrate Down
+
+DELETE FROM twilio_sms_callbacks WHERE shift_request_id NOTNULL;
+ALTER TABLE twilio_sms_callbacks DROP shift_request_id;
@@ -0,0 +1,150 @@
+package newrelic
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// post handles both the default workflow webhook payload (issueId, title, state)
+// and the legacy alert channel payload (incident_id, condition_name, current_state).
+type post struct {
+	IssueID  string `json:"issueId"`
+	IssueURL string `json:"issueUrl"`
+	Title    string `json:"title"`
+	Priority string `json:"priority"`
+	State    string `json:"state"`
+
+	IncidentID    json.Number `json:"incident_id"`
+	IncidentURL   string      `json:"incident_url"`
+	PolicyName    string      `json:"policy_name"`
+	ConditionName string      `json:"condition_name"`
+	CurrentState  string      `json:"current_state"`
+	Details       string      `json:"details"`
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+func (p post) isLegacy() bool { return p.IssueID == "" }
+
+func (p post) dedup() string {
+	if p.isLegacy() {
+		return p.IncidentID.String()
+	}
+	return p.IssueID
+}
+
+func (p post) state() string {
+	if p.isLegacy() {
+		return p.CurrentState
+	}
+	return p.State
+}
+
+func (p post) summary() string {
+	if p.isLegacy() {
+		if p.PolicyName == "" {
+			return p.ConditionName
+		}
+		return p.PolicyName + ": " + p.ConditionName
+	}
+	return p.Title
+}
+
+func (p post) details() string {
+	urlStr, field, details := p.IssueURL, "issueUrl", ""
+	if p.isLegacy() {
+		urlStr, field, details = p.IncidentURL, "incident_url", p.Details
+	} else if p.Priority != "" {
+		details = "Priority: " + p.Priority
+	}
+	if validate.AbsoluteURL(field, urlStr) != nil {
+		urlStr = ""
+	}
+
+	return strings.TrimSpace(urlStr + "\n\n" + details)
+}
+
+func NewRelicToEventsAPI(aDB alert.Store, intDB integrationkey.Store) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		var p post
+		err = json.NewDecoder(r.Body).Decode(&p)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from new relic: %v", err)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"IssueID": p.dedup(),
+			"State":   p.state(),
+		})
+
+		var status alert.Status
+		switch strings.ToLower(p.state()) {
+		case "open", "created", "activated":
+			status = alert.StatusTriggered
+		case "closed":
+			status = alert.StatusClosed
+		case "acknowledged":
+			// acknowledgement in New Relic does not change the alert
+			return
+		default:
+			log.Logf(ctx, "bad request from new relic: missing or invalid state")
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+
+		dedup := r.FormValue("dedup")
+		if dedup == "" {
+			dedup = p.dedup()
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(p.summary(), alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(p.details(), alert.MaxDetailsLength),
+			Status:    status,
+			Source:    alert.SourceNewRelic,
+			ServiceID: serviceID,
+			Dedup:     alert.NewUserDedup(dedup),
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for new relic")) {
+			return
+		}
+	}
+}
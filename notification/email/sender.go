@@ -109,6 +109,65 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (string, *n
 		}}
 		e.Body.Outros = []string{"You are receiving this message because you have status updates enabled. Visit your Profile page to change this."}
 
+	case notification.ShiftRequest:
+		if m.ForRequester {
+			subject = fmt.Sprintf("GoAlert: %s %s your shift request on %s", m.UserName, m.Status, m.ScheduleName)
+			e.Body.Title = "Shift Request " + strings.Title(m.Status)
+		} else {
+			subject = fmt.Sprintf("GoAlert: Shift request from %s on %s", m.RequesterName, m.ScheduleName)
+			e.Body.Title = "Shift Request"
+			e.Body.Outros = []string{"Open the shift requests page of the schedule to accept or decline."}
+		}
+		e.Body.Intros = []string{m.Summary()}
+		e.Body.Actions = []hermes.Action{{
+			Button: hermes.Button{
+				Text: "Open Shift Requests",
+				Link: cfg.CallbackURL(fmt.Sprintf("/schedules/%s/shift-requests", m.ScheduleID)),
+			},
+		}}
+
+	case notification.ScheduleCoverageGap:
+		subject = fmt.Sprintf("GoAlert: Upcoming coverage gap on %s", m.ScheduleName)
+		e.Body.Title = "Upcoming Coverage Gap"
+		e.Body.Intros = []string{m.Summary()}
+		e.Body.Actions = []hermes.Action{{
+			Button: hermes.Button{
+				Text: "Open Schedule Shifts",
+				Link: cfg.CallbackURL(fmt.Sprintf("/schedules/%s/shifts", m.ScheduleID)),
+			},
+		}}
+		e.Body.Outros = []string{"You are receiving this message because you are a contact for coverage gaps on this schedule, or have it marked as a favorite."}
+
+	case notification.ShiftHandoff:
+		if m.Ended {
+			subject = fmt.Sprintf("GoAlert: Your on-call shift for %s has ended", m.ScheduleName)
+			e.Body.Title = "On-Call Shift Ended"
+		} else {
+			subject = fmt.Sprintf("GoAlert: Your on-call shift for %s starts soon", m.ScheduleName)
+			e.Body.Title = "On-Call Shift Starting"
+		}
+		e.Body.Intros = []string{m.Summary()}
+		for _, a := range m.Alerts {
+			status := "closed"
+			if a.Open {
+				status = "open"
+			}
+			e.Body.Dictionary = append(e.Body.Dictionary, hermes.Entry{
+				Key:   fmt.Sprintf("Alert #%d (%s)", a.ID, status),
+				Value: a.Summary + " - " + cfg.CallbackURL(fmt.Sprintf("/alerts/%d", a.ID)),
+			})
+		}
+		if more := m.AlertCount - len(m.Alerts); more > 0 {
+			e.Body.Intros = append(e.Body.Intros, fmt.Sprintf("The first %d alerts are listed below, %d more are not shown.", len(m.Alerts), more))
+		}
+		e.Body.Actions = []hermes.Action{{
+			Button: hermes.Button{
+				Text: "Open Schedule Shifts",
+				Link: cfg.CallbackURL(fmt.Sprintf("/schedules/%s/shifts", m.ScheduleID)),
+			},
+		}}
+		e.Body.Outros = []string{"You are receiving this message because you have on-call handoff notifications enabled. Visit your Profile page to change this."}
+
 	default:
 		return "", nil, errors.New("message type not supported")
 	}
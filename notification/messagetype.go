@@ -19,6 +19,9 @@ const (
 	MessageTypeVerification
 	MessageTypeAlertBundle
 	MessageTypeAlertStatusBundle
+	MessageTypeShiftRequest
+	MessageTypeScheduleCoverageGap
+	MessageTypeShiftHandoff
 )
 
 func (s MessageType) Value() (driver.Value, error) {
@@ -35,6 +38,12 @@ func (s MessageType) Value() (driver.Value, error) {
 		return "alert_notification_bundle", nil
 	case MessageTypeAlertStatusBundle:
 		return "alert_status_update_bundle", nil
+	case MessageTypeShiftRequest:
+		return "shift_request", nil
+	case MessageTypeScheduleCoverageGap:
+		return "schedule_coverage_gap", nil
+	case MessageTypeShiftHandoff:
+		return "shift_handoff", nil
 	}
 	return nil, fmt.Errorf("could not process unknown type for MessageType %s", s)
 }
@@ -55,6 +64,12 @@ func (s *MessageType) Scan(value interface{}) error {
 		*s = MessageTypeAlertBundle
 	case "alert_status_update_bundle":
 		*s = MessageTypeAlertStatusBundle
+	case "shift_request":
+		*s = MessageTypeShiftRequest
+	case "schedule_coverage_gap":
+		*s = MessageTypeScheduleCoverageGap
+	case "shift_handoff":
+		*s = MessageTypeShiftHandoff
 	default:
 		return fmt.Errorf("could not process unknown type for MessageType %str", str)
 	}
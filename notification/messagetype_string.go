@@ -15,11 +15,14 @@ func _() {
 	_ = x[MessageTypeVerification-4]
 	_ = x[MessageTypeAlertBundle-5]
 	_ = x[MessageTypeAlertStatusBundle-6]
+	_ = x[MessageTypeShiftRequest-7]
+	_ = x[MessageTypeScheduleCoverageGap-8]
+	_ = x[MessageTypeShiftHandoff-9]
 }
 
-const _MessageType_name = "MessageTypeUnknownMessageTypeAlertMessageTypeAlertStatusMessageTypeTestMessageTypeVerificationMessageTypeAlertBundleMessageTypeAlertStatusBundle"
+const _MessageType_name = "MessageTypeUnknownMessageTypeAlertMessageTypeAlertStatusMessageTypeTestMessageTypeVerificationMessageTypeAlertBundleMessageTypeAlertStatusBundleMessageTypeShiftRequestMessageTypeScheduleCoverageGapMessageTypeShiftHandoff"
 
-var _MessageType_index = [...]uint8{0, 18, 34, 56, 71, 94, 116, 144}
+var _MessageType_index = [...]uint8{0, 18, 34, 56, 71, 94, 116, 144, 167, 197, 220}
 
 func (i MessageType) String() string {
 	if i < 0 || i >= MessageType(len(_MessageType_index)-1) {
@@ -9,4 +9,6 @@ type Result int
 const (
 	ResultAcknowledge Result = iota
 	ResultResolve
+	ResultAccept
+	ResultDecline
 )
@@ -10,11 +10,13 @@ func _() {
 	var x [1]struct{}
 	_ = x[ResultAcknowledge-0]
 	_ = x[ResultResolve-1]
+	_ = x[ResultAccept-2]
+	_ = x[ResultDecline-3]
 }
 
-const _Result_name = "ResultAcknowledgeResultResolve"
+const _Result_name = "ResultAcknowledgeResultResolveResultAcceptResultDecline"
 
-var _Result_index = [...]uint8{0, 17, 30}
+var _Result_index = [...]uint8{0, 17, 30, 42, 55}
 
 func (i Result) String() string {
 	if i < 0 || i >= Result(len(_Result_index)-1) {
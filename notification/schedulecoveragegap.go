@@ -0,0 +1,30 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+)
+
+// ScheduleCoverageGap represents an outgoing notification about an upcoming period
+// where nobody will be on-call for a schedule.
+type ScheduleCoverageGap struct {
+	Dest       Dest
+	CallbackID string // CallbackID is the identifier used to communicate a response to the notification
+
+	ScheduleID   string
+	ScheduleName string
+
+	// Start and End should be in the schedule's time zone.
+	Start, End time.Time
+}
+
+var _ Message = &ScheduleCoverageGap{}
+
+func (g ScheduleCoverageGap) Type() MessageType { return MessageTypeScheduleCoverageGap }
+func (g ScheduleCoverageGap) ID() string        { return g.CallbackID }
+func (g ScheduleCoverageGap) Destination() Dest { return g.Dest }
+
+// Summary returns a short description of the gap, suitable for SMS or an email intro.
+func (g ScheduleCoverageGap) Summary() string {
+	return fmt.Sprintf("Nobody is scheduled to be on-call for %s from %s.", g.ScheduleName, shiftRange(g.Start, g.End))
+}
@@ -0,0 +1,89 @@
+package notification
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ShiftHandoff represents an outgoing notification to a user that their on-call shift
+// is about to start, or has ended.
+type ShiftHandoff struct {
+	Dest       Dest
+	CallbackID string // CallbackID is the identifier used to communicate a response to the notification
+
+	ScheduleID   string
+	ScheduleName string
+
+	// Start and End should be in the schedule's time zone. End is zero if
+	// the end of an upcoming shift is not yet known.
+	Start, End time.Time
+
+	// Ended is true if the shift is over, rather than about to start.
+	Ended bool
+
+	// AlertCount and OpenAlertCount summarize alerts relevant to an ended shift.
+	AlertCount     int
+	OpenAlertCount int
+
+	// Alerts lists some or all of the counted alerts.
+	Alerts []ShiftHandoffAlert
+}
+
+// ShiftHandoffAlert is an alert relevant to an ended shift.
+type ShiftHandoffAlert struct {
+	ID      int
+	Summary string
+	Open    bool
+}
+
+var _ Message = &ShiftHandoff{}
+
+func (h ShiftHandoff) Type() MessageType { return MessageTypeShiftHandoff }
+func (h ShiftHandoff) ID() string        { return h.CallbackID }
+func (h ShiftHandoff) Destination() Dest { return h.Dest }
+
+// Summary returns a short description of the handoff, suitable for SMS or an email intro.
+func (h ShiftHandoff) Summary() string {
+	if !h.Ended {
+		if h.End.IsZero() {
+			return fmt.Sprintf("Your on-call shift for %s starts %s.", h.ScheduleName, h.Start.Format(shiftTimeFmt))
+		}
+		return fmt.Sprintf("Your on-call shift for %s starts %s (until %s).", h.ScheduleName, h.Start.Format(shiftTimeFmt), h.End.Format(shiftTimeFmt))
+	}
+
+	var alerts string
+	switch {
+	case h.AlertCount == 0:
+		alerts = "No alerts fired during your shift."
+	case h.AlertCount == 1:
+		alerts = "1 alert fired during your shift"
+	default:
+		alerts = fmt.Sprintf("%d alerts fired during your shift", h.AlertCount)
+	}
+	if h.AlertCount > 0 {
+		alerts += fmt.Sprintf(" (%d still open).", h.OpenAlertCount)
+	}
+
+	return fmt.Sprintf("Your on-call shift for %s from %s has ended. %s", h.ScheduleName, shiftRange(h.Start, h.End), alerts)
+}
+
+// AlertIDs returns a short list of the alerts relevant to an ended shift (e.g. `#1, #2 and 3 more`),
+// or an empty string if there are none.
+func (h ShiftHandoff) AlertIDs() string {
+	if len(h.Alerts) == 0 {
+		return ""
+	}
+
+	ids := make([]string, len(h.Alerts))
+	for i, a := range h.Alerts {
+		ids[i] = "#" + strconv.Itoa(a.ID)
+	}
+	res := strings.Join(ids, ", ")
+	if more := h.AlertCount - len(h.Alerts); more > 0 {
+		res += fmt.Sprintf(" and %d more", more)
+	}
+
+	return res
+}
@@ -0,0 +1,59 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+)
+
+const shiftTimeFmt = "Mon Jan 2 3:04pm MST"
+
+// ShiftRequest represents an outgoing notification about a shift swap or cover request.
+type ShiftRequest struct {
+	Dest       Dest
+	CallbackID string // CallbackID is the identifier used to communicate a response to the notification
+
+	RequestID     string
+	ScheduleID    string
+	ScheduleName  string
+	RequesterName string
+	UserName      string // The counterpart asked to take the shift
+
+	// Start, End, SwapStart and SwapEnd should be in the schedule's time zone. SwapStart
+	// and SwapEnd are zero unless the request is a trade.
+	Start, End         time.Time
+	SwapStart, SwapEnd time.Time
+
+	// Status is one of `pending`, `accepted`, or `declined`.
+	Status string
+
+	// ForRequester is true if the notification is sent to the requester, rather than the counterpart.
+	ForRequester bool
+}
+
+var _ Message = &ShiftRequest{}
+
+func (r ShiftRequest) Type() MessageType { return MessageTypeShiftRequest }
+func (r ShiftRequest) ID() string        { return r.CallbackID }
+func (r ShiftRequest) Destination() Dest { return r.Dest }
+
+func shiftRange(start, end time.Time) string {
+	return start.Format(shiftTimeFmt) + " to " + end.Format(shiftTimeFmt)
+}
+
+// Summary returns a short description of the request, suitable for SMS or an email intro.
+func (r ShiftRequest) Summary() string {
+	var trade string
+	if !r.SwapStart.IsZero() {
+		trade = fmt.Sprintf(" in exchange for %s", shiftRange(r.SwapStart, r.SwapEnd))
+	}
+
+	if r.ForRequester {
+		return fmt.Sprintf("%s %s your request to cover %s on %s%s.",
+			r.UserName, r.Status, shiftRange(r.Start, r.End), r.ScheduleName, trade,
+		)
+	}
+
+	return fmt.Sprintf("%s asked you to cover %s on %s%s.",
+		r.RequesterName, shiftRange(r.Start, r.End), r.ScheduleName, trade,
+	)
+}
@@ -17,8 +17,9 @@ type dbSMS struct {
 	lookupLatest *sql.Stmt
 	existingCode *sql.Stmt
 
-	lookupByAlert   *sql.Stmt
-	lookupSvcByCode *sql.Stmt
+	lookupByAlert     *sql.Stmt
+	lookupSvcByCode   *sql.Stmt
+	lookupShiftByCode *sql.Stmt
 
 	getInUse *sql.Stmt
 }
@@ -39,7 +40,8 @@ func newDB(ctx context.Context, db *sql.DB) (*dbSMS, error) {
 			WHERE
 				phone_number = $1 AND (
 					service_id NOTNULL OR
-					(SELECT true FROM alerts a WHERE a.id = cb.alert_id AND a.status != 'closed')
+					(SELECT true FROM alerts a WHERE a.id = cb.alert_id AND a.status != 'closed') OR
+					(SELECT true FROM shift_requests r WHERE r.id = cb.shift_request_id AND r.status = 'pending')
 				)
 		`),
 
@@ -48,7 +50,8 @@ func newDB(ctx context.Context, db *sql.DB) (*dbSMS, error) {
 			FROM twilio_sms_callbacks cb
 			WHERE
 				phone_number = $1 AND (
-					service_id = $3 OR (
+					service_id = $3 OR
+					shift_request_id = $4 OR (
 						cb.alert_id = $2 AND
 						(SELECT true FROM alerts a WHERE a.id = $2 AND a.status != 'closed')
 					)
@@ -56,14 +59,15 @@ func newDB(ctx context.Context, db *sql.DB) (*dbSMS, error) {
 		`),
 
 		insert: p(`
-			INSERT INTO twilio_sms_callbacks (phone_number, callback_id, code, alert_id, service_id)
-			VALUES ($1, $2, $3, $4, $5)
+			INSERT INTO twilio_sms_callbacks (phone_number, callback_id, code, alert_id, service_id, shift_request_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
 			ON CONFLICT (phone_number, code) DO UPDATE
 			SET
 				callback_id = $2,
 				alert_id = $4,
 				sent_at = now(),
-				service_id = $5
+				service_id = $5,
+				shift_request_id = $6
 		`),
 
 		lookupSvcByCode: p(`
@@ -72,6 +76,11 @@ func newDB(ctx context.Context, db *sql.DB) (*dbSMS, error) {
 			JOIN services svc ON svc.id = service_id
 			WHERE phone_number = $1 AND code = $2
 		`),
+		lookupShiftByCode: p(`
+			SELECT callback_id, shift_request_id
+			FROM twilio_sms_callbacks
+			WHERE phone_number = $1 AND code = $2 AND shift_request_id NOTNULL
+		`),
 		lookupByCode:  p(`SELECT callback_id, alert_id, NULL FROM twilio_sms_callbacks WHERE phone_number = $1 AND code = $2`),
 		lookupByAlert: p(`SELECT callback_id, alert_id, NULL FROM twilio_sms_callbacks WHERE phone_number = $1 AND alert_id = $2`),
 
@@ -85,7 +94,7 @@ func newDB(ctx context.Context, db *sql.DB) (*dbSMS, error) {
 	}, prep.Err
 }
 
-func (db *dbSMS) insertDB(ctx context.Context, phoneNumber, callbackID string, alertID int, serviceID string) (int, error) {
+func (db *dbSMS) insertDB(ctx context.Context, phoneNumber, callbackID string, alertID int, serviceID, shiftRequestID string) (int, error) {
 	tx, err := db.db.BeginTx(ctx, nil)
 	if err != nil {
 		return 0, err
@@ -97,15 +106,19 @@ func (db *dbSMS) insertDB(ctx context.Context, phoneNumber, callbackID string, a
 	}
 	aID := sql.NullInt64{Int64: int64(alertID)}
 	sID := sql.NullString{String: serviceID}
+	rID := sql.NullString{String: shiftRequestID}
 	if alertID != 0 {
 		aID.Valid = true
 	}
 	if serviceID != "" {
 		sID.Valid = true
 	}
+	if shiftRequestID != "" {
+		rID.Valid = true
+	}
 
 	var existingCode sql.NullInt64
-	err = tx.StmtContext(ctx, db.existingCode).QueryRowContext(ctx, phoneNumber, aID, sID).Scan(&existingCode)
+	err = tx.StmtContext(ctx, db.existingCode).QueryRowContext(ctx, phoneNumber, aID, sID, rID).Scan(&existingCode)
 	if errors.Is(err, sql.ErrNoRows) {
 		err = nil
 	}
@@ -142,7 +155,7 @@ func (db *dbSMS) insertDB(ctx context.Context, phoneNumber, callbackID string, a
 		code++
 	}
 
-	_, err = tx.StmtContext(ctx, db.insert).ExecContext(ctx, phoneNumber, callbackID, code, aID, sID)
+	_, err = tx.StmtContext(ctx, db.insert).ExecContext(ctx, phoneNumber, callbackID, code, aID, sID, rID)
 	if err != nil {
 		return 0, err
 	}
@@ -151,9 +164,10 @@ func (db *dbSMS) insertDB(ctx context.Context, phoneNumber, callbackID string, a
 }
 
 type codeInfo struct {
-	ServiceName string
-	AlertID     int
-	CallbackID  string
+	ServiceName    string
+	AlertID        int
+	ShiftRequestID string
+	CallbackID     string
 }
 
 func (c *codeInfo) scanFrom(row *sql.Row) error {
@@ -195,3 +209,8 @@ func (db *dbSMS) LookupSvcByCode(ctx context.Context, phoneNumber string, code i
 	err := info.scanFrom(row)
 	return info, err
 }
+func (db *dbSMS) LookupShiftByCode(ctx context.Context, phoneNumber string, code int) (*codeInfo, error) {
+	info := &codeInfo{}
+	err := db.lookupShiftByCode.QueryRowContext(ctx, phoneNumber, code).Scan(&info.CallbackID, &info.ShiftRequestID)
+	return info, err
+}
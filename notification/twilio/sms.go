@@ -17,6 +17,7 @@ import (
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
 
 	"github.com/pkg/errors"
 )
@@ -27,6 +28,8 @@ var (
 	alertReplyRx = regexp.MustCompile(`^'?\s*(c|close|a|ack[a-z]*)\s*#?\s*([0-9]+)\s*'?$`)
 
 	svcReplyRx = regexp.MustCompile(`^'?\s*([0-9]+)\s*(cc|aa)\s*'?$`)
+
+	shiftReplyRx = regexp.MustCompile(`^'?\s*([0-9]+)\s*(y|yes|accept|n|no|decline)\s*'?$`)
 )
 
 // SMS implements a notification.Sender for Twilio SMS.
@@ -101,10 +104,10 @@ func (s *SMS) Send(ctx context.Context, msg notification.Message) (string, *noti
 		return "", nil, errors.New("number had too many outgoing errors recently")
 	}
 
-	makeSMSCode := func(alertID int, serviceID string) int {
+	makeSMSCode := func(alertID int, serviceID, shiftRequestID string) int {
 		var code int
 		if hasTwoWaySMSSupport(ctx, destNumber) {
-			code, err = s.b.insertDB(ctx, destNumber, msg.ID(), alertID, serviceID)
+			code, err = s.b.insertDB(ctx, destNumber, msg.ID(), alertID, serviceID, shiftRequestID)
 			if err != nil {
 				log.Log(ctx, errors.Wrap(err, "insert alert id for SMS callback -- sending 1-way SMS as fallback"))
 			}
@@ -135,7 +138,7 @@ func (s *SMS) Send(ctx context.Context, msg notification.Message) (string, *noti
 			Count: t.Count,
 			Body:  t.ServiceName,
 			Link:  link,
-			Code:  makeSMSCode(0, t.ServiceID),
+			Code:  makeSMSCode(0, t.ServiceID, ""),
 		}.Render()
 	case notification.Alert:
 		var link string
@@ -147,8 +150,33 @@ func (s *SMS) Send(ctx context.Context, msg notification.Message) (string, *noti
 			ID:   t.AlertID,
 			Body: t.Summary,
 			Link: link,
-			Code: makeSMSCode(t.AlertID, ""),
+			Code: makeSMSCode(t.AlertID, "", ""),
 		}.Render()
+	case notification.ShiftRequest:
+		message = "GoAlert: " + t.Summary()
+		if !t.ForRequester {
+			if code := makeSMSCode(0, "", t.RequestID); code != 0 {
+				message += fmt.Sprintf(" Reply '%dy' to accept, '%dn' to decline.", code, code)
+			} else {
+				message += " Accept or decline in GoAlert."
+			}
+		}
+		if !cfg.General.DisableSMSLinks {
+			message += " " + cfg.CallbackURL(fmt.Sprintf("/schedules/%s/shift-requests", t.ScheduleID))
+		}
+	case notification.ScheduleCoverageGap:
+		message = "GoAlert: " + t.Summary()
+		if !cfg.General.DisableSMSLinks {
+			message += " " + cfg.CallbackURL(fmt.Sprintf("/schedules/%s/shifts", t.ScheduleID))
+		}
+	case notification.ShiftHandoff:
+		message = "GoAlert: " + t.Summary()
+		if ids := t.AlertIDs(); ids != "" {
+			message += " Alerts: " + ids + "."
+		}
+		if !cfg.General.DisableSMSLinks {
+			message += " " + cfg.CallbackURL(fmt.Sprintf("/schedules/%s/shifts", t.ScheduleID))
+		}
 	case notification.Test:
 		message = "This is a test message from GoAlert."
 	case notification.Verification:
@@ -315,6 +343,16 @@ func (s *SMS) ServeMessage(w http.ResponseWriter, req *http.Request) {
 
 	body = strings.TrimSpace(body)
 	body = strings.ToLower(body)
+	if m := shiftReplyRx.FindStringSubmatch(body); len(m) == 3 {
+		code, err := strconv.Atoi(m[1])
+		if err == nil {
+			ctx = log.WithField(ctx, "Code", code)
+			s.serveShiftReply(ctx, from, code, m[2] == "accept" || strings.HasPrefix(m[2], "y"), respond, retryOpts)
+			return
+		}
+		log.Debug(ctx, errors.Wrap(err, "parse code"))
+	}
+
 	var lookupFn func() (*codeInfo, error)
 	var result notification.Result
 	var isSvc bool
@@ -442,3 +480,44 @@ func (s *SMS) ServeMessage(w http.ResponseWriter, req *http.Request) {
 		respond("", fmt.Sprintf("%s alert #%d", prefix, info.AlertID))
 	}
 }
+
+// serveShiftReply will accept or decline the shift request associated with the given reply code.
+func (s *SMS) serveShiftReply(ctx context.Context, from string, code int, accept bool, respond func(errMsg, msg string), retryOpts []retry.Option) {
+	result := notification.ResultDecline
+	prefix := "Declined"
+	if accept {
+		result = notification.ResultAccept
+		prefix = "Accepted"
+	}
+
+	err := retry.DoTemporaryError(func(int) error {
+		info, err := s.b.LookupShiftByCode(ctx, from, code)
+		if err != nil {
+			return errors.Wrap(err, "lookup code")
+		}
+
+		err = s.r.Receive(ctx, info.CallbackID, result)
+		if err != nil {
+			return fmt.Errorf("process notification response: %w", err)
+		}
+		return nil
+	}, retryOpts...)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		respond("unknown callbackID", "Unknown reply code for this action. Visit the dashboard to manage shift requests.")
+		return
+	}
+	var fieldErr validation.FieldError
+	if errors.As(err, &fieldErr) {
+		log.Debug(ctx, err)
+		respond("", "Unable to respond to shift request: "+fieldErr.Reason())
+		return
+	}
+	if err != nil {
+		log.Log(ctx, err)
+		respond("", "System error. Visit the dashboard to manage shift requests.")
+		return
+	}
+
+	respond("", prefix+" shift request.")
+}
@@ -0,0 +1,40 @@
+package oncall
+
+import (
+	"sort"
+	"time"
+)
+
+// A Gap represents a duration where nobody is on-call.
+type Gap struct {
+	Start time.Time `json:"start_time"`
+	End   time.Time `json:"end_time"`
+}
+
+// CoverageGaps will return the periods between start and end that are not
+// covered by any of the provided shifts, in order.
+func CoverageGaps(shifts []Shift, start, end time.Time) []Gap {
+	sorted := make([]Shift, len(shifts))
+	copy(sorted, shifts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var gaps []Gap
+	cur := start
+	for _, s := range sorted {
+		if !s.End.After(cur) {
+			continue
+		}
+		if !s.Start.Before(end) {
+			break
+		}
+		if s.Start.After(cur) {
+			gaps = append(gaps, Gap{Start: cur, End: s.Start})
+		}
+		cur = s.End
+	}
+	if cur.Before(end) {
+		gaps = append(gaps, Gap{Start: cur, End: end})
+	}
+
+	return gaps
+}
@@ -0,0 +1,48 @@
+package oncall
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoverageGaps(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	at := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+
+	check := func(name string, shifts []Shift, exp []Gap) {
+		t.Helper()
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, exp, CoverageGaps(shifts, start, end))
+		})
+	}
+
+	check("empty", nil, []Gap{{Start: start, End: end}})
+	check("full",
+		[]Shift{{UserID: "a", Start: at(-2), End: at(30)}},
+		nil,
+	)
+	check("overlapping",
+		[]Shift{
+			{UserID: "b", Start: at(6), End: at(12)},
+			{UserID: "a", Start: at(-2), End: at(8)},
+			{UserID: "c", Start: at(10), End: at(24)},
+		},
+		nil,
+	)
+	check("holes",
+		[]Shift{
+			{UserID: "a", Start: at(2), End: at(8)},
+			{UserID: "b", Start: at(3), End: at(5)},
+			{UserID: "c", Start: at(12), End: at(20)},
+			{UserID: "d", Start: at(25), End: at(30)},
+		},
+		[]Gap{
+			{Start: start, End: at(2)},
+			{Start: at(8), End: at(12)},
+			{Start: at(20), End: end},
+		},
+	)
+}
@@ -0,0 +1,122 @@
+package oncall
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+// A HandoffNotice is a recorded notification to a user about the start or end of one
+// of their on-call shifts.
+type HandoffNotice struct {
+	ID           int
+	ScheduleID   string
+	ScheduleName string
+	TimeZone     *time.Location
+	UserID       string
+
+	// Ended is true if the notice is for the end of the shift, otherwise it is
+	// for an upcoming shift.
+	Ended bool
+
+	// End is zero if the end of an upcoming shift is not yet known.
+	Start, End time.Time
+
+	// AlertCount and OpenAlertCount are only set for ended shifts and refer to
+	// alerts created during the shift on services escalating directly to the schedule,
+	// as well as any alerts the user was notified about during the shift.
+	AlertCount     int
+	OpenAlertCount int
+
+	// Alerts holds the first MaxHandoffAlerts of the counted alerts, by ID.
+	Alerts []HandoffAlert
+}
+
+// MaxHandoffAlerts is the maximum number of alerts listed with a HandoffNotice.
+const MaxHandoffAlerts = 10
+
+// A HandoffAlert is an alert relevant to an ended shift.
+type HandoffAlert struct {
+	ID      int
+	Summary string
+	Open    bool
+}
+
+// handoffAlertIDs selects the IDs of alerts relevant to the ended shift of handoff notice `n`.
+const handoffAlertIDs = `
+	select a.id
+	from alerts a
+	join services svc on svc.id = a.service_id
+	join escalation_policy_steps step on step.escalation_policy_id = svc.escalation_policy_id
+	join escalation_policy_actions act on act.escalation_policy_step_id = step.id
+	where
+		act.schedule_id = n.schedule_id and
+		a.created_at >= n.shift_start and
+		a.created_at < n.shift_end
+	union
+	select l.alert_id
+	from alert_logs l
+	where
+		l.event = 'notification_sent' and
+		l.sub_user_id = n.user_id and
+		l.timestamp >= n.shift_start and
+		l.timestamp < n.shift_end
+`
+
+// FindHandoffNotice will return the handoff notice with the given ID.
+func (db *DB) FindHandoffNotice(ctx context.Context, id int) (*HandoffNotice, error) {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return nil, err
+	}
+
+	var n HandoffNotice
+	var tz string
+	var end sqlutil.NullTime
+	var alertCount, openCount sql.NullInt64
+	err = db.findHandoff.QueryRowContext(ctx, id).Scan(
+		&n.ID,
+		&n.ScheduleID,
+		&n.ScheduleName,
+		&tz,
+		&n.UserID,
+		&n.Ended,
+		&n.Start,
+		&end,
+		&alertCount,
+		&openCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n.End = end.Time
+	n.AlertCount = int(alertCount.Int64)
+	n.OpenAlertCount = int(openCount.Int64)
+	n.TimeZone, err = util.LoadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+	if n.AlertCount == 0 {
+		return &n, nil
+	}
+
+	rows, err := db.findHandoffAlerts.QueryContext(ctx, id, MaxHandoffAlerts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a HandoffAlert
+		err = rows.Scan(&a.ID, &a.Summary, &a.Open)
+		if err != nil {
+			return nil, err
+		}
+		n.Alerts = append(n.Alerts, a)
+	}
+
+	return &n, rows.Err()
+}
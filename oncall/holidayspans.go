@@ -0,0 +1,92 @@
+package oncall
+
+import (
+	"sort"
+	"time"
+
+	"github.com/target/goalert/holiday"
+	"github.com/target/goalert/schedule/rule"
+)
+
+type activeSpan struct {
+	Start, End time.Time
+}
+
+// mergeSpans will sort spans and combine any that overlap or are adjacent.
+func mergeSpans(spans []activeSpan) []activeSpan {
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Start.Before(spans[j].Start) })
+
+	var result []activeSpan
+	for _, s := range spans {
+		if !s.End.After(s.Start) {
+			continue
+		}
+		if len(result) > 0 && !s.Start.After(result[len(result)-1].End) {
+			if s.End.After(result[len(result)-1].End) {
+				result[len(result)-1].End = s.End
+			}
+			continue
+		}
+		result = append(result, s)
+	}
+
+	return result
+}
+
+// subtractSpans will remove all time covered by b from a. Both must be merged.
+func subtractSpans(a, b []activeSpan) []activeSpan {
+	var result []activeSpan
+	for _, s := range a {
+		for _, cut := range b {
+			if !cut.End.After(s.Start) || !cut.Start.Before(s.End) {
+				continue
+			}
+			if cut.Start.After(s.Start) {
+				result = append(result, activeSpan{Start: s.Start, End: cut.Start})
+			}
+			s.Start = cut.End
+			if !s.End.After(s.Start) {
+				break
+			}
+		}
+		if s.End.After(s.Start) {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+// holidaySpans will return a span for each holiday, from midnight to midnight in loc, that
+// overlaps the given range.
+func holidaySpans(set holiday.Set, loc *time.Location, start, end time.Time) []activeSpan {
+	if len(set) == 0 {
+		return nil
+	}
+
+	var result []activeSpan
+	s := start.In(loc)
+	day := time.Date(s.Year(), s.Month(), s.Day(), 0, 0, 0, 0, loc)
+	for day.Before(end) {
+		next := day.AddDate(0, 0, 1)
+		if set.Contains(day) {
+			result = append(result, activeSpan{Start: day, End: next})
+		}
+		day = next
+	}
+
+	return mergeSpans(result)
+}
+
+// applyHolidays will remove or add holidays within the given range to the active spans
+// of the rule, according to its HolidayMode.
+func (r ResolvedRule) applyHolidays(spans []activeSpan, loc *time.Location, start, end time.Time) []activeSpan {
+	switch r.HolidayMode {
+	case rule.HolidayModeExclude:
+		return subtractSpans(mergeSpans(spans), holidaySpans(r.Holidays, loc, start, end))
+	case rule.HolidayModeInclude:
+		return mergeSpans(append(spans, holidaySpans(r.Holidays, loc, start, end)...))
+	}
+
+	return spans
+}
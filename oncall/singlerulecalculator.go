@@ -36,18 +36,24 @@ func (t *TimeIterator) NewSingleRuleCalculator(loc *time.Location, rule Resolved
 		return true
 	}
 
+	var spans []activeSpan
 	if rule.AlwaysActive() {
 		// always active so just add one span for the entire duration +1 step
-		calc.act.SetSpan(t.Start(), t.End().Add(t.Step()))
+		spans = append(spans, activeSpan{Start: t.Start(), End: t.End().Add(t.Step())})
 	} else if !rule.NeverActive() {
 		cur := rule.StartTime(t.Start().In(loc))
 		// loop through rule active times
 		for cur.Before(t.End()) && limit() {
 			end := rule.EndTime(cur)
-			calc.act.SetSpan(cur, end)
+			spans = append(spans, activeSpan{Start: cur, End: end})
 			cur = rule.StartTime(end)
 		}
 	}
+
+	spans = rule.applyHolidays(spans, loc, t.Start(), t.End().Add(t.Step()))
+	for _, s := range spans {
+		calc.act.SetSpan(s.Start, s.End)
+	}
 	calc.act.Init()
 
 	if rule.Rotation != nil {
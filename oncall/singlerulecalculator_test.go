@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/holiday"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/util/timeutil"
@@ -61,3 +62,91 @@ func TestSingleRuleCalculator(t *testing.T) {
 		},
 	)
 }
+
+func TestSingleRuleCalculator_Holidays(t *testing.T) {
+	type result struct {
+		Time  time.Time
+		Value string
+	}
+	var (
+		start = time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC)
+		end   = time.Date(2026, 12, 29, 0, 0, 0, 0, time.UTC)
+	)
+	at := func(day, hour int) time.Time { return time.Date(2026, 12, day, hour, 0, 0, 0, time.UTC) }
+	check := func(desc string, expected []result, rule oncall.ResolvedRule) {
+		t.Run(desc, func(t *testing.T) {
+			iter := oncall.NewTimeIterator(
+				start,
+				end,
+				time.Minute,
+			).NewSingleRuleCalculator(time.UTC, rule)
+
+			var results []result
+			for iter.Next() {
+				results = append(results, result{Time: time.Unix(iter.Unix(), 0).UTC(), Value: iter.ActiveUser()})
+			}
+
+			assert.EqualValues(t, expected, results)
+		})
+	}
+	holidays := holiday.Set{"2026-12-25": true}
+
+	check("exclude",
+		[]result{
+			{Time: start},
+			{Time: at(24, 9), Value: "day"},
+			{Time: at(24, 17)},
+			{Time: at(26, 9), Value: "day"},
+			{Time: at(26, 17)},
+			{Time: at(27, 9), Value: "day"},
+			{Time: at(27, 17)},
+			{Time: at(28, 9), Value: "day"},
+			{Time: at(28, 17)},
+			{Time: end},
+		},
+		oncall.ResolvedRule{
+			Rule: rule.Rule{
+				Start:         timeutil.NewClock(9, 0),
+				End:           timeutil.NewClock(17, 0),
+				WeekdayFilter: timeutil.EveryDay(),
+				Target:        assignment.UserTarget("day"),
+				HolidayMode:   rule.HolidayModeExclude,
+			},
+			Holidays: holidays,
+		},
+	)
+
+	// Dec 26-27 2026 is a weekend, the holiday on Friday extends it
+	check("include",
+		[]result{
+			{Time: start},
+			{Time: at(25, 0), Value: "weekend"},
+			{Time: at(28, 0)},
+			{Time: end},
+		},
+		oncall.ResolvedRule{
+			Rule: rule.Rule{
+				WeekdayFilter: timeutil.WeekdayFilter{1, 0, 0, 0, 0, 0, 1},
+				Target:        assignment.UserTarget("weekend"),
+				HolidayMode:   rule.HolidayModeInclude,
+			},
+			Holidays: holidays,
+		},
+	)
+
+	check("ignore",
+		[]result{
+			{Time: start},
+			{Time: at(26, 0), Value: "weekend"},
+			{Time: at(28, 0)},
+			{Time: end},
+		},
+		oncall.ResolvedRule{
+			Rule: rule.Rule{
+				WeekdayFilter: timeutil.WeekdayFilter{1, 0, 0, 0, 0, 0, 1},
+				Target:        assignment.UserTarget("weekend"),
+			},
+			Holidays: holidays,
+		},
+	)
+}
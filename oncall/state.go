@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/holiday"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
@@ -14,6 +15,10 @@ import (
 type ResolvedRule struct {
 	rule.Rule
 	Rotation *ResolvedRotation
+
+	// Holidays are the holidays of the schedule, used by rules with a HolidayMode
+	// other than ignore.
+	Holidays holiday.Set
 }
 type ResolvedRotation struct {
 	rotation.Rotation
@@ -21,6 +26,24 @@ type ResolvedRotation struct {
 	CurrentStart time.Time
 	CurrentEnd   time.Time
 	Users        []string
+
+	// Availability holds the skip state of each user, by position. Future shifts skip
+	// unavailable users the same way the engine does. It is ignored unless there is an
+	// entry for every user.
+	Availability []rotation.Availability
+
+	// ResumeIndex, if set, is where the rotation continues from after the current (credited) shift.
+	ResumeIndex *int
+
+	// skip state as of the start of the current shift, from which future shifts are calculated
+	base   *rotationBase
+	parts  []rotation.Availability
+	resume int
+}
+
+type rotationBase struct {
+	Index      int
+	Start, End time.Time
 }
 
 type state struct {
@@ -49,14 +72,27 @@ func (r *ResolvedRotation) UserID(t time.Time) string {
 		r.CurrentEnd = r.EndTime(r.CurrentStart)
 	}
 
+	if r.base == nil {
+		r.base = &rotationBase{Index: r.CurrentIndex, Start: r.CurrentStart, End: r.CurrentEnd}
+		r.reset()
+	}
+
 	if t.Before(r.CurrentEnd) && !t.Before(r.CurrentStart) {
 		return r.Users[r.CurrentIndex]
 	}
 
+	if !t.Before(r.base.Start) && (t.Before(r.CurrentStart) || r.CurrentStart.Before(r.base.Start)) {
+		// skips can only be calculated going forward from the current shift
+		r.CurrentIndex = r.base.Index
+		r.CurrentStart = r.base.Start
+		r.CurrentEnd = r.base.End
+		r.reset()
+	}
+
 	for !t.Before(r.CurrentEnd) {
 		r.CurrentStart = r.CurrentEnd
 		r.CurrentEnd = r.EndTime(r.CurrentStart)
-		r.CurrentIndex++
+		r.advance()
 	}
 	for t.Before(r.CurrentStart) {
 		r.CurrentEnd = r.CurrentStart
@@ -70,8 +106,29 @@ func (r *ResolvedRotation) UserID(t time.Time) string {
 
 	return r.Users[r.CurrentIndex]
 }
+
+// reset will restore the skip state to that of the current shift.
+func (r *ResolvedRotation) reset() {
+	r.parts = append(r.parts[:0], r.Availability...)
+	r.resume = -1
+	if r.ResumeIndex != nil {
+		r.resume = *r.ResumeIndex
+	}
+}
+
+// advance will move to the next position for the shift starting at CurrentStart.
+func (r *ResolvedRotation) advance() {
+	if len(r.parts) != len(r.Users) || r.CurrentStart.Before(r.base.Start) {
+		// no skip state, or before the current shift (where history is used)
+		r.CurrentIndex++
+		return
+	}
+
+	r.CurrentIndex %= len(r.Users)
+	r.CurrentIndex, r.resume = rotation.NextPosition(r.parts, r.CurrentIndex, r.resume, r.CurrentStart)
+}
 func (r ResolvedRule) UserID(t time.Time) string {
-	if !r.IsActive(t) {
+	if !r.IsActiveHoliday(t, r.Holidays.Contains(t)) {
 		return ""
 	}
 	switch r.Target.TargetType() {
@@ -92,7 +149,51 @@ func sortShifts(s []Shift) {
 	})
 }
 
+type shiftKey struct {
+	UserID string
+	Source ShiftSource
+}
+
+// CalculateShifts will calculate the on-call shifts for the given time range.
 func (s *state) CalculateShifts(start, end time.Time) []Shift {
+	return s.calculateShifts(start, end, false)
+}
+
+// CalculateSourcedShifts is like CalculateShifts, but will also set the Source of
+// each shift. A new shift is started whenever the source for a user changes.
+func (s *state) CalculateSourcedShifts(start, end time.Time) []Shift {
+	return s.calculateShifts(start, end, true)
+}
+
+// ruleSource will return the source for an active user, given the current (minute) time.
+func (s *state) ruleSource(rules *RulesCalculator, t time.Time, userID string) ShiftSource {
+	for _, o := range s.overrides {
+		if o.AddUserID != userID {
+			continue
+		}
+		if t.Before(o.Start.Truncate(time.Minute)) || !t.Before(o.End.Truncate(time.Minute)) {
+			continue
+		}
+
+		return ShiftSource{Type: ShiftSourceOverride, OverrideID: o.ID}
+	}
+
+	for _, r := range rules.rules {
+		if r.ActiveUser() != userID {
+			continue
+		}
+		if r.rule.Rotation != nil {
+			return ShiftSource{Type: ShiftSourceRotation, RuleID: r.rule.ID, RotationID: r.rule.Rotation.ID}
+		}
+
+		return ShiftSource{Type: ShiftSourceUser, RuleID: r.rule.ID}
+	}
+
+	// should not happen, but an unknown source is better than no shift
+	return ShiftSource{}
+}
+
+func (s *state) calculateShifts(start, end time.Time, withSource bool) []Shift {
 	start = start.Truncate(time.Minute)
 	end = end.Truncate(time.Minute)
 	tiStart := start
@@ -121,29 +222,42 @@ func (s *state) CalculateShifts(start, end time.Time) []Shift {
 	rules := t.NewRulesCalculator(s.loc, s.rules)
 
 	var shifts []Shift
-	isOnCall := make(map[string]*Shift)
-	stillOnCall := make(map[string]bool)
+	isOnCall := make(map[shiftKey]*Shift)
+	stillOnCall := make(map[shiftKey]bool)
+	var keys []shiftKey
 
-	setOnCall := func(userIDs []string) {
+	setOnCall := func(userIDs []string, src func(userID string) ShiftSource) {
 		// reset map
-		for id := range stillOnCall {
-			delete(stillOnCall, id)
+		for key := range stillOnCall {
+			delete(stillOnCall, key)
 		}
 		now := time.Unix(t.Unix(), 0)
+		keys = keys[:0]
 		for _, id := range userIDs {
-			stillOnCall[id] = true
-			s := isOnCall[id]
+			key := shiftKey{UserID: id}
+			if withSource {
+				key.Source = src(id)
+			}
+			keys = append(keys, key)
+		}
+		for _, key := range keys {
+			stillOnCall[key] = true
+			s := isOnCall[key]
 			if s != nil {
 				continue
 			}
 
-			isOnCall[id] = &Shift{
+			isOnCall[key] = &Shift{
 				Start:  now,
-				UserID: id,
+				UserID: key.UserID,
+			}
+			if withSource {
+				src := key.Source
+				isOnCall[key].Source = &src
 			}
 		}
-		for id, s := range isOnCall {
-			if stillOnCall[id] {
+		for key, s := range isOnCall {
+			if stillOnCall[key] {
 				continue
 			}
 
@@ -152,25 +266,33 @@ func (s *state) CalculateShifts(start, end time.Time) []Shift {
 				s.End = now
 				shifts = append(shifts, *s)
 			}
-			delete(isOnCall, id)
+			delete(isOnCall, key)
 		}
 	}
+	staticSource := func(typ ShiftSourceType) func(string) ShiftSource {
+		return func(string) ShiftSource { return ShiftSource{Type: typ} }
+	}
+	histSource := staticSource(ShiftSourceHistory)
+	tempSource := staticSource(ShiftSourceTemporarySchedule)
+	activeSource := func(userID string) ShiftSource {
+		return s.ruleSource(rules, time.Unix(t.Unix(), 0), userID)
+	}
 
 	for t.Next() {
 		if time.Unix(t.Unix(), 0).Before(historyCutoff) {
 			// use history if in the past
-			setOnCall(hist.ActiveUsers())
+			setOnCall(hist.ActiveUsers(), histSource)
 			continue
 		}
 
 		if tempScheds.Active() {
 			// use TemporarySchedule if one is active
-			setOnCall(tempScheds.ActiveUsers())
+			setOnCall(tempScheds.ActiveUsers(), tempSource)
 			continue
 		}
 
 		// apply any overrides
-		setOnCall(overrides.MapUsers(rules.ActiveUsers()))
+		setOnCall(overrides.MapUsers(rules.ActiveUsers()), activeSource)
 	}
 
 	// remaining shifts are truncated
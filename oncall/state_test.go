@@ -253,6 +253,73 @@ func TestState_CalculateShifts(t *testing.T) {
 		},
 	)
 
+	skipUntil := time.Date(2018, 1, 3, 0, 0, 0, 0, time.UTC)
+	check("RotationSkip",
+		time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 1, 6, 0, 0, 0, 0, time.UTC),
+		&state{
+			loc: time.UTC,
+			now: time.Date(2018, 1, 1, 7, 0, 0, 0, time.UTC),
+			history: []Shift{
+				{
+					UserID: "a",
+					Start:  time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			rules: []ResolvedRule{
+				{
+					Rule: rule.Rule{
+						WeekdayFilter: timeutil.WeekdayFilter{1, 1, 1, 1, 1, 1, 1},
+						Start:         timeutil.NewClock(0, 0),
+						End:           timeutil.NewClock(0, 0),
+						Target:        assignment.RotationTarget("rot"),
+					},
+					Rotation: &ResolvedRotation{
+						Rotation: rotation.Rotation{
+							ID:          "rot",
+							Type:        rotation.TypeDaily,
+							ShiftLength: 1,
+							Start:       time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+						},
+						CurrentStart: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+						Users:        []string{"a", "b", "c"},
+						Availability: []rotation.Availability{{}, {UnavailableUntil: &skipUntil}, {}},
+					},
+				},
+			},
+		},
+		[]Shift{
+			{
+				Start:  time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC),
+				UserID: "a",
+			},
+			{
+				// b is unavailable, and credited a turn
+				Start:  time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2018, 1, 3, 0, 0, 0, 0, time.UTC),
+				UserID: "c",
+			},
+			{
+				// b takes the credited turn
+				Start:  time.Date(2018, 1, 3, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2018, 1, 4, 0, 0, 0, 0, time.UTC),
+				UserID: "b",
+			},
+			{
+				// then the rotation resumes where it left off
+				Start:  time.Date(2018, 1, 4, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2018, 1, 5, 0, 0, 0, 0, time.UTC),
+				UserID: "a",
+			},
+			{
+				Start:  time.Date(2018, 1, 5, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2018, 1, 6, 0, 0, 0, 0, time.UTC),
+				UserID: "b",
+			},
+		},
+	)
+
 	check("HistoryRemainder",
 		time.Date(2018, 1, 1, 8, 0, 0, 0, time.UTC), // 8:00AM
 		time.Date(2018, 1, 1, 9, 0, 0, 0, time.UTC), // 9:00AM
@@ -751,3 +818,94 @@ func TestState_CalculateShifts(t *testing.T) {
 	)
 
 }
+
+func TestState_CalculateSourcedShifts(t *testing.T) {
+	s := &state{
+		loc: time.UTC,
+		now: time.Date(2018, 1, 1, 7, 0, 0, 0, time.UTC),
+		history: []Shift{
+			{UserID: "bob", Start: time.Date(2018, 1, 1, 6, 0, 0, 0, time.UTC)},
+		},
+		rules: []ResolvedRule{
+			{
+				Rule: rule.Rule{
+					ID:            "rule1",
+					WeekdayFilter: timeutil.WeekdayFilter{1, 1, 1, 1, 1, 1, 1},
+					Start:         timeutil.NewClock(0, 0),
+					End:           timeutil.NewClock(0, 0),
+					Target:        assignment.RotationTarget("rot1"),
+				},
+				Rotation: &ResolvedRotation{
+					Rotation: rotation.Rotation{ID: "rot1"},
+					Users:    []string{"bob"},
+				},
+			},
+			{Rule: rule.Rule{
+				ID:            "rule2",
+				WeekdayFilter: timeutil.WeekdayFilter{1, 1, 1, 1, 1, 1, 1},
+				Start:         timeutil.NewClock(10, 0),
+				End:           timeutil.NewClock(11, 0),
+				Target:        assignment.UserTarget("joe"),
+			}},
+		},
+		overrides: []override.UserOverride{
+			{
+				ID:           "ovr1",
+				AddUserID:    "ann",
+				RemoveUserID: "bob",
+				Start:        time.Date(2018, 1, 1, 8, 0, 0, 0, time.UTC),
+				End:          time.Date(2018, 1, 1, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		tempScheds: []schedule.TemporarySchedule{
+			{
+				Start: time.Date(2018, 1, 1, 11, 0, 0, 0, time.UTC),
+				End:   time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC),
+				Shifts: []schedule.FixedShift{
+					{UserID: "bob", Start: time.Date(2018, 1, 1, 11, 0, 0, 0, time.UTC), End: time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
+	}
+
+	hist := &ShiftSource{Type: ShiftSourceHistory}
+	rot := &ShiftSource{Type: ShiftSourceRotation, RuleID: "rule1", RotationID: "rot1"}
+	exp := []Shift{
+		{UserID: "bob", Start: time.Date(2018, 1, 1, 6, 0, 0, 0, time.UTC), End: time.Date(2018, 1, 1, 7, 1, 0, 0, time.UTC), Source: hist},
+		{UserID: "bob", Start: time.Date(2018, 1, 1, 7, 1, 0, 0, time.UTC), End: time.Date(2018, 1, 1, 8, 0, 0, 0, time.UTC), Source: rot},
+		{UserID: "ann", Start: time.Date(2018, 1, 1, 8, 0, 0, 0, time.UTC), End: time.Date(2018, 1, 1, 9, 0, 0, 0, time.UTC), Source: &ShiftSource{Type: ShiftSourceOverride, OverrideID: "ovr1"}},
+		{UserID: "bob", Start: time.Date(2018, 1, 1, 9, 0, 0, 0, time.UTC), End: time.Date(2018, 1, 1, 11, 0, 0, 0, time.UTC), Source: rot},
+		{UserID: "joe", Start: time.Date(2018, 1, 1, 10, 0, 0, 0, time.UTC), End: time.Date(2018, 1, 1, 11, 0, 0, 0, time.UTC), Source: &ShiftSource{Type: ShiftSourceUser, RuleID: "rule2"}},
+		{UserID: "bob", Start: time.Date(2018, 1, 1, 11, 0, 0, 0, time.UTC), End: time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC), Source: &ShiftSource{Type: ShiftSourceTemporarySchedule}},
+		{UserID: "bob", Start: time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC), End: time.Date(2018, 1, 1, 13, 0, 0, 0, time.UTC), Source: rot, Truncated: true},
+	}
+
+	res := s.CalculateSourcedShifts(time.Date(2018, 1, 1, 6, 0, 0, 0, time.UTC), time.Date(2018, 1, 1, 13, 0, 0, 0, time.UTC))
+	for i, exp := range exp {
+		if i >= len(res) {
+			t.Errorf("shift[%d]: missing", i)
+			continue
+		}
+		if !res[i].Start.Equal(exp.Start) || !res[i].End.Equal(exp.End) || res[i].UserID != exp.UserID || res[i].Truncated != exp.Truncated {
+			t.Errorf("shift[%d] = %s %s-%s (truncated=%t); want %s %s-%s (truncated=%t)", i,
+				res[i].UserID, res[i].Start, res[i].End, res[i].Truncated,
+				exp.UserID, exp.Start, exp.End, exp.Truncated,
+			)
+		}
+		if res[i].Source == nil || *res[i].Source != *exp.Source {
+			t.Errorf("shift[%d]: source = %+v; want %+v", i, res[i].Source, *exp.Source)
+		}
+	}
+	if len(res) > len(exp) {
+		for _, res := range res[len(exp):] {
+			t.Errorf("extra shift: %v", res)
+		}
+	}
+
+	// regular shifts should be unaffected by sources
+	for _, shift := range s.CalculateShifts(time.Date(2018, 1, 1, 6, 0, 0, 0, time.UTC), time.Date(2018, 1, 1, 13, 0, 0, 0, time.UTC)) {
+		if shift.Source != nil {
+			t.Errorf("unexpected source for shift: %v", shift)
+		}
+	}
+}
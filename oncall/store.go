@@ -7,9 +7,11 @@ import (
 
 	uuid "github.com/satori/go.uuid"
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/holiday"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/sqlutil"
@@ -22,6 +24,9 @@ import (
 type Store interface {
 	OnCallUsersByService(ctx context.Context, serviceID string) ([]ServiceOnCallUser, error)
 	HistoryBySchedule(ctx context.Context, scheduleID string, start, end time.Time) ([]Shift, error)
+	HistoryByRotation(ctx context.Context, rotationID string, start, end time.Time) ([]Shift, error)
+	SourcedHistoryBySchedule(ctx context.Context, scheduleID string, start, end time.Time) ([]Shift, error)
+	FindHandoffNotice(ctx context.Context, id int) (*HandoffNotice, error)
 }
 
 // ServiceOnCallUser represents a currently on-call user for a service.
@@ -40,6 +45,44 @@ type Shift struct {
 	Start     time.Time `json:"start_time"`
 	End       time.Time `json:"end_time"`
 	Truncated bool      `json:"truncated"`
+
+	// Source is only set for shifts calculated with SourcedHistoryBySchedule.
+	Source *ShiftSource `json:"source,omitempty"`
+}
+
+// ShiftSourceType indicates why a user was on-call for a shift.
+type ShiftSourceType string
+
+// Known shift source types.
+const (
+	// ShiftSourceHistory indicates the shift was recorded in the past, the original reason is not known.
+	ShiftSourceHistory ShiftSourceType = "history"
+
+	// ShiftSourceTemporarySchedule indicates the shift is from a temporary schedule.
+	ShiftSourceTemporarySchedule ShiftSourceType = "temporary_schedule"
+
+	// ShiftSourceOverride indicates the user was added by an override.
+	ShiftSourceOverride ShiftSourceType = "override"
+
+	// ShiftSourceRotation indicates the user was on-call from a rotation assigned to the schedule.
+	ShiftSourceRotation ShiftSourceType = "rotation"
+
+	// ShiftSourceUser indicates the user was assigned to the schedule directly.
+	ShiftSourceUser ShiftSourceType = "user"
+)
+
+// ShiftSource describes why a user was on-call for a shift.
+type ShiftSource struct {
+	Type ShiftSourceType `json:"type"`
+
+	// OverrideID is set for ShiftSourceOverride.
+	OverrideID string `json:"override_id,omitempty"`
+
+	// RuleID is set for ShiftSourceRotation and ShiftSourceUser.
+	RuleID string `json:"rule_id,omitempty"`
+
+	// RotationID is set for ShiftSourceRotation.
+	RotationID string `json:"rotation_id,omitempty"`
 }
 
 // DB implements the Store interface from Postgres.
@@ -53,6 +96,13 @@ type DB struct {
 	schedTZ     *sql.Stmt
 	schedRot    *sql.Stmt
 	rotParts    *sql.Stmt
+	holidays    *sql.Stmt
+
+	rotInfo   *sql.Stmt
+	rotOnCall *sql.Stmt
+
+	findHandoff       *sql.Stmt
+	findHandoffAlerts *sql.Stmt
 
 	ruleStore  rule.Store
 	schedStore *schedule.Store
@@ -69,6 +119,7 @@ func NewDB(ctx context.Context, db *sql.DB, ruleStore rule.Store, schedStore *sc
 
 		schedOverrides: p.P(`
 			select
+				id,
 				start_time,
 				end_time,
 				add_user_id,
@@ -110,22 +161,91 @@ func NewDB(ctx context.Context, db *sql.DB, ruleStore rule.Store, schedStore *sc
 				rot.shift_length,
 				rot.time_zone,
 				state.position,
-				state.shift_start
+				state.shift_start,
+				state.resume_position
 			from schedule_rules rule
 			join rotations rot on rot.id = rule.tgt_rotation_id
 			join rotation_state state on state.rotation_id = rule.tgt_rotation_id
 			where rule.schedule_id = $1 and rule.tgt_rotation_id notnull
 		`),
+		holidays: p.P(`
+			select to_char(h.date, 'YYYY-MM-DD')
+			from schedules s
+			join holidays h on h.calendar_id = s.holiday_calendar_id
+			where
+				s.id = $1 and
+				h.date between
+					($2::timestamptz at time zone s.time_zone)::date - 1 and
+					($3::timestamptz at time zone s.time_zone)::date + 1
+		`),
 		rotParts: p.P(`
 			select
 				rotation_id,
-				user_id
+				user_id,
+				unavailable_until,
+				skip_credit
 			from rotation_participants
 			where rotation_id = any($1)
 			order by
 				rotation_id,
 				position
 		`),
+		rotInfo: p.P(`
+			select
+				rot.type,
+				rot.start_time,
+				rot.shift_length,
+				rot.time_zone,
+				state.position,
+				state.shift_start,
+				state.resume_position,
+				now()
+			from rotations rot
+			join rotation_state state on state.rotation_id = rot.id
+			where rot.id = $1
+		`),
+		rotOnCall: p.P(`
+			select
+				user_id,
+				start_time,
+				end_time
+			from rotation_shift_history
+			where
+				rotation_id = $1 and
+				($2, $3) OVERLAPS (start_time, coalesce(end_time, 'infinity')) and
+				(end_time isnull or (end_time - start_time) > '1 minute'::interval)
+		`),
+		findHandoff: p.P(`
+			select
+				n.id,
+				s.id,
+				s.name,
+				s.time_zone,
+				n.user_id,
+				n.shift_ended,
+				n.shift_start,
+				n.shift_end,
+				alerts.total,
+				alerts.open
+			from user_handoff_notices n
+			join schedules s on s.id = n.schedule_id
+			left join lateral (
+				select
+					count(*) total,
+					count(*) filter (where a.status != 'closed') open
+				from alerts a
+				where n.shift_ended and a.id in (` + handoffAlertIDs + `)
+			) alerts on true
+			where n.id = $1
+		`),
+		findHandoffAlerts: p.P(`
+			select a.id, a.summary, a.status != 'closed'
+			from user_handoff_notices n
+			join alerts a on a.id in (` + handoffAlertIDs + `)
+			where n.id = $1 and n.shift_ended
+			order by a.id
+			limit $2
+		`),
 	}, p.Err
 }
 
@@ -158,6 +278,137 @@ func (db *DB) OnCallUsersByService(ctx context.Context, serviceID string) ([]Ser
 
 // HistoryBySchedule will return the list of shifts that overlap the start and end time for the given schedule.
 func (db *DB) HistoryBySchedule(ctx context.Context, scheduleID string, start, end time.Time) ([]Shift, error) {
+	s, err := db.scheduleState(ctx, scheduleID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CalculateShifts(start, end), nil
+}
+
+// SourcedHistoryBySchedule is like HistoryBySchedule, but will also set the Source of each shift.
+//
+// Shifts are split wherever the source changes, for example when an override
+// is added for a user that was already on-call from a rotation.
+func (db *DB) SourcedHistoryBySchedule(ctx context.Context, scheduleID string, start, end time.Time) ([]Shift, error) {
+	s, err := db.scheduleState(ctx, scheduleID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CalculateSourcedShifts(start, end), nil
+}
+
+// HistoryByRotation will return the list of shifts that overlap the start and end time for the given rotation,
+// as if it were the only target of a schedule. Rotations without participants have no shifts.
+func (db *DB) HistoryByRotation(ctx context.Context, rotationID string, start, end time.Time) ([]Shift, error) {
+	s, err := db.rotationState(ctx, rotationID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, nil
+	}
+
+	return s.CalculateShifts(start, end), nil
+}
+
+func (db *DB) rotationState(ctx context.Context, rotationID string, start, end time.Time) (*state, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("RotationID", rotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.db.BeginTx(ctx, &sql.TxOptions{
+		ReadOnly:  true,
+		Isolation: sql.LevelRepeatableRead,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "begin transaction")
+	}
+	defer tx.Rollback()
+
+	var rot ResolvedRotation
+	var rotTZ string
+	var resume sql.NullInt64
+	var now time.Time
+	rot.ID = rotationID
+	err = tx.StmtContext(ctx, db.rotInfo).QueryRowContext(ctx, rotationID).
+		Scan(&rot.Type, &rot.Start, &rot.ShiftLength, &rotTZ, &rot.CurrentIndex, &rot.CurrentStart, &resume, &now)
+	if errors.Is(err, sql.ErrNoRows) {
+		// no participants (or deleted)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup rotation info")
+	}
+	loc, err := util.LoadLocation(rotTZ)
+	if err != nil {
+		return nil, errors.Wrap(err, "load time zone info")
+	}
+	rot.Start = rot.Start.In(loc)
+	if resume.Valid {
+		idx := int(resume.Int64)
+		rot.ResumeIndex = &idx
+	}
+
+	rows, err := tx.StmtContext(ctx, db.rotParts).QueryContext(ctx, sqlutil.UUIDArray{rotationID})
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup rotation participants")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rotID, userID string
+		var avail rotation.Availability
+		var until sqlutil.NullTime
+		err = rows.Scan(&rotID, &userID, &until, &avail.SkipCredit)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan rotation participant info")
+		}
+		if until.Valid {
+			avail.UnavailableUntil = &until.Time
+		}
+		rot.Users = append(rot.Users, userID)
+		rot.Availability = append(rot.Availability, avail)
+	}
+
+	rows, err = tx.StmtContext(ctx, db.rotOnCall).QueryContext(ctx, rotationID, start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup on-call history")
+	}
+	defer rows.Close()
+	var userHistory []Shift
+	for rows.Next() {
+		var s Shift
+		var end sqlutil.NullTime
+		err = rows.Scan(&s.UserID, &s.Start, &end)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan on-call history info")
+		}
+		s.End = end.Time
+		userHistory = append(userHistory, s)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		// Can't use the data we read (e.g. serialization error)
+		return nil, errors.Wrap(err, "commit tx")
+	}
+
+	r := rule.NewAlwaysActive("", assignment.RotationTarget(rotationID))
+	return &state{
+		rules:   []ResolvedRule{{Rule: *r, Rotation: &rot}},
+		history: userHistory,
+		now:     now,
+		loc:     loc,
+	}, nil
+}
+
+func (db *DB) scheduleState(ctx context.Context, scheduleID string, start, end time.Time) (*state, error) {
 	err := permission.LimitCheckAny(ctx, permission.User)
 	if err != nil {
 		return nil, err
@@ -193,10 +444,15 @@ func (db *DB) HistoryBySchedule(ctx context.Context, scheduleID string, start, e
 	for rows.Next() {
 		var rot ResolvedRotation
 		var rotTZ string
-		err = rows.Scan(&rot.ID, &rot.Type, &rot.Start, &rot.ShiftLength, &rotTZ, &rot.CurrentIndex, &rot.CurrentStart)
+		var resume sql.NullInt64
+		err = rows.Scan(&rot.ID, &rot.Type, &rot.Start, &rot.ShiftLength, &rotTZ, &rot.CurrentIndex, &rot.CurrentStart, &resume)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan rotation info")
 		}
+		if resume.Valid {
+			idx := int(resume.Int64)
+			rot.ResumeIndex = &idx
+		}
 		loc, err := util.LoadLocation(rotTZ)
 		if err != nil {
 			return nil, errors.Wrap(err, "load time zone info")
@@ -213,11 +469,17 @@ func (db *DB) HistoryBySchedule(ctx context.Context, scheduleID string, start, e
 	defer rows.Close()
 	for rows.Next() {
 		var rotID, userID string
-		err = rows.Scan(&rotID, &userID)
+		var avail rotation.Availability
+		var until sqlutil.NullTime
+		err = rows.Scan(&rotID, &userID, &until, &avail.SkipCredit)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan rotation participant info")
 		}
+		if until.Valid {
+			avail.UnavailableUntil = &until.Time
+		}
 		rots[rotID].Users = append(rots[rotID].Users, userID)
+		rots[rotID].Availability = append(rots[rotID].Availability, avail)
 	}
 
 	rawRules, err := db.ruleStore.FindAllTx(ctx, tx, scheduleID)
@@ -225,15 +487,31 @@ func (db *DB) HistoryBySchedule(ctx context.Context, scheduleID string, start, e
 		return nil, errors.Wrap(err, "lookup schedule rules")
 	}
 
+	rows, err = tx.StmtContext(ctx, db.holidays).QueryContext(ctx, scheduleID, start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup holidays")
+	}
+	defer rows.Close()
+	holidays := make(holiday.Set)
+	for rows.Next() {
+		var date string
+		err = rows.Scan(&date)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan holiday")
+		}
+		holidays[date] = true
+	}
+
 	var rules []ResolvedRule
 	for _, r := range rawRules {
 		if r.Target.TargetType() == assignment.TargetTypeRotation {
 			rules = append(rules, ResolvedRule{
 				Rule:     r,
 				Rotation: rots[r.Target.TargetID()],
+				Holidays: holidays,
 			})
 		} else {
-			rules = append(rules, ResolvedRule{Rule: r})
+			rules = append(rules, ResolvedRule{Rule: r, Holidays: holidays})
 		}
 	}
 
@@ -263,7 +541,7 @@ func (db *DB) HistoryBySchedule(ctx context.Context, scheduleID string, start, e
 	for rows.Next() {
 		var add, rem sql.NullString
 		var ov override.UserOverride
-		err = rows.Scan(&ov.Start, &ov.End, &add, &rem)
+		err = rows.Scan(&ov.ID, &ov.Start, &ov.End, &add, &rem)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan override info")
 		}
@@ -289,14 +567,12 @@ func (db *DB) HistoryBySchedule(ctx context.Context, scheduleID string, start, e
 	if err != nil {
 		return nil, errors.Wrap(err, "load time zone info")
 	}
-	s := state{
+	return &state{
 		rules:      rules,
 		overrides:  overrides,
 		history:    userHistory,
 		now:        now,
 		loc:        tz,
 		tempScheds: tempScheds,
-	}
-
-	return s.CalculateShifts(start, end), nil
+	}, nil
 }
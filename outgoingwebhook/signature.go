@@ -0,0 +1,32 @@
+package outgoingwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Request header names set on each delivery.
+const (
+	HeaderEvent     = "X-GoAlert-Event"
+	HeaderDelivery  = "X-GoAlert-Delivery"
+	HeaderSignature = "X-GoAlert-Signature"
+)
+
+// Signature returns the value of the signature header for a request body sent at time t.
+//
+// The format is `t=<unix seconds>,v1=<hex HMAC-SHA256>` where the HMAC is computed over
+// the string `<unix seconds>.<body>` using the webhook secret. Receivers should reject
+// requests with an old timestamp to prevent replay.
+func Signature(secret []byte, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
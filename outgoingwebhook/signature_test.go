@@ -0,0 +1,16 @@
+package outgoingwebhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignature(t *testing.T) {
+	// echo -n '1600000000.{"type":"alert.created"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"t=1600000000,v1=dfa356024bdd3ea6fb6a4aadd825c519ba1222cdb9065515def8f92f97f7d47d",
+		Signature([]byte("secret"), time.Unix(1600000000, 0), []byte(`{"type":"alert.created"}`)),
+	)
+}
@@ -0,0 +1,220 @@
+package outgoingwebhook
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store allows the lookup and management of outgoing webhooks.
+type Store struct {
+	db   *sql.DB
+	keys keyring.Keys
+
+	create     *sql.Stmt
+	update     *sql.Stmt
+	delete     *sql.Stmt
+	findAll    *sql.Stmt
+	findOneUpd *sql.Stmt
+}
+
+// NewStore will create a new Store with the given parameters. Secrets
+// are encrypted with the provided keys.
+func NewStore(ctx context.Context, db *sql.DB, keys keyring.Keys) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db:   db,
+		keys: keys,
+
+		create: p.P(`
+			insert into outgoing_webhooks (id, name, url, secret, event_types, disabled)
+			values ($1, $2, $3, $4, $5, $6)
+		`),
+		update: p.P(`
+			update outgoing_webhooks
+			set name = $2, url = $3, event_types = $4, disabled = $5
+			where id = $1
+		`),
+		delete: p.P(`delete from outgoing_webhooks where id = any($1)`),
+		findAll: p.P(`
+			select id, name, url, event_types, disabled
+			from outgoing_webhooks
+			order by name
+		`),
+		findOneUpd: p.P(`
+			select id, name, url, event_types, disabled
+			from outgoing_webhooks
+			where id = $1
+			for update
+		`),
+	}, p.Err
+}
+
+func newSecret() (string, error) {
+	buf := make([]byte, 32)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// CreateTx will create a new webhook with a randomly generated secret. The returned
+// Webhook is the only time the secret is made available.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, w *Webhook) (*Webhook, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := w.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	n.ID = uuid.NewV4().String()
+	n.Secret, err = newSecret()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate secret")
+	}
+	encSecret, err := s.keys.Encrypt("OUTGOING_WEBHOOK_SECRET", []byte(n.Secret))
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypt secret")
+	}
+
+	stmt := s.create
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.URL, encSecret, eventTypeArray(n.EventTypes), n.Disabled)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// UpdateTx will update the name, URL, event types, and disabled status of a webhook.
+// The secret is never changed.
+func (s *Store) UpdateTx(ctx context.Context, tx *sql.Tx, w *Webhook) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	n, err := w.Normalize()
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ID", n.ID)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.update
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.URL, eventTypeArray(n.EventTypes), n.Disabled)
+	return err
+}
+
+// DeleteTx will delete the webhooks with the given IDs, along with any pending deliveries.
+func (s *Store) DeleteTx(ctx context.Context, tx *sql.Tx, ids ...string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	err = validate.ManyUUID("ID", ids, 50)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.delete
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx, sqlutil.UUIDArray(ids))
+	return err
+}
+
+// FindOneForUpdateTx will return the webhook with the given ID, locking it for the remainder of the transaction.
+func (s *Store) FindOneForUpdateTx(ctx context.Context, tx *sql.Tx, id string) (*Webhook, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var w Webhook
+	err = w.scanFrom(tx.StmtContext(ctx, s.findOneUpd).QueryRowContext(ctx, id).Scan)
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// FindAll will return all webhooks, ordered by name.
+func (s *Store) FindAll(ctx context.Context) ([]Webhook, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAll.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Webhook
+	for rows.Next() {
+		var w Webhook
+		err = w.scanFrom(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, w)
+	}
+
+	return result, rows.Err()
+}
+
+func (w *Webhook) scanFrom(scanFn func(...interface{}) error) error {
+	var types sqlutil.StringArray
+	err := scanFn(&w.ID, &w.Name, &w.URL, &types, &w.Disabled)
+	if err != nil {
+		return err
+	}
+
+	w.EventTypes = make([]EventType, len(types))
+	for i, t := range types {
+		w.EventTypes[i] = EventType(t)
+	}
+	return nil
+}
+
+func eventTypeArray(types []EventType) sqlutil.StringArray {
+	res := make(sqlutil.StringArray, len(types))
+	for i, t := range types {
+		res[i] = string(t)
+	}
+	return res
+}
@@ -0,0 +1,94 @@
+package outgoingwebhook
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// An EventType identifies a kind of event that can be delivered to a Webhook.
+type EventType string
+
+// Supported event types.
+const (
+	EventAlertCreated      EventType = "alert.created"
+	EventAlertAcknowledged EventType = "alert.acknowledged"
+	EventAlertEscalated    EventType = "alert.escalated"
+	EventAlertClosed       EventType = "alert.closed"
+	EventOnCallStarted     EventType = "oncall.started"
+	EventOnCallEnded       EventType = "oncall.ended"
+	EventOverrideCreated   EventType = "override.created"
+	EventOverrideUpdated   EventType = "override.updated"
+	EventOverrideDeleted   EventType = "override.deleted"
+)
+
+// EventTypes is the list of all supported event types.
+var EventTypes = []EventType{
+	EventAlertCreated,
+	EventAlertAcknowledged,
+	EventAlertEscalated,
+	EventAlertClosed,
+	EventOnCallStarted,
+	EventOnCallEnded,
+	EventOverrideCreated,
+	EventOverrideUpdated,
+	EventOverrideDeleted,
+}
+
+// A Webhook is an admin-registered URL that receives events via signed HTTP POST requests.
+type Webhook struct {
+	ID         string
+	Name       string
+	URL        string
+	EventTypes []EventType
+	Disabled   bool
+
+	// Secret is the key used to sign requests. It is only available
+	// on a newly-created Webhook.
+	Secret string
+}
+
+// Normalize will validate and produce a normalized Webhook.
+func (w Webhook) Normalize() (*Webhook, error) {
+	err := validate.Many(
+		validate.IDName("Name", w.Name),
+		validate.AbsoluteURL("URL", w.URL),
+		validate.Range("EventTypes", len(w.EventTypes), 1, len(EventTypes)),
+	)
+	if err == nil {
+		u, _ := url.Parse(w.URL)
+		if u.Scheme != "http" && u.Scheme != "https" {
+			err = validation.NewFieldError("URL", "scheme must be http or https")
+		}
+	}
+
+	seen := make(map[EventType]bool, len(w.EventTypes))
+	types := make([]EventType, 0, len(w.EventTypes))
+	for i, t := range w.EventTypes {
+		err = validate.Many(err, validateEventType("EventTypes["+strconv.Itoa(i)+"]", t))
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		types = append(types, t)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	w.EventTypes = types
+
+	return &w, nil
+}
+
+func validateEventType(fname string, t EventType) error {
+	opts := make([]interface{}, len(EventTypes))
+	for i, t := range EventTypes {
+		opts[i] = t
+	}
+	return validate.OneOf(fname, t, opts...)
+}
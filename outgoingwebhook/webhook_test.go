@@ -0,0 +1,47 @@
+package outgoingwebhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_Normalize(t *testing.T) {
+	check := func(desc string, w Webhook, ok bool) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			_, err := w.Normalize()
+			if ok {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	valid := Webhook{Name: "Status Page", URL: "https://status.example.com/hook", EventTypes: []EventType{EventAlertCreated}}
+	check("valid", valid, true)
+
+	w := valid
+	w.URL = "ftp://example.com"
+	check("bad scheme", w, false)
+
+	w = valid
+	w.URL = "/relative"
+	check("relative url", w, false)
+
+	w = valid
+	w.EventTypes = nil
+	check("no events", w, false)
+
+	w = valid
+	w.EventTypes = []EventType{"alert.foo"}
+	check("unknown event", w, false)
+
+	w = valid
+	w.EventTypes = []EventType{EventOverrideDeleted, EventAlertClosed, EventOverrideDeleted}
+	n, err := w.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, []EventType{EventAlertClosed, EventOverrideDeleted}, n.EventTypes, "should sort and remove duplicates")
+}
@@ -113,6 +113,26 @@ func AuthCheckCountContext(ctx context.Context, max uint64) context.Context {
 	return ctx
 }
 
+// NewAuthCheckCountContext will return a new context with a new AuthCheckCount,
+// independent of any parent context, and the maximum set to the provided value.
+func NewAuthCheckCountContext(ctx context.Context, max uint64) context.Context {
+	ctx = context.WithValue(ctx, contextKeyCheckCount, new(uint64))
+	ctx = context.WithValue(ctx, contextKeyCheckCountMax, max)
+
+	return ctx
+}
+
+// ResetAuthCheckCount will reset the current number of authorization checks to zero.
+// It is used by long-lived requests (like GraphQL subscriptions) to apply the limit
+// to each unit of work rather than the request as a whole.
+func ResetAuthCheckCount(ctx context.Context) {
+	val, ok := ctx.Value(contextKeyCheckCount).(*uint64)
+	if !ok {
+		return
+	}
+	atomic.StoreUint64(val, 0)
+}
+
 // ServiceSourceContext behaves like ServiceContext, but provides SourceInfo about the authorization.
 func ServiceSourceContext(ctx context.Context, id string, src *SourceInfo) context.Context {
 	ctx = SourceContext(ctx, src)
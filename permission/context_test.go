@@ -74,3 +74,28 @@ func TestWithoutAuth(t *testing.T) {
 		check(d.ctx, d.name)
 	}
 }
+
+func TestResetAuthCheckCount(t *testing.T) {
+	ctx := UserContext(AuthCheckCountContext(context.Background(), 2), "00000000-0000-0000-0000-000000000001", RoleUser)
+	for i := 0; i < 2; i++ {
+		if err := LimitCheckAny(ctx, User); err != nil {
+			t.Fatalf("check %d: err = %v; want nil", i, err)
+		}
+	}
+	if err := LimitCheckAny(ctx, User); err == nil {
+		t.Fatal("err = nil; want limit error")
+	}
+
+	ResetAuthCheckCount(ctx)
+	if err := LimitCheckAny(ctx, User); err != nil {
+		t.Errorf("after reset: err = %v; want nil", err)
+	}
+
+	newCtx := NewAuthCheckCountContext(ctx, 1)
+	if err := LimitCheckAny(newCtx, User); err != nil {
+		t.Errorf("new counter: err = %v; want nil", err)
+	}
+	if n, _ := AuthCheckCount(ctx); n != 1 {
+		t.Errorf("parent count = %d; want 1", n)
+	}
+}
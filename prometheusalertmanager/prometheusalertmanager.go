@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -99,23 +100,33 @@ type postBody struct {
 	}
 }
 type postBodyAlert struct {
-	Labels struct {
-		AlertName string
-		Instance  string
+	Status       string
+	Labels       map[string]string
+	Annotations  map[string]string
+	GeneratorURL string
+	Fingerprint  string
+}
+
+// lookup returns the value for key in m, ignoring case if there is no exact match.
+func lookup(m map[string]string, key string) string {
+	if v, ok := m[key]; ok {
+		return v
 	}
-	Annotations struct {
-		Summary string
-		Details string
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v
+		}
 	}
-	GeneratorURL string
+
+	return ""
 }
 
 func (a postBodyAlert) Summary() string {
-	if a.Annotations.Summary != "" {
-		return a.Annotations.Summary
+	if summary := lookup(a.Annotations, "summary"); summary != "" {
+		return summary
 	}
 
-	return a.Labels.AlertName + " " + a.Labels.Instance
+	return lookup(a.Labels, "alertname") + " " + lookup(a.Labels, "instance")
 }
 func (a postBodyAlert) gen() string {
 	if a.GeneratorURL == "" {
@@ -125,17 +136,91 @@ func (a postBodyAlert) gen() string {
 	return fmt.Sprintf(" [View](%s)", a.GeneratorURL)
 }
 func (a postBodyAlert) Details() string {
-	if a.Annotations.Details != "" {
-		return a.Annotations.Details + a.gen()
+	if details := lookup(a.Annotations, "details"); details != "" {
+		return details + a.gen()
 	}
 
 	return a.Summary() + a.gen()
 }
+
+// DedupKey returns the key identifying the alert within Alertmanager.
+//
+// Alertmanager versions prior to 0.19 do not send a fingerprint, in which case
+// the sorted label set is used.
+func (a postBodyAlert) DedupKey() string {
+	if a.Fingerprint != "" {
+		return a.Fingerprint
+	}
+
+	keys := make([]string, 0, len(a.Labels))
+	for k := range a.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var s strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&s, "%s=%q,", k, a.Labels[k])
+	}
+	return s.String()
+}
+
+// writeTable writes a markdown table of the map, sorted by key.
+func writeTable(s *strings.Builder, m map[string]string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	s.WriteString("| Name | Value |\n| --- | --- |\n")
+	for _, k := range keys {
+		v := strings.ReplaceAll(m[k], "\n", " ")
+		fmt.Fprintf(s, "| %s | %s |\n", k, strings.ReplaceAll(v, "|", "\\|"))
+	}
+}
+
+// AlertDetails returns the details of a single alert, including its description,
+// runbook, labels and annotations.
+func (a postBodyAlert) AlertDetails(externalURL string) string {
+	var s strings.Builder
+	if desc := lookup(a.Annotations, "description"); desc != "" {
+		s.WriteString(desc + "\n\n")
+	} else if details := lookup(a.Annotations, "details"); details != "" {
+		s.WriteString(details + "\n\n")
+	}
+
+	var links []string
+	if u := lookup(a.Annotations, "runbook_url"); validate.AbsoluteURL("runbook_url", u) == nil {
+		links = append(links, fmt.Sprintf("[Runbook](%s)", u))
+	}
+	if validate.AbsoluteURL("GeneratorURL", a.GeneratorURL) == nil {
+		links = append(links, fmt.Sprintf("[View](%s)", a.GeneratorURL))
+	}
+	if validate.AbsoluteURL("ExternalURL", externalURL) == nil {
+		links = append(links, fmt.Sprintf("[Prometheus Alertmanager UI](%s)", externalURL))
+	}
+	if len(links) > 0 {
+		s.WriteString(strings.Join(links, " | ") + "\n\n")
+	}
+
+	if len(a.Labels) > 0 {
+		s.WriteString("## Labels\n\n")
+		writeTable(&s, a.Labels)
+		s.WriteString("\n")
+	}
+	if len(a.Annotations) > 0 {
+		s.WriteString("## Annotations\n\n")
+		writeTable(&s, a.Annotations)
+	}
+
+	return s.String()
+}
+
 func (b postBody) Summary() string {
 	if b.CommonAnnotations.Summary != "" {
 		return b.CommonAnnotations.Summary
 	}
-	if b.CommonLabels.AlertName == "" {
+	if b.CommonLabels.AlertName == "" && len(b.Alerts) > 0 {
 		// different alerts
 		return b.Alerts[0].Summary() + fmt.Sprintf(" and %d others", len(b.Alerts)-1)
 	}
@@ -147,7 +232,7 @@ func (b postBody) Summary() string {
 
 	var instances []string
 	for _, a := range b.Alerts {
-		instances = append(instances, a.Labels.Instance)
+		instances = append(instances, lookup(a.Labels, "instance"))
 	}
 
 	return b.CommonLabels.AlertName + " " + strings.Join(instances, ",")
@@ -200,46 +285,97 @@ func PrometheusAlertmanagerEventsAPI(aDB alert.Store, intDB integrationkey.Store
 			return
 		}
 
-		var status alert.Status
-		switch body.Status {
-		case "firing":
-			status = alert.StatusTriggered
-		case "resolved":
-			status = alert.StatusClosed
+		var msgs []*alert.Alert
+		switch r.FormValue("mode") {
+		case "", "group":
+			msg, err := groupAlert(body, buf.Bytes())
+			if clientError(w, http.StatusBadRequest, err) {
+				log.Logf(ctx, "bad request from prometheus alertmanager: %v", err)
+				return
+			}
+			msgs = append(msgs, msg)
+		case "alert":
+			msgs, err = individualAlerts(body)
+			if clientError(w, http.StatusBadRequest, err) {
+				log.Logf(ctx, "bad request from prometheus alertmanager: %v", err)
+				return
+			}
 		default:
-			log.Logf(ctx, "bad request from prometheus alertmanager: missing or invalid state")
-			http.Error(w, "invalid state", http.StatusBadRequest)
+			log.Logf(ctx, "bad request from prometheus alertmanager: invalid mode")
+			http.Error(w, "invalid mode", http.StatusBadRequest)
 			return
 		}
 
-		data := make([]byte, buf.Len())
-		copy(data, buf.Bytes())
-		buf.Reset()
-		err = json.Indent(&buf, data, "", "  ")
-		if err == nil {
-			data = buf.Bytes()
-		}
+		for _, msg := range msgs {
+			msg.Source = alert.SourcePrometheusAlertmanager
+			msg.ServiceID = serviceID
 
-		summary := validate.SanitizeText(body.Summary(), alert.MaxSummaryLength)
-		msg := &alert.Alert{
-			Summary:   summary,
-			Details:   validate.SanitizeText(body.Details(string(data)), alert.MaxDetailsLength),
-			Status:    status,
-			Source:    alert.SourcePrometheusAlertmanager,
-			ServiceID: serviceID,
-			Dedup:     alert.NewUserDedup(summary),
+			err = retry.DoTemporaryError(func(int) error {
+				_, err = aDB.CreateOrUpdate(ctx, msg)
+				return err
+			},
+				retry.Log(ctx),
+				retry.Limit(10),
+				retry.FibBackoff(time.Second),
+			)
+			if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for prometheus alertmanager")) {
+				return
+			}
 		}
+	}
+}
 
-		err = retry.DoTemporaryError(func(int) error {
-			_, err = aDB.CreateOrUpdate(ctx, msg)
-			return err
-		},
-			retry.Log(ctx),
-			retry.Limit(10),
-			retry.FibBackoff(time.Second),
-		)
-		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for prometheus alertmanager")) {
-			return
+func parseStatus(status string) (alert.Status, error) {
+	switch status {
+	case "firing":
+		return alert.StatusTriggered, nil
+	case "resolved":
+		return alert.StatusClosed, nil
+	}
+
+	return "", errors.Errorf("missing or invalid status '%s'", status)
+}
+
+// groupAlert returns a single alert representing the entire group notification.
+func groupAlert(body postBody, payload []byte) (*alert.Alert, error) {
+	status, err := parseStatus(body.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	data := payload
+	var buf bytes.Buffer
+	err = json.Indent(&buf, payload, "", "  ")
+	if err == nil {
+		data = buf.Bytes()
+	}
+
+	summary := validate.SanitizeText(body.Summary(), alert.MaxSummaryLength)
+	return &alert.Alert{
+		Summary: summary,
+		Details: validate.SanitizeText(body.Details(string(data)), alert.MaxDetailsLength),
+		Status:  status,
+		Dedup:   alert.NewUserDedup(summary),
+	}, nil
+}
+
+// individualAlerts returns an alert for every alert in the group notification,
+// deduplicated by fingerprint so that each is opened and closed independently.
+func individualAlerts(body postBody) ([]*alert.Alert, error) {
+	msgs := make([]*alert.Alert, 0, len(body.Alerts))
+	for i, a := range body.Alerts {
+		status, err := parseStatus(a.Status)
+		if err != nil {
+			return nil, errors.Wrapf(err, "alert %d", i)
 		}
+
+		msgs = append(msgs, &alert.Alert{
+			Summary: validate.SanitizeText(a.Summary(), alert.MaxSummaryLength),
+			Details: validate.SanitizeText(a.AlertDetails(body.ExternalURL), alert.MaxDetailsLength),
+			Status:  status,
+			Dedup:   alert.NewUserDedup(a.DedupKey()),
+		})
 	}
+
+	return msgs, nil
 }
@@ -0,0 +1,39 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+)
+
+// CoverageGap is a detected period of an upcoming shift where nobody will be on-call.
+type CoverageGap struct {
+	ID           int
+	ScheduleID   string
+	ScheduleName string
+	TimeZone     *time.Location
+	Start, End   time.Time
+}
+
+// FindCoverageGap will return the recorded coverage gap with the given ID.
+func (store *Store) FindCoverageGap(ctx context.Context, id int) (*CoverageGap, error) {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return nil, err
+	}
+
+	var g CoverageGap
+	var tz string
+	err = store.findGap.QueryRowContext(ctx, id).Scan(&g.ID, &g.ScheduleID, &g.ScheduleName, &tz, &g.Start, &g.End)
+	if err != nil {
+		return nil, err
+	}
+	g.TimeZone, err = util.LoadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
@@ -1,6 +1,8 @@
 package rotation
 
 import (
+	"time"
+
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/validation/validate"
 )
@@ -10,6 +12,25 @@ type Participant struct {
 	Position   int    `json:"position"`
 	RotationID string `json:"rotation_id"`
 	Target     assignment.Target
+
+	// UnavailableUntil, if set, is when the participant can take turns again. Until then
+	// they are skipped, and credited an extra turn once available.
+	UnavailableUntil time.Time `json:"unavailable_until"`
+
+	// SkipCredit is the number of skipped turns owed to the participant.
+	SkipCredit int `json:"skip_credit"`
+}
+
+// ParticipantHours is the time a user spent on-call for a rotation over a period.
+type ParticipantHours struct {
+	UserID   string
+	Duration time.Duration
+
+	// Shifts is the number of shifts that overlapped the period.
+	Shifts int
+
+	// SkipCredit is the number of skipped turns currently owed to the user.
+	SkipCredit int
 }
 
 func (p Participant) Normalize() (*Participant, error) {
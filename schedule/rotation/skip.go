@@ -0,0 +1,51 @@
+package rotation
+
+import "time"
+
+// Availability holds the skip state of a rotation participant.
+type Availability struct {
+	UnavailableUntil *time.Time `json:"unavailable_until"`
+	SkipCredit       int        `json:"skip_credit"`
+}
+
+// Available will return true if the participant can take a shift starting at t.
+func (a Availability) Available(t time.Time) bool {
+	return a.UnavailableUntil == nil || !a.UnavailableUntil.After(t)
+}
+
+// NextPosition will return the position of the participant that takes the shift starting at t after pos.
+// A resume value of -1 indicates the rotation is not resuming after a credited turn.
+//
+// Participants unavailable at t are skipped and credited a turn. Once available, a participant with
+// credit takes the next shift out of order, after which the rotation resumes where it left off. If
+// nobody is available the rotation advances normally.
+//
+// The SkipCredit of parts is updated in place.
+func NextPosition(parts []Availability, pos, resume int, t time.Time) (newPos, newResume int) {
+	n := len(parts)
+	next := (pos + 1) % n
+	if resume >= 0 && resume < n {
+		next = resume
+	}
+
+	for i := 0; i < n; i++ {
+		c := (next + i) % n
+		if c != pos && parts[c].SkipCredit > 0 && parts[c].Available(t) {
+			parts[c].SkipCredit--
+			return c, next
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		c := (next + i) % n
+		if !parts[c].Available(t) {
+			continue
+		}
+		for j := 0; j < i; j++ {
+			parts[(next+j)%n].SkipCredit++
+		}
+		return c, -1
+	}
+
+	return next, -1
+}
@@ -0,0 +1,49 @@
+package rotation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextPosition(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := now.Add(24 * time.Hour)
+	away := Availability{UnavailableUntil: &later}
+
+	t.Run("normal", func(t *testing.T) {
+		parts := []Availability{{}, {}, {}}
+		pos, resume := NextPosition(parts, 2, -1, now)
+		assert.Equal(t, 0, pos)
+		assert.Equal(t, -1, resume)
+	})
+
+	t.Run("skip unavailable", func(t *testing.T) {
+		parts := []Availability{{}, away, {}}
+		pos, resume := NextPosition(parts, 0, -1, now)
+		assert.Equal(t, 2, pos)
+		assert.Equal(t, -1, resume)
+		assert.Equal(t, 1, parts[1].SkipCredit)
+	})
+
+	t.Run("all unavailable", func(t *testing.T) {
+		parts := []Availability{away, away}
+		pos, resume := NextPosition(parts, 0, -1, now)
+		assert.Equal(t, 1, pos)
+		assert.Equal(t, -1, resume)
+		assert.Equal(t, 0, parts[0].SkipCredit+parts[1].SkipCredit)
+	})
+
+	t.Run("credit back", func(t *testing.T) {
+		parts := []Availability{{}, {SkipCredit: 1}, {}, {}}
+		pos, resume := NextPosition(parts, 2, -1, later)
+		assert.Equal(t, 1, pos)
+		assert.Equal(t, 3, resume)
+		assert.Equal(t, 0, parts[1].SkipCredit)
+
+		pos, resume = NextPosition(parts, pos, resume, later)
+		assert.Equal(t, 3, pos)
+		assert.Equal(t, -1, resume)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
@@ -44,6 +45,9 @@ type Store interface {
 	DeleteRotationParticipantsTx(ctx context.Context, tx *sql.Tx, partIDs []string) error
 	UpdateParticipantUserIDTx(ctx context.Context, tx *sql.Tx, partID, userID string) error
 	DeleteStateTx(ctx context.Context, tx *sql.Tx, rotationID string) error
+
+	SetParticipantUnavailableTx(ctx context.Context, tx *sql.Tx, rotationID string, position int, until time.Time) error
+	FindParticipantHours(ctx context.Context, rotationID string, start, end time.Time) ([]ParticipantHours, error)
 }
 type StateStore interface {
 	ReadStore
@@ -107,8 +111,12 @@ type DB struct {
 	deleteParticipants      *sql.Stmt
 	updateParticipantUserID *sql.Stmt
 	setActiveIndex          *sql.Stmt
+	clearResumePosition     *sql.Stmt
 
 	findPartCount *sql.Stmt
+
+	setUnavailable *sql.Stmt
+	partHours      *sql.Stmt
 }
 
 func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
@@ -234,12 +242,12 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 		`),
 		setActiveParticipant: p.P(`
 			UPDATE rotation_state
-			SET rotation_participant_id = $2
+			SET rotation_participant_id = $2, resume_position = NULL
 			WHERE rotation_id = $1
 		`),
 
 		findPartPos:         p.P(`SELECT position, rotation_id FROM rotation_participants WHERE id = $1`),
-		findAllParticipants: p.P(`SELECT id, rotation_id, position, user_id FROM rotation_participants WHERE rotation_id = $1 ORDER BY position`),
+		findAllParticipants: p.P(`SELECT id, rotation_id, position, user_id, unavailable_until, skip_credit FROM rotation_participants WHERE rotation_id = $1 ORDER BY position`),
 
 		findParticipant:   p.P(`SELECT rotation_id, position, user_id FROM rotation_participants WHERE id = $1`),
 		participantActive: p.P(`SELECT 1 FROM rotation_state WHERE rotation_participant_id = $1 LIMIT 1`),
@@ -260,15 +268,62 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 		`),
 
 		updateParticipantUserID: p.P(`
-			UPDATE rotation_participants SET user_id = $2 WHERE id = $1
+			UPDATE rotation_participants
+			SET
+				user_id = $2,
+				unavailable_until = CASE WHEN user_id = $2 THEN unavailable_until END,
+				skip_credit = CASE WHEN user_id = $2 THEN skip_credit ELSE 0 END
+			WHERE id = $1
 		`),
 
 		setActiveIndex: p.P(`
 			UPDATE rotation_state SET rotation_participant_id = (SELECT id FROM rotation_participants WHERE rotation_id = $1 AND position = $2),
-			position = $2
+			position = $2,
+			resume_position = NULL
 			WHERE rotation_id = $1
 		`),
+
+		// resume_position refers to a participant position, so it is only valid until
+		// participants are moved, removed, or replaced.
+		clearResumePosition: p.P(`
+			UPDATE rotation_state
+			SET resume_position = NULL
+			WHERE
+				resume_position NOTNULL AND
+				rotation_id IN (SELECT rotation_id FROM rotation_participants WHERE id = ANY($1))
+		`),
 		findPartCount: p.P(`SELECT participant_count FROM rotations WHERE id = $1`),
+
+		setUnavailable: p.P(`
+			UPDATE rotation_participants
+			SET unavailable_until = $3
+			WHERE rotation_id = $1 AND position = $2
+		`),
+		partHours: p.P(`
+			WITH members AS (
+				SELECT user_id FROM rotation_participants WHERE rotation_id = $1
+				UNION
+				SELECT user_id
+				FROM rotation_shift_history
+				WHERE rotation_id = $1 AND start_time < $3 AND coalesce(end_time, now()) > $2
+			)
+			SELECT
+				u.user_id,
+				coalesce(sum(extract(epoch FROM least(coalesce(h.end_time, now()), $3) - greatest(h.start_time, $2))), 0)::float8,
+				count(h.id),
+				coalesce((
+					SELECT sum(part.skip_credit)
+					FROM rotation_participants part
+					WHERE part.rotation_id = $1 AND part.user_id = u.user_id
+				), 0)
+			FROM members u
+			LEFT JOIN rotation_shift_history h ON
+				h.rotation_id = $1 AND
+				h.user_id = u.user_id AND
+				h.start_time < $3 AND
+				coalesce(h.end_time, now()) > $2
+			GROUP BY u.user_id
+		`),
 	}, p.Err
 }
 
@@ -679,12 +734,14 @@ func (db *DB) FindAllParticipantsTx(ctx context.Context, tx *sql.Tx, rotationID
 
 	var p Participant
 	var userID sql.NullString
+	var until sqlutil.NullTime
 	var res []Participant
 	for rows.Next() {
-		err = rows.Scan(&p.ID, &p.RotationID, &p.Position, &userID)
+		err = rows.Scan(&p.ID, &p.RotationID, &p.Position, &userID, &until, &p.SkipCredit)
 		if err != nil {
 			return nil, err
 		}
+		p.UnavailableUntil = until.Time
 		if userID.Valid {
 			p.Target = assignment.UserTarget(userID.String)
 		} else {
@@ -746,12 +803,23 @@ func (db *DB) RemoveParticipantTx(ctx context.Context, tx *sql.Tx, id string) (s
 		return "", err
 	}
 
-	s := db.deleteParticipant
-	if tx != nil {
-		s = tx.Stmt(s)
+	var ownsTx bool
+	if tx == nil {
+		ownsTx = true
+		tx, err = db.db.BeginTx(ctx, nil)
+		if err != nil {
+			return "", err
+		}
+		defer tx.Rollback()
+	}
+
+	_, err = tx.StmtContext(ctx, db.clearResumePosition).ExecContext(ctx, sqlutil.UUIDArray{id})
+	if err != nil {
+		return "", err
 	}
+
 	var rotID string
-	err = s.QueryRowContext(ctx, id).Scan(&rotID)
+	err = tx.Stmt(db.deleteParticipant).QueryRowContext(ctx, id).Scan(&rotID)
 	if err != nil {
 		return "", err
 	}
@@ -762,6 +830,13 @@ func (db *DB) RemoveParticipantTx(ctx context.Context, tx *sql.Tx, id string) (s
 		return "", err
 	}
 
+	if ownsTx {
+		err = tx.Commit()
+		if err != nil {
+			return "", err
+		}
+	}
+
 	return rotID, nil
 }
 func (db *DB) MoveParticipant(ctx context.Context, id string, newPos int) error {
@@ -777,9 +852,24 @@ func (db *DB) MoveParticipant(ctx context.Context, id string, newPos int) error
 		return err
 	}
 
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.StmtContext(ctx, db.clearResumePosition).ExecContext(ctx, sqlutil.UUIDArray{id})
+	if err != nil {
+		return err
+	}
+
 	var rotID string
-	err = db.moveParticipant.QueryRowContext(ctx, id, newPos).Scan(&rotID)
-	return err
+	err = tx.StmtContext(ctx, db.moveParticipant).QueryRowContext(ctx, id, newPos).Scan(&rotID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (db *DB) SetActiveParticipant(ctx context.Context, rotID string, partID string) error {
@@ -887,11 +977,18 @@ func (db *DB) DeleteRotationParticipantsTx(ctx context.Context, tx *sql.Tx, part
 		return err
 	}
 
+	clearStmt := db.clearResumePosition
 	stmt := db.deleteParticipants
 	if tx != nil {
+		clearStmt = tx.StmtContext(ctx, clearStmt)
 		stmt = tx.StmtContext(ctx, stmt)
 	}
 
+	_, err = clearStmt.ExecContext(ctx, sqlutil.UUIDArray(partIDs))
+	if err != nil {
+		return err
+	}
+
 	_, err = stmt.ExecContext(ctx, sqlutil.UUIDArray(partIDs))
 	return err
 }
@@ -910,11 +1007,18 @@ func (db *DB) UpdateParticipantUserIDTx(ctx context.Context, tx *sql.Tx, partID,
 		return err
 	}
 
+	clearStmt := db.clearResumePosition
 	stmt := db.updateParticipantUserID
 	if tx != nil {
+		clearStmt = tx.StmtContext(ctx, clearStmt)
 		stmt = tx.StmtContext(ctx, stmt)
 	}
 
+	_, err = clearStmt.ExecContext(ctx, sqlutil.UUIDArray{partID})
+	if err != nil {
+		return err
+	}
+
 	_, err = stmt.ExecContext(ctx, partID, userID)
 	return err
 }
@@ -937,3 +1041,72 @@ func (db *DB) DeleteStateTx(ctx context.Context, tx *sql.Tx, rotationID string)
 	_, err = stmt.ExecContext(ctx, rotationID)
 	return err
 }
+
+// SetParticipantUnavailableTx will mark the participant at the given position as unavailable until the
+// provided time, causing them to be skipped when their turn comes. A zero time marks them available.
+func (db *DB) SetParticipantUnavailableTx(ctx context.Context, tx *sql.Tx, rotationID string, position int, until time.Time) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("RotationID", rotationID)
+	if err != nil {
+		return err
+	}
+
+	stmt := db.setUnavailable
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	res, err := stmt.ExecContext(ctx, rotationID, position, sqlutil.NullTime{Time: until, Valid: !until.IsZero()})
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return validation.NewFieldError("UserIndex", "invalid index for rotation")
+	}
+
+	return nil
+}
+
+// FindParticipantHours will return the time each participant of a rotation was on-call between start and end.
+func (db *DB) FindParticipantHours(ctx context.Context, rotationID string, start, end time.Time) ([]ParticipantHours, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("RotationID", rotationID)
+	if !end.After(start) {
+		err = validate.Many(err, validation.NewFieldError("End", "must be after Start"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.partHours.QueryContext(ctx, rotationID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []ParticipantHours
+	for rows.Next() {
+		var h ParticipantHours
+		var secs float64
+		err = rows.Scan(&h.UserID, &secs, &h.Shifts, &h.SkipCredit)
+		if err != nil {
+			return nil, err
+		}
+		h.Duration = time.Duration(secs * float64(time.Second))
+		res = append(res, h)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Duration > res[j].Duration })
+
+	return res, rows.Err()
+}
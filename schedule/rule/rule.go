@@ -19,8 +19,26 @@ type Rule struct {
 	End       timeutil.Clock `json:"end"`
 	CreatedAt time.Time      `json:"created_at"`
 	Target    assignment.Target
+
+	// HolidayMode determines how the rule behaves on holidays of the schedule's holiday calendar.
+	HolidayMode HolidayMode `json:"holiday_mode"`
 }
 
+// HolidayMode determines how a rule behaves on holidays.
+type HolidayMode string
+
+const (
+	// HolidayModeIgnore will treat holidays like any other day.
+	HolidayModeIgnore HolidayMode = "ignore"
+
+	// HolidayModeExclude will make the rule inactive for the entirety of a holiday.
+	HolidayModeExclude HolidayMode = "exclude"
+
+	// HolidayModeInclude will make the rule active for the entirety of a holiday, in addition
+	// to its normal schedule (e.g., a weekend rotation that also covers holidays).
+	HolidayModeInclude HolidayMode = "include"
+)
+
 func NewAlwaysActive(scheduleID string, tgt assignment.Target) *Rule {
 	return &Rule{
 		WeekdayFilter: timeutil.EveryDay(),
@@ -30,7 +48,13 @@ func NewAlwaysActive(scheduleID string, tgt assignment.Target) *Rule {
 }
 
 func (r Rule) Normalize() (*Rule, error) {
-	err := validate.UUID("ScheduleID", r.ScheduleID)
+	if r.HolidayMode == "" {
+		r.HolidayMode = HolidayModeIgnore
+	}
+	err := validate.Many(
+		validate.UUID("ScheduleID", r.ScheduleID),
+		validate.OneOf("HolidayMode", r.HolidayMode, HolidayModeIgnore, HolidayModeExclude, HolidayModeInclude),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -52,6 +76,7 @@ func (r *Rule) scanFrom(s scanner) error {
 		&r.WeekdayFilter,
 		&r.Start,
 		&r.End,
+		&r.HolidayMode,
 	}
 	var usr, rot sql.NullString
 	f = append(f, &usr, &rot)
@@ -87,7 +112,7 @@ func (r Rule) readFields() []interface{} {
 		rot.Valid = true
 		rot.String = r.Target.TargetID()
 	}
-	return append(f, usr, rot)
+	return append(f, usr, rot, r.HolidayMode)
 }
 
 // StartTime will return the next time the rule would be active.
@@ -186,6 +211,21 @@ func (r Rule) IsActive(t time.Time) bool {
 	return !r.StartTime(t).After(t)
 }
 
+// IsActiveHoliday is like IsActive, but takes the HolidayMode into account. The
+// isHoliday parameter should be true if t falls on a holiday.
+func (r Rule) IsActiveHoliday(t time.Time, isHoliday bool) bool {
+	if isHoliday {
+		switch r.HolidayMode {
+		case HolidayModeExclude:
+			return false
+		case HolidayModeInclude:
+			return true
+		}
+	}
+
+	return r.IsActive(t)
+}
+
 // String returns a human-readable string describing the rule
 func (r Rule) String() string {
 	if r.AlwaysActive() {
@@ -79,8 +79,9 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				start_time,
 				end_time,
 				tgt_user_id,
-				tgt_rotation_id
-			) values ($1, $2, ($3::Bool[])[1], ($3::Bool[])[2], ($3::Bool[])[3], ($3::Bool[])[4], ($3::Bool[])[5], ($3::Bool[])[6], ($3::Bool[])[7], $4, $5, $6, $7)
+				tgt_rotation_id,
+				holiday_mode
+			) values ($1, $2, ($3::Bool[])[1], ($3::Bool[])[2], ($3::Bool[])[3], ($3::Bool[])[4], ($3::Bool[])[5], ($3::Bool[])[6], ($3::Bool[])[7], $4, $5, $6, $7, $8)
 		`),
 		update: p.P(`
 			update schedule_rules
@@ -96,7 +97,8 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				start_time = $4,
 				end_time = $5,
 				tgt_user_id = $6,
-				tgt_rotation_id = $7
+				tgt_rotation_id = $7,
+				holiday_mode = $8
 			where id = $1
 		`),
 		delete: p.P(`delete from schedule_rules where id = any($1)`),
@@ -122,6 +124,7 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				],
 				start_time,
 				end_time,
+				holiday_mode,
 				tgt_user_id,
 				tgt_rotation_id
 			from schedule_rules
@@ -143,6 +146,7 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				],
 				start_time,
 				end_time,
+				holiday_mode,
 				tgt_user_id,
 				tgt_rotation_id
 			from schedule_rules
@@ -164,6 +168,7 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				],
 				start_time,
 				end_time,
+				holiday_mode,
 				tgt_user_id,
 				tgt_rotation_id
 			from schedule_rules
@@ -192,6 +197,7 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				],
 				start_time,
 				end_time,
+				holiday_mode,
 				case when tgt_user_id is not null then
 					tgt_user_id
 				else
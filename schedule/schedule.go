@@ -12,13 +12,53 @@ type Schedule struct {
 	Description    string         `json:"description"`
 	TimeZone       *time.Location `json:"time_zone"`
 	isUserFavorite bool
+
+	// GapNotifyDays is how many days ahead of an upcoming coverage gap
+	// notifications will be sent. Zero disables gap notifications.
+	GapNotifyDays int `json:"gap_notify_days"`
+
+	// GapNotifyUserID, if set, is the only user notified of coverage gaps;
+	// otherwise users that have favorited the schedule are notified.
+	GapNotifyUserID string `json:"gap_notify_user_id"`
+
+	// TimeOffStrategy determines how users are taken off the schedule during recorded time off.
+	TimeOffStrategy TimeOffStrategy `json:"time_off_strategy"`
+
+	// HolidayCalendarID, if set, is the holiday calendar used by rules of the schedule
+	// that are suppressed or activated on holidays.
+	HolidayCalendarID string `json:"holiday_calendar_id"`
 }
 
+// TimeOffStrategy is the backfill strategy used for overrides generated from user time off.
+type TimeOffStrategy string
+
+const (
+	// TimeOffRemove will remove the user, leaving any remaining users on-call.
+	TimeOffRemove TimeOffStrategy = "remove"
+
+	// TimeOffReplaceNext will replace the user with the next participant of their rotation.
+	TimeOffReplaceNext TimeOffStrategy = "replace_next"
+)
+
+// MaxGapNotifyDays is the maximum value of GapNotifyDays.
+const MaxGapNotifyDays = 30
+
 func (s Schedule) Normalize() (*Schedule, error) {
 	err := validate.Many(
 		validate.IDName("Name", s.Name),
 		validate.Text("Description", s.Description, 1, 255),
+		validate.Range("GapNotifyDays", s.GapNotifyDays, 0, MaxGapNotifyDays),
 	)
+	if s.TimeOffStrategy == "" {
+		s.TimeOffStrategy = TimeOffRemove
+	}
+	err = validate.Many(err, validate.OneOf("TimeOffStrategy", s.TimeOffStrategy, TimeOffRemove, TimeOffReplaceNext))
+	if err == nil && s.GapNotifyUserID != "" {
+		err = validate.UUID("GapNotifyUserID", s.GapNotifyUserID)
+	}
+	if err == nil && s.HolidayCalendarID != "" {
+		err = validate.UUID("HolidayCalendarID", s.HolidayCalendarID)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -46,6 +46,10 @@ var searchTemplate = template.Must(template.New("search").Parse(`
 		sched.name,
 		sched.description,
 		sched.time_zone,
+		sched.gap_notify_days,
+		sched.gap_notify_user_id,
+		sched.time_off_strategy,
+		sched.holiday_calendar_id,
 		fav IS DISTINCT FROM NULL
 	FROM schedules sched
 	{{if not .FavoritesOnly }}
@@ -153,8 +157,9 @@ func (store *Store) Search(ctx context.Context, opts *SearchOptions) ([]Schedule
 	var result []Schedule
 	var s Schedule
 	var tz string
+	var gapUser, holidayCal sql.NullString
 	for rows.Next() {
-		err = rows.Scan(&s.ID, &s.Name, &s.Description, &tz, &s.isUserFavorite)
+		err = rows.Scan(&s.ID, &s.Name, &s.Description, &tz, &s.GapNotifyDays, &gapUser, &s.TimeOffStrategy, &holidayCal, &s.isUserFavorite)
 		if err != nil {
 			return nil, err
 		}
@@ -163,6 +168,8 @@ func (store *Store) Search(ctx context.Context, opts *SearchOptions) ([]Schedule
 			return nil, err
 		}
 		s.TimeZone = loc
+		s.GapNotifyUserID = gapUser.String
+		s.HolidayCalendarID = holidayCal.String
 		result = append(result, s)
 	}
 
@@ -0,0 +1,70 @@
+package shiftexport
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+func parseTime(name, value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return t, validation.NewFieldError(name, "must be an RFC 3339 timestamp")
+	}
+	return t, nil
+}
+
+// ServeExport will export shifts for the schedules and time range provided by the
+// `schedule_id` (may be repeated), `start`, `end`, and `format` (csv or json) query params.
+func (s *Store) ServeExport(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if req.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := req.URL.Query()
+	format := Format(q.Get("format"))
+	if format == "" {
+		format = FormatCSV
+	}
+	err := validate.OneOf("format", format, FormatCSV, FormatJSON)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	var opts Options
+	opts.ScheduleIDs = q["schedule_id"]
+	opts.Start, err = parseTime("start", q.Get("start"))
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	opts.End, err = parseTime("end", q.Get("end"))
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	shifts, err := s.Export(ctx, opts)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	var buf bytes.Buffer
+	err = Write(&buf, format, shifts)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	if format == FormatCSV {
+		w.Header().Set("Content-Type", "text/csv")
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="goalert-shifts.%s"`, format))
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,81 @@
+package shiftexport
+
+import (
+	"time"
+
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Format is the format of exported shift data.
+type Format string
+
+// Supported export formats.
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// Limits for a single export.
+const (
+	MaxSchedules = 50
+	MaxRange     = 366 * 24 * time.Hour
+)
+
+// Options configure an export.
+type Options struct {
+	ScheduleIDs []string
+	Start, End  time.Time
+}
+
+// A Shift is an on-call shift for one of the exported schedules.
+//
+// Start and End are clipped to the requested range, so that Hours can be summed
+// directly.
+type Shift struct {
+	oncall.Shift
+
+	ScheduleID   string
+	ScheduleName string
+
+	UserName  string
+	UserEmail string
+
+	// RotationName is set when the shift is from a rotation.
+	RotationName string
+}
+
+// Hours returns the length of the shift in hours.
+func (s Shift) Hours() float64 { return s.End.Sub(s.Start).Hours() }
+
+// Normalize will validate the Options and return a normalized copy.
+func (o Options) Normalize() (*Options, error) {
+	// de-duplicate schedule IDs
+	ids := make([]string, 0, len(o.ScheduleIDs))
+	seen := make(map[string]bool, len(o.ScheduleIDs))
+	for _, id := range o.ScheduleIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	o.ScheduleIDs = ids
+
+	err := validate.Many(
+		validate.Range("ScheduleIDs", len(o.ScheduleIDs), 1, MaxSchedules),
+		validate.ManyUUID("ScheduleIDs", o.ScheduleIDs, MaxSchedules),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !o.End.After(o.Start) {
+		return nil, validation.NewFieldError("End", "must be after start time")
+	}
+	if o.End.Sub(o.Start) > MaxRange {
+		return nil, validation.NewFieldError("End", "range must not be more than 366 days")
+	}
+
+	return &o, nil
+}
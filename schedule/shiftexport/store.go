@@ -0,0 +1,179 @@
+package shiftexport
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+)
+
+// Store allows exporting on-call shifts for one or more schedules.
+type Store struct {
+	oc oncall.Store
+
+	schedNames *sql.Stmt
+	userInfo   *sql.Stmt
+	rotNames   *sql.Stmt
+}
+
+// NewStore will create a new Store with the given parameters.
+func NewStore(ctx context.Context, db *sql.DB, oc oncall.Store) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		oc: oc,
+
+		schedNames: p.P(`select id, name from schedules where id = any($1)`),
+		userInfo:   p.P(`select id, name, email from users where id = any($1)`),
+		rotNames:   p.P(`select id, name from rotations where id = any($1)`),
+	}, p.Err
+}
+
+// Export will return all shifts, including their source, for the requested schedules
+// and time range. Shifts are sorted by schedule name and then start time.
+func (s *Store) Export(ctx context.Context, opts Options) ([]Shift, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	o, err := opts.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	schedNames, err := s.names(ctx, s.schedNames, o.ScheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range o.ScheduleIDs {
+		if _, ok := schedNames[id]; !ok {
+			return nil, validation.NewFieldError("ScheduleIDs", "schedule not found: "+id)
+		}
+	}
+
+	var result []Shift
+	for _, id := range o.ScheduleIDs {
+		shifts, err := s.oc.SourcedHistoryBySchedule(ctx, id, o.Start, o.End)
+		if err != nil {
+			return nil, err
+		}
+		for _, shift := range shifts {
+			if shift.Start.Before(o.Start) {
+				shift.Start = o.Start
+			}
+			if shift.End.After(o.End) {
+				shift.End = o.End
+			}
+			if !shift.End.After(shift.Start) {
+				continue
+			}
+			result = append(result, Shift{
+				Shift:        shift,
+				ScheduleID:   id,
+				ScheduleName: schedNames[id],
+			})
+		}
+	}
+
+	err = s.fillNames(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].ScheduleName != result[j].ScheduleName {
+			return strings.ToLower(result[i].ScheduleName) < strings.ToLower(result[j].ScheduleName)
+		}
+		if !result[i].Start.Equal(result[j].Start) {
+			return result[i].Start.Before(result[j].Start)
+		}
+		return result[i].UserName < result[j].UserName
+	})
+
+	return result, nil
+}
+
+func (s *Store) names(ctx context.Context, stmt *sql.Stmt, ids []string) (map[string]string, error) {
+	names := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	rows, err := stmt.QueryContext(ctx, sqlutil.UUIDArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, name string
+		err = rows.Scan(&id, &name)
+		if err != nil {
+			return nil, err
+		}
+		names[id] = name
+	}
+
+	return names, rows.Err()
+}
+
+// fillNames will set user and rotation info for all shifts.
+func (s *Store) fillNames(ctx context.Context, shifts []Shift) error {
+	var userIDs, rotIDs []string
+	seen := make(map[string]bool)
+	for _, shift := range shifts {
+		if !seen[shift.UserID] {
+			seen[shift.UserID] = true
+			userIDs = append(userIDs, shift.UserID)
+		}
+		if shift.Source == nil || shift.Source.RotationID == "" || seen[shift.Source.RotationID] {
+			continue
+		}
+		seen[shift.Source.RotationID] = true
+		rotIDs = append(rotIDs, shift.Source.RotationID)
+	}
+
+	rotNames, err := s.names(ctx, s.rotNames, rotIDs)
+	if err != nil {
+		return err
+	}
+
+	type userInfo struct{ Name, Email string }
+	users := make(map[string]userInfo, len(userIDs))
+	if len(userIDs) > 0 {
+		rows, err := s.userInfo.QueryContext(ctx, sqlutil.UUIDArray(userIDs))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id string
+			var info userInfo
+			err = rows.Scan(&id, &info.Name, &info.Email)
+			if err != nil {
+				return err
+			}
+			users[id] = info
+		}
+		if err = rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	for i := range shifts {
+		info := users[shifts[i].UserID]
+		shifts[i].UserName = info.Name
+		shifts[i].UserEmail = info.Email
+		if shifts[i].Source != nil {
+			shifts[i].RotationName = rotNames[shifts[i].Source.RotationID]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package shiftexport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/target/goalert/oncall"
+)
+
+var csvHeader = []string{
+	"schedule_id",
+	"schedule_name",
+	"user_id",
+	"user_name",
+	"user_email",
+	"start",
+	"end",
+	"hours",
+	"truncated",
+	"source",
+	"rotation_id",
+	"rotation_name",
+	"override_id",
+	"rule_id",
+}
+
+// Write will encode the shifts to w in the given format.
+func Write(w io.Writer, format Format, shifts []Shift) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, shifts)
+	case FormatJSON:
+		return WriteJSON(w, shifts)
+	}
+
+	return fmt.Errorf("unsupported format '%s'", format)
+}
+
+func formatHours(h float64) string { return strconv.FormatFloat(h, 'f', 2, 64) }
+
+// WriteCSV will write the shifts as CSV, with a header row. Times are in UTC and RFC 3339 format.
+func WriteCSV(w io.Writer, shifts []Shift) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range shifts {
+		var src oncall.ShiftSource
+		if s.Source != nil {
+			src = *s.Source
+		}
+		err = cw.Write([]string{
+			s.ScheduleID,
+			s.ScheduleName,
+			s.UserID,
+			s.UserName,
+			s.UserEmail,
+			s.Start.UTC().Format(time.RFC3339),
+			s.End.UTC().Format(time.RFC3339),
+			formatHours(s.Hours()),
+			strconv.FormatBool(s.Truncated),
+			string(src.Type),
+			src.RotationID,
+			s.RotationName,
+			src.OverrideID,
+			src.RuleID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+type jsonShift struct {
+	ScheduleID   string             `json:"schedule_id"`
+	ScheduleName string             `json:"schedule_name"`
+	UserID       string             `json:"user_id"`
+	UserName     string             `json:"user_name"`
+	UserEmail    string             `json:"user_email"`
+	Start        time.Time          `json:"start"`
+	End          time.Time          `json:"end"`
+	Hours        json.Number        `json:"hours"`
+	Truncated    bool               `json:"truncated"`
+	Source       oncall.ShiftSource `json:"source"`
+	RotationName string             `json:"rotation_name,omitempty"`
+}
+
+// WriteJSON will write the shifts as a JSON array.
+func WriteJSON(w io.Writer, shifts []Shift) error {
+	data := make([]jsonShift, 0, len(shifts))
+	for _, s := range shifts {
+		js := jsonShift{
+			ScheduleID:   s.ScheduleID,
+			ScheduleName: s.ScheduleName,
+			UserID:       s.UserID,
+			UserName:     s.UserName,
+			UserEmail:    s.UserEmail,
+			Start:        s.Start.UTC(),
+			End:          s.End.UTC(),
+			Hours:        json.Number(formatHours(s.Hours())),
+			Truncated:    s.Truncated,
+			RotationName: s.RotationName,
+		}
+		if s.Source != nil {
+			js.Source = *s.Source
+		}
+		data = append(data, js)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
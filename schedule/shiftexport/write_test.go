@@ -0,0 +1,74 @@
+package shiftexport
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/oncall"
+)
+
+var testShifts = []Shift{
+	{
+		Shift: oncall.Shift{
+			UserID: "user1",
+			Start:  time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC),
+			End:    time.Date(2021, 3, 1, 21, 30, 0, 0, time.UTC),
+			Source: &oncall.ShiftSource{Type: oncall.ShiftSourceRotation, RuleID: "rule1", RotationID: "rot1"},
+		},
+		ScheduleID:   "sched1",
+		ScheduleName: "Primary, West",
+		UserName:     "Bob",
+		UserEmail:    "bob@example.com",
+		RotationName: "Weekly",
+	},
+	{
+		Shift: oncall.Shift{
+			UserID:    "user2",
+			Start:     time.Date(2021, 3, 1, 21, 30, 0, 0, time.UTC),
+			End:       time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC),
+			Truncated: true,
+			Source:    &oncall.ShiftSource{Type: oncall.ShiftSourceOverride, OverrideID: "ovr1"},
+		},
+		ScheduleID:   "sched1",
+		ScheduleName: "Primary, West",
+		UserName:     "Joe",
+		UserEmail:    "joe@example.com",
+	},
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, testShifts))
+
+	assert.Equal(t,
+		"schedule_id,schedule_name,user_id,user_name,user_email,start,end,hours,truncated,source,rotation_id,rotation_name,override_id,rule_id\n"+
+			"sched1,\"Primary, West\",user1,Bob,bob@example.com,2021-03-01T09:00:00Z,2021-03-01T21:30:00Z,12.50,false,rotation,rot1,Weekly,,rule1\n"+
+			"sched1,\"Primary, West\",user2,Joe,joe@example.com,2021-03-01T21:30:00Z,2021-03-02T00:00:00Z,2.50,true,override,,,ovr1,\n",
+		buf.String(),
+	)
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, testShifts[1:]))
+
+	assert.JSONEq(t, `[{
+		"schedule_id": "sched1",
+		"schedule_name": "Primary, West",
+		"user_id": "user2",
+		"user_name": "Joe",
+		"user_email": "joe@example.com",
+		"start": "2021-03-01T21:30:00Z",
+		"end": "2021-03-02T00:00:00Z",
+		"hours": 2.5,
+		"truncated": true,
+		"source": {"type": "override", "override_id": "ovr1"}
+	}]`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteJSON(&buf, nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
@@ -0,0 +1,222 @@
+package shiftimport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/util/ical"
+)
+
+// Parse will parse shifts from r in the given format. Times without an explicit offset or
+// time zone are interpreted in loc.
+func Parse(format Format, r io.Reader, loc *time.Location) ([]Row, error) {
+	switch format {
+	case FormatCSV:
+		return ParseCSV(r, loc)
+	case FormatICal:
+		return ParseICal(r, loc)
+	}
+
+	return nil, fmt.Errorf("unsupported format '%s'", format)
+}
+
+var csvTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func parseCSVTime(s string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range csvTimeLayouts {
+		t, err := time.ParseInLocation(layout, s, loc)
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time '%s'", s)
+}
+
+// ParseCSV will parse rows of (user email, start, end). A header row is optional; if present
+// it must contain the columns "email", "start", and "end" (in any order).
+func ParseCSV(r io.Reader, loc *time.Location) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	cols := map[string]int{"email": 0, "start": 1, "end": 2}
+	var rows []Row
+	var line int
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line++
+		if len(rec) == 1 && strings.TrimSpace(rec[0]) == "" {
+			continue
+		}
+
+		if len(rows) == 0 && !strings.Contains(strings.Join(rec, ""), "@") {
+			// header row
+			hdr := make(map[string]int, len(rec))
+			for i, name := range rec {
+				hdr[strings.ToLower(strings.TrimSpace(name))] = i
+			}
+			for name := range cols {
+				idx, ok := hdr[name]
+				if !ok {
+					return nil, fmt.Errorf("line %d: header is missing column '%s'", line, name)
+				}
+				cols[name] = idx
+			}
+			continue
+		}
+
+		for name, idx := range cols {
+			if idx >= len(rec) {
+				return nil, fmt.Errorf("line %d: missing column '%s'", line, name)
+			}
+		}
+
+		row := Row{Line: line, Email: strings.TrimSpace(rec[cols["email"]])}
+		row.Start, err = parseCSVTime(rec[cols["start"]], loc)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: start: %w", line, err)
+		}
+		row.End, err = parseCSVTime(rec[cols["end"]], loc)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: end: %w", line, err)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseICalTime(p ical.Property, loc *time.Location) (time.Time, error) {
+	if tzid := p.Params["TZID"]; tzid != "" {
+		var err error
+		loc, err = time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unknown time zone '%s'", tzid)
+		}
+	}
+
+	if p.Params["VALUE"] == "DATE" || len(p.Value) == 8 {
+		return time.ParseInLocation("20060102", p.Value, loc)
+	}
+	if strings.HasSuffix(p.Value, "Z") {
+		return time.Parse("20060102T150405Z", p.Value)
+	}
+
+	return time.ParseInLocation("20060102T150405", p.Value, loc)
+}
+
+var icalDurRx = regexp.MustCompile(`^\+?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+func parseICalDuration(s string) (time.Duration, error) {
+	m := icalDurRx.FindStringSubmatch(s)
+	if m == nil || s == "P" || s == "PT" {
+		return 0, fmt.Errorf("invalid duration '%s'", s)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var dur time.Duration
+	for i, unit := range units {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s'", s)
+		}
+		dur += time.Duration(n) * unit
+	}
+
+	return dur, nil
+}
+
+var emailRx = regexp.MustCompile(`[^\s<>"'():;,]+@[^\s<>"'():;,]+`)
+
+// ParseICal will parse each VEVENT as a shift. The user is identified by the email of the first
+// ATTENDEE, falling back to the ORGANIZER and then to an email address in the SUMMARY.
+//
+// Recurring events are not supported and will result in an error.
+func ParseICal(r io.Reader, loc *time.Location) ([]Row, error) {
+	events, err := ical.ReadEvents(r, MaxDataSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for _, ev := range events {
+		row := Row{Line: ev.Line}
+		var attendee, organizer, summary string
+		var start, end, dur *ical.Property
+		for i, p := range ev.Properties {
+			switch {
+			case p.Name == "RRULE" || p.Name == "RDATE":
+				return nil, fmt.Errorf("line %d: recurring events are not supported", p.Line)
+			case p.Name == "ATTENDEE" && attendee == "":
+				attendee = emailRx.FindString(p.Value)
+			case p.Name == "ORGANIZER":
+				organizer = emailRx.FindString(p.Value)
+			case p.Name == "SUMMARY":
+				summary = emailRx.FindString(p.Value)
+			case p.Name == "DTSTART":
+				start = &ev.Properties[i]
+			case p.Name == "DTEND":
+				end = &ev.Properties[i]
+			case p.Name == "DURATION":
+				dur = &ev.Properties[i]
+			}
+		}
+
+		switch {
+		case attendee != "":
+			row.Email = attendee
+		case organizer != "":
+			row.Email = organizer
+		default:
+			row.Email = summary
+		}
+		if start == nil {
+			return nil, fmt.Errorf("line %d: event is missing DTSTART", row.Line)
+		}
+		row.Start, err = parseICalTime(*start, loc)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: DTSTART: %w", row.Line, err)
+		}
+		switch {
+		case end != nil:
+			row.End, err = parseICalTime(*end, loc)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DTEND: %w", row.Line, err)
+			}
+		case dur != nil:
+			d, err := parseICalDuration(dur.Value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DURATION: %w", row.Line, err)
+			}
+			row.End = row.Start.Add(d)
+		default:
+			return nil, fmt.Errorf("line %d: event is missing DTEND", row.Line)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
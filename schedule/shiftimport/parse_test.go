@@ -0,0 +1,106 @@
+package shiftimport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	chi, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	t.Run("header", func(t *testing.T) {
+		rows, err := ParseCSV(strings.NewReader(
+			"Start,End,Email\n"+
+				"2021-03-01 09:00,2021-03-02 09:00,bob@example.com\n"+
+				"\n"+
+				"2021-03-02T09:00:00Z,2021-03-03T09:00:00Z, joe@example.com\n",
+		), chi)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+
+		assert.Equal(t, Row{
+			Line:  2,
+			Email: "bob@example.com",
+			Start: time.Date(2021, 3, 1, 9, 0, 0, 0, chi),
+			End:   time.Date(2021, 3, 2, 9, 0, 0, 0, chi),
+		}, rows[0])
+		assert.Equal(t, "joe@example.com", rows[1].Email)
+		assert.True(t, rows[1].Start.Equal(time.Date(2021, 3, 2, 9, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("no header", func(t *testing.T) {
+		rows, err := ParseCSV(strings.NewReader("bob@example.com,2021-03-01,2021-03-08\n"), time.UTC)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, 1, rows[0].Line)
+		assert.Equal(t, time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC), rows[0].End)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ParseCSV(strings.NewReader("user,start,end\n"), time.UTC)
+		assert.EqualError(t, err, "line 1: header is missing column 'email'")
+
+		_, err = ParseCSV(strings.NewReader("bob@example.com,2021-03-01,tomorrow\n"), time.UTC)
+		assert.EqualError(t, err, "line 1: end: invalid time 'tomorrow'")
+
+		_, err = ParseCSV(strings.NewReader("bob@example.com,2021-03-01\n"), time.UTC)
+		assert.EqualError(t, err, "line 1: missing column 'end'")
+	})
+}
+
+func TestParseICal(t *testing.T) {
+	chi, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	const data = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:1\r\n" +
+		"SUMMARY:On-Call\r\n" +
+		"ATTENDEE;CN=\"Bob: Primary\";ROLE=REQ-PARTICIPANT:mailto:bob@exam\r\n" +
+		" ple.com\r\n" +
+		"DTSTART;TZID=America/Chicago:20210301T090000\r\n" +
+		"DTEND;TZID=America/Chicago:20210302T090000\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"TRIGGER:-PT15M\r\n" +
+		"DESCRIPTION:joe@example.com\r\n" +
+		"END:VALARM\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:joe@example.com\r\n" +
+		"DTSTART:20210302T150000Z\r\n" +
+		"DURATION:P1DT2H\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"ORGANIZER:mailto:ann@example.com\r\n" +
+		"DTSTART;VALUE=DATE:20210305\r\n" +
+		"DTEND;VALUE=DATE:20210306\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	rows, err := ParseICal(strings.NewReader(data), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 3, rows[0].Line)
+	assert.Equal(t, "bob@example.com", rows[0].Email)
+	assert.True(t, rows[0].Start.Equal(time.Date(2021, 3, 1, 9, 0, 0, 0, chi)))
+	assert.True(t, rows[0].End.Equal(time.Date(2021, 3, 2, 9, 0, 0, 0, chi)))
+
+	assert.Equal(t, "joe@example.com", rows[1].Email)
+	assert.Equal(t, 26*time.Hour, rows[1].End.Sub(rows[1].Start))
+
+	assert.Equal(t, "ann@example.com", rows[2].Email)
+	assert.Equal(t, time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC), rows[2].Start)
+
+	_, err = ParseICal(strings.NewReader("BEGIN:VEVENT\nDTSTART:20210301T090000Z\nRRULE:FREQ=WEEKLY\nEND:VEVENT\n"), time.UTC)
+	assert.EqualError(t, err, "line 3: recurring events are not supported")
+
+	_, err = ParseICal(strings.NewReader("BEGIN:VEVENT\nDTSTART:20210301T090000Z\nEND:VEVENT\n"), time.UTC)
+	assert.EqualError(t, err, "line 1: event is missing DTEND")
+}
@@ -0,0 +1,96 @@
+package shiftimport
+
+import (
+	"time"
+
+	"github.com/target/goalert/validation/validate"
+)
+
+// Format is the format of imported shift data.
+type Format string
+
+// Supported import formats.
+const (
+	FormatCSV  Format = "csv"
+	FormatICal Format = "ical"
+)
+
+// Mode determines how imported shifts are applied to a schedule.
+type Mode string
+
+const (
+	// ModeTemporarySchedule will replace the schedule, for the entire imported time range, with
+	// exactly the imported shifts.
+	ModeTemporarySchedule Mode = "temporary_schedule"
+
+	// ModeOverrides will add each imported shift as an "add" override, leaving the existing
+	// schedule configuration in place.
+	ModeOverrides Mode = "overrides"
+)
+
+// MaxDataSize is the maximum size, in bytes, of data that can be imported at once.
+const MaxDataSize = 1024 * 1024
+
+// A Row is a single shift parsed from imported data.
+type Row struct {
+	// Line is the line (or CSV record) number the shift was defined on, starting at 1.
+	Line int
+
+	Email      string
+	Start, End time.Time
+}
+
+// A Shift is an imported Row that has been matched to a user.
+type Shift struct {
+	Row
+	UserID string
+}
+
+// A Conflict is a problem that prevents a Row from being imported.
+type Conflict struct {
+	Row
+	Message string
+}
+
+// Options configure an import.
+type Options struct {
+	ScheduleID string
+	Mode       Mode
+	Rows       []Row
+
+	// DryRun, if set, will only compute the Result without applying any changes.
+	DryRun bool
+}
+
+// Result is the outcome (or preview) of an import.
+type Result struct {
+	// Shifts are the shifts that will be (or were) created.
+	Shifts []Shift
+
+	// Conflicts prevent the import from being applied while non-empty.
+	Conflicts []Conflict
+
+	// Skipped is the number of rows that were ignored because they ended in the past.
+	Skipped int
+
+	// Applied indicates the shifts were created.
+	Applied bool
+}
+
+// Normalize will validate the Options and return a normalized copy.
+func (o Options) Normalize() (*Options, error) {
+	if o.Mode == "" {
+		o.Mode = ModeTemporarySchedule
+	}
+
+	err := validate.Many(
+		validate.UUID("ScheduleID", o.ScheduleID),
+		validate.OneOf("Mode", o.Mode, ModeTemporarySchedule, ModeOverrides),
+		validate.Range("Rows", len(o.Rows), 1, 5000),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
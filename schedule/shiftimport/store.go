@@ -0,0 +1,300 @@
+package shiftimport
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/override"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+)
+
+// Store allows importing shifts into a schedule.
+type Store struct {
+	sched *schedule.Store
+	ovr   override.Store
+
+	findUsers     *sql.Stmt
+	findOverrides *sql.Stmt
+}
+
+// NewStore will create a new Store with the given parameters.
+func NewStore(ctx context.Context, db *sql.DB, sched *schedule.Store, ovr override.Store) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		sched: sched,
+		ovr:   ovr,
+
+		findUsers: p.P(`
+			select lower(email), id
+			from users
+			where lower(email) = any($1)
+		`),
+		findOverrides: p.P(`
+			select add_user_id, remove_user_id, start_time, end_time
+			from user_overrides
+			where
+				tgt_schedule_id = $1 and
+				end_time > $2 and
+				start_time < $3
+		`),
+	}, p.Err
+}
+
+// ImportTx will match imported rows to users and check them for conflicts. Unless DryRun is
+// set, and as long as there are no conflicts, the shifts will then be applied to the schedule.
+//
+// Rows that have already ended are skipped. A transaction is required.
+func (s *Store) ImportTx(ctx context.Context, tx *sql.Tx, opts Options) (*Result, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	o, err := opts.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	// ensure the schedule exists, and prevent concurrent imports
+	_, err = s.sched.FindOneForUpdate(ctx, tx, o.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs, err := s.lookupUsers(ctx, tx, o.Rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var res Result
+	now := time.Now()
+	for _, row := range o.Rows {
+		ids := userIDs[strings.ToLower(row.Email)]
+		switch {
+		case !row.End.After(row.Start):
+			res.Conflicts = append(res.Conflicts, Conflict{Row: row, Message: "end must be after start"})
+		case !row.End.After(now):
+			res.Skipped++
+		case row.Email == "":
+			res.Conflicts = append(res.Conflicts, Conflict{Row: row, Message: "no user email specified"})
+		case len(ids) == 0:
+			res.Conflicts = append(res.Conflicts, Conflict{Row: row, Message: "no user found with this email"})
+		case len(ids) > 1:
+			res.Conflicts = append(res.Conflicts, Conflict{Row: row, Message: "multiple users found with this email"})
+		default:
+			res.Shifts = append(res.Shifts, Shift{Row: row, UserID: ids[0]})
+		}
+	}
+	sort.Slice(res.Shifts, func(i, j int) bool { return res.Shifts[i].Start.Before(res.Shifts[j].Start) })
+	if len(res.Shifts) == 0 {
+		return &res, nil
+	}
+
+	switch o.Mode {
+	case ModeTemporarySchedule:
+		err = s.checkTemporarySchedule(ctx, tx, o.ScheduleID, &res)
+	case ModeOverrides:
+		err = s.checkOverrides(ctx, tx, o.ScheduleID, &res)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(res.Conflicts, func(i, j int) bool { return res.Conflicts[i].Line < res.Conflicts[j].Line })
+
+	if o.DryRun || len(res.Conflicts) > 0 {
+		return &res, nil
+	}
+
+	switch o.Mode {
+	case ModeTemporarySchedule:
+		err = s.applyTemporarySchedule(ctx, tx, o.ScheduleID, res.Shifts)
+	case ModeOverrides:
+		err = s.applyOverrides(ctx, tx, o.ScheduleID, res.Shifts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	res.Applied = true
+
+	return &res, nil
+}
+
+func (s *Store) lookupUsers(ctx context.Context, tx *sql.Tx, rows []Row) (map[string][]string, error) {
+	var emails []string
+	for _, row := range rows {
+		if row.Email == "" {
+			continue
+		}
+		emails = append(emails, strings.ToLower(row.Email))
+	}
+
+	stmt := s.findUsers
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	dbRows, err := stmt.QueryContext(ctx, sqlutil.StringArray(emails))
+	if err != nil {
+		return nil, err
+	}
+	defer dbRows.Close()
+
+	result := make(map[string][]string)
+	for dbRows.Next() {
+		var email, id string
+		err = dbRows.Scan(&email, &id)
+		if err != nil {
+			return nil, err
+		}
+		result[email] = append(result[email], id)
+	}
+
+	return result, dbRows.Err()
+}
+
+func shiftBounds(shifts []Shift) (start, end time.Time) {
+	start, end = shifts[0].Start, shifts[0].End
+	for _, s := range shifts[1:] {
+		if s.Start.Before(start) {
+			start = s.Start
+		}
+		if s.End.After(end) {
+			end = s.End
+		}
+	}
+	return start, end
+}
+
+// checkTemporarySchedule will add a conflict for every shift overlapping an existing
+// temporary schedule, as it would otherwise be silently replaced.
+func (s *Store) checkTemporarySchedule(ctx context.Context, tx *sql.Tx, scheduleID string, res *Result) error {
+	if len(res.Shifts) > schedule.FixedShiftsPerTemporaryScheduleLimit {
+		res.Conflicts = append(res.Conflicts, Conflict{
+			Message: fmt.Sprintf("too many shifts for a temporary schedule (%d, max %d); split the data or import as overrides",
+				len(res.Shifts), schedule.FixedShiftsPerTemporaryScheduleLimit,
+			),
+		})
+	}
+
+	temps, err := s.sched.TemporarySchedules(ctx, tx, uuid.FromStringOrNil(scheduleID))
+	if err != nil {
+		return err
+	}
+
+	start, end := shiftBounds(res.Shifts)
+	for _, tmp := range temps {
+		if !tmp.Start.Before(end) || !tmp.End.After(start) {
+			continue
+		}
+		for _, shift := range res.Shifts {
+			if !shift.Start.Before(tmp.End) || !shift.End.After(tmp.Start) {
+				continue
+			}
+			res.Conflicts = append(res.Conflicts, Conflict{Row: shift.Row, Message: "overlaps an existing temporary schedule"})
+		}
+	}
+
+	return nil
+}
+
+// checkOverrides will add a conflict for every shift overlapping another shift or existing
+// override for the same user, as overlapping overrides are not allowed.
+func (s *Store) checkOverrides(ctx context.Context, tx *sql.Tx, scheduleID string, res *Result) error {
+	start, end := shiftBounds(res.Shifts)
+
+	stmt := s.findOverrides
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	rows, err := stmt.QueryContext(ctx, scheduleID, start, end)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type span struct {
+		UserID     string
+		Start, End time.Time
+	}
+	var existing []span
+	for rows.Next() {
+		var add, rem sql.NullString
+		var sp span
+		err = rows.Scan(&add, &rem, &sp.Start, &sp.End)
+		if err != nil {
+			return err
+		}
+		if add.Valid {
+			existing = append(existing, span{UserID: add.String, Start: sp.Start, End: sp.End})
+		}
+		if rem.Valid {
+			existing = append(existing, span{UserID: rem.String, Start: sp.Start, End: sp.End})
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for i, shift := range res.Shifts {
+		for _, sp := range existing {
+			if sp.UserID != shift.UserID || !shift.Start.Before(sp.End) || !shift.End.After(sp.Start) {
+				continue
+			}
+			res.Conflicts = append(res.Conflicts, Conflict{Row: shift.Row, Message: "overlaps an existing override for this user"})
+			break
+		}
+
+		// shifts are sorted by start time, so only earlier shifts need to be checked
+		for _, prev := range res.Shifts[:i] {
+			if prev.UserID != shift.UserID || !prev.End.After(shift.Start) {
+				continue
+			}
+			res.Conflicts = append(res.Conflicts, Conflict{Row: shift.Row, Message: fmt.Sprintf("overlaps another shift for this user (line %d)", prev.Line)})
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) applyTemporarySchedule(ctx context.Context, tx *sql.Tx, scheduleID string, shifts []Shift) error {
+	start, end := shiftBounds(shifts)
+	temp := schedule.TemporarySchedule{Start: start, End: end}
+	for _, shift := range shifts {
+		temp.Shifts = append(temp.Shifts, schedule.FixedShift{
+			Start:  shift.Start,
+			End:    shift.End,
+			UserID: shift.UserID,
+		})
+	}
+
+	return s.sched.SetTemporarySchedule(ctx, tx, uuid.FromStringOrNil(scheduleID), temp)
+}
+
+func (s *Store) applyOverrides(ctx context.Context, tx *sql.Tx, scheduleID string, shifts []Shift) error {
+	for _, shift := range shifts {
+		_, err := s.ovr.CreateUserOverrideTx(ctx, tx, &override.UserOverride{
+			AddUserID: shift.UserID,
+			Start:     shift.Start,
+			End:       shift.End,
+			Target:    assignment.ScheduleTarget(scheduleID),
+		})
+		if validation.IsValidationError(err) {
+			return validation.NewFieldError("Rows", fmt.Sprintf("line %d: %s", shift.Line, err.Error()))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
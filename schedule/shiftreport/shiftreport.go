@@ -0,0 +1,213 @@
+package shiftreport
+
+import (
+	"time"
+
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Limits for a single report.
+const (
+	MaxSchedules = 50
+	MaxUsers     = 200
+	MaxHolidays  = 500
+	MaxRange     = 366 * 24 * time.Hour
+)
+
+// DayType categorizes a calendar day.
+type DayType int
+
+// Known day types.
+const (
+	DayTypeWeekday DayType = iota
+	DayTypeWeekend
+	DayTypeHoliday
+)
+
+// Options configure an on-call hours report.
+type Options struct {
+	ScheduleIDs []string
+
+	// UserIDs, if set, limits the report to the given users.
+	UserIDs []string
+
+	Start, End time.Time
+
+	// TimeZone is used to determine days, weekends, and business hours. Defaults to UTC.
+	TimeZone string
+
+	// Holidays are dates (YYYY-MM-DD), in TimeZone, that are counted as holidays.
+	Holidays []string
+
+	// BusinessStart and BusinessEnd are the bounds of business hours on weekdays. If
+	// both are zero, 9:00 to 17:00 is used.
+	BusinessStart, BusinessEnd timeutil.Clock
+
+	// IncludePages will count the pages (alerts a user was notified for) received during the period.
+	IncludePages bool
+}
+
+// Hours are on-call hours split by DayType.
+type Hours struct {
+	Weekday float64
+	Weekend float64
+	Holiday float64
+}
+
+// Total returns the total number of hours.
+func (h Hours) Total() float64 { return h.Weekday + h.Weekend + h.Holiday }
+
+func (h *Hours) add(o Hours) {
+	h.Weekday += o.Weekday
+	h.Weekend += o.Weekend
+	h.Holiday += o.Holiday
+}
+
+// ScheduleHours are the on-call hours of a user for a single schedule.
+type ScheduleHours struct {
+	ScheduleID string
+	Hours
+}
+
+// UserReport contains the on-call hours, and optionally pages, for a single user.
+//
+// Hours are the sum of all ScheduleHours, so they may exceed the length of the period
+// if the user was on-call for multiple schedules at once.
+type UserReport struct {
+	UserID   string
+	UserName string
+	Hours
+
+	Schedules []ScheduleHours
+
+	// Pages is the number of alerts the user was notified for. Only set if IncludePages was requested.
+	Pages int
+
+	// AfterHoursPages is the number of Pages where the first notification was sent outside of business hours.
+	AfterHoursPages int
+}
+
+// Report is the result of an on-call hours report.
+type Report struct {
+	Start, End time.Time
+	TimeZone   string
+	Users      []UserReport
+}
+
+type normalized struct {
+	Options
+
+	loc      *time.Location
+	holidays map[string]bool
+}
+
+// dayType returns the DayType of the calendar day t falls on.
+func (n *normalized) dayType(t time.Time) DayType {
+	t = t.In(n.loc)
+	if n.holidays[t.Format("2006-01-02")] {
+		return DayTypeHoliday
+	}
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return DayTypeWeekend
+	}
+
+	return DayTypeWeekday
+}
+
+// isAfterHours returns true if t is outside of business hours.
+func (n *normalized) isAfterHours(t time.Time) bool {
+	if n.dayType(t) != DayTypeWeekday {
+		return true
+	}
+
+	c := timeutil.NewClockFromTime(t.In(n.loc))
+	return c < n.BusinessStart || c >= n.BusinessEnd
+}
+
+// hours splits the time between start and end by the type of day.
+func (n *normalized) hours(start, end time.Time) Hours {
+	var h Hours
+	cur := start.In(n.loc)
+	for cur.Before(end) {
+		y, m, d := cur.Date()
+		next := time.Date(y, m, d+1, 0, 0, 0, 0, n.loc)
+		if next.After(end) {
+			next = end
+		}
+
+		dur := next.Sub(cur).Hours()
+		switch n.dayType(cur) {
+		case DayTypeHoliday:
+			h.Holiday += dur
+		case DayTypeWeekend:
+			h.Weekend += dur
+		default:
+			h.Weekday += dur
+		}
+		cur = next
+	}
+
+	return h
+}
+
+func dedup(ids []string) []string {
+	result := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+func (o Options) normalize() (*normalized, error) {
+	o.ScheduleIDs = dedup(o.ScheduleIDs)
+	o.UserIDs = dedup(o.UserIDs)
+	if o.TimeZone == "" {
+		o.TimeZone = "UTC"
+	}
+	if o.BusinessStart == 0 && o.BusinessEnd == 0 {
+		o.BusinessStart = timeutil.NewClock(9, 0)
+		o.BusinessEnd = timeutil.NewClock(17, 0)
+	}
+
+	err := validate.Many(
+		validate.Range("ScheduleIDs", len(o.ScheduleIDs), 1, MaxSchedules),
+		validate.ManyUUID("ScheduleIDs", o.ScheduleIDs, MaxSchedules),
+		validate.ManyUUID("UserIDs", o.UserIDs, MaxUsers),
+		validate.Range("Holidays", len(o.Holidays), 0, MaxHolidays),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !o.End.After(o.Start) {
+		return nil, validation.NewFieldError("End", "must be after start time")
+	}
+	if o.End.Sub(o.Start) > MaxRange {
+		return nil, validation.NewFieldError("End", "range must not be more than 366 days")
+	}
+	if o.BusinessEnd <= o.BusinessStart {
+		return nil, validation.NewFieldError("BusinessEnd", "must be after business start")
+	}
+
+	n := &normalized{Options: o, holidays: make(map[string]bool, len(o.Holidays))}
+	n.loc, err = util.LoadLocation(o.TimeZone)
+	if err != nil {
+		return nil, validation.NewFieldError("TimeZone", err.Error())
+	}
+	for _, day := range o.Holidays {
+		_, err = time.Parse("2006-01-02", day)
+		if err != nil {
+			return nil, validation.NewFieldError("Holidays", "invalid date '"+day+"', must be YYYY-MM-DD")
+		}
+		n.holidays[day] = true
+	}
+
+	return n, nil
+}
@@ -0,0 +1,66 @@
+package shiftreport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestOptions_Hours(t *testing.T) {
+	o, err := Options{
+		ScheduleIDs: []string{"00000000-0000-0000-0000-000000000001"},
+		Start:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		TimeZone:    "America/Chicago",
+		Holidays:    []string{"2021-01-18"},
+	}.normalize()
+	require.NoError(t, err)
+
+	chi := o.loc
+
+	// Friday 5PM to Tuesday 9AM, with Monday a holiday
+	h := o.hours(time.Date(2021, 1, 15, 17, 0, 0, 0, chi), time.Date(2021, 1, 19, 9, 0, 0, 0, chi))
+	assert.Equal(t, Hours{Weekday: 7 + 9, Weekend: 48, Holiday: 24}, h)
+	assert.Equal(t, float64(88), h.Total())
+
+	// days are determined in the report time zone, not UTC
+	h = o.hours(time.Date(2021, 1, 16, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 16, 6, 0, 0, 0, time.UTC))
+	assert.Equal(t, Hours{Weekday: 6}, h)
+
+	assert.False(t, o.isAfterHours(time.Date(2021, 1, 15, 9, 0, 0, 0, chi)))
+	assert.True(t, o.isAfterHours(time.Date(2021, 1, 15, 17, 0, 0, 0, chi)))
+	assert.True(t, o.isAfterHours(time.Date(2021, 1, 16, 12, 0, 0, 0, chi)))
+	assert.True(t, o.isAfterHours(time.Date(2021, 1, 18, 12, 0, 0, 0, chi)))
+}
+
+func TestOptions_Normalize(t *testing.T) {
+	valid := Options{
+		ScheduleIDs: []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000001"},
+		Start:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	o, err := valid.normalize()
+	require.NoError(t, err)
+	assert.Len(t, o.ScheduleIDs, 1)
+	assert.Equal(t, "UTC", o.TimeZone)
+	assert.Equal(t, timeutil.NewClock(9, 0), o.BusinessStart)
+	assert.Equal(t, timeutil.NewClock(17, 0), o.BusinessEnd)
+
+	check := func(name string, fn func(o *Options)) {
+		t.Helper()
+		o := valid
+		fn(&o)
+		_, err := o.normalize()
+		assert.Error(t, err, name)
+	}
+	check("no schedules", func(o *Options) { o.ScheduleIDs = nil })
+	check("end before start", func(o *Options) { o.End = o.Start.Add(-time.Hour) })
+	check("range", func(o *Options) { o.End = o.Start.AddDate(2, 0, 0) })
+	check("time zone", func(o *Options) { o.TimeZone = "Not/AZone" })
+	check("holiday", func(o *Options) { o.Holidays = []string{"01/18/2021"} })
+	check("business hours", func(o *Options) { o.BusinessStart, o.BusinessEnd = timeutil.NewClock(17, 0), timeutil.NewClock(8, 0) })
+}
@@ -0,0 +1,224 @@
+package shiftreport
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+)
+
+// Store allows generating on-call hours reports.
+type Store struct {
+	oc oncall.Store
+
+	schedIDs  *sql.Stmt
+	userNames *sql.Stmt
+	pages     *sql.Stmt
+}
+
+// NewStore will create a new Store with the given parameters.
+func NewStore(ctx context.Context, db *sql.DB, oc oncall.Store) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		oc: oc,
+
+		schedIDs:  p.P(`select id from schedules where id = any($1)`),
+		userNames: p.P(`select id, name from users where id = any($1)`),
+		pages: p.P(`
+			select sub_user_id, min(timestamp)
+			from alert_logs
+			where
+				event = 'notification_sent' and
+				sub_user_id = any($1) and
+				timestamp >= $2 and
+				timestamp < $3
+			group by sub_user_id, alert_id
+		`),
+	}, p.Err
+}
+
+// Report will total on-call hours, per user and schedule, for the given period. Shifts are
+// calculated the same way as for the schedule UI.
+func (s *Store) Report(ctx context.Context, opts Options) (*Report, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	o, err := opts.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkSchedules(ctx, o.ScheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := make(map[string]bool, len(o.UserIDs))
+	users := make(map[string]*UserReport)
+	for _, id := range o.UserIDs {
+		filter[id] = true
+		users[id] = &UserReport{UserID: id}
+	}
+
+	for _, schedID := range o.ScheduleIDs {
+		shifts, err := s.oc.HistoryBySchedule(ctx, schedID, o.Start, o.End)
+		if err != nil {
+			return nil, err
+		}
+
+		schedHours := make(map[string]*Hours)
+		for _, shift := range shifts {
+			if len(filter) > 0 && !filter[shift.UserID] {
+				continue
+			}
+			start, end := shift.Start, shift.End
+			if start.Before(o.Start) {
+				start = o.Start
+			}
+			if end.After(o.End) {
+				end = o.End
+			}
+			if !end.After(start) {
+				continue
+			}
+
+			h := schedHours[shift.UserID]
+			if h == nil {
+				h = new(Hours)
+				schedHours[shift.UserID] = h
+			}
+			h.add(o.hours(start, end))
+		}
+
+		for userID, h := range schedHours {
+			u := users[userID]
+			if u == nil {
+				u = &UserReport{UserID: userID}
+				users[userID] = u
+			}
+			u.Hours.add(*h)
+			u.Schedules = append(u.Schedules, ScheduleHours{ScheduleID: schedID, Hours: *h})
+		}
+	}
+
+	userIDs := make([]string, 0, len(users))
+	for id := range users {
+		userIDs = append(userIDs, id)
+	}
+
+	if o.IncludePages && len(userIDs) > 0 {
+		err = s.countPages(ctx, o, userIDs, users)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.fillNames(ctx, userIDs, users)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Report{
+		Start:    o.Start,
+		End:      o.End,
+		TimeZone: o.TimeZone,
+		Users:    make([]UserReport, 0, len(users)),
+	}
+	for _, u := range users {
+		r.Users = append(r.Users, *u)
+	}
+	sort.Slice(r.Users, func(i, j int) bool {
+		a, b := strings.ToLower(r.Users[i].UserName), strings.ToLower(r.Users[j].UserName)
+		if a != b {
+			return a < b
+		}
+		return r.Users[i].UserID < r.Users[j].UserID
+	})
+
+	return r, nil
+}
+
+func (s *Store) checkSchedules(ctx context.Context, ids []string) error {
+	rows, err := s.schedIDs.QueryContext(ctx, sqlutil.UUIDArray(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	found := make(map[string]bool, len(ids))
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return err
+		}
+		found[id] = true
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if !found[id] {
+			return validation.NewFieldError("ScheduleIDs", "schedule not found: "+id)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) countPages(ctx context.Context, o *normalized, userIDs []string, users map[string]*UserReport) error {
+	rows, err := s.pages.QueryContext(ctx, sqlutil.UUIDArray(userIDs), o.Start, o.End)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var t time.Time
+		err = rows.Scan(&userID, &t)
+		if err != nil {
+			return err
+		}
+		u := users[userID]
+		u.Pages++
+		if o.isAfterHours(t) {
+			u.AfterHoursPages++
+		}
+	}
+
+	return rows.Err()
+}
+
+func (s *Store) fillNames(ctx context.Context, userIDs []string, users map[string]*UserReport) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	rows, err := s.userNames.QueryContext(ctx, sqlutil.UUIDArray(userIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, name string
+		err = rows.Scan(&id, &name)
+		if err != nil {
+			return err
+		}
+		users[id].UserName = name
+	}
+
+	return rows.Err()
+}
@@ -30,6 +30,8 @@ type Store struct {
 
 	findMany *sql.Stmt
 
+	findGap *sql.Stmt
+
 	usr user.Store
 }
 
@@ -45,22 +47,26 @@ func NewStore(ctx context.Context, db *sql.DB, usr user.Store) (*Store, error) {
 		insertData:  p.P(`INSERT INTO schedule_data (schedule_id, data) VALUES ($1, '{}')`),
 		updateData:  p.P(`UPDATE schedule_data SET data = $2 WHERE schedule_id = $1`),
 
-		create:  p.P(`INSERT INTO schedules (id, name, description, time_zone) VALUES (DEFAULT, $1, $2, $3) RETURNING id`),
-		update:  p.P(`UPDATE schedules SET name = $2, description = $3, time_zone = $4 WHERE id = $1`),
-		findAll: p.P(`SELECT id, name, description, time_zone FROM schedules`),
+		create:  p.P(`INSERT INTO schedules (id, name, description, time_zone, gap_notify_days, gap_notify_user_id, time_off_strategy, holiday_calendar_id) VALUES (DEFAULT, $1, $2, $3, $4, $5, $6, $7) RETURNING id`),
+		update:  p.P(`UPDATE schedules SET name = $2, description = $3, time_zone = $4, gap_notify_days = $5, gap_notify_user_id = $6, time_off_strategy = $7, holiday_calendar_id = $8 WHERE id = $1`),
+		findAll: p.P(`SELECT id, name, description, time_zone, gap_notify_days, gap_notify_user_id, time_off_strategy, holiday_calendar_id FROM schedules`),
 		findOne: p.P(`
 			SELECT
 				s.id,
 				s.name,
 				s.description,
 				s.time_zone,
+				s.gap_notify_days,
+				s.gap_notify_user_id,
+				s.time_off_strategy,
+				s.holiday_calendar_id,
 				fav IS DISTINCT FROM NULL
 			FROM schedules s
 			LEFT JOIN user_favorites fav ON
 				fav.tgt_schedule_id = s.id AND fav.user_id = $2
 			WHERE s.id = $1
 		`),
-		findOneUp: p.P(`SELECT id, name, description, time_zone FROM schedules WHERE id = $1 FOR UPDATE`),
+		findOneUp: p.P(`SELECT id, name, description, time_zone, gap_notify_days, gap_notify_user_id, time_off_strategy, holiday_calendar_id FROM schedules WHERE id = $1 FOR UPDATE`),
 
 		findMany: p.P(`
 			SELECT
@@ -68,6 +74,10 @@ func NewStore(ctx context.Context, db *sql.DB, usr user.Store) (*Store, error) {
 				s.name,
 				s.description,
 				s.time_zone,
+				s.gap_notify_days,
+				s.gap_notify_user_id,
+				s.time_off_strategy,
+				s.holiday_calendar_id,
 				fav is distinct from null
 			FROM schedules s
 			LEFT JOIN user_favorites fav ON
@@ -76,6 +86,13 @@ func NewStore(ctx context.Context, db *sql.DB, usr user.Store) (*Store, error) {
 		`),
 
 		delete: p.P(`DELETE FROM schedules WHERE id = any($1)`),
+
+		findGap: p.P(`
+			SELECT g.id, s.id, s.name, s.time_zone, g.start_time, g.end_time
+			FROM schedule_coverage_gaps g
+			JOIN schedules s ON s.id = g.schedule_id
+			WHERE g.id = $1
+		`),
 	}, p.Err
 }
 func (store *Store) FindMany(ctx context.Context, ids []string) ([]Schedule, error) {
@@ -100,12 +117,15 @@ func (store *Store) FindMany(ctx context.Context, ids []string) ([]Schedule, err
 	result := make([]Schedule, 0, len(ids))
 	var s Schedule
 	var tz string
+	var gapUser, holidayCal sql.NullString
 	for rows.Next() {
-		err = rows.Scan(&s.ID, &s.Name, &s.Description, &tz, &s.isUserFavorite)
+		err = rows.Scan(&s.ID, &s.Name, &s.Description, &tz, &s.GapNotifyDays, &gapUser, &s.TimeOffStrategy, &holidayCal, &s.isUserFavorite)
 		if err != nil {
 			return nil, err
 		}
 
+		s.GapNotifyUserID = gapUser.String
+		s.HolidayCalendarID = holidayCal.String
 		s.TimeZone, err = util.LoadLocation(tz)
 		if err != nil {
 			return nil, err
@@ -133,7 +153,7 @@ func (store *Store) CreateScheduleTx(ctx context.Context, tx *sql.Tx, s *Schedul
 	if tx != nil {
 		stmt = tx.Stmt(stmt)
 	}
-	row := stmt.QueryRowContext(ctx, n.Name, n.Description, n.TimeZone.String())
+	row := stmt.QueryRowContext(ctx, n.Name, n.Description, n.TimeZone.String(), n.GapNotifyDays, gapNotifyUserID(n), n.TimeOffStrategy, holidayCalendarID(n))
 	err = row.Scan(&n.ID)
 	return n, err
 }
@@ -154,7 +174,7 @@ func (store *Store) Update(ctx context.Context, s *Schedule) error {
 		return err
 	}
 
-	_, err = store.update.ExecContext(ctx, n.ID, n.Name, n.Description, n.TimeZone.String())
+	_, err = store.update.ExecContext(ctx, n.ID, n.Name, n.Description, n.TimeZone.String(), n.GapNotifyDays, gapNotifyUserID(n), n.TimeOffStrategy, holidayCalendarID(n))
 	return err
 }
 func (store *Store) UpdateTx(ctx context.Context, tx *sql.Tx, s *Schedule) error {
@@ -172,7 +192,7 @@ func (store *Store) UpdateTx(ctx context.Context, tx *sql.Tx, s *Schedule) error
 		return err
 	}
 
-	_, err = tx.StmtContext(ctx, store.update).ExecContext(ctx, n.ID, n.Name, n.Description, n.TimeZone.String())
+	_, err = tx.StmtContext(ctx, store.update).ExecContext(ctx, n.ID, n.Name, n.Description, n.TimeZone.String(), n.GapNotifyDays, gapNotifyUserID(n), n.TimeOffStrategy, holidayCalendarID(n))
 	return err
 }
 
@@ -190,12 +210,15 @@ func (store *Store) FindAll(ctx context.Context) ([]Schedule, error) {
 
 	var s Schedule
 	var tz string
+	var gapUser, holidayCal sql.NullString
 	var res []Schedule
 	for rows.Next() {
-		err = rows.Scan(&s.ID, &s.Name, &s.Description, &tz)
+		err = rows.Scan(&s.ID, &s.Name, &s.Description, &tz, &s.GapNotifyDays, &gapUser, &s.TimeOffStrategy, &holidayCal)
 		if err != nil {
 			return nil, err
 		}
+		s.GapNotifyUserID = gapUser.String
+		s.HolidayCalendarID = holidayCal.String
 		s.TimeZone, err = util.LoadLocation(tz)
 		if err != nil {
 			return nil, errors.Wrap(err, "parse scanned time zone")
@@ -219,11 +242,14 @@ func (store *Store) FindOneForUpdate(ctx context.Context, tx *sql.Tx, id string)
 	row := tx.StmtContext(ctx, store.findOneUp).QueryRowContext(ctx, id)
 	var s Schedule
 	var tz string
-	err = row.Scan(&s.ID, &s.Name, &s.Description, &tz)
+	var gapUser, holidayCal sql.NullString
+	err = row.Scan(&s.ID, &s.Name, &s.Description, &tz, &s.GapNotifyDays, &gapUser, &s.TimeOffStrategy, &holidayCal)
 	if err != nil {
 		return nil, err
 	}
 
+	s.GapNotifyUserID = gapUser.String
+	s.HolidayCalendarID = holidayCal.String
 	s.TimeZone, err = util.LoadLocation(tz)
 	if err != nil {
 		return nil, err
@@ -245,11 +271,14 @@ func (store *Store) FindOne(ctx context.Context, id string) (*Schedule, error) {
 	row := store.findOne.QueryRowContext(ctx, id, userID)
 	var s Schedule
 	var tz string
-	err = row.Scan(&s.ID, &s.Name, &s.Description, &tz, &s.isUserFavorite)
+	var gapUser, holidayCal sql.NullString
+	err = row.Scan(&s.ID, &s.Name, &s.Description, &tz, &s.GapNotifyDays, &gapUser, &s.TimeOffStrategy, &holidayCal, &s.isUserFavorite)
 	if err != nil {
 		return nil, err
 	}
 
+	s.GapNotifyUserID = gapUser.String
+	s.HolidayCalendarID = holidayCal.String
 	s.TimeZone, err = util.LoadLocation(tz)
 	if err != nil {
 		return nil, err
@@ -282,3 +311,11 @@ func (store *Store) DeleteManyTx(ctx context.Context, tx *sql.Tx, ids []string)
 	_, err = s.ExecContext(ctx, sqlutil.UUIDArray(ids))
 	return err
 }
+
+func gapNotifyUserID(s *Schedule) sql.NullString {
+	return sql.NullString{String: s.GapNotifyUserID, Valid: s.GapNotifyUserID != ""}
+}
+
+func holidayCalendarID(s *Schedule) sql.NullString {
+	return sql.NullString{String: s.HolidayCalendarID, Valid: s.HolidayCalendarID != ""}
+}
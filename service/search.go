@@ -48,6 +48,7 @@ var searchTemplate = template.Must(template.New("search").Parse(`
 		svc.name,
 		svc.description,
 		svc.escalation_policy_id,
+		svc.auto_resolve_minutes,
 		fav IS DISTINCT FROM NULL
 	FROM services svc
 	{{if not .FavoritesOnly }}LEFT {{end}}JOIN user_favorites fav ON svc.id = fav.tgt_service_id AND {{if .FavoritesUserID}}fav.user_id = :favUserID{{else}}false{{end}}
@@ -213,7 +214,7 @@ func (db *DB) Search(ctx context.Context, opts *SearchOptions) ([]Service, error
 	var result []Service
 	for rows.Next() {
 		var s Service
-		err = rows.Scan(&s.ID, &s.Name, &s.Description, &s.EscalationPolicyID, &s.isUserFavorite)
+		err = rows.Scan(&s.ID, &s.Name, &s.Description, &s.EscalationPolicyID, &s.AutoResolveMinutes, &s.isUserFavorite)
 		if err != nil {
 			return nil, err
 		}
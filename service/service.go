@@ -2,12 +2,19 @@ package service
 
 import "github.com/target/goalert/validation/validate"
 
+// MaxAutoResolveMinutes is the maximum value of AutoResolveMinutes (30 days).
+const MaxAutoResolveMinutes = 30 * 24 * 60
+
 type Service struct {
 	ID                 string `json:"id"`
 	Name               string `json:"name"`
 	Description        string `json:"description"`
 	EscalationPolicyID string `json:"escalation_policy_id"`
 
+	// AutoResolveMinutes, if non-zero, is the number of minutes without activity
+	// after which open alerts are closed automatically.
+	AutoResolveMinutes int `json:"auto_resolve_minutes"`
+
 	epName         string
 	isUserFavorite bool
 }
@@ -28,6 +35,7 @@ func (s Service) Normalize() (*Service, error) {
 		validate.IDName("Name", s.Name),
 		validate.Text("Description", s.Description, 1, 255),
 		validate.UUID("EscalationPolicyID", s.EscalationPolicyID),
+		validate.Range("AutoResolveMinutes", s.AutoResolveMinutes, 0, MaxAutoResolveMinutes),
 	)
 	if err != nil {
 		return nil, err
@@ -57,6 +57,7 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 			s.name,
 			s.description,
 			s.escalation_policy_id,
+			s.auto_resolve_minutes,
 			e.name,
 			fav	is distinct from null
 		FROM
@@ -71,7 +72,8 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 			s.id,
 			s.name,
 			s.description,
-			s.escalation_policy_id
+			s.escalation_policy_id,
+			s.auto_resolve_minutes
 		FROM services s
 		WHERE s.id = $1
 		FOR UPDATE
@@ -82,6 +84,7 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 			s.name,
 			s.description,
 			s.escalation_policy_id,
+			s.auto_resolve_minutes,
 			e.name,
 			fav	is distinct from null
 		FROM
@@ -98,6 +101,7 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 			s.name,
 			s.description,
 			s.escalation_policy_id,
+			s.auto_resolve_minutes,
 			e.name,
 			false
 		FROM
@@ -112,6 +116,7 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 			s.name,
 			s.description,
 			s.escalation_policy_id,
+			s.auto_resolve_minutes,
 			e.name,
 			false
 		FROM
@@ -121,8 +126,8 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 			e.id = $1 AND
 			e.id = s.escalation_policy_id
 	`)
-	s.insert = p(`INSERT INTO services (id,name,description,escalation_policy_id) VALUES ($1,$2,$3,$4)`)
-	s.update = p(`UPDATE services SET name = $2, description = $3, escalation_policy_id = $4 WHERE id = $1`)
+	s.insert = p(`INSERT INTO services (id,name,description,escalation_policy_id,auto_resolve_minutes) VALUES ($1,$2,$3,$4,$5)`)
+	s.update = p(`UPDATE services SET name = $2, description = $3, escalation_policy_id = $4, auto_resolve_minutes = $5 WHERE id = $1`)
 	s.delete = p(`DELETE FROM services WHERE id = any($1)`)
 
 	return s, prep.Err
@@ -138,7 +143,7 @@ func (db *DB) FindOneForUpdate(ctx context.Context, tx *sql.Tx, id string) (*Ser
 		return nil, err
 	}
 	var s Service
-	err = tx.StmtContext(ctx, db.findOneUp).QueryRowContext(ctx, id).Scan(&s.ID, &s.Name, &s.Description, &s.EscalationPolicyID)
+	err = tx.StmtContext(ctx, db.findOneUp).QueryRowContext(ctx, id).Scan(&s.ID, &s.Name, &s.Description, &s.EscalationPolicyID, &s.AutoResolveMinutes)
 	if err != nil {
 		return nil, err
 	}
@@ -186,7 +191,7 @@ func (db *DB) CreateServiceTx(ctx context.Context, tx *sql.Tx, s *Service) (*Ser
 	if tx != nil {
 		stmt = tx.Stmt(stmt)
 	}
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.EscalationPolicyID)
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Description, n.EscalationPolicyID, n.AutoResolveMinutes)
 	if err != nil {
 		return nil, err
 	}
@@ -245,7 +250,7 @@ func (db *DB) UpdateTx(ctx context.Context, tx *sql.Tx, s *Service) error {
 		return err
 	}
 
-	_, err = wrap(tx, db.update).ExecContext(ctx, n.ID, n.Name, n.Description, n.EscalationPolicyID)
+	_, err = wrap(tx, db.update).ExecContext(ctx, n.ID, n.Name, n.Description, n.EscalationPolicyID, n.AutoResolveMinutes)
 	return err
 }
 
@@ -289,7 +294,7 @@ func (db *DB) FindOne(ctx context.Context, id string) (*Service, error) {
 }
 
 func scanFrom(s *Service, f func(args ...interface{}) error) error {
-	return f(&s.ID, &s.Name, &s.Description, &s.EscalationPolicyID, &s.epName, &s.isUserFavorite)
+	return f(&s.ID, &s.Name, &s.Description, &s.EscalationPolicyID, &s.AutoResolveMinutes, &s.epName, &s.isUserFavorite)
 }
 
 func scanAllFrom(rows *sql.Rows) (services []Service, err error) {
@@ -0,0 +1,133 @@
+package shiftrequest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/override"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Status is the current state of a shift request.
+type Status string
+
+const (
+	// StatusPending means the counterpart has not yet responded.
+	StatusPending Status = "pending"
+
+	// StatusAccepted means the counterpart accepted, and overrides were created.
+	StatusAccepted Status = "accepted"
+
+	// StatusDeclined means the counterpart declined the request.
+	StatusDeclined Status = "declined"
+
+	// StatusCancelled means the requester withdrew the request before a response.
+	StatusCancelled Status = "cancelled"
+)
+
+// Scan handles reading Status from the DB format
+func (s *Status) Scan(value interface{}) error {
+	switch t := value.(type) {
+	case []byte:
+		*s = Status(t)
+	case string:
+		*s = Status(t)
+	default:
+		return fmt.Errorf("could not process unknown type for status %T", t)
+	}
+
+	return nil
+}
+
+// MaxNoteLength is the maximum length of the Note field.
+const MaxNoteLength = 255
+
+// A Request asks another user to cover a shift on a schedule, or to trade shifts with the requester.
+type Request struct {
+	ID         string
+	ScheduleID string
+
+	// RequesterID is the user that created the request and currently holds the shift.
+	RequesterID string
+
+	// UserID is the counterpart asked to take the shift.
+	UserID string
+
+	// Start and End are the bounds of the requester's shift to be covered.
+	Start, End time.Time
+
+	// SwapStart and SwapEnd, if set, are the bounds of the counterpart's shift the
+	// requester will take in return.
+	SwapStart, SwapEnd time.Time
+
+	Note   string
+	Status Status
+
+	CreatedAt   time.Time
+	RespondedAt time.Time
+
+	// Read-only fields, populated when the request is fetched from the DB.
+	ScheduleName     string
+	ScheduleTimeZone *time.Location
+	RequesterName    string
+	UserName         string
+}
+
+// IsSwap will return true if the request is a trade rather than a one-way cover.
+func (r Request) IsSwap() bool { return !r.SwapStart.IsZero() }
+
+// Normalize will validate fields and return a normalized copy.
+func (r Request) Normalize() (*Request, error) {
+	err := validate.Many(
+		validate.UUID("ScheduleID", r.ScheduleID),
+		validate.UUID("RequesterID", r.RequesterID),
+		validate.UUID("UserID", r.UserID),
+		validate.Text("Note", r.Note, 0, MaxNoteLength),
+	)
+	if r.UserID == r.RequesterID {
+		err = validate.Many(err, validation.NewFieldError("UserID", "must be a different user than the requester"))
+	}
+	if !r.Start.Before(r.End) {
+		err = validate.Many(err, validation.NewFieldError("End", "must occur after Start time"))
+	}
+	if r.SwapStart.IsZero() != r.SwapEnd.IsZero() {
+		err = validate.Many(err, validation.NewFieldError("SwapEnd", "must be set along with SwapStart"))
+	} else if r.IsSwap() && !r.SwapStart.Before(r.SwapEnd) {
+		err = validate.Many(err, validation.NewFieldError("SwapEnd", "must occur after SwapStart time"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.Start = r.Start.Truncate(time.Minute)
+	r.End = r.End.Truncate(time.Minute)
+	r.SwapStart = r.SwapStart.Truncate(time.Minute)
+	r.SwapEnd = r.SwapEnd.Truncate(time.Minute)
+
+	return &r, nil
+}
+
+// Overrides will return the replace overrides that apply the request once accepted.
+func (r Request) Overrides() []override.UserOverride {
+	tgt := assignment.ScheduleTarget(r.ScheduleID)
+	result := []override.UserOverride{{
+		AddUserID:    r.UserID,
+		RemoveUserID: r.RequesterID,
+		Start:        r.Start,
+		End:          r.End,
+		Target:       tgt,
+	}}
+	if !r.IsSwap() {
+		return result
+	}
+
+	return append(result, override.UserOverride{
+		AddUserID:    r.RequesterID,
+		RemoveUserID: r.UserID,
+		Start:        r.SwapStart,
+		End:          r.SwapEnd,
+		Target:       tgt,
+	})
+}
@@ -0,0 +1,84 @@
+package shiftrequest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRequest_Normalize(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	valid := Request{
+		ScheduleID:  "00000000-0000-0000-0000-000000000001",
+		RequesterID: "00000000-0000-0000-0000-000000000002",
+		UserID:      "00000000-0000-0000-0000-000000000003",
+		Start:       start,
+		End:         start.Add(8 * time.Hour),
+	}
+
+	check := func(name string, r Request, expValid bool) {
+		t.Helper()
+		t.Run(name, func(t *testing.T) {
+			_, err := r.Normalize()
+			if expValid && err != nil {
+				t.Errorf("err = %v; want nil", err)
+			}
+			if !expValid && err == nil {
+				t.Error("err = nil; want validation error")
+			}
+		})
+	}
+
+	check("valid", valid, true)
+
+	r := valid
+	r.UserID = r.RequesterID
+	check("same user", r, false)
+
+	r = valid
+	r.End = r.Start
+	check("empty shift", r, false)
+
+	r = valid
+	r.SwapStart = start.Add(24 * time.Hour)
+	check("missing swap end", r, false)
+
+	r.SwapEnd = r.SwapStart.Add(-time.Hour)
+	check("swap end before start", r, false)
+
+	r.SwapEnd = r.SwapStart.Add(8 * time.Hour)
+	check("swap", r, true)
+}
+
+func TestRequest_Overrides(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	r := Request{
+		ScheduleID:  "00000000-0000-0000-0000-000000000001",
+		RequesterID: "00000000-0000-0000-0000-000000000002",
+		UserID:      "00000000-0000-0000-0000-000000000003",
+		Start:       start,
+		End:         start.Add(8 * time.Hour),
+	}
+
+	o := r.Overrides()
+	if len(o) != 1 {
+		t.Fatalf("len(cover overrides) = %d; want 1", len(o))
+	}
+	if o[0].AddUserID != r.UserID || o[0].RemoveUserID != r.RequesterID {
+		t.Errorf("cover override = %s; want counterpart replacing requester", o[0])
+	}
+
+	r.SwapStart = start.Add(24 * time.Hour)
+	r.SwapEnd = r.SwapStart.Add(8 * time.Hour)
+	o = r.Overrides()
+	if len(o) != 2 {
+		t.Fatalf("len(swap overrides) = %d; want 2", len(o))
+	}
+	if o[1].AddUserID != r.RequesterID || o[1].RemoveUserID != r.UserID || !o[1].Start.Equal(r.SwapStart) {
+		t.Errorf("swap override = %s; want requester replacing counterpart during swap shift", o[1])
+	}
+	for _, uo := range o {
+		if _, err := uo.Normalize(); err != nil {
+			t.Errorf("override %s: %v", uo, err)
+		}
+	}
+}
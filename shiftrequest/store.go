@@ -0,0 +1,311 @@
+package shiftrequest
+
+import (
+	"context"
+	"database/sql"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/target/goalert/override"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store allows the lookup and management of shift requests.
+type Store struct {
+	db *sql.DB
+
+	overrides override.Store
+
+	create     *sql.Stmt
+	setStatus  *sql.Stmt
+	notify     *sql.Stmt
+	findOne    *sql.Stmt
+	findOneUpd *sql.Stmt
+	findAll    *sql.Stmt
+}
+
+const requestColumns = `
+	r.id, r.schedule_id, r.requester_id, r.user_id,
+	r.start_time, r.end_time, r.swap_start_time, r.swap_end_time,
+	r.note, r.status, r.created_at, r.responded_at,
+	sched.name, sched.time_zone, req.name, u.name
+`
+
+const requestJoins = `
+	from shift_requests r
+	join schedules sched on sched.id = r.schedule_id
+	join users req on req.id = r.requester_id
+	join users u on u.id = r.user_id
+`
+
+// NewStore will create a new Store with the given parameters. Accepted requests
+// are applied through the provided override.Store.
+func NewStore(ctx context.Context, db *sql.DB, overrides override.Store) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db: db,
+
+		overrides: overrides,
+
+		create: p.P(`
+			insert into shift_requests (
+				id, schedule_id, requester_id, user_id,
+				start_time, end_time, swap_start_time, swap_end_time, note
+			) values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`),
+		setStatus: p.P(`
+			update shift_requests
+			set status = $2, responded_at = now()
+			where id = $1
+		`),
+		notify: p.P(`
+			insert into outgoing_messages (message_type, contact_method_id, user_id, shift_request_id)
+			select 'shift_request', cm.id, cm.user_id, $2
+			from user_contact_methods cm
+			where
+				cm.user_id = $1 and
+				cm.type in ('SMS', 'EMAIL') and
+				not cm.disabled
+		`),
+		findOne: p.P(`select ` + requestColumns + requestJoins + `where r.id = $1`),
+		findOneUpd: p.P(`
+			select ` + requestColumns + requestJoins + `
+			where r.id = $1
+			for update of r
+		`),
+		findAll: p.P(`
+			select ` + requestColumns + requestJoins + `
+			where
+				($1::uuid isnull or r.schedule_id = $1) and
+				($2::uuid isnull or r.requester_id = $2 or r.user_id = $2) and
+				(cardinality($3::enum_shift_request_status[]) = 0 or r.status = any($3))
+			order by r.created_at desc, r.id
+			limit 150
+		`),
+	}, p.Err
+}
+
+func (r *Request) scanFrom(scanFn func(...interface{}) error) error {
+	var swapStart, swapEnd, respondedAt sqlutil.NullTime
+	var tz string
+	err := scanFn(
+		&r.ID, &r.ScheduleID, &r.RequesterID, &r.UserID,
+		&r.Start, &r.End, &swapStart, &swapEnd,
+		&r.Note, &r.Status, &r.CreatedAt, &respondedAt,
+		&r.ScheduleName, &tz, &r.RequesterName, &r.UserName,
+	)
+	if err != nil {
+		return err
+	}
+	r.SwapStart = swapStart.Time
+	r.SwapEnd = swapEnd.Time
+	r.RespondedAt = respondedAt.Time
+
+	r.ScheduleTimeZone, err = util.LoadLocation(tz)
+	return err
+}
+
+// CreateTx will create a new pending request and notify the counterpart. Only the requester
+// (or an admin) may create a request on their behalf.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, r *Request) (*Request, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(r.RequesterID))
+	if err != nil {
+		return nil, err
+	}
+	n, err := r.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	n.ID = uuid.NewV4().String()
+	n.Status = StatusPending
+
+	swapStart := sqlutil.NullTime{Time: n.SwapStart, Valid: n.IsSwap()}
+	swapEnd := sqlutil.NullTime{Time: n.SwapEnd, Valid: n.IsSwap()}
+
+	stmt := s.create
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx,
+		n.ID, n.ScheduleID, n.RequesterID, n.UserID,
+		n.Start, n.End, swapStart, swapEnd, n.Note,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.notifyTx(ctx, tx, n.UserID, n.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+func (s *Store) notifyTx(ctx context.Context, tx *sql.Tx, userID, requestID string) error {
+	stmt := s.notify
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err := stmt.ExecContext(ctx, userID, requestID)
+	return err
+}
+
+// FindOne will return the request with the given ID.
+func (s *Store) FindOne(ctx context.Context, id string) (*Request, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.System)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var r Request
+	err = r.scanFrom(s.findOne.QueryRowContext(ctx, id).Scan)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// SearchOptions allow filtering the results of FindAll.
+type SearchOptions struct {
+	// ScheduleID, if set, limits results to the given schedule.
+	ScheduleID string
+
+	// UserID, if set, limits results to requests made by or sent to the given user.
+	UserID string
+
+	// Status, if set, limits results to requests with any of the given statuses.
+	Status []Status
+}
+
+// FindAll will return the most recent requests matching the provided options.
+func (s *Store) FindAll(ctx context.Context, opts SearchOptions) ([]Request, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.System)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ScheduleID != "" {
+		err = validate.Many(err, validate.UUID("ScheduleID", opts.ScheduleID))
+	}
+	if opts.UserID != "" {
+		err = validate.Many(err, validate.UUID("UserID", opts.UserID))
+	}
+	err = validate.Many(err, validate.Range("Status", len(opts.Status), 0, 4))
+	statuses := make(sqlutil.StringArray, 0, len(opts.Status))
+	for _, st := range opts.Status {
+		err = validate.Many(err, validate.OneOf("Status", st, StatusPending, StatusAccepted, StatusDeclined, StatusCancelled))
+		statuses = append(statuses, string(st))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAll.QueryContext(ctx,
+		sql.NullString{String: opts.ScheduleID, Valid: opts.ScheduleID != ""},
+		sql.NullString{String: opts.UserID, Valid: opts.UserID != ""},
+		statuses,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Request
+	for rows.Next() {
+		var r Request
+		err = r.scanFrom(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+
+	return result, rows.Err()
+}
+
+func (s *Store) findPendingForUpdateTx(ctx context.Context, tx *sql.Tx, id string) (*Request, error) {
+	err := validate.UUID("ID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var r Request
+	err = r.scanFrom(tx.StmtContext(ctx, s.findOneUpd).QueryRowContext(ctx, id).Scan)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status != StatusPending {
+		return nil, validation.NewFieldError("Status", "request is already "+string(r.Status))
+	}
+
+	return &r, nil
+}
+
+// RespondTx will accept or decline a pending request and notify the requester. Only the counterpart
+// (or an admin) may respond. Accepting will create replace overrides on the schedule for the shifts
+// involved.
+func (s *Store) RespondTx(ctx context.Context, tx *sql.Tx, id string, accept bool) (*Request, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.findPendingForUpdateTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(r.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	r.Status = StatusDeclined
+	if accept {
+		r.Status = StatusAccepted
+		for _, o := range r.Overrides() {
+			_, err = s.overrides.CreateUserOverrideTx(ctx, tx, &o)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	_, err = tx.StmtContext(ctx, s.setStatus).ExecContext(ctx, r.ID, r.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.notifyTx(ctx, tx, r.RequesterID, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// CancelTx will withdraw a pending request. Only the requester (or an admin) may cancel.
+func (s *Store) CancelTx(ctx context.Context, tx *sql.Tx, id string) error {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.Admin)
+	if err != nil {
+		return err
+	}
+	r, err := s.findPendingForUpdateTx(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(r.RequesterID))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.setStatus).ExecContext(ctx, r.ID, StatusCancelled)
+	return err
+}
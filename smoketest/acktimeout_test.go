@@ -0,0 +1,61 @@
+package smoketest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestAckTimeout checks that an acknowledged alert reverts to unacknowledged and
+// re-notifies the current step once the escalation policy's ack timeout expires.
+func TestAckTimeout(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "user"}}, 'bob', 'joe', 'user');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name, repeat, ack_timeout_minutes)
+	values
+		({{uuid "eid"}}, 'esc policy', 0, 30);
+	insert into escalation_policy_steps (id, escalation_policy_id, delay)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}}, 60);
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (id, service_id, summary, dedup_key)
+	values
+		(198, {{uuid "sid"}}, 'testing', 'user:1:testing');
+`
+	h := harness.NewHarness(t, sql, "ack-timeout-log-event")
+	defer h.Close()
+
+	d1 := h.Twilio(t).Device(h.Phone("1"))
+
+	d1.ExpectSMS("testing").
+		ThenReply("ack198").
+		ThenExpect("acknowledged")
+
+	h.FastForward(20 * time.Minute)
+	h.Trigger()
+
+	// still within the timeout, no more messages
+
+	h.FastForward(15 * time.Minute)
+	d1.ExpectSMS("testing")
+}
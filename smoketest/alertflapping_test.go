@@ -0,0 +1,112 @@
+package smoketest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestAlertFlapping verifies that an alert is held open once its dedup key flaps
+// and is closed after it has been stable for the flap window.
+func TestAlertFlapping(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'generic', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "add-flap-module")
+	defer h.Close()
+
+	h.SetConfigValue("Alerts.FlapThreshold", "4")
+	h.SetConfigValue("Alerts.FlapWindowMinutes", "60")
+
+	fire := func(close bool) {
+		t.Helper()
+		v := make(url.Values)
+		v.Set("summary", "disk space low")
+		v.Set("dedup", "disk")
+		if close {
+			v.Set("action", "close")
+		}
+
+		resp, err := http.Post(h.URL()+"/v1/api/alerts?key="+h.UUID("int_key"), "application/x-www-form-urlencoded", bytes.NewBufferString(v.Encode()))
+		require.NoError(t, err)
+		require.Equal(t, 2, resp.StatusCode/100, "HTTP response code")
+		resp.Body.Close()
+	}
+
+	openAlerts := func() []struct{ Flapping bool } {
+		t.Helper()
+		var data struct {
+			Alerts struct {
+				Nodes []struct{ Flapping bool }
+			}
+		}
+		res := h.GraphQLQuery2(fmt.Sprintf(`{alerts(input: {filterByServiceID: ["%s"], filterByStatus: [StatusUnacknowledged, StatusAcknowledged]}) {nodes {flapping}}}`, h.UUID("sid")))
+		assert.Empty(t, res.Errors, "errors")
+		require.NoError(t, json.Unmarshal(res.Data, &data))
+		return data.Alerts.Nodes
+	}
+
+	d := h.Twilio(t).Device(h.Phone("1"))
+
+	fire(false)
+	d.ExpectSMS("disk space low")
+	fire(true)
+	fire(false)
+	d.ExpectSMS("disk space low")
+
+	// 4th transition within the window, alert should be held open
+	fire(true)
+	fire(false)
+	fire(true)
+
+	h.Trigger()
+	open := openAlerts()
+	require.Len(t, open, 1, "open alerts")
+	assert.True(t, open[0].Flapping, "flapping")
+
+	h.FastForward(61 * time.Minute)
+	h.Trigger()
+
+	// stable, and the last transition was a close
+	assert.Empty(t, openAlerts(), "open alerts")
+}
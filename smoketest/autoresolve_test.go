@@ -0,0 +1,61 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestAutoResolve verifies that alerts without activity are closed after the service's
+// AutoResolveMinutes, and that new activity resets the timer.
+func TestAutoResolve(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into services (id, escalation_policy_id, name, auto_resolve_minutes)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service', 60);
+
+	insert into alerts (id, service_id, summary, dedup_key)
+	values
+		(1, {{uuid "sid"}}, 'stale', 'user:1:stale'),
+		(2, {{uuid "sid"}}, 'active', 'user:1:active');
+`
+	h := harness.NewHarness(t, sql, "service-auto-resolve")
+	defer h.Close()
+
+	status := func() (string, string) {
+		t.Helper()
+		var data struct {
+			A, B struct{ Status string }
+		}
+		res := h.GraphQLQuery2("{a:alert(id: 1){status} b:alert(id: 2){status}}")
+		assert.Empty(t, res.Errors, "errors")
+		require.NoError(t, json.Unmarshal(res.Data, &data))
+		return data.A.Status, data.B.Status
+	}
+
+	h.FastForward(45 * time.Minute)
+	h.GraphQLQuery2(`mutation{updateAlerts(input: {alertIDs: [2], newStatus: StatusAcknowledged}){id}}`)
+
+	h.FastForward(30 * time.Minute)
+	h.Trigger()
+
+	a, b := status()
+	assert.Equal(t, "StatusClosed", a, "stale alert")
+	assert.Equal(t, "StatusAcknowledged", b, "recently acknowledged alert")
+
+	h.FastForward(45 * time.Minute)
+	h.Trigger()
+
+	_, b = status()
+	assert.Equal(t, "StatusClosed", b, "recently acknowledged alert")
+}
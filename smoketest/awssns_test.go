@@ -0,0 +1,101 @@
+package smoketest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/target/goalert/awssns"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+func TestAWSSNS(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0),
+		({{uuid "user"}}, {{uuid "cm1"}}, 30);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'awsSNS', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "datadog-newrelic-sns-integrations")
+	defer h.Close()
+
+	url := h.URL() + "/api/v2/sns/incoming?token=" + h.UUID("int_key")
+	post := func(msg awssns.Message) {
+		t.Helper()
+		if code := h.PostSNS(url, msg); code/100 != 2 {
+			t.Errorf("non-2xx response: %d", code)
+		}
+	}
+
+	post(awssns.Message{Type: awssns.TypeSubscriptionConfirmation, Token: "tok", TopicArn: "arn:topic"})
+	if !h.SNSConfirmed("tok") {
+		t.Error("subscription was not confirmed")
+	}
+
+	post(awssns.Message{
+		Type:     awssns.TypeNotification,
+		TopicArn: "arn:topic",
+		Subject:  "ALARM: cpu-high",
+		Message:  `{"AlarmName": "cpu-high", "AlarmArn": "arn:alarm:cpu", "NewStateValue": "ALARM", "NewStateReason": "Threshold crossed"}`,
+	})
+	post(awssns.Message{
+		Type:     awssns.TypeNotification,
+		TopicArn: "arn:topic",
+		Subject:  "ALARM: disk-full",
+		Message:  `{"AlarmName": "disk-full", "AlarmArn": "arn:alarm:disk", "NewStateValue": "ALARM", "NewStateReason": "Threshold crossed"}`,
+	})
+
+	d := h.Twilio(t).Device(h.Phone("1"))
+	d.ExpectSMS("cpu-high")
+	d.ExpectSMS("disk-full")
+
+	post(awssns.Message{
+		Type:     awssns.TypeNotification,
+		TopicArn: "arn:topic",
+		Message:  `{"AlarmName": "disk-full", "AlarmArn": "arn:alarm:disk", "NewStateValue": "OK"}`,
+	})
+
+	// unsigned messages must be rejected
+	resp, err := http.Post(url, "text/plain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		t.Errorf("got %s for invalid message; want error", resp.Status)
+	}
+
+	h.FastForward(30 * time.Minute)
+
+	d.ExpectSMS("cpu-high")
+}
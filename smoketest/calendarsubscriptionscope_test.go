@@ -0,0 +1,145 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestCalendarSubscriptionScope checks that a schedule-wide subscription includes shifts
+// for every user on the schedule, along with attendee details.
+func TestCalendarSubscriptionScope(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+		insert into users (id, name, email)
+		values
+			({{uuid "bob"}}, 'bob', 'bob@example.com'),
+			({{uuid "joe"}}, 'joe', 'joe@example.com');
+		insert into schedules (id, name, time_zone)
+		values
+			({{uuid "sched"}}, 'sched', 'UTC');
+		insert into schedule_rules (schedule_id, tgt_user_id)
+		values
+			({{uuid "sched"}}, {{uuid "bob"}}),
+			({{uuid "sched"}}, {{uuid "joe"}});
+	`
+	h := harness.NewHarness(t, sql, "calendar-subscription-scope")
+	defer h.Close()
+
+	g := h.GraphQLQuery2(fmt.Sprintf(`
+		mutation {
+			createUserCalendarSubscription(input: {
+				name: "team",
+				scope: schedule,
+				scheduleID: "%s",
+				lookaheadDays: 7,
+				includeDetails: true,
+			}) {
+				url
+			}
+		}
+	`, h.UUID("sched")))
+	for _, err := range g.Errors {
+		t.Error("GraphQL Error:", err.Message)
+	}
+	require.Empty(t, g.Errors)
+
+	var cs struct{ CreateUserCalendarSubscription struct{ URL string } }
+	require.NoError(t, json.Unmarshal(g.Data, &cs))
+
+	resp, err := http.Get(cs.CreateUserCalendarSubscription.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode, "serve iCalendar")
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "mailto:bob@example.com")
+	assert.Contains(t, string(data), "mailto:joe@example.com")
+	assert.Contains(t, string(data), "Schedule: sched")
+}
+
+// TestCalendarSubscriptionScope_Service checks that a service subscription includes shifts
+// from rotations and users the escalation policy targets directly, not just schedules.
+func TestCalendarSubscriptionScope_Service(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+		insert into users (id, name, email)
+		values
+			({{uuid "bob"}}, 'bob', 'bob@example.com'),
+			({{uuid "joe"}}, 'joe', 'joe@example.com'),
+			({{uuid "ann"}}, 'ann', 'ann@example.com');
+		insert into schedules (id, name, time_zone)
+		values
+			({{uuid "sched"}}, 'sched', 'UTC');
+		insert into schedule_rules (schedule_id, tgt_user_id)
+		values
+			({{uuid "sched"}}, {{uuid "bob"}});
+		insert into rotations (id, name, type, start_time, shift_length, time_zone)
+		values
+			({{uuid "rot"}}, 'rot', 'daily', now(), 1, 'UTC');
+		insert into rotation_participants (id, rotation_id, user_id, position)
+		values
+			({{uuid ""}}, {{uuid "rot"}}, {{uuid "joe"}}, 0);
+
+		insert into escalation_policies (id, name)
+		values
+			({{uuid "eid"}}, 'esc policy');
+		insert into escalation_policy_steps (id, escalation_policy_id)
+		values
+			({{uuid "esid"}}, {{uuid "eid"}});
+		insert into escalation_policy_actions (escalation_policy_step_id, schedule_id, rotation_id, user_id)
+		values
+			({{uuid "esid"}}, {{uuid "sched"}}, null, null),
+			({{uuid "esid"}}, null, {{uuid "rot"}}, null),
+			({{uuid "esid"}}, null, null, {{uuid "ann"}});
+		insert into services (id, escalation_policy_id, name)
+		values
+			({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+	`
+	h := harness.NewHarness(t, sql, "calendar-subscription-scope")
+	defer h.Close()
+
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("bob"), h.UUID("joe"), h.UUID("ann"))
+
+	g := h.GraphQLQuery2(fmt.Sprintf(`
+		mutation {
+			createUserCalendarSubscription(input: {
+				name: "service",
+				scope: service,
+				serviceID: "%s",
+				lookaheadDays: 7,
+				includeDetails: true,
+			}) {
+				url
+			}
+		}
+	`, h.UUID("sid")))
+	for _, err := range g.Errors {
+		t.Error("GraphQL Error:", err.Message)
+	}
+	require.Empty(t, g.Errors)
+
+	var cs struct{ CreateUserCalendarSubscription struct{ URL string } }
+	require.NoError(t, json.Unmarshal(g.Data, &cs))
+
+	resp, err := http.Get(cs.CreateUserCalendarSubscription.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode, "serve iCalendar")
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Schedule: sched")
+	assert.Contains(t, string(data), "Rotation: rot")
+	assert.Contains(t, string(data), "Escalation Policy: esc policy")
+	assert.Contains(t, string(data), "mailto:ann@example.com")
+}
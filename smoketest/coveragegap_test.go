@@ -0,0 +1,67 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestCoverageGap checks that an upcoming gap in schedule coverage is reported
+// by the API, and that users who favorited the schedule are notified once.
+func TestCoverageGap(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "joe"}}, 'joe', 'joe@example.com', 'user');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "bob"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "joe"}}, 'personal', 'SMS', {{phone "2"}});
+
+	insert into schedules (id, name, time_zone, gap_notify_days)
+	values
+		({{uuid "sched"}}, 'primary', 'UTC', 2);
+
+	insert into user_favorites (user_id, tgt_schedule_id)
+	values
+		({{uuid "bob"}}, {{uuid "sched"}});
+
+	insert into user_overrides (id, tgt_schedule_id, add_user_id, start_time, end_time)
+	values
+		({{uuid "o1"}}, {{uuid "sched"}}, {{uuid "joe"}}, now() - '1 hour'::interval, now() + '1 day'::interval);
+`
+	h := harness.NewHarness(t, sql, "schedule-coverage-gaps")
+	defer h.Close()
+
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("nobody", "on-call", "primary")
+
+	start := time.Now().UTC().Truncate(time.Minute)
+	g := h.GraphQLQueryUserT(t, h.UUID("bob"), fmt.Sprintf(`
+		query {
+			schedule(id: "%s") {
+				coverageGaps(start: "%s", end: "%s") { start, end }
+			}
+		}
+	`, h.UUID("sched"), start.Format(time.RFC3339), start.Add(48*time.Hour).Format(time.RFC3339)))
+	for _, err := range g.Errors {
+		t.Error("GraphQL Error:", err.Message)
+	}
+	require.Empty(t, g.Errors)
+
+	var resp struct {
+		Schedule struct {
+			CoverageGaps []struct{ Start, End string }
+		}
+	}
+	require.NoError(t, json.Unmarshal(g.Data, &resp))
+	require.Len(t, resp.Schedule.CoverageGaps, 1)
+	assert.Equal(t, start.Add(48*time.Hour).Format(time.RFC3339), resp.Schedule.CoverageGaps[0].End)
+}
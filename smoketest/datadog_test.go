@@ -0,0 +1,76 @@
+package smoketest
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/target/goalert/smoketest/harness"
+)
+
+func TestDatadog(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0),
+		({{uuid "user"}}, {{uuid "cm1"}}, 30);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'datadog', 'my key', {{uuid "sid"}});
+`
+	h := harness.NewHarness(t, sql, "datadog-newrelic-sns-integrations")
+	defer h.Close()
+
+	url := h.URL() + "/api/v2/datadog/incoming?token=" + h.UUID("int_key")
+	fire := func(body string) {
+		resp, err := http.Post(url, "application/json", bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatal("post to datadog endpoint failed:", err)
+		} else if resp.StatusCode/100 != 2 {
+			t.Error("non-2xx response:", resp.Status)
+		}
+		resp.Body.Close()
+	}
+
+	fire(`{"id": "1", "title": "[Triggered on {host:a}] CPU high", "body": "cpu > 90", "transition": "Triggered", "alert_id": "100", "aggreg_key": "agg-a"}`)
+	fire(`{"id": "2", "title": "[Triggered on {host:b}] CPU high", "body": "cpu > 90", "transition": "Triggered", "alert_id": "100", "aggreg_key": "agg-b"}`)
+
+	d := h.Twilio(t).Device(h.Phone("1"))
+	d.ExpectSMS("CPU high")
+	d.ExpectSMS("CPU high")
+
+	fire(`{"id": "3", "title": "[Recovered on {host:b}] CPU high", "transition": "Recovered", "alert_id": "100", "aggreg_key": "agg-b"}`)
+
+	h.FastForward(30 * time.Minute)
+
+	// only the alert for host a should still be open
+	d.ExpectSMS("CPU high")
+}
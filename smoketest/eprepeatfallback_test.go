@@ -0,0 +1,67 @@
+package smoketest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestEPRepeatFallback checks that an escalation policy waits for the repeat delay before
+// repeating, and notifies the fallback target once all repeats are exhausted.
+func TestEPRepeatFallback(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "user"}}, 'bob', 'joe', 'user'),
+		({{uuid "manager"}}, 'alice', 'alice', 'user');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "manager"}}, 'personal', 'SMS', {{phone "2"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0),
+		({{uuid "manager"}}, {{uuid "cm2"}}, 0);
+
+	insert into escalation_policies (id, name, repeat, repeat_delay_minutes, fallback_user_id)
+	values
+		({{uuid "eid"}}, 'esc policy', 1, 20, {{uuid "manager"}});
+	insert into escalation_policy_steps (id, escalation_policy_id, delay)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}}, 10);
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (service_id, summary, dedup_key)
+	values
+		({{uuid "sid"}}, 'testing', 'user:1:testing');
+`
+	h := harness.NewHarness(t, sql, "ep-repeat-delay-fallback")
+	defer h.Close()
+
+	tw := h.Twilio(t)
+	d1 := tw.Device(h.Phone("1"))
+	d2 := tw.Device(h.Phone("2"))
+
+	d1.ExpectSMS("testing")
+
+	// step delay has passed, but not the repeat delay
+	h.FastForward(15 * time.Minute)
+	h.Trigger()
+
+	h.FastForward(20 * time.Minute)
+	d1.ExpectSMS("testing")
+
+	// all repeats exhausted
+	h.FastForward(10 * time.Minute)
+	d2.ExpectSMS("testing")
+}
@@ -0,0 +1,76 @@
+package smoketest
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/target/goalert/smoketest/harness"
+)
+
+func TestGenericJSON(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0),
+		({{uuid "user"}}, {{uuid "cm1"}}, 30);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id, json_mapping)
+	values
+		({{uuid "int_key"}}, 'genericJSON', 'my key', {{uuid "sid"}},
+			'{"summary": "alert.title", "details": "alert.body", "dedup": "alert.id", "action": "alert.state"}');
+`
+	h := harness.NewHarness(t, sql, "generic-json-integration")
+	defer h.Close()
+
+	fire := func(body string) {
+		u := h.URL() + "/api/v2/generic/json?token=" + h.UUID("int_key")
+		resp, err := http.Post(u, "application/json", bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatal("post to generic json endpoint failed:", err)
+		} else if resp.StatusCode/100 != 2 {
+			t.Error("non-2xx response:", resp.Status)
+		}
+		resp.Body.Close()
+	}
+
+	fire(`{"alert": {"id": "a1", "title": "first", "body": "details", "state": "firing"}}`)
+	fire(`{"alert": {"id": "a2", "title": "second", "state": "firing"}}`)
+
+	d := h.Twilio(t).Device(h.Phone("1"))
+	d.ExpectSMS("first")
+	d.ExpectSMS("second")
+
+	fire(`{"alert": {"id": "a2", "state": "resolved"}}`)
+
+	h.FastForward(30 * time.Minute)
+
+	d.ExpectSMS("first")
+}
@@ -0,0 +1,77 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestGraphQLSubscription checks that alert status subscriptions receive an event for
+// a new alert and for each status change.
+func TestGraphQLSubscription(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "user"}}, 'bob', 'joe', 'user');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+	h := harness.NewHarness(t, sql, "graphql-subscription-notify")
+	defer h.Close()
+
+	events := h.GraphQLSubscribeUserT(t, h.UUID("user"), fmt.Sprintf(`
+		subscription {
+			alertStatusChanged(serviceIDs: ["%s"]) { id status }
+		}
+	`, h.UUID("sid")))
+
+	type alertEvent struct {
+		AlertStatusChanged struct {
+			ID     int
+			Status string
+		}
+	}
+	expectEvent := func(status string) int {
+		t.Helper()
+		select {
+		case data, ok := <-events:
+			require.True(t, ok, "subscription closed")
+			var ev alertEvent
+			require.NoError(t, json.Unmarshal(data, &ev))
+			assert.Equal(t, status, ev.AlertStatusChanged.Status)
+			return ev.AlertStatusChanged.ID
+		case <-time.After(15 * time.Second):
+			t.Fatal("timeout waiting for subscription event")
+		}
+		return 0
+	}
+
+	doQL := func(query string) {
+		t.Helper()
+		g := h.GraphQLQueryUserT(t, h.UUID("user"), query)
+		for _, err := range g.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		if len(g.Errors) > 0 {
+			t.Fatal("errors returned from GraphQL")
+		}
+	}
+
+	doQL(fmt.Sprintf(`mutation { createAlert(input: {serviceID: "%s", summary: "test"}) { id } }`, h.UUID("sid")))
+	id := expectEvent("StatusUnacknowledged")
+
+	doQL(fmt.Sprintf(`mutation { updateAlerts(input: {alertIDs: [%d], newStatus: StatusAcknowledged}) { id } }`, id))
+	assert.Equal(t, id, expectEvent("StatusAcknowledged"))
+}
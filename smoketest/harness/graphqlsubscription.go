@@ -0,0 +1,103 @@
+package harness
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/target/goalert/auth"
+)
+
+type qlWSMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// GraphQLSubscribeUserT will start a GraphQL subscription (graphql-ws protocol) with the provided UserID.
+// The data of each event is sent on the returned channel, which is closed once the connection ends.
+// The connection is closed when the test completes.
+func (h *Harness) GraphQLSubscribeUserT(t *testing.T, userID, query string) <-chan json.RawMessage {
+	t.Helper()
+
+	h.mx.Lock()
+	tok := h.gqlSessions[userID]
+	if tok == "" {
+		tok = h.insertGraphQLUser(userID)
+	}
+	h.mx.Unlock()
+
+	query = strings.Replace(query, "\t", "", -1)
+	t.Log("Subscription:", query)
+
+	hdr := make(http.Header)
+	hdr.Set("Cookie", (&http.Cookie{Name: auth.CookieName, Value: tok}).String())
+	dialer := websocket.Dialer{Subprotocols: []string{"graphql-ws"}}
+	url := "ws" + strings.TrimPrefix(h.URL(), "http") + "/api/graphql"
+	conn, _, err := dialer.Dial(url, hdr)
+	if err != nil {
+		t.Fatal("failed to connect:", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	send := func(msg qlWSMessage) {
+		t.Helper()
+		err := conn.WriteJSON(msg)
+		if err != nil {
+			t.Fatal("failed to send message:", err)
+		}
+	}
+
+	send(qlWSMessage{Type: "connection_init"})
+	var msg qlWSMessage
+	err = conn.ReadJSON(&msg)
+	if err != nil {
+		t.Fatal("failed to read connection ack:", err)
+	}
+	if msg.Type != "connection_ack" {
+		t.Fatalf("expected connection_ack but got '%s': %s", msg.Type, string(msg.Payload))
+	}
+
+	payload, err := json.Marshal(struct {
+		Query string `json:"query"`
+	}{Query: query})
+	if err != nil {
+		t.Fatal("failed to marshal graphql query")
+	}
+	send(qlWSMessage{ID: "1", Type: "start", Payload: payload})
+
+	ch := make(chan json.RawMessage, 100)
+	go func() {
+		defer close(ch)
+		for {
+			var msg qlWSMessage
+			err := conn.ReadJSON(&msg)
+			if err != nil {
+				return
+			}
+
+			switch msg.Type {
+			case "data":
+				var r QLResponse
+				err = json.Unmarshal(msg.Payload, &r)
+				if err != nil {
+					t.Error("failed to parse subscription event:", err)
+					return
+				}
+				for _, e := range r.Errors {
+					t.Error("GraphQL Error:", e.Message)
+				}
+				ch <- r.Data
+			case "error", "connection_error":
+				t.Error("subscription error:", string(msg.Payload))
+				return
+			case "complete":
+				return
+			}
+		}
+	}()
+
+	return ch
+}
@@ -84,6 +84,8 @@ type Harness struct {
 	slackApp  mockslack.AppInfo
 	slackUser mockslack.UserInfo
 
+	sns *snsServer
+
 	ignoreErrors []string
 
 	backend     *app.App
@@ -209,6 +211,7 @@ func NewStoppedHarness(t *testing.T, initSQL string, sqlData interface{}, migrat
 	}, mocktwilio.NewServer(twCfg), h.phoneCCG.Get("twilio"))
 
 	h.twS = httptest.NewServer(h.tw)
+	h.sns = newSNSServer(t)
 
 	// freeze DB time until backend starts
 	h.execQuery(`
@@ -291,6 +294,7 @@ func (h *Harness) Start() {
 	appCfg.TwilioBaseURL = h.twS.URL
 	appCfg.DBMaxOpen = 5
 	appCfg.SlackBaseURL = h.slackS.URL
+	appCfg.SNSBaseURL = h.sns.URL
 	appCfg.InitialConfig = &h.cfg
 
 	r, w := io.Pipe()
@@ -570,6 +574,7 @@ func (h *Harness) Close() error {
 
 	h.slackS.Close()
 	h.twS.Close()
+	h.sns.Close()
 
 	h.tw.Close()
 	h.dumpDB()
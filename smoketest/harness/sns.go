@@ -0,0 +1,109 @@
+package harness
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/target/goalert/awssns"
+)
+
+// snsServer mocks the AWS SNS endpoints used to verify message signatures and confirm subscriptions.
+type snsServer struct {
+	*httptest.Server
+
+	key     *rsa.PrivateKey
+	certPEM []byte
+
+	mx        sync.Mutex
+	confirmed map[string]bool
+}
+
+func newSNSServer(t *testing.T) *snsServer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal("generate sns key:", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal("create sns certificate:", err)
+	}
+
+	s := &snsServer{
+		key:       key,
+		certPEM:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		confirmed: make(map[string]bool),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cert.pem", func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write(s.certPEM)
+	})
+	mux.HandleFunc("/confirm", func(w http.ResponseWriter, req *http.Request) {
+		s.mx.Lock()
+		s.confirmed[req.FormValue("Token")] = true
+		s.mx.Unlock()
+	})
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// PostSNS will sign and send an AWS SNS message to urlStr, returning the response status code.
+//
+// The signing certificate, message ID, timestamp and (for subscription confirmations) the subscribe
+// URL are set automatically.
+func (h *Harness) PostSNS(urlStr string, msg awssns.Message) int {
+	h.t.Helper()
+
+	msg.MessageID = uuid.NewV4().String()
+	msg.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	msg.SignatureVersion = "1"
+	msg.SigningCertURL = h.sns.URL + "/cert.pem"
+	if msg.Type == awssns.TypeSubscriptionConfirmation {
+		msg.SubscribeURL = h.sns.URL + "/confirm?Token=" + msg.Token
+	}
+	err := msg.Sign(h.sns.key)
+	if err != nil {
+		h.t.Fatal("sign sns message:", err)
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		h.t.Fatal("encode sns message:", err)
+	}
+	resp, err := http.Post(urlStr, "text/plain; charset=UTF-8", bytes.NewReader(data))
+	if err != nil {
+		h.t.Fatal("post sns message:", err)
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+// SNSConfirmed returns true if the subscription with the given token was confirmed.
+func (h *Harness) SNSConfirmed(token string) bool {
+	h.sns.mx.Lock()
+	defer h.sns.mx.Unlock()
+
+	return h.sns.confirmed[token]
+}
@@ -75,3 +75,145 @@ func TestHeartbeat(t *testing.T) {
 	h.FastForward(15 * time.Minute) // next notification rule
 	// no SMS, healthy
 }
+
+func TestHeartbeat_Report(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0),
+		({{uuid "user"}}, {{uuid "cm1"}}, 15);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'generic', 'my key', {{uuid "sid"}});
+
+	insert into heartbeat_monitors (id, name, service_id, heartbeat_interval)
+	values
+		({{uuid "hb_key"}}, 'nightly', {{uuid "sid"}}, '60 minutes');
+`
+	h := harness.NewHarness(t, sql, "heartbeat-last-failure")
+	defer h.Close()
+
+	report := func(status, message string) {
+		v := make(url.Values)
+		v.Set("integrationKey", h.UUID("int_key"))
+		v.Set("status", status)
+		v.Set("message", message)
+		resp, err := http.PostForm(h.URL()+"/api/v2/heartbeat/"+h.UUID("hb_key"), v)
+		if err != nil {
+			t.Fatal("post to heartbeat endpoint failed:", err)
+		} else if resp.StatusCode/100 != 2 {
+			t.Error("non-2xx response:", resp.Status)
+		}
+		resp.Body.Close()
+	}
+
+	report("ok", "")
+	h.Trigger()
+
+	// a failure report alerts without waiting for the interval to expire
+	report("fail", "backup job exited 1")
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("nightly", "reported failure")
+
+	report("ok", "backup job recovered")
+	h.Trigger() // cycle engine (to close/process heartbeat) before fast-forwarding
+
+	h.FastForward(15 * time.Minute) // next notification rule
+	// no SMS, healthy
+}
+
+func TestHeartbeat_Cron(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0),
+		({{uuid "user"}}, {{uuid "cm1"}}, 15);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'generic', 'my key', {{uuid "sid"}});
+
+	insert into heartbeat_monitors (id, name, service_id, heartbeat_interval, cron_schedule, time_zone, grace_period)
+	values
+		({{uuid "hb_key"}}, 'hourly', {{uuid "sid"}}, '60 minutes', '0 * * * *', 'UTC', '5 minutes');
+`
+	h := harness.NewHarness(t, sql, "heartbeat-last-failure")
+	defer h.Close()
+
+	heartbeat := func() {
+		v := make(url.Values)
+		v.Set("integrationKey", h.UUID("int_key"))
+		resp, err := http.PostForm(h.URL()+"/api/v2/heartbeat/"+h.UUID("hb_key"), v)
+		if err != nil {
+			t.Fatal("post to heartbeat endpoint failed:", err)
+		} else if resp.StatusCode/100 != 2 {
+			t.Error("non-2xx response:", resp.Status)
+		}
+		resp.Body.Close()
+	}
+
+	heartbeat()
+	h.Trigger() // calculate the next deadline
+
+	// the next scheduled check-in is at most an hour away; the grace period follows it
+	h.FastForward(66 * time.Minute)
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("hourly")
+
+	heartbeat()
+	h.Trigger() // cycle engine (to close/process heartbeat) before fast-forwarding
+
+	h.FastForward(15 * time.Minute) // next notification rule
+	// no SMS, healthy
+}
@@ -0,0 +1,127 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestHolidayCalendar checks that rules of a schedule are suppressed, or activated, on
+// holidays imported into the schedule's holiday calendar.
+func TestHolidayCalendar(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "joe"}}, 'joe', 'joe@example.com', 'user');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'sched', 'UTC');
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "esid"}}, {{uuid "sched"}});
+
+	insert into schedule_rules (schedule_id, tgt_user_id, holiday_mode)
+	values
+		({{uuid "sched"}}, {{uuid "bob"}}, 'exclude');
+	insert into schedule_rules (schedule_id, tgt_user_id, holiday_mode, sunday, monday, tuesday, wednesday, thursday, friday, saturday)
+	values
+		({{uuid "sched"}}, {{uuid "joe"}}, 'include', false, false, false, false, false, false, false);
+`
+	h := harness.NewHarness(t, sql, "holiday-calendars")
+	defer h.Close()
+
+	doQL := func(query string, res interface{}) {
+		t.Helper()
+		g := h.GraphQLQueryUserT(t, h.UUID("bob"), query)
+		for _, err := range g.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		if len(g.Errors) > 0 {
+			t.Fatal("errors returned from GraphQL")
+		}
+		if res == nil {
+			return
+		}
+		require.NoError(t, json.Unmarshal(g.Data, res))
+	}
+
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("bob"))
+
+	var created struct {
+		CreateHolidayCalendar struct{ ID string }
+	}
+	doQL(`mutation { createHolidayCalendar(input: {name: "Public"}) { id } }`, &created)
+	calID := created.CreateHolidayCalendar.ID
+
+	// cover yesterday through tomorrow, so the test is not affected by crossing midnight
+	day := time.Now().UTC().AddDate(0, 0, -1)
+	ical := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Company Holiday\n" +
+		"DTSTART;VALUE=DATE:" + day.Format("20060102") + "\n" +
+		"DTEND;VALUE=DATE:" + day.AddDate(0, 0, 3).Format("20060102") + "\n" +
+		"END:VEVENT\nEND:VCALENDAR\n"
+
+	var imported struct{ ImportHolidays int }
+	doQL(fmt.Sprintf(`
+		mutation {
+			importHolidays(input: {calendarID: "%s", data: %s})
+		}
+	`, calID, strconv.Quote(ical)), &imported)
+	assert.Equal(t, 3, imported.ImportHolidays)
+
+	doQL(fmt.Sprintf(`
+		mutation {
+			updateSchedule(input: {id: "%s", holidayCalendarID: "%s"})
+		}
+	`, h.UUID("sched"), calID), nil)
+
+	h.Trigger()
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("joe"))
+
+	var resp struct {
+		Schedule struct {
+			HolidayCalendar struct {
+				Holidays []struct{ Date, Name string }
+			}
+			Shifts []struct{ UserID string }
+		}
+	}
+	now := time.Now().UTC()
+	doQL(fmt.Sprintf(`
+		query {
+			schedule(id: "%s") {
+				holidayCalendar { holidays { date name } }
+				shifts(start: "%s", end: "%s") { userID }
+			}
+		}
+	`, h.UUID("sched"), now.Format(time.RFC3339), now.Add(time.Hour).Format(time.RFC3339)), &resp)
+	require.Len(t, resp.Schedule.HolidayCalendar.Holidays, 3)
+	assert.Equal(t, "Company Holiday", resp.Schedule.HolidayCalendar.Holidays[0].Name)
+	require.Len(t, resp.Schedule.Shifts, 1)
+	assert.Equal(t, h.UUID("joe"), resp.Schedule.Shifts[0].UserID)
+
+	// removing the calendar restores the normal rules
+	doQL(fmt.Sprintf(`mutation { deleteHolidayCalendar(id: "%s") }`, calID), nil)
+
+	h.Trigger()
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("bob"))
+}
@@ -0,0 +1,102 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestOnCallHoursReport checks that on-call hours and pages are totaled per user.
+func TestOnCallHoursReport(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', 'user');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'default', 'UTC');
+	insert into schedule_rules (schedule_id, tgt_user_id)
+	values
+		({{uuid "sched"}}, {{uuid "bob"}});
+
+	insert into alerts (id, service_id, summary, dedup_key)
+	values
+		(1, {{uuid "sid"}}, 'testing', 'user:1:testing');
+	insert into alert_logs (alert_id, event, sub_type, sub_user_id, message, timestamp)
+	values
+		(1, 'notification_sent', 'user', {{uuid "bob"}}, 'SMS', now() + '2 hours'::interval),
+		(1, 'notification_sent', 'user', {{uuid "bob"}}, 'Voice', now() + '3 hours'::interval);
+`
+	h := harness.NewHarness(t, sql, "calendar-subscription-scope")
+	defer h.Close()
+
+	start := time.Now().Add(time.Hour).UTC()
+	end := start.Add(24 * time.Hour)
+	g := h.GraphQLQueryUserT(t, h.UUID("bob"), fmt.Sprintf(`
+		query {
+			onCallHoursReport(input: {scheduleIDs: ["%s"], start: "%s", end: "%s", holidays: ["%s"], includePages: true}) {
+				users {
+					userID
+					weekdayHours
+					weekendHours
+					holidayHours
+					totalHours
+					schedules { scheduleID totalHours }
+					pages
+				}
+			}
+		}
+	`, h.UUID("sched"), start.Format(time.RFC3339), end.Format(time.RFC3339), start.Add(12*time.Hour).Format("2006-01-02")))
+	for _, err := range g.Errors {
+		t.Error("GraphQL Error:", err.Message)
+	}
+	if len(g.Errors) > 0 {
+		t.Fatal("errors returned from GraphQL")
+	}
+
+	var res struct {
+		OnCallHoursReport struct {
+			Users []struct {
+				UserID       string
+				WeekdayHours float64
+				WeekendHours float64
+				HolidayHours float64
+				TotalHours   float64
+				Schedules    []struct {
+					ScheduleID string
+					TotalHours float64
+				}
+				Pages int
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(g.Data, &res))
+
+	require.Len(t, res.OnCallHoursReport.Users, 1)
+	u := res.OnCallHoursReport.Users[0]
+	assert.Equal(t, h.UUID("bob"), u.UserID)
+	assert.InDelta(t, 24, u.TotalHours, 0.02)
+	assert.InDelta(t, u.TotalHours, u.WeekdayHours+u.WeekendHours+u.HolidayHours, 0.001)
+	assert.Greater(t, u.HolidayHours, float64(0))
+	require.Len(t, u.Schedules, 1)
+	assert.Equal(t, h.UUID("sched"), u.Schedules[0].ScheduleID)
+	assert.InDelta(t, u.TotalHours, u.Schedules[0].TotalHours, 0.001)
+
+	// multiple notifications for the same alert are a single page
+	assert.Equal(t, 1, u.Pages)
+}
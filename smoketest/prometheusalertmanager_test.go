@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/target/goalert/smoketest/harness"
@@ -71,3 +72,105 @@ func TestPrometheusAlertManager(t *testing.T) {
 
 	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("InstanceDown")
 }
+
+func TestPrometheusAlertManagerPerAlert(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "user"}}, 'bob', 'joe');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'SMS', {{phone "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0),
+		({{uuid "user"}}, {{uuid "cm1"}}, 30);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into integration_keys (id, type, name, service_id)
+	values
+		({{uuid "int_key"}}, 'prometheusAlertmanager', 'my key', {{uuid "sid"}});
+`
+
+	h := harness.NewHarness(t, sql, "prometheus-alertmanager-integration")
+	defer h.Close()
+
+	url := h.URL() + "/api/v2/prometheusalertmanager/incoming?mode=alert&token=" + h.UUID("int_key")
+	fire := func(body string) {
+		t.Helper()
+		resp, err := http.Post(url, "application/json", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode, "HTTP response code")
+		resp.Body.Close()
+	}
+
+	fire(`
+		{
+			"status": "firing",
+			"alerts": [
+				{
+					"status": "firing",
+					"labels": {"alertname": "NodeDown", "cluster": "c1", "instance": "node-a"},
+					"annotations": {"summary": "node-a down", "runbook_url": "http://runbook.example.com"},
+					"fingerprint": "aaaa"
+				},
+				{
+					"status": "firing",
+					"labels": {"alertname": "NodeDown", "cluster": "c1", "instance": "node-b"},
+					"annotations": {"summary": "node-b down"},
+					"fingerprint": "bbbb"
+				}
+			],
+			"commonLabels": {"alertname": "NodeDown", "cluster": "c1"}
+		}
+	`)
+
+	d := h.Twilio(t).Device(h.Phone("1"))
+	d.ExpectSMS("node-a down")
+	d.ExpectSMS("node-b down")
+
+	fire(`
+		{
+			"status": "firing",
+			"alerts": [
+				{
+					"status": "firing",
+					"labels": {"alertname": "NodeDown", "cluster": "c1", "instance": "node-a"},
+					"annotations": {"summary": "node-a down"},
+					"fingerprint": "aaaa"
+				},
+				{
+					"status": "resolved",
+					"labels": {"alertname": "NodeDown", "cluster": "c1", "instance": "node-b"},
+					"annotations": {"summary": "node-b down"},
+					"fingerprint": "bbbb"
+				}
+			],
+			"commonLabels": {"alertname": "NodeDown", "cluster": "c1"}
+		}
+	`)
+
+	h.FastForward(30 * time.Minute)
+
+	// only node-a should still be open
+	d.ExpectSMS("node-a down")
+}
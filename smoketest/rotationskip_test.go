@@ -0,0 +1,173 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestRotation_Skip checks that an unavailable participant is skipped when their turn
+// comes, and that the skipped turn is credited back to them.
+func TestRotation_Skip(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', 'admin'),
+		({{uuid "joe"}}, 'joe', 'joe@example.com', 'user'),
+		({{uuid "ann"}}, 'ann', 'ann@example.com', 'user');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'default', 'UTC');
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "esid"}}, {{uuid "sched"}});
+
+	insert into rotations (id, name, type, start_time, shift_length, time_zone)
+	values
+		({{uuid "rot"}}, 'rot', 'hourly', now(), 1, 'UTC');
+	insert into rotation_participants (id, rotation_id, user_id, position)
+	values
+		({{uuid ""}}, {{uuid "rot"}}, {{uuid "bob"}}, 0),
+		({{uuid ""}}, {{uuid "rot"}}, {{uuid "joe"}}, 1),
+		({{uuid ""}}, {{uuid "rot"}}, {{uuid "ann"}}, 2);
+
+	insert into schedule_rules (schedule_id, tgt_rotation_id)
+	values
+		({{uuid "sched"}}, {{uuid "rot"}});
+`
+	h := harness.NewHarness(t, sql, "rotation-participant-skip")
+	defer h.Close()
+
+	doQL := func(query string, res interface{}) {
+		t.Helper()
+		g := h.GraphQLQueryUserT(t, h.UUID("bob"), query)
+		for _, err := range g.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		if len(g.Errors) > 0 {
+			t.Fatal("errors returned from GraphQL")
+		}
+		if res == nil {
+			return
+		}
+		require.NoError(t, json.Unmarshal(g.Data, res))
+	}
+
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("bob"))
+
+	doQL(fmt.Sprintf(`
+		mutation {
+			setRotationParticipantUnavailable(input: {rotationID: "%s", userIndex: 1, until: "%s"})
+		}
+	`, h.UUID("rot"), time.Now().Add(90*time.Minute).UTC().Format(time.RFC3339)), nil)
+
+	h.FastForward(time.Hour)
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("ann"))
+
+	var resp struct {
+		Rotation struct {
+			Participants []struct{ SkipCredit int }
+		}
+	}
+	doQL(fmt.Sprintf(`
+		query {
+			rotation(id: "%s") { participants { skipCredit } }
+		}
+	`, h.UUID("rot")), &resp)
+	require.Len(t, resp.Rotation.Participants, 3)
+	assert.Equal(t, 1, resp.Rotation.Participants[1].SkipCredit, "joe should be owed a turn")
+
+	// joe is available again and takes the owed turn before rotation resumes
+	h.FastForward(time.Hour)
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("joe"))
+}
+
+// TestRotation_SkipResumeReset checks that manually setting the active participant
+// discards where the rotation would have resumed after a credited turn.
+func TestRotation_SkipResumeReset(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', 'admin'),
+		({{uuid "joe"}}, 'joe', 'joe@example.com', 'user'),
+		({{uuid "ann"}}, 'ann', 'ann@example.com', 'user'),
+		({{uuid "cat"}}, 'cat', 'cat@example.com', 'user');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'default', 'UTC');
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "esid"}}, {{uuid "sched"}});
+
+	insert into rotations (id, name, type, start_time, shift_length, time_zone)
+	values
+		({{uuid "rot"}}, 'rot', 'hourly', now(), 1, 'UTC');
+	insert into rotation_participants (id, rotation_id, user_id, position)
+	values
+		({{uuid "p0"}}, {{uuid "rot"}}, {{uuid "bob"}}, 0),
+		({{uuid "p1"}}, {{uuid "rot"}}, {{uuid "joe"}}, 1),
+		({{uuid "p2"}}, {{uuid "rot"}}, {{uuid "ann"}}, 2),
+		({{uuid "p3"}}, {{uuid "rot"}}, {{uuid "cat"}}, 3);
+
+	-- joe is taking a credited turn, after which the rotation would resume with cat
+	update rotation_state
+	set rotation_participant_id = {{uuid "p1"}}, resume_position = 3, version = 2
+	where rotation_id = {{uuid "rot"}};
+
+	insert into schedule_rules (schedule_id, tgt_rotation_id)
+	values
+		({{uuid "sched"}}, {{uuid "rot"}});
+`
+	h := harness.NewHarness(t, sql, "rotation-participant-skip")
+	defer h.Close()
+
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("joe"))
+
+	g := h.GraphQLQueryUserT(t, h.UUID("bob"), fmt.Sprintf(`
+		mutation {
+			updateRotation(input: {id: "%s", activeUserIndex: 0})
+		}
+	`, h.UUID("rot")))
+	for _, err := range g.Errors {
+		t.Error("GraphQL Error:", err.Message)
+	}
+	if len(g.Errors) > 0 {
+		t.Fatal("errors returned from GraphQL")
+	}
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("bob"))
+
+	// rotation continues from bob rather than jumping to cat
+	h.FastForward(time.Hour)
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("joe"))
+}
@@ -0,0 +1,105 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestScheduleExport checks that exported shifts include their rotation and override sources.
+func TestScheduleExport(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "joe"}}, 'joe', 'joe@example.com', 'user');
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'default', 'UTC');
+
+	insert into rotations (id, name, type, start_time, shift_length, time_zone)
+	values
+		({{uuid "rot"}}, 'primary rot', 'weekly', now(), 1, 'UTC');
+	insert into rotation_participants (id, rotation_id, user_id, position)
+	values
+		({{uuid ""}}, {{uuid "rot"}}, {{uuid "bob"}}, 0);
+
+	insert into schedule_rules (schedule_id, tgt_rotation_id)
+	values
+		({{uuid "sched"}}, {{uuid "rot"}});
+
+	insert into user_overrides (id, tgt_schedule_id, add_user_id, remove_user_id, start_time, end_time)
+	values
+		({{uuid "ovr"}}, {{uuid "sched"}}, {{uuid "joe"}}, {{uuid "bob"}}, now() + '1 hour'::interval, now() + '2 hours'::interval);
+`
+	h := harness.NewHarness(t, sql, "calendar-subscription-scope")
+	defer h.Close()
+
+	start := time.Now().Add(30 * time.Minute).UTC().Format(time.RFC3339)
+	end := time.Now().Add(3 * time.Hour).UTC().Format(time.RFC3339)
+	g := h.GraphQLQueryUserT(t, h.UUID("bob"), fmt.Sprintf(`
+		query {
+			exportScheduleShifts(input: {scheduleIDs: ["%s"], start: "%s", end: "%s", format: csv}) {
+				data
+				shifts { userID userName source overrideID rotationID rotationName truncated }
+			}
+		}
+	`, h.UUID("sched"), start, end))
+	for _, err := range g.Errors {
+		t.Error("GraphQL Error:", err.Message)
+	}
+	if len(g.Errors) > 0 {
+		t.Fatal("errors returned from GraphQL")
+	}
+
+	type shift struct {
+		UserID       string
+		UserName     string
+		Source       string
+		OverrideID   *string
+		RotationID   *string
+		RotationName *string
+		Truncated    bool
+	}
+	var res struct {
+		ExportScheduleShifts struct {
+			Data   string
+			Shifts []shift
+		}
+	}
+	require.NoError(t, json.Unmarshal(g.Data, &res))
+
+	shifts := res.ExportScheduleShifts.Shifts
+	require.Len(t, shifts, 3)
+
+	for _, i := range []int{0, 2} {
+		assert.Equal(t, h.UUID("bob"), shifts[i].UserID)
+		assert.Equal(t, "rotation", shifts[i].Source)
+		require.NotNil(t, shifts[i].RotationID)
+		assert.Equal(t, h.UUID("rot"), *shifts[i].RotationID)
+		require.NotNil(t, shifts[i].RotationName)
+		assert.Equal(t, "primary rot", *shifts[i].RotationName)
+	}
+	assert.True(t, shifts[2].Truncated)
+
+	assert.Equal(t, h.UUID("joe"), shifts[1].UserID)
+	assert.Equal(t, "joe", shifts[1].UserName)
+	assert.Equal(t, "override", shifts[1].Source)
+	require.NotNil(t, shifts[1].OverrideID)
+	assert.Equal(t, h.UUID("ovr"), *shifts[1].OverrideID)
+	assert.Nil(t, shifts[1].RotationID)
+
+	lines := strings.Split(strings.TrimSpace(res.ExportScheduleShifts.Data), "\n")
+	require.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "schedule_id,schedule_name,user_id"))
+	assert.Contains(t, lines[2], ",override,")
+}
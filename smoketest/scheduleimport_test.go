@@ -0,0 +1,99 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestScheduleImport checks that a CSV import is previewed with conflicts, and that the
+// imported shifts take effect once applied.
+func TestScheduleImport(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', 'user');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'default', 'UTC');
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "esid"}}, {{uuid "sched"}});
+`
+	h := harness.NewHarness(t, sql, "rotation-participant-skip")
+	defer h.Close()
+
+	type result struct {
+		ImportSchedule struct {
+			Shifts []struct {
+				Line   int
+				UserID string
+			}
+			Conflicts []struct {
+				Line    int
+				Message string
+			}
+			Applied bool
+		}
+	}
+	doImport := func(data string, dryRun bool) result {
+		t.Helper()
+		g := h.GraphQLQueryUserT(t, h.UUID("bob"), fmt.Sprintf(`
+			mutation {
+				importSchedule(input: {scheduleID: "%s", format: csv, data: %s, dryRun: %t}) {
+					shifts { line userID }
+					conflicts { line message }
+					applied
+				}
+			}
+		`, h.UUID("sched"), strconv.Quote(data), dryRun))
+		for _, err := range g.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		if len(g.Errors) > 0 {
+			t.Fatal("errors returned from GraphQL")
+		}
+		var res result
+		require.NoError(t, json.Unmarshal(g.Data, &res))
+		return res
+	}
+
+	start := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	end := time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339)
+
+	res := doImport(fmt.Sprintf("email,start,end\nbob@example.com,%s,%s\nnobody@example.com,%s,%s\n", start, end, start, end), false)
+	assert.False(t, res.ImportSchedule.Applied)
+	require.Len(t, res.ImportSchedule.Shifts, 1)
+	assert.Equal(t, h.UUID("bob"), res.ImportSchedule.Shifts[0].UserID)
+	require.Len(t, res.ImportSchedule.Conflicts, 1)
+	assert.Equal(t, 3, res.ImportSchedule.Conflicts[0].Line)
+
+	res = doImport(fmt.Sprintf("bob@example.com,%s,%s\n", start, end), true)
+	assert.False(t, res.ImportSchedule.Applied)
+	assert.Empty(t, res.ImportSchedule.Conflicts)
+
+	res = doImport(fmt.Sprintf("bob@example.com,%s,%s\n", start, end), false)
+	assert.True(t, res.ImportSchedule.Applied)
+
+	h.Trigger()
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("bob"))
+}
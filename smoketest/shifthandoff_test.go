@@ -0,0 +1,73 @@
+package smoketest
+
+import (
+	"testing"
+
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestShiftHandoff checks that users with handoff notifications enabled are notified
+// before an override-driven shift starts, and with an alert summary when a shift ends. The
+// summary includes alerts the user was notified about, even if not through the schedule.
+func TestShiftHandoff(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, handoff_notify, handoff_notify_minutes)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', true, 60),
+		({{uuid "joe"}}, 'joe', 'joe@example.com', true, 60);
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "bob"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "joe"}}, 'personal', 'SMS', {{phone "2"}});
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'primary', 'UTC');
+
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "esid"}}, {{uuid "sched"}});
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into schedule_on_call_users (schedule_id, user_id, start_time, end_time)
+	values
+		({{uuid "sched"}}, {{uuid "bob"}}, now() - '1 day'::interval, now() - '5 minutes'::interval);
+	insert into alerts (id, service_id, summary, status, created_at)
+	values
+		(1, {{uuid "sid"}}, 'during shift', 'closed', now() - '1 hour'::interval);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid2"}}, 'direct policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid2"}}, {{uuid "eid2"}}, 'direct service');
+	insert into alerts (id, service_id, summary, status, dedup_key, created_at)
+	values
+		(2, {{uuid "sid2"}}, 'paged directly', 'active', 'user:1:paged', now() - '2 days'::interval);
+	insert into alert_logs (alert_id, event, sub_type, sub_user_id, message, timestamp)
+	values
+		(2, 'notification_sent', 'user', {{uuid "bob"}}, 'SMS', now() - '2 hours'::interval);
+
+	insert into user_overrides (id, tgt_schedule_id, add_user_id, start_time, end_time)
+	values
+		({{uuid "o1"}}, {{uuid "sched"}}, {{uuid "joe"}}, now() + '30 minutes'::interval, now() + '2 hours'::interval);
+`
+	h := harness.NewHarness(t, sql, "shift-handoff-notices")
+	defer h.Close()
+
+	d := h.Twilio(t)
+	d.Device(h.Phone("1")).ExpectSMS("primary", "ended", "2 alerts", "1 still open", "#1, #2")
+	d.Device(h.Phone("2")).ExpectSMS("primary", "starts")
+}
@@ -0,0 +1,106 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestShiftRequest checks that a cover request notifies the counterpart, and that
+// accepting it notifies the requester and creates a replace override.
+func TestShiftRequest(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "joe"}}, 'joe', 'joe@example.com', 'user');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "bob"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "joe"}}, 'personal', 'SMS', {{phone "2"}});
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'primary', 'UTC');
+`
+	h := harness.NewHarness(t, sql, "shift-requests")
+	defer h.Close()
+
+	doQL := func(userID, query string, res interface{}) {
+		t.Helper()
+		g := h.GraphQLQueryUserT(t, userID, query)
+		for _, err := range g.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		if len(g.Errors) > 0 {
+			t.Fatal("errors returned from GraphQL")
+		}
+		if res == nil {
+			return
+		}
+		require.NoError(t, json.Unmarshal(g.Data, res))
+	}
+
+	start := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Hour).UTC()
+	end := start.Add(8 * time.Hour)
+
+	var created struct {
+		CreateShiftRequest struct{ ID string }
+	}
+	doQL(h.UUID("bob"), fmt.Sprintf(`
+		mutation {
+			createShiftRequest(input: {
+				scheduleID: "%s",
+				userID: "%s",
+				start: "%s",
+				end: "%s",
+				note: "dentist"
+			}) { id }
+		}
+	`, h.UUID("sched"), h.UUID("joe"), start.Format(time.RFC3339), end.Format(time.RFC3339)), &created)
+
+	h.Twilio(t).Device(h.Phone("2")).ExpectSMS("bob", "cover", "primary")
+
+	// only the counterpart may respond
+	g := h.GraphQLQueryUserT(t, h.UUID("bob"), fmt.Sprintf(`
+		mutation { respondShiftRequest(input: {id: "%s", accept: true}) }
+	`, created.CreateShiftRequest.ID))
+	assert.NotEmpty(t, g.Errors, "requester should not be able to accept")
+
+	doQL(h.UUID("joe"), fmt.Sprintf(`
+		mutation { respondShiftRequest(input: {id: "%s", accept: true}) }
+	`, created.CreateShiftRequest.ID), nil)
+
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("joe", "accepted")
+
+	var overrides struct {
+		UserOverrides struct {
+			Nodes []struct {
+				Start, End string
+				AddUser    struct{ ID string }
+				RemoveUser struct{ ID string }
+			}
+		}
+	}
+	doQL(h.UUID("joe"), fmt.Sprintf(`
+		query {
+			userOverrides(input: {scheduleID: "%s"}) {
+				nodes { start, end, addUser { id }, removeUser { id } }
+			}
+		}
+	`, h.UUID("sched")), &overrides)
+
+	require.Len(t, overrides.UserOverrides.Nodes, 1)
+	o := overrides.UserOverrides.Nodes[0]
+	assert.Equal(t, h.UUID("joe"), o.AddUser.ID)
+	assert.Equal(t, h.UUID("bob"), o.RemoveUser.ID)
+	assert.Equal(t, start.Format(time.RFC3339), o.Start)
+	assert.Equal(t, end.Format(time.RFC3339), o.End)
+}
@@ -0,0 +1,95 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestShiftRequestSMS checks that a cover request can be accepted by replying to the SMS
+// with the provided code, creating a replace override.
+func TestShiftRequestSMS(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "joe"}}, 'joe', 'joe@example.com', 'user');
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "bob"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "joe"}}, 'personal', 'SMS', {{phone "2"}});
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'primary', 'UTC');
+`
+	h := harness.NewHarness(t, sql, "twilio-sms-shift-request-codes")
+	defer h.Close()
+
+	doQL := func(userID, query string, res interface{}) {
+		t.Helper()
+		g := h.GraphQLQueryUserT(t, userID, query)
+		for _, err := range g.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		if len(g.Errors) > 0 {
+			t.Fatal("errors returned from GraphQL")
+		}
+		if res == nil {
+			return
+		}
+		require.NoError(t, json.Unmarshal(g.Data, res))
+	}
+
+	start := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Hour).UTC()
+	end := start.Add(8 * time.Hour)
+
+	doQL(h.UUID("bob"), fmt.Sprintf(`
+		mutation {
+			createShiftRequest(input: {
+				scheduleID: "%s",
+				userID: "%s",
+				start: "%s",
+				end: "%s"
+			}) { id }
+		}
+	`, h.UUID("sched"), h.UUID("joe"), start.Format(time.RFC3339), end.Format(time.RFC3339)), nil)
+
+	tw := h.Twilio(t)
+	tw.Device(h.Phone("2")).ExpectSMS("bob", "cover", "1y", "1n").
+		ThenReply("1y").
+		ThenExpect("Accepted", "shift request")
+
+	tw.Device(h.Phone("1")).ExpectSMS("joe", "accepted")
+
+	var overrides struct {
+		UserOverrides struct {
+			Nodes []struct {
+				Start, End string
+				AddUser    struct{ ID string }
+				RemoveUser struct{ ID string }
+			}
+		}
+	}
+	doQL(h.UUID("joe"), fmt.Sprintf(`
+		query {
+			userOverrides(input: {scheduleID: "%s"}) {
+				nodes { start, end, addUser { id }, removeUser { id } }
+			}
+		}
+	`, h.UUID("sched")), &overrides)
+
+	require.Len(t, overrides.UserOverrides.Nodes, 1)
+	o := overrides.UserOverrides.Nodes[0]
+	assert.Equal(t, h.UUID("joe"), o.AddUser.ID)
+	assert.Equal(t, h.UUID("bob"), o.RemoveUser.ID)
+	assert.Equal(t, start.Format(time.RFC3339), o.Start)
+	assert.Equal(t, end.Format(time.RFC3339), o.End)
+}
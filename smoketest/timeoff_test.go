@@ -0,0 +1,104 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestTimeOff checks that recording time off replaces the user with the next rotation
+// participant, and that time off conflicting with an existing override is surfaced as a notice.
+func TestTimeOff(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com', 'user'),
+		({{uuid "joe"}}, 'joe', 'joe@example.com', 'user');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into schedules (id, name, time_zone, time_off_strategy)
+	values
+		({{uuid "sched"}}, 'primary', 'UTC', 'replace_next'),
+		({{uuid "sched2"}}, 'secondary', 'UTC', 'remove');
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "esid"}}, {{uuid "sched"}});
+
+	insert into rotations (id, name, type, start_time, time_zone)
+	values
+		({{uuid "rot"}}, 'rot', 'weekly', now() - '1 hour'::interval, 'UTC');
+	insert into rotation_participants (id, rotation_id, user_id, position)
+	values
+		({{uuid ""}}, {{uuid "rot"}}, {{uuid "bob"}}, 0),
+		({{uuid ""}}, {{uuid "rot"}}, {{uuid "joe"}}, 1);
+
+	insert into schedule_rules (schedule_id, tgt_rotation_id)
+	values
+		({{uuid "sched"}}, {{uuid "rot"}});
+	insert into schedule_rules (schedule_id, tgt_user_id)
+	values
+		({{uuid "sched2"}}, {{uuid "bob"}});
+
+	insert into user_overrides (id, tgt_schedule_id, add_user_id, start_time, end_time)
+	values
+		({{uuid "o1"}}, {{uuid "sched2"}}, {{uuid "bob"}}, now() + '1 hour'::interval, now() + '2 hours'::interval);
+`
+	h := harness.NewHarness(t, sql, "user-time-off")
+	defer h.Close()
+
+	doQL := func(query string, res interface{}) {
+		t.Helper()
+		g := h.GraphQLQueryUserT(t, h.UUID("bob"), query)
+		for _, err := range g.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		if len(g.Errors) > 0 {
+			t.Fatal("errors returned from GraphQL")
+		}
+		if res == nil {
+			return
+		}
+		require.NoError(t, json.Unmarshal(g.Data, res))
+	}
+
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("bob"))
+
+	start := time.Now().Add(-time.Hour).UTC()
+	doQL(fmt.Sprintf(`
+		mutation {
+			createTimeOff(input: {start: "%s", end: "%s", note: "vacation"}) { id }
+		}
+	`, start.Format(time.RFC3339), start.Add(24*time.Hour).Format(time.RFC3339)), nil)
+
+	h.Trigger()
+	h.WaitAndAssertOnCallUsers(h.UUID("sid"), h.UUID("joe"))
+
+	var resp struct {
+		Schedule struct {
+			Notices []struct{ Message string }
+		}
+	}
+	doQL(fmt.Sprintf(`
+		query {
+			schedule(id: "%s") { notices { message } }
+		}
+	`, h.UUID("sched2")), &resp)
+	require.Len(t, resp.Schedule.Notices, 1)
+	assert.Contains(t, resp.Schedule.Notices[0].Message, "bob")
+}
@@ -0,0 +1,174 @@
+package syntheticcheck
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Type is the kind of synthetic check to perform.
+type Type string
+
+// Supported check types.
+const (
+	// TypeHTTP performs an HTTP GET request against a URL.
+	TypeHTTP Type = "http"
+
+	// TypeTCP opens a TCP connection to a `host:port` address.
+	TypeTCP Type = "tcp"
+)
+
+// State represents the result of the most recent check.
+type State string
+
+const (
+	// StateInactive means the check has not yet run.
+	StateInactive State = "inactive"
+
+	// StateHealthy indicates the last check was successful.
+	StateHealthy State = "healthy"
+
+	// StateUnhealthy indicates the last check failed.
+	StateUnhealthy State = "unhealthy"
+)
+
+// Scan handles reading State from the DB format
+func (s *State) Scan(value interface{}) error {
+	switch t := value.(type) {
+	case []byte:
+		*s = State(t)
+	case string:
+		*s = State(t)
+	default:
+		return fmt.Errorf("could not process unknown type for state %T", t)
+	}
+
+	return nil
+}
+
+// MaxBodyContainsLength is the maximum length of the BodyContains string.
+const MaxBodyContainsLength = 1024
+
+// A Check is periodically run by the engine, opening an alert on its service when it fails
+// and closing it once it succeeds.
+type Check struct {
+	ID        string
+	ServiceID string
+	Name      string
+	Type      Type
+
+	// Target is the URL for HTTP checks, or the `host:port` address for TCP checks.
+	Target   string
+	Interval time.Duration
+
+	// ExpectedStatus is the required HTTP status code; if zero, any 2xx status is accepted.
+	ExpectedStatus int
+
+	// BodyContains, if set, must be present in the HTTP response body.
+	BodyContains string
+
+	// TLSExpiryDays, if set, will fail an HTTPS check when the server certificate expires within this many days.
+	TLSExpiryDays int
+
+	Disabled bool
+
+	lastState State
+	lastRun   time.Time
+	lastError string
+}
+
+// LastState returns the result of the most recent check.
+func (c Check) LastState() State { return c.lastState }
+
+// LastRun returns the time of the most recent check.
+func (c Check) LastRun() time.Time { return c.lastRun }
+
+// LastError returns the reason the most recent check failed, if any.
+func (c Check) LastError() string { return c.lastError }
+
+// Normalize performs validation and returns a new copy.
+func (c Check) Normalize() (*Check, error) {
+	err := validate.Many(
+		validate.UUID("ServiceID", c.ServiceID),
+		validate.IDName("Name", c.Name),
+		validate.OneOf("Type", c.Type, TypeHTTP, TypeTCP),
+		validate.Duration("Interval", c.Interval, time.Minute, 1440*time.Minute),
+	)
+
+	switch c.Type {
+	case TypeHTTP:
+		err = validate.Many(err,
+			validateHTTPTarget(c.Target),
+			validate.Text("BodyContains", c.BodyContains, 0, MaxBodyContainsLength),
+			validate.Range("TLSExpiryDays", c.TLSExpiryDays, 0, 365),
+		)
+		if c.ExpectedStatus != 0 {
+			err = validate.Many(err, validate.Range("ExpectedStatus", c.ExpectedStatus, 100, 599))
+		}
+	case TypeTCP:
+		err = validate.Many(err, validateTCPTarget(c.Target))
+		c.ExpectedStatus = 0
+		c.BodyContains = ""
+		c.TLSExpiryDays = 0
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.Interval = c.Interval.Truncate(time.Minute)
+
+	return &c, nil
+}
+
+func validateHTTPTarget(target string) error {
+	err := validate.AbsoluteURL("Target", target)
+	if err != nil {
+		return err
+	}
+	u, _ := url.Parse(target)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return validation.NewFieldError("Target", "scheme must be http or https")
+	}
+	return nil
+}
+
+func validateTCPTarget(target string) error {
+	host, port, err := net.SplitHostPort(target)
+	if err != nil {
+		return validation.NewFieldError("Target", "must be in the format host:port")
+	}
+	if host == "" {
+		return validation.NewFieldError("Target", "host is required")
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil || p < 1 || p > 65535 {
+		return validation.NewFieldError("Target", "port must be between 1 and 65535")
+	}
+	return nil
+}
+
+func (c *Check) scanFrom(scanFn func(...interface{}) error) error {
+	var interval pgtype.Interval
+	var lastRun sqlutil.NullTime
+	err := scanFn(
+		&c.ID, &c.ServiceID, &c.Name, &c.Type, &c.Target, &interval,
+		&c.ExpectedStatus, &c.BodyContains, &c.TLSExpiryDays, &c.Disabled,
+		&c.lastState, &lastRun, &c.lastError,
+	)
+	if err != nil {
+		return err
+	}
+	err = interval.AssignTo(&c.Interval)
+	if err != nil {
+		return err
+	}
+	c.lastRun = lastRun.Time
+	return nil
+}
@@ -0,0 +1,70 @@
+package syntheticcheck
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheck_Normalize(t *testing.T) {
+	valid := Check{
+		ServiceID: "00000000-0000-0000-0000-000000000001",
+		Name:      "Website",
+		Type:      TypeHTTP,
+		Target:    "https://example.com/health",
+		Interval:  5 * time.Minute,
+	}
+
+	check := func(name string, c Check, expValid bool) {
+		t.Helper()
+		t.Run(name, func(t *testing.T) {
+			_, err := c.Normalize()
+			if expValid && err != nil {
+				t.Errorf("err = %v; want nil", err)
+			}
+			if !expValid && err == nil {
+				t.Error("err = nil; want validation error")
+			}
+		})
+	}
+
+	check("valid", valid, true)
+
+	c := valid
+	c.Target = "ftp://example.com"
+	check("bad scheme", c, false)
+
+	c = valid
+	c.ExpectedStatus = 700
+	check("bad status", c, false)
+
+	c = valid
+	c.Interval = 30 * time.Second
+	check("short interval", c, false)
+
+	c = valid
+	c.Type = TypeTCP
+	check("tcp url", c, false)
+
+	c.Target = "example.com:443"
+	check("tcp", c, true)
+
+	c.Target = "example.com:0"
+	check("tcp port", c, false)
+
+	c = valid
+	c.Type = "ping"
+	check("bad type", c, false)
+
+	c = valid
+	c.Type = TypeTCP
+	c.Target = "db.example.com:5432"
+	c.BodyContains = "ok"
+	c.ExpectedStatus = 200
+	n, err := c.Normalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.BodyContains != "" || n.ExpectedStatus != 0 {
+		t.Error("expected HTTP options to be cleared for TCP checks")
+	}
+}
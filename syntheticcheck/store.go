@@ -0,0 +1,245 @@
+package syntheticcheck
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgtype"
+	uuid "github.com/satori/go.uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store allows the lookup and management of synthetic checks.
+type Store struct {
+	db *sql.DB
+
+	create     *sql.Stmt
+	update     *sql.Stmt
+	delete     *sql.Stmt
+	findMany   *sql.Stmt
+	findAll    *sql.Stmt
+	findOneUpd *sql.Stmt
+}
+
+const checkColumns = `
+	id, service_id, name, check_type, target, check_interval,
+	expected_status, body_contains, tls_expiry_days, disabled,
+	last_state, last_run_at, last_error
+`
+
+// NewStore will create a new Store with the given parameters.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db: db,
+
+		create: p.P(`
+			insert into synthetic_checks (
+				id, service_id, name, check_type, target, check_interval,
+				expected_status, body_contains, tls_expiry_days, disabled
+			) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`),
+		update: p.P(`
+			update synthetic_checks
+			set
+				name = $2,
+				check_type = $3,
+				target = $4,
+				check_interval = $5,
+				expected_status = $6,
+				body_contains = $7,
+				tls_expiry_days = $8,
+				disabled = $9
+			where id = $1
+		`),
+		delete: p.P(`delete from synthetic_checks where id = any($1)`),
+		findMany: p.P(`
+			select ` + checkColumns + `
+			from synthetic_checks
+			where id = any($1)
+		`),
+		findAll: p.P(`
+			select ` + checkColumns + `
+			from synthetic_checks
+			where service_id = $1
+			order by lower(name)
+		`),
+		findOneUpd: p.P(`
+			select ` + checkColumns + `
+			from synthetic_checks
+			where id = $1
+			for update
+		`),
+	}, p.Err
+}
+
+// CreateTx will create a new synthetic check. Since checks make requests from GoAlert's network,
+// only admins may create or change them.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, c *Check) (*Check, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	n, err := c.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	n.ID = uuid.NewV4().String()
+	n.lastState = StateInactive
+
+	var interval pgtype.Interval
+	err = interval.Set(n.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := s.create
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx,
+		n.ID, n.ServiceID, n.Name, n.Type, n.Target, &interval,
+		n.ExpectedStatus, n.BodyContains, n.TLSExpiryDays, n.Disabled,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// UpdateTx will update all configurable fields of a synthetic check.
+func (s *Store) UpdateTx(ctx context.Context, tx *sql.Tx, c *Check) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return err
+	}
+	n, err := c.Normalize()
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ID", n.ID)
+	if err != nil {
+		return err
+	}
+
+	var interval pgtype.Interval
+	err = interval.Set(n.Interval)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.update
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx,
+		n.ID, n.Name, n.Type, n.Target, &interval,
+		n.ExpectedStatus, n.BodyContains, n.TLSExpiryDays, n.Disabled,
+	)
+	return err
+}
+
+// DeleteTx will delete the synthetic checks with the given IDs.
+func (s *Store) DeleteTx(ctx context.Context, tx *sql.Tx, ids ...string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	err = validate.ManyUUID("ID", ids, 50)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.delete
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx, sqlutil.UUIDArray(ids))
+	return err
+}
+
+// FindOneForUpdateTx will return the check with the given ID, locking it for the remainder of the transaction.
+func (s *Store) FindOneForUpdateTx(ctx context.Context, tx *sql.Tx, id string) (*Check, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Check
+	err = c.scanFrom(tx.StmtContext(ctx, s.findOneUpd).QueryRowContext(ctx, id).Scan)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// FindMany will return the checks with the given IDs.
+func (s *Store) FindMany(ctx context.Context, ids ...string) ([]Check, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.System)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	err = validate.ManyUUID("IDs", ids, search.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findMany.QueryContext(ctx, sqlutil.UUIDArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAll(rows)
+}
+
+// FindAllByService will return all checks belonging to the given service, ordered by name.
+func (s *Store) FindAllByService(ctx context.Context, serviceID string) ([]Check, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.System)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAll.QueryContext(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAll(rows)
+}
+
+func scanAll(rows *sql.Rows) ([]Check, error) {
+	var result []Check
+	for rows.Next() {
+		var c Check
+		err := c.scanFrom(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,278 @@
+package timeoff
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store allows the lookup and management of user time off.
+type Store struct {
+	create        *sql.Stmt
+	delete        *sql.Stmt
+	findOneUpd    *sql.Stmt
+	findAllByUser *sql.Stmt
+	findConflicts *sql.Stmt
+	apply         *sql.Stmt
+}
+
+// candidates selects every (time off, schedule) pair that does not yet have a generated
+// override, along with the user to add (if any) and whether the override can be created.
+//
+// A user belongs to a schedule if they are targeted directly by a rule, or are a participant of
+// a rotation targeted by a rule. With the replace_next strategy, the next participant of the
+// rotation (wrapping around) is used to backfill; directly-targeted users are always removed.
+const candidates = `
+	with members as (
+		select distinct on (m.schedule_id, m.user_id) m.schedule_id, m.user_id, m.backfill_id
+		from (
+			select r.schedule_id, r.tgt_user_id user_id, null::uuid backfill_id
+			from schedule_rules r
+			where r.tgt_user_id notnull
+			union all
+			select r.schedule_id, part.user_id, (
+				select nxt.user_id
+				from rotation_participants nxt
+				where
+					nxt.rotation_id = part.rotation_id and
+					nxt.user_id != part.user_id
+				order by nxt.position <= part.position, nxt.position
+				limit 1
+			)
+			from schedule_rules r
+			join rotation_participants part on part.rotation_id = r.tgt_rotation_id
+		) m
+		order by m.schedule_id, m.user_id, m.backfill_id nulls last
+	), candidates as (
+		select
+			t.id time_off_id,
+			m.schedule_id,
+			t.user_id,
+			case when sched.time_off_strategy = 'replace_next' then m.backfill_id end add_user_id,
+			greatest(t.start_time, now()) start_time,
+			t.end_time
+		from user_time_off t
+		join members m on m.user_id = t.user_id
+		join schedules sched on sched.id = m.schedule_id
+		where
+			t.end_time > now() + '1 minute'::interval and
+			not exists (
+				select 1
+				from user_overrides o
+				where o.time_off_id = t.id and o.tgt_schedule_id = m.schedule_id
+			)
+	)
+	select
+		c.*,
+		exists (
+			select 1
+			from user_overrides o
+			where
+				o.tgt_schedule_id = c.schedule_id and
+				(
+					o.add_user_id in (c.user_id, c.add_user_id) or
+					o.remove_user_id in (c.user_id, c.add_user_id)
+				) and
+				(o.start_time, o.end_time) overlaps (c.start_time, c.end_time)
+		) has_conflict,
+		coalesce(lim.max, -1) != -1 and coalesce(lim.max, -1) <= (
+			select count(*)
+			from user_overrides o
+			where o.tgt_schedule_id = c.schedule_id and o.end_time > now()
+		) at_limit
+	from candidates c
+	left join config_limits lim on lim.id = 'user_overrides_per_schedule'
+`
+
+// NewStore will create a new Store with the given parameters.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		create: p.P(`
+			insert into user_time_off (id, user_id, start_time, end_time, note)
+			values ($1, $2, $3, $4, $5)
+		`),
+		delete: p.P(`delete from user_time_off where id = $1`),
+		findOneUpd: p.P(`
+			select id, user_id, start_time, end_time, note, created_at
+			from user_time_off
+			where id = $1
+			for update
+		`),
+		findAllByUser: p.P(`
+			select id, user_id, start_time, end_time, note, created_at
+			from user_time_off
+			where user_id = $1 and end_time > now()
+			order by start_time, id
+		`),
+		findConflicts: p.P(`
+			select c.time_off_id, c.user_id, u.name, c.start_time, c.end_time, c.at_limit
+			from (` + candidates + `) c
+			join users u on u.id = c.user_id
+			where
+				c.schedule_id = $1 and
+				(c.has_conflict or c.at_limit)
+			order by c.start_time, u.name
+		`),
+
+		// Only one override is created per schedule at a time, so that multiple generated
+		// overrides can never conflict with each other; remaining ones are picked up on
+		// subsequent calls.
+		apply: p.P(`
+			insert into user_overrides (id, tgt_schedule_id, add_user_id, remove_user_id, start_time, end_time, time_off_id)
+			select distinct on (c.schedule_id)
+				gen_random_uuid(), c.schedule_id, c.add_user_id, c.user_id, c.start_time, c.end_time, c.time_off_id
+			from (` + candidates + `) c
+			where not c.has_conflict and not c.at_limit
+			order by c.schedule_id, c.start_time
+		`),
+	}, p.Err
+}
+
+func (t *TimeOff) scanFrom(scanFn func(...interface{}) error) error {
+	return scanFn(&t.ID, &t.UserID, &t.Start, &t.End, &t.Note, &t.CreatedAt)
+}
+
+// CreateTx will record a new period of time off. Only the user (or an admin) may
+// record time off on their behalf.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, t *TimeOff) (*TimeOff, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(t.UserID))
+	if err != nil {
+		return nil, err
+	}
+	n, err := t.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	n.ID = uuid.NewV4().String()
+	n.CreatedAt = time.Now()
+
+	stmt := s.create
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx, n.ID, n.UserID, n.Start, n.End, n.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// DeleteTx will delete a period of time off, along with any overrides generated from it.
+// Only the user (or an admin) may delete it. sql.ErrNoRows is returned if it does not exist.
+func (s *Store) DeleteTx(ctx context.Context, tx *sql.Tx, id string) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ID", id)
+	if err != nil {
+		return err
+	}
+
+	var t TimeOff
+	err = t.scanFrom(tx.StmtContext(ctx, s.findOneUpd).QueryRowContext(ctx, id).Scan)
+	if err != nil {
+		return err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(t.UserID))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.delete).ExecContext(ctx, id)
+	return err
+}
+
+// FindAllByUser will return all current and upcoming time off for the given user, in order.
+func (s *Store) FindAllByUser(ctx context.Context, userID string) ([]TimeOff, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.System)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAllByUser.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []TimeOff
+	for rows.Next() {
+		var t TimeOff
+		err = t.scanFrom(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, rows.Err()
+}
+
+// FindScheduleConflicts will return all time off for members of the given schedule that
+// could not be applied as an override.
+func (s *Store) FindScheduleConflicts(ctx context.Context, scheduleID string) ([]Conflict, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.System)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ScheduleID", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findConflicts.QueryContext(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Conflict
+	for rows.Next() {
+		var c Conflict
+		var atLimit bool
+		err = rows.Scan(&c.TimeOffID, &c.UserID, &c.UserName, &c.Start, &c.End, &atLimit)
+		if err != nil {
+			return nil, err
+		}
+		c.Reason = ConflictOverride
+		if atLimit {
+			c.Reason = ConflictLimit
+		}
+		result = append(result, c)
+	}
+
+	return result, rows.Err()
+}
+
+// ApplyOverridesTx will create overrides for time off that has not yet been applied, returning
+// the number created. At most one override is created per schedule per call.
+func (s *Store) ApplyOverridesTx(ctx context.Context, tx *sql.Tx) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt := s.apply
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	res, err := stmt.ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
@@ -0,0 +1,64 @@
+package timeoff
+
+import (
+	"time"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxNoteLength is the maximum length of the Note field.
+const MaxNoteLength = 255
+
+// TimeOff is a range of time a user is unavailable. While it is active, the user is
+// taken off every schedule they belong to with automatically generated overrides.
+type TimeOff struct {
+	ID     string
+	UserID string
+
+	Start, End time.Time
+
+	Note      string
+	CreatedAt time.Time
+}
+
+// Normalize will validate fields and return a normalized copy.
+func (t TimeOff) Normalize() (*TimeOff, error) {
+	err := validate.Many(
+		validate.UUID("UserID", t.UserID),
+		validate.Text("Note", t.Note, 0, MaxNoteLength),
+	)
+	if !t.Start.Before(t.End) {
+		err = validate.Many(err, validation.NewFieldError("End", "must occur after Start time"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t.Start = t.Start.Truncate(time.Minute)
+	t.End = t.End.Truncate(time.Minute)
+
+	return &t, nil
+}
+
+// ConflictReason indicates why an override could not be generated for a period of time off.
+type ConflictReason string
+
+const (
+	// ConflictOverride means an existing override on the schedule overlaps the time off.
+	ConflictOverride ConflictReason = "override"
+
+	// ConflictLimit means the schedule has reached the maximum number of overrides.
+	ConflictLimit ConflictReason = "limit"
+)
+
+// A Conflict is a period of time off that could not be applied to a schedule.
+type Conflict struct {
+	TimeOffID string
+	UserID    string
+	UserName  string
+
+	Start, End time.Time
+
+	Reason ConflictReason
+}
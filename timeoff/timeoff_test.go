@@ -0,0 +1,48 @@
+package timeoff
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeOff_Normalize(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 30, 0, time.UTC)
+	test := func(valid bool, to TimeOff) {
+		name := "valid"
+		if !valid {
+			name = "invalid"
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Logf("%+v", to)
+			n, err := to.Normalize()
+			if valid && err != nil {
+				t.Errorf("got %v; want nil", err)
+			} else if !valid && err == nil {
+				t.Errorf("got nil err; want non-nil")
+			}
+			if valid && n.Start.Second() != 0 {
+				t.Errorf("got start %s; want truncated to minute", n.Start)
+			}
+		})
+	}
+
+	userID := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+
+	valid := []TimeOff{
+		{UserID: userID, Start: start, End: start.Add(24 * time.Hour)},
+		{UserID: userID, Start: start, End: start.Add(time.Hour), Note: "vacation"},
+	}
+	invalid := []TimeOff{
+		{Start: start, End: start.Add(time.Hour)},
+		{UserID: userID, Start: start, End: start},
+		{UserID: userID, Start: start, End: start.Add(-time.Hour)},
+		{UserID: userID, Start: start, End: start.Add(time.Hour), Note: strings.Repeat("a", MaxNoteLength+1)},
+	}
+	for _, to := range valid {
+		test(true, to)
+	}
+	for _, to := range invalid {
+		test(false, to)
+	}
+}
@@ -92,9 +92,10 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 
 		insert: p.P(`
 			INSERT INTO users (
-				id, name, email, avatar_url, role, alert_status_log_contact_method_id
+				id, name, email, avatar_url, role, alert_status_log_contact_method_id,
+				handoff_notify, handoff_notify_minutes
 			)
-			VALUES ($1, $2, $3, $4, $5, $6)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		`),
 
 		update: p.P(`
@@ -102,7 +103,9 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 			SET
 				name = $2,
 				email = $3,
-				alert_status_log_contact_method_id = $4
+				alert_status_log_contact_method_id = $4,
+				handoff_notify = $5,
+				handoff_notify_minutes = $6
 			WHERE id = $1
 		`),
 
@@ -117,7 +120,8 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 
 		findMany: p.P(`
 			SELECT
-				id, name, email, avatar_url, role, alert_status_log_contact_method_id
+				id, name, email, avatar_url, role, alert_status_log_contact_method_id,
+				handoff_notify, handoff_notify_minutes
 			FROM users
 			WHERE id = any($1)
 		`),
@@ -130,13 +134,15 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 
 		findOne: p.P(`
 			SELECT
-				id, name, email, avatar_url, role, alert_status_log_contact_method_id
+				id, name, email, avatar_url, role, alert_status_log_contact_method_id,
+				handoff_notify, handoff_notify_minutes
 			FROM users
 			WHERE id = $1
 		`),
 		findOneForUpdate: p.P(`
 			SELECT
-				id, name, email, avatar_url, role, alert_status_log_contact_method_id
+				id, name, email, avatar_url, role, alert_status_log_contact_method_id,
+				handoff_notify, handoff_notify_minutes
 			FROM users
 			WHERE id = $1
 			FOR UPDATE
@@ -150,7 +156,8 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 
 		findAll: p.P(`
 			SELECT
-				id, name, email, avatar_url, role, alert_status_log_contact_method_id
+				id, name, email, avatar_url, role, alert_status_log_contact_method_id,
+				handoff_notify, handoff_notify_minutes
 			FROM users
 		`),
 
@@ -33,8 +33,18 @@ type User struct {
 
 	// The Role of the user
 	Role permission.Role `json:"role" store:"readonly"`
+
+	// HandoffNotify enables notifications before the user's on-call shifts start
+	// and when they end.
+	HandoffNotify bool `json:"handoff_notify"`
+
+	// HandoffNotifyMinutes is how long before a shift starts the user is notified.
+	HandoffNotifyMinutes int `json:"handoff_notify_minutes"`
 }
 
+// MaxHandoffNotifyMinutes is the maximum value of HandoffNotifyMinutes (1 week).
+const MaxHandoffNotifyMinutes = 10080
+
 // ResolveAvatarURL will resolve the user avatar URL, using the email if none is set.
 func (u User) ResolveAvatarURL(fullSize bool) string {
 	if u.AvatarURL == "" {
@@ -59,6 +69,8 @@ func (u *User) scanFrom(fn scanFn) error {
 		&u.AvatarURL,
 		&u.Role,
 		&statusCM,
+		&u.HandoffNotify,
+		&u.HandoffNotifyMinutes,
 	)
 	u.AlertStatusCMID = statusCM.String
 	return err
@@ -75,6 +87,8 @@ func (u *User) userUpdateFields() []interface{} {
 		u.Name,
 		u.Email,
 		statusCM,
+		u.HandoffNotify,
+		u.HandoffNotifyMinutes,
 	}
 }
 func (u *User) fields() []interface{} {
@@ -90,6 +104,8 @@ func (u *User) fields() []interface{} {
 		u.AvatarURL,
 		u.Role,
 		statusCM,
+		u.HandoffNotify,
+		u.HandoffNotifyMinutes,
 	}
 }
 
@@ -123,6 +139,7 @@ func (u User) Normalize() (*User, error) {
 		err,
 		validate.Name("Name", u.Name),
 		validate.OneOf("Role", u.Role, permission.RoleAdmin, permission.RoleUser),
+		validate.Range("HandoffNotifyMinutes", u.HandoffNotifyMinutes, 0, MaxHandoffNotifyMinutes),
 	)
 	if err != nil {
 		return nil, err
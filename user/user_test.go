@@ -24,9 +24,12 @@ func TestUser_Normalize(t *testing.T) {
 
 	valid := []User{
 		{Name: "Joe", Role: permission.RoleAdmin, Email: "foo@bar.com"},
+		{Name: "Joe", Role: permission.RoleUser, HandoffNotify: true, HandoffNotifyMinutes: 60},
 	}
 	invalid := []User{
 		{},
+		{Name: "Joe", Role: permission.RoleUser, HandoffNotify: true, HandoffNotifyMinutes: -1},
+		{Name: "Joe", Role: permission.RoleUser, HandoffNotifyMinutes: MaxHandoffNotifyMinutes + 1},
 	}
 	for _, u := range valid {
 		test(true, u)
@@ -0,0 +1,139 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Property is a single (unfolded) content line of an iCalendar component.
+type Property struct {
+	// Name is the upper-case property name (e.g., DTSTART).
+	Name string
+
+	// Params holds parameter values keyed by upper-case name, with any quotes removed.
+	Params map[string]string
+
+	Value string
+
+	// Line is the line number, starting at 1, the property begins on.
+	Line int
+}
+
+// Event is a VEVENT component. Properties of nested components (e.g., VALARM) are not included.
+type Event struct {
+	// Line is the line number of the BEGIN:VEVENT property.
+	Line int
+
+	Properties []Property
+}
+
+type logicalLine struct {
+	Num  int
+	Text string
+}
+
+// unfold will read all content lines from r, joining folded lines (RFC 5545 section 3.1).
+// Blank lines are omitted.
+func unfold(r io.Reader, maxSize int) ([]logicalLine, error) {
+	var lines []logicalLine
+	sc := bufio.NewScanner(r)
+	bufSize := 4096
+	if maxSize < bufSize {
+		// the max token size is the larger of the two
+		bufSize = maxSize
+	}
+	sc.Buffer(make([]byte, 0, bufSize), maxSize)
+	var num int
+	for sc.Scan() {
+		num++
+		text := strings.TrimRight(sc.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+			lines[len(lines)-1].Text += text[1:]
+			continue
+		}
+		if text == "" {
+			continue
+		}
+		lines = append(lines, logicalLine{Num: num, Text: text})
+	}
+
+	return lines, sc.Err()
+}
+
+func parseProperty(s string, line int) Property {
+	p := Property{Line: line, Params: make(map[string]string)}
+
+	// the value starts at the first colon not within a quoted parameter value
+	var quoted bool
+	idx := -1
+	for i, c := range s {
+		if c == '"' {
+			quoted = !quoted
+		}
+		if c == ':' && !quoted {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		p.Name = strings.ToUpper(s)
+		return p
+	}
+	p.Value = s[idx+1:]
+
+	parts := strings.Split(s[:idx], ";")
+	p.Name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		p.Params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+
+	return p
+}
+
+// ReadEvents will read every VEVENT from the iCalendar data in r, in order. Content outside
+// of an event is ignored. An error is returned if a (folded) line is longer than maxSize
+// bytes, or if an event is not terminated.
+func ReadEvents(r io.Reader, maxSize int) ([]Event, error) {
+	lines, err := unfold(r, maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var ev *Event
+	var nested int
+	for _, l := range lines {
+		p := parseProperty(l.Text, l.Num)
+		switch {
+		case p.Name == "BEGIN" && strings.EqualFold(p.Value, "VEVENT"):
+			if ev == nil {
+				events = append(events, Event{})
+				ev = &events[len(events)-1]
+			}
+			// an unterminated event is replaced by the new one
+			*ev = Event{Line: l.Num}
+			nested = 0
+		case ev == nil:
+		case p.Name == "BEGIN":
+			nested++
+		case p.Name == "END" && nested > 0:
+			nested--
+		case nested > 0:
+		case p.Name == "END" && strings.EqualFold(p.Value, "VEVENT"):
+			ev = nil
+		default:
+			ev.Properties = append(ev.Properties, p)
+		}
+	}
+	if ev != nil {
+		return nil, fmt.Errorf("line %d: unterminated event", ev.Line)
+	}
+
+	return events, nil
+}
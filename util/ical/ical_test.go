@@ -0,0 +1,53 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadEvents(t *testing.T) {
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"SUMMARY:Folded",
+		"  summary",
+		"",
+		`ATTENDEE;CN="Doe: Jane";ROLE=REQ-PARTICIPANT:mailto:jane@example.com`,
+		"BEGIN:VALARM",
+		"SUMMARY:ignored",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20260101",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, err := ReadEvents(strings.NewReader(data), 1024)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, 3, events[0].Line)
+	require.Len(t, events[0].Properties, 2)
+	assert.Equal(t, Property{Name: "SUMMARY", Params: map[string]string{}, Value: "Folded summary", Line: 4}, events[0].Properties[0])
+	assert.Equal(t, Property{
+		Name:   "ATTENDEE",
+		Params: map[string]string{"CN": "Doe: Jane", "ROLE": "REQ-PARTICIPANT"},
+		Value:  "mailto:jane@example.com",
+		Line:   7,
+	}, events[0].Properties[1])
+
+	assert.Equal(t, 12, events[1].Line)
+	require.Len(t, events[1].Properties, 1)
+	assert.Equal(t, "DATE", events[1].Properties[0].Params["VALUE"])
+
+	_, err = ReadEvents(strings.NewReader("BEGIN:VEVENT\nSUMMARY:test\n"), 1024)
+	assert.EqualError(t, err, "line 1: unterminated event")
+
+	_, err = ReadEvents(strings.NewReader("BEGIN:VEVENT\nSUMMARY:"+strings.Repeat("a", 100)+"\nEND:VEVENT\n"), 64)
+	assert.Error(t, err, "line too long")
+}
@@ -0,0 +1,81 @@
+package pubsub
+
+import "sync"
+
+// bufferSize is the number of undelivered messages held for each subscriber
+// before new messages are dropped.
+const bufferSize = 100
+
+// Broker fans out published messages to subscribers of a topic.
+type Broker struct {
+	mx   sync.Mutex
+	subs map[string]map[*Subscriber]struct{}
+}
+
+// Subscriber receives messages for one or more topics.
+type Subscriber struct {
+	b      *Broker
+	topics []string
+	ch     chan string
+	once   sync.Once
+}
+
+// NewBroker will create a new, empty, Broker.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[string]map[*Subscriber]struct{})}
+}
+
+// Subscribe will return a new Subscriber for the provided topics. Close must be called
+// when the Subscriber is no longer needed.
+func (b *Broker) Subscribe(topics ...string) *Subscriber {
+	s := &Subscriber{
+		b:      b,
+		topics: topics,
+		ch:     make(chan string, bufferSize),
+	}
+
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	for _, t := range topics {
+		m := b.subs[t]
+		if m == nil {
+			m = make(map[*Subscriber]struct{})
+			b.subs[t] = m
+		}
+		m[s] = struct{}{}
+	}
+
+	return s
+}
+
+// Publish will send the payload to all current subscribers of the topic. It never blocks;
+// if a subscriber is not keeping up the message is dropped for that subscriber.
+func (b *Broker) Publish(topic, payload string) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	for s := range b.subs[topic] {
+		select {
+		case s.ch <- payload:
+		default:
+		}
+	}
+}
+
+// Messages returns the channel of message payloads. It is closed after Close is called.
+func (s *Subscriber) Messages() <-chan string { return s.ch }
+
+// Close will unsubscribe from all topics and close the Messages channel.
+func (s *Subscriber) Close() {
+	s.once.Do(func() {
+		s.b.mx.Lock()
+		defer s.b.mx.Unlock()
+		for _, t := range s.topics {
+			delete(s.b.subs[t], s)
+			if len(s.b.subs[t]) == 0 {
+				delete(s.b.subs, t)
+			}
+		}
+		close(s.ch)
+	})
+}
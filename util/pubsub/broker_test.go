@@ -0,0 +1,46 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker(t *testing.T) {
+	b := NewBroker()
+
+	a := b.Subscribe("foo", "bar")
+	c := b.Subscribe("bar")
+
+	b.Publish("foo", "1")
+	b.Publish("bar", "2")
+	b.Publish("baz", "3")
+
+	assert.Equal(t, "1", <-a.Messages())
+	assert.Equal(t, "2", <-a.Messages())
+	assert.Equal(t, "2", <-c.Messages())
+	assert.Len(t, a.Messages(), 0)
+	assert.Len(t, c.Messages(), 0)
+
+	a.Close()
+	a.Close() // safe to call more than once
+	_, ok := <-a.Messages()
+	assert.False(t, ok, "closed")
+
+	b.Publish("bar", "4")
+	assert.Equal(t, "4", <-c.Messages())
+
+	c.Close()
+	assert.Empty(t, b.subs)
+}
+
+func TestBroker_Full(t *testing.T) {
+	b := NewBroker()
+	s := b.Subscribe("foo")
+	defer s.Close()
+
+	for i := 0; i < bufferSize+10; i++ {
+		b.Publish("foo", "x")
+	}
+	assert.Len(t, s.Messages(), bufferSize)
+}
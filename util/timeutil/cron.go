@@ -0,0 +1,202 @@
+package timeutil
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CronSchedule is a parsed standard 5-field cron expression (minute, hour, day-of-month, month, day-of-week).
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny are set when the field is `*`; if both day fields are restricted,
+	// a day matches if either field matches.
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDOM    = cronField{name: "day-of-month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12,
+		names: []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	cronDOW = cronField{name: "day-of-week", min: 0, max: 7,
+		names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron will parse a standard 5-field cron expression. Fields may contain
+// `*`, numbers, ranges (`1-5`), steps (`*/15`, `0-30/10`) and lists (`1,15`).
+// Month and day-of-week fields also accept 3-letter names (e.g. `jan`, `mon`), and
+// macros like `@daily` or `@hourly` are supported.
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(strings.ToLower(expr))
+	if m, ok := cronMacros[expr]; ok {
+		expr = m
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("expected 5 fields but got %d", len(fields))
+	}
+
+	var s CronSchedule
+	var err error
+	if s.minute, err = cronMinute.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.hour, err = cronHour.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if s.dom, err = cronDOM.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if s.month, err = cronMonth.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.dow, err = cronDOW.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		// 7 is an alias for Sunday
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domAny = fields[2] == "*" || strings.HasPrefix(fields[2], "*/")
+	s.dowAny = fields[4] == "*" || strings.HasPrefix(fields[4], "*/")
+
+	return &s, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	for i, n := range f.names {
+		if n != "" && s == n {
+			return i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.Errorf("invalid %s value '%s'", f.name, s)
+	}
+	if v < f.min || v > f.max {
+		return 0, errors.Errorf("%s value %d out of range %d-%d", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+func (f cronField) parse(field string) (uint64, error) {
+	var result uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr := part, ""
+		if idx := strings.IndexByte(part, '/'); idx != -1 {
+			rng, stepStr = part[:idx], part[idx+1:]
+		}
+
+		step := 1
+		if stepStr != "" {
+			var err error
+			step, err = strconv.Atoi(stepStr)
+			if err != nil || step < 1 {
+				return 0, errors.Errorf("invalid %s step '%s'", f.name, stepStr)
+			}
+		}
+
+		var start, end int
+		var err error
+		switch {
+		case rng == "*":
+			start, end = f.min, f.max
+		case strings.Contains(rng, "-"):
+			idx := strings.IndexByte(rng, '-')
+			start, err = f.value(rng[:idx])
+			if err != nil {
+				return 0, err
+			}
+			end, err = f.value(rng[idx+1:])
+			if err != nil {
+				return 0, err
+			}
+			if end < start {
+				return 0, errors.Errorf("invalid %s range '%s'", f.name, rng)
+			}
+		default:
+			start, err = f.value(rng)
+			if err != nil {
+				return 0, err
+			}
+			end = start
+			if stepStr != "" {
+				end = f.max
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			result |= 1 << uint(i)
+		}
+	}
+
+	return result, nil
+}
+
+func (s CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Next returns the first scheduled time strictly after t, in the location of t. If the
+// schedule can never match (e.g. February 30th), the zero time is returned.
+func (s CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// no valid schedule will go more than 8 years without a match (leap days)
+	limit := t.AddDate(8, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// DST transitions can map the next wall-clock hour back onto the current one
+				next = t.Truncate(time.Hour).Add(time.Hour)
+			}
+			t = next
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
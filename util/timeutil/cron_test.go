@@ -0,0 +1,78 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"0 2 * * 1-5",
+		"*/15 * * * *",
+		"0 0 1,15 * *",
+		"30 6 * jan-mar mon,wed,fri",
+		"0 0 * * 7",
+		"@daily",
+		"@hourly",
+	}
+	for _, expr := range valid {
+		_, err := ParseCron(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"foo * * * *",
+		"@sometimes",
+	}
+	for _, expr := range invalid {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	check := func(expr, start, exp string) {
+		t.Helper()
+		s, err := ParseCron(expr)
+		require.NoError(t, err)
+		st, err := time.ParseInLocation("2006-01-02 15:04", start, chicago)
+		require.NoError(t, err)
+		assert.Equal(t, exp, s.Next(st).Format("2006-01-02 15:04 MST"), "%s after %s", expr, start)
+	}
+
+	// Friday, Oct 16
+	check("0 2 * * 1-5", "2026-10-16 02:01", "2026-10-19 02:00 CDT")
+	check("0 2 * * 1-5", "2026-10-16 01:59", "2026-10-16 02:00 CDT")
+	check("0 2 * * mon-fri", "2026-10-16 02:00", "2026-10-19 02:00 CDT")
+	check("*/15 * * * *", "2026-10-16 02:01", "2026-10-16 02:15 CDT")
+	check("0 0 1 * *", "2026-10-16 02:01", "2026-11-01 00:00 CDT")
+	check("0 0 29 2 *", "2026-10-16 02:01", "2028-02-29 00:00 CST")
+
+	// day-of-month OR day-of-week when both are restricted
+	check("0 0 20 * fri", "2026-10-16 02:00", "2026-10-20 00:00 CDT")
+
+	// DST: 2:30 does not exist on Mar 8, 2026
+	check("30 2 * * *", "2026-03-07 03:00", "2026-03-09 02:30 CDT")
+	check("0 * * * *", "2026-11-01 00:30", "2026-11-01 01:00 CDT")
+	check("0 * * * *", "2026-11-01 01:30", "2026-11-01 01:00 CST")
+
+	s, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero(), "never")
+}
@@ -442,6 +442,7 @@ function AlertDetails(props) {
               <Grid item xs={12}>
                 <Typography variant='body1' data-cy='alert-status'>
                   {alert.status.toUpperCase().replace('STATUS', '')}
+                  {alert.flapping && ' (FLAPPING)'}
                 </Typography>
               </Grid>
             </Grid>
@@ -20,6 +20,9 @@ function PolicyCreateDialog(props) {
     name: '',
     description: '',
     repeat: { label: '3', value: '3' },
+    ackTimeoutMinutes: 0,
+    repeatDelayMinutes: 0,
+    fallbackUserID: null,
     favorite: true,
   }
   const [createPolicy, createPolicyStatus] = useMutation(mutation, {
@@ -28,6 +31,15 @@ function PolicyCreateDialog(props) {
         name: (value && value.name) || defaultValue.name,
         description: (value && value.description) || defaultValue.description,
         repeat: (value && value.repeat.value) || defaultValue.repeat.value,
+        ackTimeoutMinutes:
+          (value && value.ackTimeoutMinutes) || defaultValue.ackTimeoutMinutes,
+        repeatDelayMinutes:
+          (value && value.repeatDelayMinutes) ||
+          defaultValue.repeatDelayMinutes,
+        fallbackTarget:
+          value && value.fallbackUserID
+            ? { type: 'user', id: value.fallbackUserID }
+            : null,
         favorite: true,
       },
     },
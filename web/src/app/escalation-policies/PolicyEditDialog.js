@@ -12,6 +12,12 @@ const query = gql`
       name
       description
       repeat
+      ackTimeoutMinutes
+      repeatDelayMinutes
+      fallbackTarget {
+        id
+        type
+      }
     }
   }
 `
@@ -22,6 +28,12 @@ const mutation = gql`
   }
 `
 
+const fallbackMutation = gql`
+  mutation ($input: SetEscalationPolicyFallbackTargetInput!) {
+    setEscalationPolicyFallbackTarget(input: $input)
+  }
+`
+
 function PolicyEditDialog(props) {
   const [value, setValue] = useState(null)
   const { data, loading } = useQuery(query, {
@@ -37,7 +49,30 @@ function PolicyEditDialog(props) {
       label: data?.escalationPolicy?.repeat.toString(),
       value: data?.escalationPolicy?.repeat.toString(),
     },
+    ackTimeoutMinutes: data?.escalationPolicy?.ackTimeoutMinutes,
+    repeatDelayMinutes: data?.escalationPolicy?.repeatDelayMinutes,
+    fallbackUserID:
+      data?.escalationPolicy?.fallbackTarget?.type === 'user'
+        ? data.escalationPolicy.fallbackTarget.id
+        : null,
   }
+  const fallbackUserID =
+    value?.fallbackUserID === undefined
+      ? defaultValue.fallbackUserID
+      : value.fallbackUserID
+
+  const [setFallbackMutation, setFallbackMutationStatus] = useMutation(
+    fallbackMutation,
+    {
+      variables: {
+        input: {
+          escalationPolicyID: props.escalationPolicyID,
+          target: fallbackUserID ? { type: 'user', id: fallbackUserID } : null,
+        },
+      },
+      onCompleted: props.onClose,
+    },
+  )
 
   const [editDialogMutation, editDialogMutationStatus] = useMutation(mutation, {
     variables: {
@@ -46,25 +81,39 @@ function PolicyEditDialog(props) {
         name: value?.name || defaultValue.name,
         description: value?.description || defaultValue.description,
         repeat: value?.repeat?.value ?? defaultValue.repeat.value,
+        ackTimeoutMinutes:
+          value?.ackTimeoutMinutes ?? defaultValue.ackTimeoutMinutes,
+        repeatDelayMinutes:
+          value?.repeatDelayMinutes ?? defaultValue.repeatDelayMinutes,
       },
     },
-    onCompleted: props.onClose,
+    onCompleted: () => {
+      if (fallbackUserID === defaultValue.fallbackUserID) {
+        props.onClose()
+        return
+      }
+      setFallbackMutation()
+    },
   })
-  const fieldErrs = fieldErrors(editDialogMutationStatus.error)
+  const mutationError =
+    editDialogMutationStatus.error || setFallbackMutationStatus.error
+  const mutationLoading =
+    editDialogMutationStatus.loading || setFallbackMutationStatus.loading
+  const fieldErrs = fieldErrors(mutationError)
 
   if (loading && !data?.escalationPolicy) return null
 
   return (
     <FormDialog
       title='Edit Escalation Policy'
-      loading={(!data && loading) || editDialogMutationStatus.loading}
-      errors={nonFieldErrors(editDialogMutationStatus.error)}
+      loading={(!data && loading) || mutationLoading}
+      errors={nonFieldErrors(mutationError)}
       onClose={props.onClose}
       onSubmit={() => editDialogMutation()}
       form={
         <PolicyForm
           errors={fieldErrs}
-          disabled={editDialogMutationStatus.loading}
+          disabled={mutationLoading}
           value={value || defaultValue}
           onChange={(value) => setValue(value)}
         />
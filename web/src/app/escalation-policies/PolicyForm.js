@@ -4,6 +4,8 @@ import Grid from '@material-ui/core/Grid'
 import TextField from '@material-ui/core/TextField'
 import { FormContainer, FormField } from '../forms'
 import MaterialSelect from '../selection/MaterialSelect'
+import NumberField from '../util/NumberField'
+import { UserSelect } from '../selection'
 
 function PolicyForm(props) {
   return (
@@ -56,6 +58,43 @@ function PolicyForm(props) {
             max={5}
           />
         </Grid>
+        <Grid item xs={12}>
+          <FormField
+            component={NumberField}
+            disabled={props.disabled}
+            fieldName='ackTimeoutMinutes'
+            fullWidth
+            hint='Revert acknowledged alerts to unacknowledged after this many minutes (0 to disable)'
+            label='Acknowledgement Timeout (minutes)'
+            name='ackTimeoutMinutes'
+            min={0}
+            max={10080}
+          />
+        </Grid>
+        <Grid item xs={12}>
+          <FormField
+            component={NumberField}
+            disabled={props.disabled}
+            fieldName='repeatDelayMinutes'
+            fullWidth
+            hint='Wait this many minutes after the last step before repeating'
+            label='Repeat Delay (minutes)'
+            name='repeatDelayMinutes'
+            min={0}
+            max={9000}
+          />
+        </Grid>
+        <Grid item xs={12}>
+          <FormField
+            component={UserSelect}
+            disabled={props.disabled}
+            fieldName='fallbackTarget'
+            fullWidth
+            hint='Notified once all repeats are exhausted without acknowledgement'
+            label='Fallback User'
+            name='fallbackUserID'
+          />
+        </Grid>
       </Grid>
     </FormContainer>
   )
@@ -69,11 +108,21 @@ PolicyForm.propTypes = {
       label: p.string.isRequired,
       value: p.string.isRequired,
     }).isRequired,
+    ackTimeoutMinutes: p.number,
+    repeatDelayMinutes: p.number,
+    fallbackUserID: p.string,
   }).isRequired,
 
   errors: p.arrayOf(
     p.shape({
-      field: p.oneOf(['name', 'description', 'repeat']).isRequired,
+      field: p.oneOf([
+        'name',
+        'description',
+        'repeat',
+        'ackTimeoutMinutes',
+        'repeatDelayMinutes',
+        'fallbackTarget',
+      ]).isRequired,
       message: p.string.isRequired,
     }),
   ),
@@ -34,7 +34,13 @@ const mutation = gql`
 
 function PolicyStepsCard(props) {
   const classes = useStyles()
-  const { escalationPolicyID, repeat, steps } = props
+  const {
+    escalationPolicyID,
+    repeat,
+    repeatDelayMinutes,
+    fallbackTarget,
+    steps,
+  } = props
 
   const width = useWidth()
 
@@ -147,6 +153,15 @@ function PolicyStepsCard(props) {
     else if (repeat === 1) text = 'Repeat once'
     else text = `Repeat ${repeat} times`
 
+    if (repeat !== 0 && repeatDelayMinutes) {
+      text += `, waiting ${repeatDelayMinutes} minute${
+        repeatDelayMinutes === 1 ? '' : 's'
+      } before each repeat`
+    }
+    if (fallbackTarget) {
+      text += `, then notify ${fallbackTarget.name}`
+    }
+
     return (
       <Typography variant='subtitle1' component='p'>
         {text}
@@ -256,6 +271,12 @@ function PolicyStepsCard(props) {
 PolicyStepsCard.propTypes = {
   escalationPolicyID: p.string.isRequired,
   repeat: p.number.isRequired, // # of times EP repeats escalation process
+  repeatDelayMinutes: p.number, // minutes to wait after the last step before repeating
+  fallbackTarget: p.shape({
+    id: p.string.isRequired,
+    name: p.string,
+    type: p.string.isRequired,
+  }), // notified once all repeats are exhausted
   steps: p.arrayOf(
     p.shape({
       id: p.string.isRequired,
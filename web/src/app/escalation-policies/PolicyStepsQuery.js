@@ -10,6 +10,12 @@ export const policyStepsQuery = gql`
     escalationPolicy(id: $id) {
       id
       repeat
+      repeatDelayMinutes
+      fallbackTarget {
+        id
+        name
+        type
+      }
       steps {
         id
         delayMinutes
@@ -42,6 +48,8 @@ function PolicyStepsQuery(props) {
     <PolicyStepsCard
       escalationPolicyID={props.escalationPolicyID}
       repeat={data.escalationPolicy.repeat}
+      repeatDelayMinutes={data.escalationPolicy.repeatDelayMinutes}
+      fallbackTarget={data.escalationPolicy.fallbackTarget}
       steps={data.escalationPolicy.steps || []}
     />
   )
@@ -11,6 +11,7 @@ import DetailsPage from '../details/DetailsPage'
 import RotationEditDialog from './RotationEditDialog'
 import RotationDeleteDialog from './RotationDeleteDialog'
 import RotationUserList from './RotationUserList'
+import RotationParticipantHoursList from './RotationParticipantHoursList'
 import RotationAddUserDialog from './RotationAddUserDialog'
 import { QuerySetFavoriteButton } from '../util/QuerySetFavoriteButton'
 import Spinner from '../loading/components/Spinner'
@@ -91,7 +92,12 @@ export default function RotationDetails({ rotationID }) {
         title={data.name}
         subheader={handoffSummary(data)}
         details={data.description}
-        pageContent={<RotationUserList rotationID={rotationID} />}
+        pageContent={
+          <React.Fragment>
+            <RotationUserList rotationID={rotationID} />
+            <RotationParticipantHoursList rotationID={rotationID} />
+          </React.Fragment>
+        }
         secondaryActions={[
           {
             label: 'Edit',
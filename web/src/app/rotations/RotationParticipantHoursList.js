@@ -0,0 +1,98 @@
+import React, { useMemo } from 'react'
+import p from 'prop-types'
+import { gql, useQuery } from '@apollo/client'
+import { DateTime } from 'luxon'
+import { Card, CardHeader, makeStyles } from '@material-ui/core'
+
+import FlatList from '../lists/FlatList'
+import { UserAvatar } from '../util/avatars'
+import { styles as globalStyles } from '../styles/materialStyles'
+import Spinner from '../loading/components/Spinner'
+import { GenericError } from '../error-pages'
+
+const query = gql`
+  query rotationParticipantHours(
+    $id: ID!
+    $start: ISOTimestamp!
+    $end: ISOTimestamp!
+  ) {
+    rotation(id: $id) {
+      id
+      participantHours(start: $start, end: $end) {
+        userID
+        user {
+          id
+          name
+        }
+        hours
+        shifts
+        skipCredit
+      }
+    }
+  }
+`
+
+const useStyles = makeStyles((theme) => {
+  const { cardHeader } = globalStyles(theme)
+
+  return {
+    cardHeader,
+  }
+})
+
+function RotationParticipantHoursList({ rotationID, days }) {
+  const classes = useStyles()
+  const [start, end] = useMemo(() => {
+    const end = DateTime.local().startOf('hour')
+    return [end.minus({ days }).toISO(), end.toISO()]
+  }, [days])
+
+  const { data, loading, error } = useQuery(query, {
+    variables: { id: rotationID, start, end },
+  })
+
+  if (loading && !data) return <Spinner />
+  if (error) return <GenericError error={error.message} />
+
+  const subText = (h) => {
+    let str = `${h.hours.toFixed(1)} hours over ${h.shifts} shift${
+      h.shifts === 1 ? '' : 's'
+    }`
+    if (h.skipCredit) {
+      str += `, owed ${h.skipCredit} skipped turn${
+        h.skipCredit === 1 ? '' : 's'
+      }`
+    }
+    return str
+  }
+
+  return (
+    <Card style={{ marginTop: '1em' }}>
+      <CardHeader
+        className={classes.cardHeader}
+        component='h3'
+        title={`On-Call Hours (last ${days} days)`}
+      />
+      <FlatList
+        data-cy='participant-hours'
+        emptyMessage='No on-call history for this rotation'
+        items={data.rotation.participantHours.map((h) => ({
+          title: h.user ? h.user.name : h.userID,
+          icon: <UserAvatar userID={h.userID} />,
+          subText: subText(h),
+        }))}
+      />
+    </Card>
+  )
+}
+
+RotationParticipantHoursList.propTypes = {
+  rotationID: p.string.isRequired,
+  days: p.number,
+}
+
+RotationParticipantHoursList.defaultProps = {
+  days: 30,
+}
+
+export default RotationParticipantHoursList
@@ -12,6 +12,7 @@ import OtherActions from '../util/OtherActions'
 import CountDown from '../util/CountDown'
 import RotationSetActiveDialog from './RotationSetActiveDialog'
 import RotationUserDeleteDialog from './RotationUserDeleteDialog'
+import RotationUserUnavailableDialog from './RotationUserUnavailableDialog'
 import { UserAvatar } from '../util/avatars'
 import { styles as globalStyles } from '../styles/materialStyles'
 import Spinner from '../loading/components/Spinner'
@@ -27,6 +28,10 @@ const query = gql`
       }
       activeUserIndex
       nextHandoffTimes
+      participants {
+        unavailableUntil
+        skipCredit
+      }
     }
   }
 `
@@ -37,6 +42,12 @@ const mutation = gql`
   }
 `
 
+const availableMutation = gql`
+  mutation ($input: SetRotationParticipantUnavailableInput!) {
+    setRotationParticipantUnavailable(input: $input)
+  }
+`
+
 const useStyles = makeStyles((theme) => {
   const { cardHeader } = globalStyles(theme)
 
@@ -49,6 +60,7 @@ function RotationUserList({ rotationID }) {
   const classes = useStyles()
   const [deleteIndex, setDeleteIndex] = useState(null)
   const [setActiveIndex, setSetActiveIndex] = useState(null)
+  const [unavailableIndex, setUnavailableIndex] = useState(null)
   const [lastSwap, setLastSwap] = useState([])
 
   const {
@@ -60,6 +72,9 @@ function RotationUserList({ rotationID }) {
   })
 
   const [updateRotation, { error: mError }] = useMutation(mutation)
+  const [setAvailable, { error: aError }] = useMutation(availableMutation, {
+    refetchQueries: ['rotationUsers'],
+  })
 
   // reset swap history on add/remove participant
   useEffect(() => {
@@ -68,10 +83,41 @@ function RotationUserList({ rotationID }) {
 
   if (qLoading && !data) return <Spinner />
   if (data && !data.rotation) return <ObjectNotFound type='rotation' />
-  if (qError || mError)
-    return <GenericError error={qError.message || mError.message} />
+  const err = qError || mError || aError
+  if (err) return <GenericError error={err.message} />
 
-  const { users, activeUserIndex, nextHandoffTimes } = data.rotation
+  const { users, activeUserIndex, nextHandoffTimes, participants } =
+    data.rotation
+
+  const isUnavailable = (index) => {
+    const until = participants[index]?.unavailableUntil
+    return Boolean(until) && DateTime.fromISO(until) > DateTime.local()
+  }
+
+  const subText = (index) => {
+    const notes = []
+    if (isUnavailable(index)) {
+      notes.push(
+        'Unavailable until ' +
+          DateTime.fromISO(participants[index].unavailableUntil).toLocaleString(
+            DateTime.DATETIME_MED,
+          ),
+      )
+    }
+    const credit = participants[index]?.skipCredit
+    if (credit) {
+      notes.push(`Owed ${credit} skipped turn${credit === 1 ? '' : 's'}`)
+    }
+    if (!notes.length) return handoff[index]
+    return (
+      <React.Fragment>
+        {handoff[index]}
+        {notes.map((note, i) => (
+          <div key={i}>{note}</div>
+        ))}
+      </React.Fragment>
+    )
+  }
 
   // duplicate first entry
   const _nextHandoffTimes = (nextHandoffTimes || [])
@@ -124,6 +170,14 @@ function RotationUserList({ rotationID }) {
           onClose={() => setDeleteIndex(null)}
         />
       )}
+      {unavailableIndex !== null && (
+        <RotationUserUnavailableDialog
+          rotationID={rotationID}
+          userIndex={unavailableIndex}
+          userName={users[unavailableIndex].name}
+          onClose={() => setUnavailableIndex(null)}
+        />
+      )}
       {setActiveIndex !== null && (
         <RotationSetActiveDialog
           rotationID={rotationID}
@@ -149,7 +203,7 @@ function RotationUserList({ rotationID }) {
             id: String(listIDs[index]),
             highlight: index === activeUserIndex,
             icon: <UserAvatar userID={u.id} />,
-            subText: handoff[index],
+            subText: subText(index),
             secondaryAction: (
               <OtherActions
                 actions={[
@@ -157,6 +211,20 @@ function RotationUserList({ rotationID }) {
                     label: 'Set Active',
                     onClick: () => setSetActiveIndex(index),
                   },
+                  isUnavailable(index)
+                    ? {
+                        label: 'Mark Available',
+                        onClick: () =>
+                          setAvailable({
+                            variables: {
+                              input: { rotationID, userIndex: index },
+                            },
+                          }),
+                      }
+                    : {
+                        label: 'Mark Unavailable',
+                        onClick: () => setUnavailableIndex(index),
+                      },
                   {
                     label: 'Remove',
                     onClick: () => setDeleteIndex(index),
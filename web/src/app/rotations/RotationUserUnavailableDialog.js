@@ -0,0 +1,68 @@
+import React, { useState } from 'react'
+import { gql, useMutation } from '@apollo/client'
+import p from 'prop-types'
+import { DateTime } from 'luxon'
+import { Grid } from '@material-ui/core'
+import FormDialog from '../dialogs/FormDialog'
+import { FormContainer, FormField } from '../forms'
+import { ISODateTimePicker } from '../util/ISOPickers'
+import { fieldErrors, nonFieldErrors } from '../util/errutil'
+
+const mutation = gql`
+  mutation ($input: SetRotationParticipantUnavailableInput!) {
+    setRotationParticipantUnavailable(input: $input)
+  }
+`
+
+const RotationUserUnavailableDialog = (props) => {
+  const { rotationID, userIndex, userName, onClose } = props
+  const [value, setValue] = useState({
+    until: DateTime.local().startOf('day').plus({ weeks: 1 }).toISO(),
+  })
+  const [mutate, { loading, error }] = useMutation(mutation, {
+    variables: {
+      input: { rotationID, userIndex, until: value.until },
+    },
+    refetchQueries: ['rotationUsers'],
+    onCompleted: onClose,
+  })
+
+  return (
+    <FormDialog
+      title={`Mark ${userName} Unavailable`}
+      subTitle='Their turns will be skipped until this time, and credited back once they are available.'
+      errors={nonFieldErrors(error)}
+      onClose={onClose}
+      onSubmit={() => mutate()}
+      form={
+        <FormContainer
+          value={value}
+          onChange={setValue}
+          errors={fieldErrors(error)}
+          disabled={loading}
+        >
+          <Grid container spacing={2}>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={ISODateTimePicker}
+                required
+                name='until'
+                label='Unavailable Until'
+              />
+            </Grid>
+          </Grid>
+        </FormContainer>
+      }
+    />
+  )
+}
+
+RotationUserUnavailableDialog.propTypes = {
+  rotationID: p.string.isRequired,
+  userIndex: p.number.isRequired,
+  userName: p.string.isRequired,
+  onClose: p.func.isRequired,
+}
+
+export default RotationUserUnavailableDialog
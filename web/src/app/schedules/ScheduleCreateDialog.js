@@ -24,6 +24,10 @@ export default class ScheduleCreateDialog extends React.PureComponent {
       description: '',
       timeZone: Intl.DateTimeFormat().resolvedOptions().timeZone,
       favorite: true,
+      gapNotifyDays: 0,
+      gapNotifyUserID: null,
+      timeOffStrategy: 'remove',
+      holidayCalendarID: null,
     },
   }
 
@@ -3,11 +3,13 @@ import p from 'prop-types'
 import { gql, useQuery } from '@apollo/client'
 import { Redirect } from 'react-router-dom'
 import _ from 'lodash'
-import { Edit, Delete } from '@material-ui/icons'
+import { Edit, Delete, Publish, GetApp } from '@material-ui/icons'
 
 import DetailsPage from '../details/DetailsPage'
 import ScheduleEditDialog from './ScheduleEditDialog'
 import ScheduleDeleteDialog from './ScheduleDeleteDialog'
+import ScheduleImportDialog from './ScheduleImportDialog'
+import ScheduleExportDialog from './ScheduleExportDialog'
 import ScheduleCalendarQuery from './ScheduleCalendarQuery'
 import { QuerySetFavoriteButton } from '../util/QuerySetFavoriteButton'
 import CalendarSubscribeButton from './calendar-subscribe/CalendarSubscribeButton'
@@ -27,6 +29,11 @@ const query = gql`
     schedule(id: $id) {
       ...ScheduleTitleQuery
       timeZone
+      notices {
+        type
+        message
+        details
+      }
     }
   }
 `
@@ -34,6 +41,8 @@ const query = gql`
 export default function ScheduleDetails({ scheduleID }) {
   const [showEdit, setShowEdit] = useState(false)
   const [showDelete, setShowDelete] = useState(false)
+  const [showImport, setShowImport] = useState(false)
+  const [showExport, setShowExport] = useState(false)
   const [configTempSchedule, setConfigTempSchedule] = useState(null)
   const [deleteTempSchedule, setDeleteTempSchedule] = useState(null)
 
@@ -73,6 +82,18 @@ export default function ScheduleDetails({ scheduleID }) {
           onClose={() => setShowDelete(false)}
         />
       )}
+      {showImport && (
+        <ScheduleImportDialog
+          scheduleID={scheduleID}
+          onClose={() => setShowImport(false)}
+        />
+      )}
+      {showExport && (
+        <ScheduleExportDialog
+          scheduleID={scheduleID}
+          onClose={() => setShowExport(false)}
+        />
+      )}
       {configTempSchedule && (
         <TempSchedDialog
           value={configTempSchedule === true ? null : configTempSchedule}
@@ -92,6 +113,7 @@ export default function ScheduleDetails({ scheduleID }) {
         title={data.name}
         subheader={`Time Zone: ${data.timeZone || 'Loading...'}`}
         details={data.description}
+        notices={data.notices}
         pageContent={
           <ScheduleCalendarQuery
             scheduleID={scheduleID}
@@ -112,6 +134,16 @@ export default function ScheduleDetails({ scheduleID }) {
             icon: <Edit />,
             handleOnClick: () => setShowEdit(true),
           },
+          {
+            label: 'Import Shifts',
+            icon: <Publish />,
+            handleOnClick: () => setShowImport(true),
+          },
+          {
+            label: 'Export Shifts',
+            icon: <GetApp />,
+            handleOnClick: () => setShowExport(true),
+          },
           {
             label: 'Delete',
             icon: <Delete />,
@@ -143,6 +175,11 @@ export default function ScheduleDetails({ scheduleID }) {
             url: 'shifts',
             subText: 'Review a list of past and future on-call shifts',
           },
+          {
+            label: 'Shift Requests',
+            url: 'shift-requests',
+            subText: 'Ask another user to cover or trade a shift',
+          },
         ]}
       />
     </React.Fragment>
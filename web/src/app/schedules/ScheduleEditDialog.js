@@ -14,6 +14,12 @@ const query = gql`
       name
       description
       timeZone
+      gapNotifyDays
+      gapNotifyUser {
+        id
+      }
+      timeOffStrategy
+      holidayCalendarID
     }
   }
 `
@@ -64,6 +70,13 @@ export default class ScheduleEditDialog extends React.PureComponent {
               input: {
                 id: this.props.scheduleID,
                 ...this.state.value,
+                // empty string clears the contact, null leaves it unchanged
+                gapNotifyUserID: this.state.value
+                  ? this.state.value.gapNotifyUserID || ''
+                  : null,
+                holidayCalendarID: this.state.value
+                  ? this.state.value.holidayCalendarID || ''
+                  : null,
               },
             },
           })
@@ -77,6 +90,12 @@ export default class ScheduleEditDialog extends React.PureComponent {
                 name: data.name,
                 description: data.description,
                 timeZone: data.timeZone,
+                gapNotifyDays: data.gapNotifyDays,
+                gapNotifyUserID: data.gapNotifyUser
+                  ? data.gapNotifyUser.id
+                  : null,
+                timeOffStrategy: data.timeOffStrategy,
+                holidayCalendarID: data.holidayCalendarID,
               }
             }
             onChange={(value) => this.setState({ value })}
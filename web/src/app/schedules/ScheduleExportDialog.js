@@ -0,0 +1,120 @@
+import React, { useEffect, useState } from 'react'
+import p from 'prop-types'
+import { gql, useLazyQuery } from '@apollo/client'
+import { DateTime } from 'luxon'
+import { Grid, MenuItem, TextField } from '@material-ui/core'
+
+import FormDialog from '../dialogs/FormDialog'
+import { FormContainer, FormField } from '../forms'
+import { ISODateTimePicker } from '../util/ISOPickers'
+import { fieldErrors, nonFieldErrors } from '../util/errutil'
+
+const query = gql`
+  query scheduleExportShifts($input: ExportScheduleShiftsInput!) {
+    exportScheduleShifts(input: $input) {
+      format
+      data
+    }
+  }
+`
+
+function download(name, type, data) {
+  const url = URL.createObjectURL(new window.Blob([data], { type }))
+  const a = document.createElement('a')
+  a.href = url
+  a.download = name
+  document.body.appendChild(a)
+  a.click()
+  a.remove()
+  URL.revokeObjectURL(url)
+}
+
+export default function ScheduleExportDialog({ scheduleID, onClose }) {
+  // default to the previous month, as that is what is typically needed for stipends
+  const thisMonth = DateTime.local().startOf('month')
+  const [value, setValue] = useState({
+    start: thisMonth.minus({ months: 1 }).toISO(),
+    end: thisMonth.toISO(),
+    format: 'csv',
+  })
+
+  const [exportShifts, { data, loading, error }] = useLazyQuery(query, {
+    fetchPolicy: 'network-only',
+    pollInterval: 0,
+  })
+
+  useEffect(() => {
+    if (!data) return
+
+    const { format, data: exported } = data.exportScheduleShifts
+    download(
+      `goalert-shifts.${format}`,
+      format === 'csv' ? 'text/csv' : 'application/json',
+      exported,
+    )
+    onClose()
+  }, [data])
+
+  return (
+    <FormDialog
+      title='Export Shifts'
+      subTitle='Download on-call shifts, including overrides and the rotation each shift came from.'
+      loading={loading}
+      errors={nonFieldErrors(error)}
+      onClose={onClose}
+      onSubmit={() =>
+        exportShifts({
+          variables: { input: { ...value, scheduleIDs: [scheduleID] } },
+        })
+      }
+      primaryActionLabel='Export'
+      form={
+        <FormContainer
+          value={value}
+          onChange={setValue}
+          errors={fieldErrors(error)}
+          disabled={loading}
+        >
+          <Grid container spacing={2}>
+            <Grid item xs={12} sm={6}>
+              <FormField
+                fullWidth
+                component={ISODateTimePicker}
+                required
+                name='start'
+                label='Start'
+              />
+            </Grid>
+            <Grid item xs={12} sm={6}>
+              <FormField
+                fullWidth
+                component={ISODateTimePicker}
+                required
+                name='end'
+                label='End'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                select
+                required
+                name='format'
+                label='Format'
+              >
+                <MenuItem value='csv'>CSV</MenuItem>
+                <MenuItem value='json'>JSON</MenuItem>
+              </FormField>
+            </Grid>
+          </Grid>
+        </FormContainer>
+      }
+    />
+  )
+}
+
+ScheduleExportDialog.propTypes = {
+  scheduleID: p.string.isRequired,
+  onClose: p.func.isRequired,
+}
@@ -1,8 +1,44 @@
 import React from 'react'
 import p from 'prop-types'
+import { gql, useQuery } from '@apollo/client'
 import { FormContainer, FormField } from '../forms'
-import { TextField, Grid } from '@material-ui/core'
-import { TimeZoneSelect } from '../selection'
+import { TextField, Grid, MenuItem } from '@material-ui/core'
+import { TimeZoneSelect, UserSelect } from '../selection'
+import NumberField from '../util/NumberField'
+
+const holidayCalendarsQuery = gql`
+  query {
+    holidayCalendars {
+      id
+      name
+    }
+  }
+`
+
+function HolidayCalendarField() {
+  const { data } = useQuery(holidayCalendarsQuery)
+  const calendars = data?.holidayCalendars ?? []
+
+  return (
+    <FormField
+      fullWidth
+      component={TextField}
+      select
+      name='holidayCalendarID'
+      label='Holiday Calendar'
+      hint='Rules can be turned off, or on, during holidays of this calendar'
+      mapValue={(value) => value || ''}
+      mapOnChangeValue={(value) => value || null}
+    >
+      <MenuItem value=''>None</MenuItem>
+      {calendars.map((c) => (
+        <MenuItem key={c.id} value={c.id}>
+          {c.name}
+        </MenuItem>
+      ))}
+    </FormField>
+  )
+}
 
 export default class ScheduleForm extends React.PureComponent {
   static propTypes = {
@@ -10,11 +46,23 @@ export default class ScheduleForm extends React.PureComponent {
       name: p.string.isRequired,
       description: p.string.isRequired,
       timeZone: p.string.isRequired,
+      gapNotifyDays: p.number,
+      gapNotifyUserID: p.string,
+      timeOffStrategy: p.oneOf(['remove', 'replace_next']),
+      holidayCalendarID: p.string,
     }).isRequired,
 
     errors: p.arrayOf(
       p.shape({
-        field: p.oneOf(['name', 'description', 'timeZone']).isRequired,
+        field: p.oneOf([
+          'name',
+          'description',
+          'timeZone',
+          'gapNotifyDays',
+          'gapNotifyUserID',
+          'timeOffStrategy',
+          'holidayCalendarID',
+        ]).isRequired,
         message: p.string.isRequired,
       }),
     ),
@@ -54,6 +102,44 @@ export default class ScheduleForm extends React.PureComponent {
               required
             />
           </Grid>
+          <Grid item xs={12}>
+            <FormField
+              fullWidth
+              component={NumberField}
+              name='gapNotifyDays'
+              label='Coverage Gap Notice (days)'
+              hint='Notify this many days before nobody is on-call (0 to disable)'
+              min={0}
+              max={30}
+            />
+          </Grid>
+          <Grid item xs={12}>
+            <FormField
+              fullWidth
+              component={UserSelect}
+              name='gapNotifyUserID'
+              label='Coverage Gap Contact'
+              hint='If unset, users that have favorited this schedule are notified'
+            />
+          </Grid>
+          <Grid item xs={12}>
+            <FormField
+              fullWidth
+              component={TextField}
+              select
+              name='timeOffStrategy'
+              label='Time Off'
+              hint='How users are taken off this schedule during their recorded time off'
+            >
+              <MenuItem value='remove'>Remove user</MenuItem>
+              <MenuItem value='replace_next'>
+                Replace with next rotation participant
+              </MenuItem>
+            </FormField>
+          </Grid>
+          <Grid item xs={12}>
+            <HolidayCalendarField />
+          </Grid>
         </Grid>
       </FormContainer>
     )
@@ -0,0 +1,211 @@
+import React, { useState } from 'react'
+import p from 'prop-types'
+import { gql, useMutation } from '@apollo/client'
+import { DateTime } from 'luxon'
+import { Button, Grid, MenuItem, TextField } from '@material-ui/core'
+
+import FormDialog from '../dialogs/FormDialog'
+import { FormContainer, FormField } from '../forms'
+import FlatList from '../lists/FlatList'
+import { UserAvatar } from '../util/avatars'
+import { fieldErrors, nonFieldErrors } from '../util/errutil'
+
+const mutation = gql`
+  mutation ($input: ImportScheduleInput!) {
+    importSchedule(input: $input) {
+      shifts {
+        line
+        userID
+        user {
+          id
+          name
+        }
+        start
+        end
+      }
+      conflicts {
+        line
+        email
+        message
+      }
+      skipped
+      applied
+    }
+  }
+`
+
+const fmtTime = (iso) =>
+  DateTime.fromISO(iso).toLocaleString(DateTime.DATETIME_MED)
+
+function ScheduleImportPreview({ result }) {
+  const items = []
+  if (result.conflicts.length) {
+    items.push({ subHeader: 'Conflicts' })
+    result.conflicts.forEach((c) =>
+      items.push({
+        title: c.line ? `Line ${c.line}: ${c.message}` : c.message,
+        subText: c.email,
+      }),
+    )
+  }
+
+  items.push({ subHeader: 'Shifts' })
+  result.shifts.forEach((s) =>
+    items.push({
+      title: s.user ? s.user.name : s.userID,
+      icon: <UserAvatar userID={s.userID} />,
+      subText: `${fmtTime(s.start)} to ${fmtTime(s.end)}`,
+    }),
+  )
+
+  let note = `${result.shifts.length} shift(s) will be imported`
+  if (result.skipped) {
+    note += `, ${result.skipped} already ended and will be skipped`
+  }
+  if (result.conflicts.length) {
+    note = 'Resolve all conflicts before importing'
+  }
+
+  return (
+    <FlatList
+      headerNote={note}
+      emptyMessage='No shifts found'
+      items={items}
+    />
+  )
+}
+
+ScheduleImportPreview.propTypes = {
+  result: p.object.isRequired,
+}
+
+export default function ScheduleImportDialog({ scheduleID, onClose }) {
+  const [value, setValue] = useState({
+    format: 'csv',
+    mode: 'temporary_schedule',
+    data: '',
+  })
+  const [preview, setPreview] = useState(null)
+
+  const [importSchedule, { loading, error }] = useMutation(mutation, {
+    refetchQueries: ['scheduleCalendarShifts'],
+    awaitRefetchQueries: true,
+  })
+
+  const input = (dryRun) => ({
+    variables: { input: { ...value, scheduleID, dryRun } },
+  })
+
+  const onNext = () =>
+    importSchedule(input(true)).then((res) =>
+      setPreview(res.data.importSchedule),
+    )
+
+  const onSubmit = () =>
+    importSchedule(input(false)).then((res) => {
+      if (res.data.importSchedule.applied) {
+        onClose()
+        return
+      }
+
+      // conflicts were found since the preview
+      setPreview(res.data.importSchedule)
+    })
+
+  const onFile = (e) => {
+    const file = e.target.files[0]
+    if (!file) return
+    file.text().then((data) =>
+      setValue({
+        ...value,
+        data,
+        format: file.name.toLowerCase().endsWith('.ics') ? 'ical' : 'csv',
+      }),
+    )
+  }
+
+  const form = (
+    <FormContainer
+      value={value}
+      onChange={setValue}
+      errors={fieldErrors(error)}
+      disabled={loading}
+    >
+      <Grid container spacing={2}>
+        <Grid item xs={12} sm={6}>
+          <FormField
+            fullWidth
+            component={TextField}
+            select
+            required
+            name='format'
+            label='Format'
+          >
+            <MenuItem value='csv'>CSV (email, start, end)</MenuItem>
+            <MenuItem value='ical'>iCalendar</MenuItem>
+          </FormField>
+        </Grid>
+        <Grid item xs={12} sm={6}>
+          <FormField
+            fullWidth
+            component={TextField}
+            select
+            required
+            name='mode'
+            label='Import As'
+          >
+            <MenuItem value='temporary_schedule'>Temporary schedule</MenuItem>
+            <MenuItem value='overrides'>Overrides</MenuItem>
+          </FormField>
+        </Grid>
+        <Grid item xs={12}>
+          <FormField
+            fullWidth
+            component={TextField}
+            required
+            multiline
+            rows={8}
+            name='data'
+            label='Data'
+            hint='Paste shifts here, or choose a file'
+          />
+        </Grid>
+        <Grid item xs={12}>
+          <Button variant='outlined' component='label'>
+            Choose File
+            <input
+              type='file'
+              accept='.csv,.ics,text/csv,text/calendar'
+              hidden
+              onChange={onFile}
+            />
+          </Button>
+        </Grid>
+      </Grid>
+    </FormContainer>
+  )
+
+  return (
+    <FormDialog
+      title='Import Shifts'
+      subTitle={
+        preview
+          ? 'Review the shifts below before importing.'
+          : 'Import fixed shifts from a spreadsheet or another calendar.'
+      }
+      loading={loading}
+      errors={nonFieldErrors(error)}
+      onClose={onClose}
+      onNext={preview ? null : onNext}
+      onBack={preview ? () => setPreview(null) : null}
+      onSubmit={onSubmit}
+      primaryActionLabel={preview ? 'Import' : null}
+      form={preview ? <ScheduleImportPreview result={preview} /> : form}
+    />
+  )
+}
+
+ScheduleImportDialog.propTypes = {
+  scheduleID: p.string.isRequired,
+  onClose: p.func.isRequired,
+}
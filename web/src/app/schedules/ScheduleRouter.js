@@ -6,6 +6,7 @@ import ScheduleDetails from './ScheduleDetails'
 import ScheduleOverrideList from './ScheduleOverrideList'
 import ScheduleAssignedToList from './ScheduleAssignedToList'
 import ScheduleShiftList from './ScheduleShiftList'
+import ScheduleShiftRequestList from './ScheduleShiftRequestList'
 
 import { PageNotFound } from '../error-pages/Errors'
 import ScheduleRuleList from './ScheduleRuleList'
@@ -82,6 +83,12 @@ export default function ScheduleRouter() {
           <ScheduleShiftList scheduleID={match.params.scheduleID} />
         )}
       />
+      <Route
+        path='/schedules/:scheduleID/shift-requests'
+        render={({ match }) => (
+          <ScheduleShiftRequestList scheduleID={match.params.scheduleID} />
+        )}
+      />
 
       <Route component={PageNotFound} />
     </Switch>
@@ -32,6 +32,7 @@ export default function ScheduleRuleCreateDialog(props) {
         start: DateTime.local().startOf('day').toUTC().toISO(),
         end: DateTime.local().plus({ day: 1 }).startOf('day').toUTC().toISO(),
         weekdayFilter: [true, true, true, true, true, true, true],
+        holidayMode: 'ignore',
       },
     ],
   })
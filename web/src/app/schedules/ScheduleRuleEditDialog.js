@@ -20,6 +20,7 @@ const query = gql`
           start
           end
           weekdayFilter
+          holidayMode
         }
       }
     }
@@ -80,6 +81,7 @@ export default class ScheduleRuleEditDialog extends React.Component {
       rules: data.rules.map((r) => ({
         id: r.id,
         weekdayFilter: r.weekdayFilter,
+        holidayMode: r.holidayMode,
         start: gqlClockTimeToISO(r.start, zone),
         end: gqlClockTimeToISO(r.end, zone),
       })),
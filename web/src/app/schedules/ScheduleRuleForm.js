@@ -37,6 +37,12 @@ const days = [
   'Saturday',
 ]
 
+const holidayModes = {
+  ignore: 'As usual',
+  exclude: 'Off',
+  include: 'All day',
+}
+
 const renderDaysValue = (value) => {
   const parts = []
   let start = ''
@@ -85,6 +91,10 @@ const styles = () => {
       minWidth: '6em',
       paddingRight: '1em',
     },
+    holidayMode: {
+      padding: 0,
+      minWidth: '6em',
+    },
     tzNote: {
       display: 'flex',
       alignItems: 'center',
@@ -118,6 +128,7 @@ export default class ScheduleRuleForm extends React.PureComponent {
           end: p.string.isRequired,
 
           weekdayFilter: p.arrayOf(p.bool).isRequired,
+          holidayMode: p.oneOf(['ignore', 'exclude', 'include']),
         }),
       ).isRequired,
     }).isRequired,
@@ -185,6 +196,9 @@ export default class ScheduleRuleForm extends React.PureComponent {
                   <Hidden mdUp>
                     <TableCell className={classes.dayFilter}>Days</TableCell>
                   </Hidden>
+                  <TableCell className={classes.holidayMode}>
+                    Holidays
+                  </TableCell>
                   <TableCell padding='none'>
                     <IconButton
                       aria-label='Add rule'
@@ -202,6 +216,7 @@ export default class ScheduleRuleForm extends React.PureComponent {
                               .toUTC()
                               .toISO(),
                             weekdayFilter: Array(days.length).fill(true),
+                            holidayMode: 'ignore',
                           }),
                         })
                       }
@@ -290,6 +305,24 @@ export default class ScheduleRuleForm extends React.PureComponent {
             </FormField>
           </TableCell>
         </Hidden>
+        <TableCell className={classes.holidayMode}>
+          <FormField
+            fullWidth
+            component={TextField}
+            select
+            noError
+            label=''
+            name={`rules[${idx}].holidayMode`}
+            aria-label='Holiday Mode'
+            mapValue={(value) => value || 'ignore'}
+          >
+            {Object.entries(holidayModes).map(([mode, label]) => (
+              <MenuItem value={mode} key={mode}>
+                {label}
+              </MenuItem>
+            ))}
+          </FormField>
+        </TableCell>
         <TableCell padding='none'>
           {this.props.value.rules.length > 1 && (
             <IconButton
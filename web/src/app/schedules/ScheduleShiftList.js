@@ -13,6 +13,7 @@ import {
   MenuItem,
   makeStyles,
 } from '@material-ui/core'
+import { Warning as WarningIcon } from '@material-ui/icons'
 import { UserAvatar } from '../util/avatars'
 import FilterContainer from '../util/FilterContainer'
 import { UserSelect } from '../selection'
@@ -36,6 +37,10 @@ const query = gql`
         end
         truncated
       }
+      coverageGaps(start: $start, end: $end) {
+        start
+        end
+      }
     }
   }
 `
@@ -119,6 +124,23 @@ function ScheduleShiftList({ scheduleID }) {
         ),
       }))
 
+    // gaps are only meaningful when all users are shown
+    if (!userFilter.length) {
+      shifts = shifts
+        .concat(
+          (data?.schedule?.coverageGaps ?? []).map((g) => ({
+            isGap: true,
+            start: DateTime.fromISO(g.start, { zone }),
+            end: DateTime.fromISO(g.end, { zone }),
+            interval: Interval.fromDateTimes(
+              DateTime.fromISO(g.start, { zone }),
+              DateTime.fromISO(g.end, { zone }),
+            ),
+          })),
+        )
+        .sort((a, b) => a.start - b.start)
+    }
+
     if (activeOnly) {
       const now = DateTime.fromObject({ zone })
       shifts = shifts.filter((s) => s.interval.contains(now))
@@ -162,6 +184,14 @@ function ScheduleShiftList({ scheduleID }) {
           // shift starts and continues on for the rest of the day
           shiftDetails = `Active after ${startTime}`
         }
+        if (s.isGap) {
+          result.push({
+            title: 'No one on-call',
+            subText: shiftDetails,
+            icon: <WarningIcon color='error' />,
+          })
+          return
+        }
         result.push({
           title: s.userName,
           subText: shiftDetails,
@@ -0,0 +1,63 @@
+import React, { useState } from 'react'
+import { gql, useMutation } from '@apollo/client'
+import p from 'prop-types'
+import { DateTime } from 'luxon'
+
+import FormDialog from '../dialogs/FormDialog'
+import ScheduleShiftRequestForm from './ScheduleShiftRequestForm'
+import { fieldErrors, nonFieldErrors } from '../util/errutil'
+
+const mutation = gql`
+  mutation ($input: CreateShiftRequestInput!) {
+    createShiftRequest(input: $input) {
+      id
+    }
+  }
+`
+
+export default function ScheduleShiftRequestCreateDialog(props) {
+  const [value, setValue] = useState({
+    userID: '',
+    start: DateTime.local().startOf('hour').toISO(),
+    end: DateTime.local().startOf('hour').plus({ hours: 8 }).toISO(),
+    swapStart: null,
+    swapEnd: null,
+    note: '',
+  })
+
+  const [mutate, { loading, error }] = useMutation(mutation, {
+    variables: {
+      input: {
+        ...value,
+        swapStart: value.swapStart || null,
+        swapEnd: value.swapEnd || null,
+        scheduleID: props.scheduleID,
+      },
+    },
+    refetchQueries: ['scheduleShiftRequests'],
+    onCompleted: props.onClose,
+  })
+
+  return (
+    <FormDialog
+      onClose={props.onClose}
+      title='Request Shift Coverage'
+      subTitle='The selected user will be notified, and your shift will be replaced with theirs if they accept.'
+      errors={nonFieldErrors(error)}
+      onSubmit={() => mutate()}
+      form={
+        <ScheduleShiftRequestForm
+          disabled={loading}
+          errors={fieldErrors(error)}
+          value={value}
+          onChange={(newValue) => setValue(newValue)}
+        />
+      }
+    />
+  )
+}
+
+ScheduleShiftRequestCreateDialog.propTypes = {
+  scheduleID: p.string.isRequired,
+  onClose: p.func,
+}
@@ -0,0 +1,94 @@
+import React from 'react'
+import p from 'prop-types'
+import { FormContainer, FormField } from '../forms'
+import { Grid, TextField, Typography } from '@material-ui/core'
+import { UserSelect } from '../selection'
+import { ISODateTimePicker } from '../util/ISOPickers'
+
+export default function ScheduleShiftRequestForm(props) {
+  return (
+    <FormContainer optionalLabels {...props}>
+      <Grid container spacing={2}>
+        <Grid item xs={12}>
+          <FormField
+            fullWidth
+            component={UserSelect}
+            required
+            name='userID'
+            label='Ask User'
+          />
+        </Grid>
+        <Grid item xs={12} sm={6}>
+          <FormField
+            fullWidth
+            component={ISODateTimePicker}
+            required
+            name='start'
+            label='Shift Start'
+          />
+        </Grid>
+        <Grid item xs={12} sm={6}>
+          <FormField
+            fullWidth
+            component={ISODateTimePicker}
+            required
+            name='end'
+            label='Shift End'
+          />
+        </Grid>
+        <Grid item xs={12}>
+          <Typography color='textSecondary' style={{ fontStyle: 'italic' }}>
+            To trade shifts, set the shift of theirs you will take in return.
+          </Typography>
+        </Grid>
+        <Grid item xs={12} sm={6}>
+          <FormField
+            fullWidth
+            component={ISODateTimePicker}
+            name='swapStart'
+            label='Their Shift Start'
+          />
+        </Grid>
+        <Grid item xs={12} sm={6}>
+          <FormField
+            fullWidth
+            component={ISODateTimePicker}
+            name='swapEnd'
+            label='Their Shift End'
+          />
+        </Grid>
+        <Grid item xs={12}>
+          <FormField fullWidth component={TextField} name='note' multiline />
+        </Grid>
+      </Grid>
+    </FormContainer>
+  )
+}
+
+ScheduleShiftRequestForm.propTypes = {
+  value: p.shape({
+    userID: p.string.isRequired,
+    start: p.string.isRequired,
+    end: p.string.isRequired,
+    swapStart: p.string,
+    swapEnd: p.string,
+    note: p.string.isRequired,
+  }).isRequired,
+
+  disabled: p.bool.isRequired,
+  errors: p.arrayOf(
+    p.shape({
+      field: p.oneOf([
+        'userID',
+        'start',
+        'end',
+        'swapStart',
+        'swapEnd',
+        'note',
+      ]).isRequired,
+      message: p.string.isRequired,
+    }),
+  ),
+
+  onChange: p.func.isRequired,
+}
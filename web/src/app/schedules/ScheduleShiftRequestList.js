@@ -0,0 +1,164 @@
+import React, { useState } from 'react'
+import p from 'prop-types'
+import { gql, useMutation, useQuery } from '@apollo/client'
+import { Button, Card, FormControlLabel, Grid, Switch } from '@material-ui/core'
+import FlatList from '../lists/FlatList'
+import CreateFAB from '../lists/CreateFAB'
+import { UserAvatar } from '../util/avatars'
+import OtherActions from '../util/OtherActions'
+import { useURLParam } from '../actions'
+import { useSessionInfo } from '../util/RequireConfig'
+import { GenericError } from '../error-pages'
+import Spinner from '../loading/components/Spinner'
+import ScheduleShiftRequestCreateDialog from './ScheduleShiftRequestCreateDialog'
+import { formatOverrideTime } from './util'
+
+// the query name `scheduleShiftRequests` is used for refetch queries
+const query = gql`
+  query scheduleShiftRequests($input: ShiftRequestSearchOptions) {
+    shiftRequests(input: $input) {
+      id
+      start
+      end
+      swapStart
+      swapEnd
+      note
+      status
+      requester {
+        id
+        name
+      }
+      user {
+        id
+        name
+      }
+    }
+  }
+`
+
+const respondMutation = gql`
+  mutation ($input: RespondShiftRequestInput!) {
+    respondShiftRequest(input: $input)
+  }
+`
+
+const cancelMutation = gql`
+  mutation ($id: ID!) {
+    cancelShiftRequest(id: $id)
+  }
+`
+
+export default function ScheduleShiftRequestList(props) {
+  const [create, setCreate] = useState(false)
+  const [showAll, setShowAll] = useURLParam('showAll', false)
+  const [zone] = useURLParam('tz', 'local')
+  const { userID, isAdmin } = useSessionInfo()
+
+  const { data, loading, error } = useQuery(query, {
+    variables: {
+      input: {
+        scheduleID: props.scheduleID,
+        status: showAll ? null : ['pending'],
+      },
+    },
+  })
+  const mutOpts = { refetchQueries: ['scheduleShiftRequests'] }
+  const [respond, respondStatus] = useMutation(respondMutation, mutOpts)
+  const [cancel, cancelStatus] = useMutation(cancelMutation, mutOpts)
+
+  if (loading && !data) return <Spinner />
+  if (error) return <GenericError error={error.message} />
+  const mutErr = respondStatus.error || cancelStatus.error
+
+  const subText = (r) => {
+    let str = `Cover ${formatOverrideTime(r.start, r.end, zone)}`
+    if (r.swapStart) {
+      str += ` in exchange for ${formatOverrideTime(
+        r.swapStart,
+        r.swapEnd,
+        zone,
+      )}`
+    }
+    if (r.note) str += ` (${r.note})`
+    if (r.status !== 'pending') str += ` — ${r.status}`
+    return str
+  }
+
+  const actions = (r) => {
+    if (r.status !== 'pending') return null
+    if (r.user.id === userID || isAdmin) {
+      const opts = (accept) => ({
+        variables: { input: { id: r.id, accept } },
+      })
+      return (
+        <React.Fragment>
+          <Button color='primary' onClick={() => respond(opts(true))}>
+            Accept
+          </Button>
+          <Button onClick={() => respond(opts(false))}>Decline</Button>
+        </React.Fragment>
+      )
+    }
+    if (r.requester.id === userID) {
+      return (
+        <OtherActions
+          actions={[
+            {
+              label: 'Cancel Request',
+              onClick: () => cancel({ variables: { id: r.id } }),
+            },
+          ]}
+        />
+      )
+    }
+    return null
+  }
+
+  return (
+    <React.Fragment>
+      <Grid container spacing={2}>
+        <Grid item xs={12}>
+          <FormControlLabel
+            control={
+              <Switch
+                checked={showAll}
+                onChange={(e) => setShowAll(e.target.checked)}
+                value='showAll'
+              />
+            }
+            label='Show answered and cancelled requests'
+          />
+        </Grid>
+        {mutErr && (
+          <Grid item xs={12}>
+            <GenericError error={mutErr.message} />
+          </Grid>
+        )}
+        <Grid item xs={12}>
+          <Card style={{ width: '100%' }}>
+            <FlatList
+              items={data.shiftRequests.map((r) => ({
+                title: `${r.requester.name} → ${r.user.name}`,
+                subText: subText(r),
+                icon: <UserAvatar userID={r.requester.id} />,
+                secondaryAction: actions(r),
+              }))}
+              emptyMessage='No shift requests found.'
+            />
+          </Card>
+        </Grid>
+      </Grid>
+      <CreateFAB onClick={() => setCreate(true)} title='Request Coverage' />
+      {create && (
+        <ScheduleShiftRequestCreateDialog
+          scheduleID={props.scheduleID}
+          onClose={() => setCreate(false)}
+        />
+      )}
+    </React.Fragment>
+  )
+}
+
+ScheduleShiftRequestList.propTypes = {
+  scheduleID: p.string.isRequired,
+}
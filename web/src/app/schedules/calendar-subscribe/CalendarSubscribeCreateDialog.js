@@ -51,17 +51,25 @@ export default function CalendarSubscribeCreateDialog(props) {
 
   const [value, setValue] = useState({
     name: '',
+    scope: 'my_shifts',
     scheduleID: props.scheduleID || null,
+    serviceID: null,
     reminderMinutes: [],
+    lookaheadDays: 30,
+    includeDetails: false,
   })
 
   const [createSubscription, status] = useMutation(mutation, {
     variables: {
       input: {
+        scope: value.scope,
         scheduleID: value.scheduleID,
+        serviceID: value.serviceID,
         name: value.name,
         reminderMinutes: [0], // default reminder at shift start time
         disabled: false,
+        lookaheadDays: value.lookaheadDays,
+        includeDetails: value.includeDetails,
       },
     },
   })
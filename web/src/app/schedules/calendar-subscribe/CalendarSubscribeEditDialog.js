@@ -13,7 +13,11 @@ const query = gql`
     userCalendarSubscription(id: $id) {
       id
       name
+      scope
       scheduleID
+      serviceID
+      lookaheadDays
+      includeDetails
     }
   }
 `
@@ -30,7 +34,11 @@ export function CalendarSubscribeEditDialogContent(props) {
   // set default values from retrieved data
   const [value, setValue] = useState({
     name: _.get(data, 'name', ''),
+    scope: _.get(data, 'scope', 'my_shifts'),
     scheduleID: _.get(data, 'scheduleID', null),
+    serviceID: _.get(data, 'serviceID', null),
+    lookaheadDays: _.get(data, 'lookaheadDays', 30),
+    includeDetails: _.get(data, 'includeDetails', false),
   })
 
   // setup the mutation
@@ -39,6 +47,8 @@ export function CalendarSubscribeEditDialogContent(props) {
       input: {
         id: props.data.id,
         name: value.name,
+        lookaheadDays: value.lookaheadDays,
+        includeDetails: value.includeDetails,
       },
     },
     onCompleted: () => props.onClose(),
@@ -57,6 +67,7 @@ export function CalendarSubscribeEditDialogContent(props) {
           onChange={setValue}
           value={value}
           scheduleReadOnly
+          scopeReadOnly
         />
       }
     />
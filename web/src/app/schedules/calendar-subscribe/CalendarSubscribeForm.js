@@ -1,10 +1,12 @@
 import React from 'react'
 import { PropTypes as p } from 'prop-types'
 import { FormContainer, FormField } from '../../forms'
-import { Grid, TextField } from '@material-ui/core'
-import { ScheduleSelect } from '../../selection'
+import { Grid, MenuItem, Switch, TextField } from '@material-ui/core'
+import { ScheduleSelect, ServiceSelect } from '../../selection'
+import NumberField from '../../util/NumberField'
 
 export default function CalendarSubscribeForm(props) {
+  const scope = props.value.scope || 'my_shifts'
   return (
     <FormContainer
       disabled={props.loading}
@@ -27,12 +29,64 @@ export default function CalendarSubscribeForm(props) {
         </Grid>
         <Grid item xs={12}>
           <FormField
-            component={ScheduleSelect}
-            disabled={props.scheduleReadOnly}
             fullWidth
-            required
-            label='Schedule'
-            name='scheduleID'
+            component={TextField}
+            select
+            disabled={props.scopeReadOnly}
+            name='scope'
+            label='Include'
+          >
+            <MenuItem value='my_shifts'>My shifts on a schedule</MenuItem>
+            <MenuItem value='schedule'>Everyone's shifts on a schedule</MenuItem>
+            <MenuItem value='user'>My shifts on all schedules</MenuItem>
+            <MenuItem value='service'>
+              Everyone's shifts for a service's escalation policy
+            </MenuItem>
+          </FormField>
+        </Grid>
+        {(scope === 'my_shifts' || scope === 'schedule') && (
+          <Grid item xs={12}>
+            <FormField
+              component={ScheduleSelect}
+              disabled={props.scheduleReadOnly}
+              fullWidth
+              required
+              label='Schedule'
+              name='scheduleID'
+            />
+          </Grid>
+        )}
+        {scope === 'service' && (
+          <Grid item xs={12}>
+            <FormField
+              component={ServiceSelect}
+              disabled={props.scopeReadOnly}
+              fullWidth
+              required
+              label='Service'
+              name='serviceID'
+            />
+          </Grid>
+        )}
+        <Grid item xs={12} sm={6}>
+          <FormField
+            fullWidth
+            component={NumberField}
+            name='lookaheadDays'
+            label='Lookahead (days)'
+            hint='How far ahead shifts are included'
+            min={1}
+            max={365}
+          />
+        </Grid>
+        <Grid item xs={12} sm={6}>
+          <FormField
+            component={Switch}
+            checkbox
+            formLabel
+            name='includeDetails'
+            label='Include Details'
+            hint='Add the on-call user as an attendee, and user and schedule names to each event'
           />
         </Grid>
       </Grid>
@@ -45,9 +99,14 @@ CalendarSubscribeForm.propTypes = {
   loading: p.bool,
   onChange: p.func.isRequired,
   scheduleReadOnly: p.bool,
+  scopeReadOnly: p.bool,
   value: p.shape({
     scheduleID: p.string,
+    serviceID: p.string,
+    scope: p.oneOf(['my_shifts', 'schedule', 'user', 'service']),
     name: p.string,
     reminderMinutes: p.array,
+    lookaheadDays: p.number,
+    includeDetails: p.bool,
   }).isRequired,
 }
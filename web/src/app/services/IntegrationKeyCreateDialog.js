@@ -39,7 +39,11 @@ export default class IntegrationKeyCreateDialog extends React.PureComponent {
   }
 
   state = {
-    value: { name: '', type: 'generic' },
+    value: {
+      name: '',
+      type: 'generic',
+      jsonMapping: { summary: '', details: '', dedup: '', action: '' },
+    },
     errors: [],
   }
 
@@ -82,9 +86,14 @@ export default class IntegrationKeyCreateDialog extends React.PureComponent {
         errors={nonFieldErrors(error)}
         onClose={this.props.onClose}
         onSubmit={() => {
+          const { jsonMapping, ...value } = this.state.value
           return commit({
             variables: {
-              input: { ...this.state.value, serviceID: this.props.serviceID },
+              input: {
+                ...value,
+                jsonMapping: value.type === 'genericJSON' ? jsonMapping : null,
+                serviceID: this.props.serviceID,
+              },
             },
           })
         }}
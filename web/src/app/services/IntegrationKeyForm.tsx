@@ -9,7 +9,10 @@ import InputAdornment from '@material-ui/core/InputAdornment'
 import { FormContainer, FormField } from '../forms'
 import { Config } from '../util/RequireConfig'
 import AppLink from '../util/AppLink'
-import { IntegrationKeyType } from '../../schema'
+import {
+  IntegrationKeyJSONMappingInput,
+  IntegrationKeyType,
+} from '../../schema'
 
 const useStyles = makeStyles((theme) => ({
   infoIcon: {
@@ -20,13 +23,14 @@ const useStyles = makeStyles((theme) => ({
 interface Value {
   name: string
   type: IntegrationKeyType
+  jsonMapping?: IntegrationKeyJSONMappingInput
 }
 
 interface IntegrationKeyFormProps {
   value: Value
 
   errors: {
-    field: 'name' | 'type'
+    field: string
     message: string
   }[]
 
@@ -76,15 +80,59 @@ export default function IntegrationKeyForm(
                   <MenuItem value='email'>Email</MenuItem>
                 )}
                 <MenuItem value='generic'>Generic API</MenuItem>
+                <MenuItem value='genericJSON'>Generic JSON Webhook</MenuItem>
                 <MenuItem value='grafana'>Grafana</MenuItem>
                 <MenuItem value='site24x7'>Site24x7</MenuItem>
                 <MenuItem value='prometheusAlertmanager'>
                   Prometheus Alertmanager
                 </MenuItem>
+                <MenuItem value='datadog'>Datadog</MenuItem>
+                <MenuItem value='newRelic'>New Relic</MenuItem>
+                <MenuItem value='awsSNS'>AWS SNS</MenuItem>
               </FormField>
             )}
           </Config>
         </Grid>
+        {props.value.type === 'genericJSON' && (
+          <React.Fragment>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Summary Expression'
+                name='jsonMapping.summary'
+                hint='JMESPath expression for the alert summary (e.g. alert.title)'
+                required
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Details Expression'
+                name='jsonMapping.details'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Dedup Expression'
+                name='jsonMapping.dedup'
+                hint='Payloads with the same value update the same alert'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Action Expression'
+                name='jsonMapping.action'
+                hint='A result of close, closed, resolve, resolved, or ok will close the alert'
+              />
+            </Grid>
+          </React.Fragment>
+        )}
       </Grid>
     </FormContainer>
   )
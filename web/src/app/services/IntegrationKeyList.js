@@ -94,10 +94,14 @@ export default function IntegrationKeyList(props) {
 
   const typeLabels = {
     generic: 'Generic API Key',
+    genericJSON: 'Generic JSON Webhook URL',
     grafana: 'Grafana Webhook URL',
     site24x7: 'Site24x7 Webhook URL',
     email: 'Email Address',
     prometheusAlertmanager: 'Alertmanager Webhook URL',
+    datadog: 'Datadog Webhook URL',
+    newRelic: 'New Relic Webhook URL',
+    awsSNS: 'AWS SNS Subscription URL',
   }
   if (loading && !data) return <Spinner />
   if (error) return <GenericError error={error.message} />
@@ -21,11 +21,15 @@ const createMutation = gql`
   }
 `
 
-function inputVars({ name, description, escalationPolicyID }, attempt = 0) {
+function inputVars(
+  { name, description, escalationPolicyID, autoResolveMinutes },
+  attempt = 0,
+) {
   const vars = {
     name,
     description,
     escalationPolicyID,
+    autoResolveMinutes,
     favorite: true,
   }
   if (!vars.escalationPolicyID) {
@@ -54,6 +58,7 @@ export default function ServiceCreateDialog(props) {
     name: '',
     description: '',
     escalationPolicyID: '',
+    autoResolveMinutes: 0,
   })
 
   const [createKey, createKeyStatus] = useMutation(createMutation)
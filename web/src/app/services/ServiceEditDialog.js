@@ -15,6 +15,7 @@ const query = gql`
       id
       name
       description
+      autoResolveMinutes
       ep: escalationPolicy {
         id
         name
@@ -40,7 +41,7 @@ export default function ServiceEditDialog({ serviceID, onClose }) {
 
   const defaults = {
     // default value is the service name & description with the ep.id
-    ..._.chain(data).get('service').pick(['name', 'description']).value(),
+    ..._.chain(data).get('service').pick(['name', 'description', 'autoResolveMinutes']).value(),
     escalationPolicyID: _.get(data, 'service.ep.id'),
   }
 
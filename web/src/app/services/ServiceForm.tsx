@@ -3,18 +3,20 @@ import Grid from '@material-ui/core/Grid'
 import TextField from '@material-ui/core/TextField'
 import { EscalationPolicySelect } from '../selection/EscalationPolicySelect'
 import { FormContainer, FormField } from '../forms'
+import NumberField from '../util/NumberField'
 
 interface Value {
   name: string
   description: string
   escalationPolicyID: string
+  autoResolveMinutes: number
 }
 
 interface ServiceFormProps {
   value: Value
 
   errors: {
-    field: 'name' | 'description' | 'escalationPolicyID'
+    field: 'name' | 'description' | 'escalationPolicyID' | 'autoResolveMinutes'
     message: string
   }[]
 
@@ -58,6 +60,17 @@ export default function ServiceForm(props: ServiceFormProps): JSX.Element {
             component={EscalationPolicySelect}
           />
         </Grid>
+        <Grid item xs={12}>
+          <FormField
+            fullWidth
+            component={NumberField}
+            label='Auto-Resolve (minutes)'
+            name='autoResolveMinutes'
+            min={0}
+            max={43200}
+            hint='Close alerts with no new activity after this many minutes (0 to disable).'
+          />
+        </Grid>
       </Grid>
     </FormContainer>
   )
@@ -25,10 +25,15 @@ export const calendarSubscriptionsQuery = gql`
         id
         name
         reminderMinutes
+        scope
         scheduleID
         schedule {
           name
         }
+        serviceID
+        service {
+          name
+        }
         lastAccess
         disabled
       }
@@ -53,10 +58,22 @@ export default function UserCalendarSubscriptionList(props) {
   if (error) return <GenericError error={error.message} />
   if (!_.get(data, 'user.id')) return loading ? <Spinner /> : <ObjectNotFound />
 
-  // sort by schedule names, then subscription names
+  // subscriptions are grouped by the schedule or service they include
+  const groupName = (sub) => {
+    if (sub.scope === 'user') return 'All Schedules'
+    if (sub.scope === 'service') return sub.service?.name ?? ''
+    return sub.schedule?.name ?? ''
+  }
+  const groupURL = (sub) => {
+    if (sub.scope === 'user') return null
+    if (sub.scope === 'service') return `/services/${sub.serviceID}`
+    return `/schedules/${sub.scheduleID}`
+  }
+
+  // sort by group names, then subscription names
   const subs = data.user.calendarSubscriptions.slice().sort((a, b) => {
-    if (a.schedule.name < b.schedule.name) return -1
-    if (a.schedule.name > b.schedule.name) return 1
+    if (groupName(a) < groupName(b)) return -1
+    if (groupName(a) > groupName(b)) return 1
 
     if (a.name > b.name) return 1
     if (a.name < b.name) return -1
@@ -82,22 +99,27 @@ export default function UserCalendarSubscriptionList(props) {
     )
   }
 
-  // push schedule names as subheaders now that the array is sorted
+  const scopeText = {
+    my_shifts: 'My shifts',
+    schedule: "Everyone's shifts",
+    user: 'My shifts',
+    service: "Everyone's shifts",
+  }
+
+  // push group names as subheaders now that the array is sorted
   subs.forEach((sub) => {
-    if (!subheaderDict[sub.schedule.name]) {
-      subheaderDict[sub.schedule.name] = true
+    const name = groupName(sub)
+    if (!subheaderDict[name]) {
+      subheaderDict[name] = true
+      const url = groupURL(sub)
       items.push({
-        subHeader: (
-          <AppLink to={`/schedules/${sub.scheduleID}`}>
-            {sub.schedule.name}
-          </AppLink>
-        ),
+        subHeader: url ? <AppLink to={url}>{name}</AppLink> : name,
       })
     }
 
-    // push subscriptions under relevant schedule subheaders
+    // push subscriptions under relevant subheaders
     items.push({
-      title: sub.name,
+      title: `${sub.name} (${scopeText[sub.scope]})`,
       subText: 'Last sync: ' + (formatTimeSince(sub.lastAccess) || 'Never'),
       secondaryAction: renderOtherActions(sub.id),
       icon: sub.disabled ? <Warning message='Disabled' /> : null,
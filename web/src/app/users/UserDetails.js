@@ -5,9 +5,10 @@ import Delete from '@material-ui/icons/Delete'
 import EditIcon from '@material-ui/icons/Edit'
 import DetailsPage from '../details/DetailsPage'
 import StatusUpdateNotification from './UserStatusUpdatePreference'
+import UserHandoffPreference from './UserHandoffPreference'
 import { UserAvatar } from '../util/avatars'
 import UserContactMethodList from './UserContactMethodList'
-import { AddAlarm, SettingsPhone } from '@material-ui/icons'
+import { AddAlarm, EventBusy, SettingsPhone } from '@material-ui/icons'
 import SpeedDial from '../util/SpeedDial'
 import UserNotificationRuleList from './UserNotificationRuleList'
 import { Grid } from '@material-ui/core'
@@ -20,6 +21,8 @@ import { GenericError, ObjectNotFound } from '../error-pages'
 import { useConfigValue, useSessionInfo } from '../util/RequireConfig'
 import UserEditDialog from './UserEditDialog'
 import UserDeleteDialog from './UserDeleteDialog'
+import UserTimeOffList from './UserTimeOffList'
+import UserTimeOffCreateDialog from './UserTimeOffCreateDialog'
 
 const userQuery = gql`
   query userInfo($id: ID!) {
@@ -92,6 +95,7 @@ export default function UserDetails(props) {
   const [disclaimer] = useConfigValue('General.NotificationDisclaimer')
   const [createCM, setCreateCM] = useState(false)
   const [createNR, setCreateNR] = useState(false)
+  const [createTimeOff, setCreateTimeOff] = useState(false)
   const [showEdit, setShowEdit] = useState(false)
   const [showVerifyDialogByID, setShowVerifyDialogByID] = useState(null)
   const [showUserDeleteDialog, setShowUserDeleteDialog] = useState(false)
@@ -178,6 +182,11 @@ export default function UserDetails(props) {
               disabled: disableNR,
               onClick: () => setCreateNR(true),
             },
+            {
+              label: 'Add Time Off',
+              icon: <EventBusy />,
+              onClick: () => setCreateTimeOff(true),
+            },
           ]}
         />
       )}
@@ -205,6 +214,12 @@ export default function UserDetails(props) {
           onClose={() => setCreateNR(false)}
         />
       )}
+      {createTimeOff && (
+        <UserTimeOffCreateDialog
+          userID={props.userID}
+          onClose={() => setCreateTimeOff(false)}
+        />
+      )}
       <DetailsPage
         avatar={<UserAvatar userID={props.userID} />}
         title={user.name + (svcCount ? ' (On-Call)' : '')}
@@ -219,6 +234,7 @@ export default function UserDetails(props) {
               userID={props.userID}
               readOnly={props.readOnly}
             />
+            <UserTimeOffList userID={props.userID} readOnly={props.readOnly} />
           </Grid>
         }
         primaryActions={
@@ -229,6 +245,10 @@ export default function UserDetails(props) {
                   key='primary-action-status-updates'
                   userID={props.userID}
                 />,
+                <UserHandoffPreference
+                  key='primary-action-handoff'
+                  userID={props.userID}
+                />,
               ]
         }
         secondaryActions={
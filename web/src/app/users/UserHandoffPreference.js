@@ -0,0 +1,82 @@
+import React from 'react'
+import { gql, useMutation, useQuery } from '@apollo/client'
+import p from 'prop-types'
+import { MenuItem, TextField } from '@material-ui/core'
+
+const query = gql`
+  query handoffPreference($id: ID!) {
+    user(id: $id) {
+      id
+      handoffNotify
+      handoffNotifyMinutes
+    }
+  }
+`
+const mutation = gql`
+  mutation ($input: UpdateUserInput!) {
+    updateUser(input: $input)
+  }
+`
+
+const disableVal = 'disable'
+const options = [
+  { label: 'At shift start', minutes: 0 },
+  { label: '15 minutes before', minutes: 15 },
+  { label: '1 hour before', minutes: 60 },
+  { label: '1 day before', minutes: 1440 },
+]
+
+export default function UserHandoffPreference(props) {
+  const { data } = useQuery(query, { variables: { id: props.userID } })
+  const [commit] = useMutation(mutation, {
+    refetchQueries: ['handoffPreference'],
+  })
+
+  const user = data?.user
+  if (!user) return null
+
+  const items = options.slice()
+  if (!items.some((o) => o.minutes === user.handoffNotifyMinutes)) {
+    items.push({
+      label: `${user.handoffNotifyMinutes} minutes before`,
+      minutes: user.handoffNotifyMinutes,
+    })
+  }
+
+  return (
+    <TextField
+      select
+      label='On-Call Handoff'
+      helperText='Notify me before my shifts start, and when they end'
+      name='handoff-notify'
+      value={
+        user.handoffNotify ? user.handoffNotifyMinutes.toString() : disableVal
+      }
+      onChange={(e) => {
+        const disable = e.target.value === disableVal
+        commit({
+          variables: {
+            input: {
+              id: props.userID,
+              handoffNotify: !disable,
+              handoffNotifyMinutes: disable
+                ? user.handoffNotifyMinutes
+                : parseInt(e.target.value, 10),
+            },
+          },
+        })
+      }}
+    >
+      <MenuItem value={disableVal}>Disabled</MenuItem>
+      {items.map((o) => (
+        <MenuItem key={o.minutes} value={o.minutes.toString()}>
+          {o.label}
+        </MenuItem>
+      ))}
+    </TextField>
+  )
+}
+
+UserHandoffPreference.propTypes = {
+  userID: p.string.isRequired,
+}
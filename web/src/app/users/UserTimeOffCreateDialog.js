@@ -0,0 +1,58 @@
+import React, { useState } from 'react'
+import { gql, useMutation } from '@apollo/client'
+import p from 'prop-types'
+import { DateTime } from 'luxon'
+
+import FormDialog from '../dialogs/FormDialog'
+import UserTimeOffForm from './UserTimeOffForm'
+import { fieldErrors, nonFieldErrors } from '../util/errutil'
+
+const mutation = gql`
+  mutation ($input: CreateTimeOffInput!) {
+    createTimeOff(input: $input) {
+      id
+    }
+  }
+`
+
+export default function UserTimeOffCreateDialog(props) {
+  const [value, setValue] = useState({
+    start: DateTime.local().startOf('day').plus({ days: 1 }).toISO(),
+    end: DateTime.local().startOf('day').plus({ days: 2 }).toISO(),
+    note: '',
+  })
+
+  const [mutate, { loading, error }] = useMutation(mutation, {
+    variables: {
+      input: {
+        ...value,
+        userID: props.userID,
+      },
+    },
+    refetchQueries: ['userTimeOff'],
+    onCompleted: props.onClose,
+  })
+
+  return (
+    <FormDialog
+      onClose={props.onClose}
+      title='Add Time Off'
+      subTitle='You will be taken off every schedule you belong to for the duration.'
+      errors={nonFieldErrors(error)}
+      onSubmit={() => mutate()}
+      form={
+        <UserTimeOffForm
+          disabled={loading}
+          errors={fieldErrors(error)}
+          value={value}
+          onChange={(newValue) => setValue(newValue)}
+        />
+      }
+    />
+  )
+}
+
+UserTimeOffCreateDialog.propTypes = {
+  userID: p.string.isRequired,
+  onClose: p.func,
+}
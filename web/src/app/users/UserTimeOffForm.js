@@ -0,0 +1,53 @@
+import React from 'react'
+import p from 'prop-types'
+import { FormContainer, FormField } from '../forms'
+import { Grid, TextField } from '@material-ui/core'
+import { ISODateTimePicker } from '../util/ISOPickers'
+
+export default function UserTimeOffForm(props) {
+  return (
+    <FormContainer optionalLabels {...props}>
+      <Grid container spacing={2}>
+        <Grid item xs={12} sm={6}>
+          <FormField
+            fullWidth
+            component={ISODateTimePicker}
+            required
+            name='start'
+            label='Start'
+          />
+        </Grid>
+        <Grid item xs={12} sm={6}>
+          <FormField
+            fullWidth
+            component={ISODateTimePicker}
+            required
+            name='end'
+            label='End'
+          />
+        </Grid>
+        <Grid item xs={12}>
+          <FormField fullWidth component={TextField} name='note' multiline />
+        </Grid>
+      </Grid>
+    </FormContainer>
+  )
+}
+
+UserTimeOffForm.propTypes = {
+  value: p.shape({
+    start: p.string.isRequired,
+    end: p.string.isRequired,
+    note: p.string.isRequired,
+  }).isRequired,
+
+  disabled: p.bool.isRequired,
+  errors: p.arrayOf(
+    p.shape({
+      field: p.oneOf(['start', 'end', 'note']).isRequired,
+      message: p.string.isRequired,
+    }),
+  ),
+
+  onChange: p.func.isRequired,
+}
@@ -0,0 +1,86 @@
+import React from 'react'
+import p from 'prop-types'
+import { gql, useMutation, useQuery } from '@apollo/client'
+import { Card, CardHeader, Grid, IconButton } from '@material-ui/core'
+import { makeStyles } from '@material-ui/core/styles'
+import { Delete } from '@material-ui/icons'
+import FlatList from '../lists/FlatList'
+import { GenericError } from '../error-pages'
+import Spinner from '../loading/components/Spinner'
+import { formatOverrideTime } from '../schedules/util'
+import { styles as globalStyles } from '../styles/materialStyles'
+
+// the query name `userTimeOff` is used for refetch queries
+const query = gql`
+  query userTimeOff($id: ID!) {
+    user(id: $id) {
+      id
+      timeOff {
+        id
+        start
+        end
+        note
+      }
+    }
+  }
+`
+
+const deleteMutation = gql`
+  mutation ($id: ID!) {
+    deleteTimeOff(id: $id)
+  }
+`
+
+const useStyles = makeStyles((theme) => {
+  const { cardHeader } = globalStyles(theme)
+  return { cardHeader }
+})
+
+export default function UserTimeOffList(props) {
+  const classes = useStyles()
+  const { data, loading, error } = useQuery(query, {
+    variables: { id: props.userID },
+  })
+  const [deleteTimeOff, deleteStatus] = useMutation(deleteMutation, {
+    refetchQueries: ['userTimeOff'],
+  })
+
+  if (loading && !data) return <Spinner />
+  if (error) return <GenericError error={error.message} />
+
+  return (
+    <Grid item xs={12}>
+      <Card>
+        <CardHeader
+          className={classes.cardHeader}
+          component='h3'
+          title='Time Off'
+        />
+        {deleteStatus.error && (
+          <GenericError error={deleteStatus.error.message} />
+        )}
+        <FlatList
+          data-cy='time-off'
+          items={data.user.timeOff.map((t) => ({
+            title: formatOverrideTime(t.start, t.end, 'local'),
+            subText: t.note,
+            secondaryAction: props.readOnly ? null : (
+              <IconButton
+                aria-label='Delete time off'
+                onClick={() => deleteTimeOff({ variables: { id: t.id } })}
+              >
+                <Delete />
+              </IconButton>
+            ),
+          }))}
+          emptyMessage='No upcoming time off'
+        />
+      </Card>
+    </Grid>
+  )
+}
+
+UserTimeOffList.propTypes = {
+  userID: p.string.isRequired,
+  readOnly: p.bool,
+}
@@ -9,11 +9,13 @@ export interface Query {
   service?: Service
   integrationKey?: IntegrationKey
   heartbeatMonitor?: HeartbeatMonitor
+  syntheticCheck?: SyntheticCheck
   services: ServiceConnection
   rotation?: Rotation
   rotations: RotationConnection
   calcRotationHandoffTimes: ISOTimestamp[]
   schedule?: Schedule
+  shiftRequests: ShiftRequest[]
   userCalendarSubscription?: UserCalendarSubscription
   schedules: ScheduleConnection
   escalationPolicy?: EscalationPolicy
@@ -31,6 +33,69 @@ export interface Query {
   userContactMethod?: UserContactMethod
   slackChannels: SlackChannelConnection
   slackChannel?: SlackChannel
+  auditLogs: AuditLogConnection
+  outgoingWebhooks: OutgoingWebhook[]
+  exportScheduleShifts: ScheduleShiftExport
+  onCallHoursReport: OnCallHoursReport
+  holidayCalendars: HolidayCalendar[]
+  holidayCalendar?: HolidayCalendar
+}
+
+export interface OutgoingWebhook {
+  id: string
+  name: string
+  url: string
+  eventTypes: string[]
+  disabled: boolean
+  secret?: string
+}
+
+export interface CreateOutgoingWebhookInput {
+  name: string
+  url: string
+  eventTypes: string[]
+  disabled?: boolean
+}
+
+export interface UpdateOutgoingWebhookInput {
+  id: string
+  name?: string
+  url?: string
+  eventTypes?: string[]
+  disabled?: boolean
+}
+
+export interface AuditLogSearchOptions {
+  first?: number
+  after?: string
+  start?: ISOTimestamp
+  end?: ISOTimestamp
+  actorUserIDs?: string[]
+  actions?: string[]
+  target?: TargetInput
+}
+
+export interface AuditLogConnection {
+  nodes: AuditLogEntry[]
+  pageInfo: PageInfo
+}
+
+export interface AuditLogEntry {
+  id: number
+  timestamp: ISOTimestamp
+  actorUser?: User
+  actorSource: string
+  action: string
+  target?: Target
+  before?: string
+  after?: string
+  changes: AuditLogChange[]
+}
+
+export interface AuditLogChange {
+  field: string
+  before?: string
+  after?: string
 }
 
 export interface SlackChannelSearchOptions {
@@ -208,6 +273,131 @@ export interface SetScheduleShiftInput {
   end: ISOTimestamp
 }
 
+export type ScheduleImportFormat = 'csv' | 'ical'
+
+export type ScheduleImportMode = 'temporary_schedule' | 'overrides'
+
+export interface ImportScheduleInput {
+  scheduleID: string
+  format: ScheduleImportFormat
+  mode?: ScheduleImportMode
+  data: string
+  timeZone?: string
+  dryRun?: boolean
+}
+
+export interface ScheduleImportResult {
+  shifts: ScheduleImportShift[]
+  conflicts: ScheduleImportConflict[]
+  skipped: number
+  applied: boolean
+}
+
+export interface ScheduleImportShift {
+  line: number
+  email: string
+  userID: string
+  user?: User
+  start: ISOTimestamp
+  end: ISOTimestamp
+}
+
+export interface ScheduleImportConflict {
+  line: number
+  email: string
+  start?: ISOTimestamp
+  end?: ISOTimestamp
+  message: string
+}
+
+export type ScheduleExportFormat = 'csv' | 'json'
+
+export interface ExportScheduleShiftsInput {
+  scheduleIDs: string[]
+  start: ISOTimestamp
+  end: ISOTimestamp
+  format?: ScheduleExportFormat
+}
+
+export interface ScheduleShiftExport {
+  format: ScheduleExportFormat
+  data: string
+  shifts: ScheduleExportShift[]
+}
+
+export type OnCallShiftSource =
+  | 'history'
+  | 'temporary_schedule'
+  | 'override'
+  | 'rotation'
+  | 'user'
+
+export interface ScheduleExportShift {
+  scheduleID: string
+  scheduleName: string
+  userID: string
+  userName: string
+  userEmail: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  hours: number
+  truncated: boolean
+  source: OnCallShiftSource
+  overrideID?: string
+  ruleID?: string
+  rotationID?: string
+  rotationName?: string
+}
+
+export interface OnCallHoursReportInput {
+  scheduleIDs: string[]
+  userIDs?: string[]
+  start: ISOTimestamp
+  end: ISOTimestamp
+  timeZone?: string
+  holidays?: string[]
+  holidayCalendarID?: string
+  businessStart?: ClockTime
+  businessEnd?: ClockTime
+  includePages?: boolean
+}
+
+export interface OnCallHoursReport {
+  start: ISOTimestamp
+  end: ISOTimestamp
+  timeZone: string
+  users: OnCallHoursReportUser[]
+}
+
+export interface OnCallHoursReportUser {
+  userID: string
+  user?: User
+  userName: string
+  weekdayHours: number
+  weekendHours: number
+  holidayHours: number
+  totalHours: number
+  schedules: OnCallHoursReportSchedule[]
+  pages: number
+  afterHoursPages: number
+}
+
+export interface OnCallHoursReportSchedule {
+  scheduleID: string
+  schedule?: Schedule
+  weekdayHours: number
+  weekendHours: number
+  holidayHours: number
+  totalHours: number
+}
+
+export interface Subscription {
+  alertStatusChanged: Alert
+  alertLogEntryAdded: AlertLogEntry
+  scheduleOnCallChanged: Schedule
+  serviceOnCallChanged: Service
+}
+
 export interface Mutation {
   setTemporarySchedule: boolean
   clearTemporarySchedules: boolean
@@ -224,6 +414,7 @@ export interface Mutation {
   setFavorite: boolean
   updateService: boolean
   updateEscalationPolicy: boolean
+  setEscalationPolicyFallbackTarget: boolean
   updateEscalationPolicyStep: boolean
   deleteAll: boolean
   createAlert?: Alert
@@ -232,6 +423,7 @@ export interface Mutation {
   createEscalationPolicyStep?: EscalationPolicyStep
   createRotation?: Rotation
   createIntegrationKey?: IntegrationKey
+  updateIntegrationKey: boolean
   createHeartbeatMonitor?: HeartbeatMonitor
   setLabel: boolean
   createSchedule?: Schedule
@@ -251,6 +443,23 @@ export interface Mutation {
   updateAlertsByService: boolean
   setConfig: boolean
   setSystemLimits: boolean
+  createOutgoingWebhook?: OutgoingWebhook
+  updateOutgoingWebhook: boolean
+  createSyntheticCheck?: SyntheticCheck
+  updateSyntheticCheck: boolean
+  createShiftRequest?: ShiftRequest
+  respondShiftRequest: boolean
+  cancelShiftRequest: boolean
+  createTimeOff?: TimeOff
+  deleteTimeOff: boolean
+  setRotationParticipantUnavailable: boolean
+  importSchedule: ScheduleImportResult
+  createHolidayCalendar?: HolidayCalendar
+  updateHolidayCalendar: boolean
+  deleteHolidayCalendar: boolean
+  setHolidays: boolean
+  deleteHolidays: boolean
+  importHolidays: number
 }
 
 export interface UpdateAlertsByServiceInput {
@@ -273,11 +482,21 @@ export interface CreateUserInput {
   role?: UserRole
 }
 
+export type CalendarSubscriptionScope =
+  | 'my_shifts'
+  | 'schedule'
+  | 'user'
+  | 'service'
+
 export interface CreateUserCalendarSubscriptionInput {
   name: string
   reminderMinutes?: number[]
-  scheduleID: string
+  scope?: CalendarSubscriptionScope
+  scheduleID?: string
+  serviceID?: string
   disabled?: boolean
+  lookaheadDays?: number
+  includeDetails?: boolean
 }
 
 export interface UpdateUserCalendarSubscriptionInput {
@@ -285,14 +504,21 @@ export interface UpdateUserCalendarSubscriptionInput {
   name?: string
   reminderMinutes?: number[]
   disabled?: boolean
+  lookaheadDays?: number
+  includeDetails?: boolean
 }
 
 export interface UserCalendarSubscription {
   id: string
   name: string
   reminderMinutes: number[]
-  scheduleID: string
+  scope: CalendarSubscriptionScope
+  scheduleID?: string
   schedule?: Schedule
+  serviceID?: string
+  service?: Service
+  lookaheadDays: number
+  includeDetails: boolean
   lastAccess: ISOTimestamp
   disabled: boolean
   url?: string
@@ -324,6 +550,10 @@ export interface CreateScheduleInput {
   description?: string
   timeZone: string
   favorite?: boolean
+  gapNotifyDays?: number
+  gapNotifyUserID?: string
+  timeOffStrategy?: TimeOffStrategy
+  holidayCalendarID?: string
   targets?: ScheduleTargetInput[]
   newUserOverrides?: CreateUserOverrideInput[]
 }
@@ -340,6 +570,7 @@ export interface ScheduleRuleInput {
   start?: ClockTime
   end?: ClockTime
   weekdayFilter?: WeekdayFilter
+  holidayMode?: ScheduleRuleHolidayMode
 }
 
 export interface SetLabelInput {
@@ -369,6 +600,7 @@ export interface CreateServiceInput {
   description?: string
   favorite?: boolean
   escalationPolicyID?: string
+  autoResolveMinutes?: number
   newEscalationPolicy?: CreateEscalationPolicyInput
   newIntegrationKeys?: CreateIntegrationKeyInput[]
   labels?: SetLabelInput[]
@@ -379,6 +611,9 @@ export interface CreateEscalationPolicyInput {
   name: string
   description?: string
   repeat?: number
+  ackTimeoutMinutes?: number
+  repeatDelayMinutes?: number
+  fallbackTarget?: TargetInput
   favorite?: boolean
   steps?: CreateEscalationPolicyStepInput[]
 }
@@ -404,6 +639,10 @@ export interface UpdateScheduleInput {
   name?: string
   description?: string
   timeZone?: string
+  gapNotifyDays?: number
+  gapNotifyUserID?: string
+  timeOffStrategy?: TimeOffStrategy
+  holidayCalendarID?: string
 }
 
 export interface UpdateServiceInput {
@@ -411,6 +650,7 @@ export interface UpdateServiceInput {
   name?: string
   description?: string
   escalationPolicyID?: string
+  autoResolveMinutes?: number
 }
 
 export interface UpdateEscalationPolicyInput {
@@ -418,9 +658,16 @@ export interface UpdateEscalationPolicyInput {
   name?: string
   description?: string
   repeat?: number
+  ackTimeoutMinutes?: number
+  repeatDelayMinutes?: number
   stepIDs?: string[]
 }
 
+export interface SetEscalationPolicyFallbackTargetInput {
+  escalationPolicyID: string
+  target?: TargetInput
+}
+
 export interface UpdateEscalationPolicyStepInput {
   id: string
   delayMinutes?: number
@@ -454,12 +701,21 @@ export interface Schedule {
   timeZone: string
   assignedTo: Target[]
   shifts: OnCallShift[]
+  coverageGaps: CoverageGap[]
+  gapNotifyDays: number
+  gapNotifyUser?: User
+  timeOffStrategy: TimeOffStrategy
+  holidayCalendarID?: string
+  holidayCalendar?: HolidayCalendar
+  notices: Notice[]
   targets: ScheduleTarget[]
   target?: ScheduleTarget
   isFavorite: boolean
   temporarySchedules: TemporarySchedule[]
 }
 
+export type TimeOffStrategy = 'remove' | 'replace_next'
+
 export interface OnCallShift {
   userID: string
   user?: User
@@ -468,6 +724,11 @@ export interface OnCallShift {
   truncated: boolean
 }
 
+export interface CoverageGap {
+  start: ISOTimestamp
+  end: ISOTimestamp
+}
+
 export interface ScheduleTarget {
   scheduleID: string
   target: Target
@@ -480,9 +741,12 @@ export interface ScheduleRule {
   start: ClockTime
   end: ClockTime
   weekdayFilter: WeekdayFilter
+  holidayMode: ScheduleRuleHolidayMode
   target: Target
 }
 
+export type ScheduleRuleHolidayMode = 'ignore' | 'exclude' | 'include'
+
 export interface RotationConnection {
   nodes: Rotation[]
   pageInfo: PageInfo
@@ -512,6 +776,30 @@ export interface Rotation {
   userIDs: string[]
   users: User[]
   nextHandoffTimes: ISOTimestamp[]
+  participants: RotationParticipant[]
+  participantHours: RotationParticipantHours[]
+}
+
+export interface RotationParticipant {
+  position: number
+  userID: string
+  user?: User
+  unavailableUntil?: ISOTimestamp
+  skipCredit: number
+}
+
+export interface RotationParticipantHours {
+  userID: string
+  user?: User
+  hours: number
+  shifts: number
+  skipCredit: number
+}
+
+export interface SetRotationParticipantUnavailableInput {
+  rotationID: string
+  userIndex: number
+  until?: ISOTimestamp
 }
 
 export type RotationType = 'weekly' | 'daily' | 'hourly'
@@ -625,6 +913,7 @@ export interface Alert {
   createdAt: ISOTimestamp
   serviceID: string
   service?: Service
+  flapping: boolean
   state?: AlertState
   recentEvents: AlertLogEntryConnection
 }
@@ -657,6 +946,7 @@ export interface AlertState {
   lastEscalation: ISOTimestamp
   stepNumber: number
   repeatCount: number
+  fallbackNotified: boolean
 }
 
 export interface Service {
@@ -666,28 +956,52 @@ export interface Service {
   escalationPolicyID: string
   escalationPolicy?: EscalationPolicy
   isFavorite: boolean
+  autoResolveMinutes: number
   onCallUsers: ServiceOnCallUser[]
   integrationKeys: IntegrationKey[]
   labels: Label[]
   heartbeatMonitors: HeartbeatMonitor[]
+  syntheticChecks: SyntheticCheck[]
 }
 
 export interface CreateIntegrationKeyInput {
   serviceID?: string
   type: IntegrationKeyType
   name: string
+  jsonMapping?: IntegrationKeyJSONMappingInput
+}
+
+export interface UpdateIntegrationKeyInput {
+  id: string
+  name?: string
+  jsonMapping?: IntegrationKeyJSONMappingInput
+}
+
+export interface IntegrationKeyJSONMappingInput {
+  summary: string
+  details?: string
+  dedup?: string
+  action?: string
 }
 
 export interface CreateHeartbeatMonitorInput {
   serviceID: string
   name: string
-  timeoutMinutes: number
+  timeoutMinutes?: number
+  cronSchedule?: string
+  timeZone?: string
+  gracePeriodMinutes?: number
+  alertAfterMisses?: number
 }
 
 export interface UpdateHeartbeatMonitorInput {
   id: string
   name?: string
   timeoutMinutes?: number
+  cronSchedule?: string
+  timeZone?: string
+  gracePeriodMinutes?: number
+  alertAfterMisses?: number
 }
 
 export type HeartbeatMonitorState = 'inactive' | 'healthy' | 'unhealthy'
@@ -697,11 +1011,169 @@ export interface HeartbeatMonitor {
   serviceID: string
   name: string
   timeoutMinutes: number
+  cronSchedule: string
+  timeZone: string
+  gracePeriodMinutes: number
+  alertAfterMisses: number
   lastState: HeartbeatMonitorState
   lastHeartbeat?: ISOTimestamp
+  lastFailure?: ISOTimestamp
+  lastMessage: string
   href: string
 }
 
+export type SyntheticCheckType = 'http' | 'tcp'
+
+export type SyntheticCheckState = 'inactive' | 'healthy' | 'unhealthy'
+
+export interface CreateSyntheticCheckInput {
+  serviceID: string
+  name: string
+  type: SyntheticCheckType
+  target: string
+  intervalMinutes?: number
+  expectedStatus?: number
+  bodyContains?: string
+  tlsExpiryDays?: number
+  disabled?: boolean
+}
+
+export interface UpdateSyntheticCheckInput {
+  id: string
+  name?: string
+  type?: SyntheticCheckType
+  target?: string
+  intervalMinutes?: number
+  expectedStatus?: number
+  bodyContains?: string
+  tlsExpiryDays?: number
+  disabled?: boolean
+}
+
+export interface SyntheticCheck {
+  id: string
+  serviceID: string
+  name: string
+  type: SyntheticCheckType
+  target: string
+  intervalMinutes: number
+  expectedStatus: number
+  bodyContains: string
+  tlsExpiryDays: number
+  disabled: boolean
+  lastState: SyntheticCheckState
+  lastRun?: ISOTimestamp
+  lastError: string
+}
+
+export type ShiftRequestStatus =
+  | 'pending'
+  | 'accepted'
+  | 'declined'
+  | 'cancelled'
+
+export interface ShiftRequestSearchOptions {
+  scheduleID?: string
+  userID?: string
+  status?: ShiftRequestStatus[]
+}
+
+export interface CreateShiftRequestInput {
+  scheduleID: string
+  requesterID?: string
+  userID: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  swapStart?: ISOTimestamp
+  swapEnd?: ISOTimestamp
+  note?: string
+}
+
+export interface RespondShiftRequestInput {
+  id: string
+  accept: boolean
+}
+
+export interface ShiftRequest {
+  id: string
+  scheduleID: string
+  schedule?: Schedule
+  requesterID: string
+  requester?: User
+  userID: string
+  user?: User
+  start: ISOTimestamp
+  end: ISOTimestamp
+  swapStart?: ISOTimestamp
+  swapEnd?: ISOTimestamp
+  note: string
+  status: ShiftRequestStatus
+  createdAt: ISOTimestamp
+  respondedAt?: ISOTimestamp
+}
+
+export interface CreateTimeOffInput {
+  userID?: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  note?: string
+}
+
+export interface HolidayCalendar {
+  id: string
+  name: string
+  description: string
+  holidays: Holiday[]
+}
+
+export interface Holiday {
+  date: string
+  name: string
+}
+
+export interface CreateHolidayCalendarInput {
+  name: string
+  description?: string
+}
+
+export interface UpdateHolidayCalendarInput {
+  id: string
+  name?: string
+  description?: string
+}
+
+export interface HolidayInput {
+  date: string
+  name?: string
+}
+
+export interface SetHolidaysInput {
+  calendarID: string
+  holidays: HolidayInput[]
+  replace?: boolean
+}
+
+export interface DeleteHolidaysInput {
+  calendarID: string
+  dates: string[]
+}
+
+export interface ImportHolidaysInput {
+  calendarID: string
+  data: string
+  replace?: boolean
+}
+
+export interface TimeOff {
+  id: string
+  userID: string
+  user?: User
+  start: ISOTimestamp
+  end: ISOTimestamp
+  note: string
+  createdAt: ISOTimestamp
+}
+
 export interface Label {
   key: string
   value: string
@@ -713,6 +1185,14 @@ export interface IntegrationKey {
   type: IntegrationKeyType
   name: string
   href: string
+  jsonMapping?: IntegrationKeyJSONMapping
+}
+
+export interface IntegrationKeyJSONMapping {
+  summary: string
+  details: string
+  dedup: string
+  action: string
 }
 
 export type IntegrationKeyType =
@@ -721,6 +1201,10 @@ export type IntegrationKeyType =
   | 'site24x7'
   | 'prometheusAlertmanager'
   | 'email'
+  | 'genericJSON'
+  | 'datadog'
+  | 'newRelic'
+  | 'awsSNS'
 
 export interface ServiceOnCallUser {
   userID: string
@@ -733,6 +1217,9 @@ export interface EscalationPolicy {
   name: string
   description: string
   repeat: number
+  ackTimeoutMinutes: number
+  repeatDelayMinutes: number
+  fallbackTarget?: Target
   isFavorite: boolean
   assignedTo: Target[]
   steps: EscalationPolicyStep[]
@@ -771,6 +1258,8 @@ export type TargetType =
   | 'heartbeatMonitor'
   | 'calendarSubscription'
   | 'userSession'
+  | 'outgoingWebhook'
+  | 'syntheticCheck'
 
 export interface ServiceConnection {
   nodes: Service[]
@@ -798,6 +1287,8 @@ export interface UpdateUserInput {
   email?: string
   role?: UserRole
   statusUpdateContactMethodID?: string
+  handoffNotify?: boolean
+  handoffNotifyMinutes?: number
 }
 
 export interface AuthSubjectInput {
@@ -817,6 +1308,9 @@ export interface User {
   notificationRules: UserNotificationRule[]
   calendarSubscriptions: UserCalendarSubscription[]
   statusUpdateContactMethodID: string
+  handoffNotify: boolean
+  handoffNotifyMinutes: number
+  timeOff: TimeOff[]
   authSubjects: AuthSubject[]
   sessions: UserSession[]
   onCallSteps: EscalationPolicyStep[]
@@ -925,6 +1419,24 @@ type ConfigID =
   | 'OIDC.UserInfoEmailPath'
   | 'OIDC.UserInfoEmailVerifiedPath'
   | 'OIDC.UserInfoNamePath'
+  | 'OIDC.GroupsPath'
+  | 'OIDC.AdminGroups'
+  | 'OIDC.RotationGroups'
+  | 'LDAP.Enable'
+  | 'LDAP.NewUsers'
+  | 'LDAP.OverrideName'
+  | 'LDAP.URL'
+  | 'LDAP.StartTLS'
+  | 'LDAP.SkipVerify'
+  | 'LDAP.BindDN'
+  | 'LDAP.BindPassword'
+  | 'LDAP.BaseDN'
+  | 'LDAP.UserSearchFilter'
+  | 'LDAP.SubjectAttribute'
+  | 'LDAP.NameAttribute'
+  | 'LDAP.EmailAttribute'
+  | 'LDAP.GroupAttribute'
+  | 'LDAP.AllowedGroups'
   | 'Mailgun.Enable'
   | 'Mailgun.APIKey'
   | 'Mailgun.EmailDomain'